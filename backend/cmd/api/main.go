@@ -2,15 +2,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"organizational-climate-survey/backend/config"
+	"organizational-climate-survey/backend/internal/application/middleware"
 	"organizational-climate-survey/backend/internal/domain/usecase"
 	httpRouter "organizational-climate-survey/backend/internal/infrastructure/http"
 	"organizational-climate-survey/backend/internal/infrastructure/postgres"
+	"organizational-climate-survey/backend/internal/infrastructure/scheduler"
 	"organizational-climate-survey/backend/pkg/crypto"
+	"organizational-climate-survey/backend/pkg/logger"
+	"organizational-climate-survey/backend/pkg/mailer"
+	"organizational-climate-survey/backend/pkg/ratelimit"
+	"organizational-climate-survey/backend/pkg/storage"
 
 	"github.com/joho/godotenv"
 )
@@ -50,6 +61,33 @@ func main() {
 	cryptoSvc := crypto.NewDefaultCryptoService()
 	log.Println("✅ Crypto service inicializado")
 
+	// Inicializa envio de emails
+	var mail mailer.Mailer
+	switch cfg.Mail.Driver {
+	case "smtp":
+		mail = mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUser,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+		})
+	case "file":
+		mail = mailer.NewFileMailer(cfg.Mail.Dir, cfg.Mail.From, logger.New(nil))
+	default:
+		mail = mailer.NewFileMailer("", cfg.Mail.From, logger.New(nil))
+	}
+	// Emails são entregues em segundo plano para não prender a requisição ao transporte
+	mailQueue := mailer.NewQueueMailer(mail, 100, logger.New(nil))
+	mail = mailQueue
+	log.Printf("✅ Mailer inicializado (%s)", cfg.Mail.Driver)
+
+	// Política de anonimato (mínimo de respondentes) compartilhada pelos use cases de resultados
+	var anonimato *usecase.PoliticaAnonimato
+	if repos.Empresa != nil {
+		anonimato = usecase.NewPoliticaAnonimato(repos.Empresa)
+	}
+
 	// Bootstrap Use Case (não depende de outros use cases)
 	var bootstrapUseCase *usecase.BootstrapUseCase
 	if repos.Empresa != nil && repos.UsuarioAdministrador != nil {
@@ -75,6 +113,49 @@ func main() {
 			repos.LogAuditoria,
 			cryptoSvc,
 		)
+		if repos.TokenRedefinicaoSenha != nil {
+			usuarioUseCase.SetPasswordReset(repos.TokenRedefinicaoSenha, mail, cfg.PasswordReset.TokenTTL, cfg.PasswordReset.URL)
+		}
+		if cfg.LoginProtection.Enabled && repos.TentativaLogin != nil {
+			usuarioUseCase.SetLoginProtection(repos.TentativaLogin, usecase.LoginProtectionPolicy{
+				MaxFailures:   cfg.LoginProtection.MaxFailures,
+				IPMaxFailures: cfg.LoginProtection.IPMaxFailures,
+				LockDuration:  cfg.LoginProtection.LockDuration,
+				BackoffBase:   cfg.LoginProtection.BackoffBase,
+				BackoffMax:    cfg.LoginProtection.BackoffMax,
+				Window:        cfg.LoginProtection.Window,
+			})
+		}
+	}
+
+	var sessaoUseCase *usecase.SessaoUseCase
+	if repos.Sessao != nil && repos.TokenRevogado != nil && repos.UsuarioAdministrador != nil && repos.LogAuditoria != nil {
+		sessaoUseCase = usecase.NewSessaoUseCase(
+			repos.Sessao,
+			repos.TokenRevogado,
+			repos.UsuarioAdministrador,
+			repos.LogAuditoria,
+			cryptoSvc,
+			cfg.JWT.RefreshTTL,
+		)
+		if usuarioUseCase != nil {
+			usuarioUseCase.SetSessoes(sessaoUseCase)
+		}
+	}
+
+	var mfaUseCase *usecase.MFAUseCase
+	if repos.MFA != nil && repos.UsuarioAdministrador != nil && repos.Empresa != nil && repos.LogAuditoria != nil {
+		mfaUseCase = usecase.NewMFAUseCase(
+			repos.MFA,
+			repos.UsuarioAdministrador,
+			repos.Empresa,
+			repos.LogAuditoria,
+			cryptoSvc,
+			cfg.MFA.Issuer,
+		)
+		if usuarioUseCase != nil {
+			mfaUseCase.SetLoginProtection(usuarioUseCase)
+		}
 	}
 
 	var setorUseCase *usecase.SetorUseCase
@@ -85,11 +166,49 @@ func main() {
 	var pesquisaUseCase *usecase.PesquisaUseCase
 	if repos.Pesquisa != nil && repos.Empresa != nil && repos.Setor != nil && repos.Dashboard != nil && repos.LogAuditoria != nil {
 		pesquisaUseCase = usecase.NewPesquisaUseCase(repos.Pesquisa, repos.Empresa, repos.Setor, repos.Dashboard, repos.LogAuditoria)
+		pesquisaUseCase.SetQRCode(storage.NewLocalStorage(cfg.Storage.Dir), usecase.QRCodeConfig{
+			PublicURL:    cfg.QRCode.PublicURL,
+			DefaultSize:  cfg.QRCode.Size,
+			DefaultLevel: cfg.QRCode.Level,
+		})
+		if repos.ModeloPesquisa != nil {
+			pesquisaUseCase.SetModelos(repos.ModeloPesquisa)
+		}
+		if repos.Dimensao != nil {
+			pesquisaUseCase.SetDimensoes(repos.Dimensao)
+		}
+		if repos.Pergunta != nil && repos.PesquisaClone != nil {
+			pesquisaUseCase.SetClonagem(repos.Pergunta, repos.PesquisaClone)
+		}
+	}
+
+	var modeloPesquisaUseCase *usecase.ModeloPesquisaUseCase
+	if repos.ModeloPesquisa != nil && repos.Empresa != nil && repos.LogAuditoria != nil {
+		modeloPesquisaUseCase = usecase.NewModeloPesquisaUseCase(repos.ModeloPesquisa, repos.Empresa, repos.LogAuditoria)
+	}
+
+	var dimensaoUseCase *usecase.DimensaoUseCase
+	if repos.Dimensao != nil && repos.Empresa != nil && repos.LogAuditoria != nil {
+		dimensaoUseCase = usecase.NewDimensaoUseCase(repos.Dimensao, repos.Empresa, repos.LogAuditoria)
+	}
+
+	var recorrenciaUseCase *usecase.RecorrenciaUseCase
+	if repos.Pesquisa != nil && repos.Pergunta != nil && repos.PesquisaCiclo != nil && repos.Dashboard != nil && repos.LogAuditoria != nil {
+		recorrenciaUseCase = usecase.NewRecorrenciaUseCase(repos.Pesquisa, repos.Pergunta, repos.PesquisaCiclo, repos.Dashboard, repos.LogAuditoria)
 	}
 
 	var perguntaUseCase *usecase.PerguntaUseCase
 	if repos.Pergunta != nil && repos.Resposta != nil && repos.Pesquisa != nil && repos.LogAuditoria != nil {
 		perguntaUseCase = usecase.NewPerguntaUseCase(repos.Pergunta, repos.Resposta, repos.Pesquisa, repos.LogAuditoria)
+		perguntaUseCase.SetAnonimato(anonimato)
+		if repos.Dimensao != nil {
+			perguntaUseCase.SetDimensoes(repos.Dimensao)
+		}
+	}
+
+	var formularioUseCase *usecase.FormularioPublicoUseCase
+	if repos.Pesquisa != nil && repos.Pergunta != nil {
+		formularioUseCase = usecase.NewFormularioPublicoUseCase(repos.Pesquisa, repos.Pergunta)
 	}
 
 	// NOVO: SubmissaoPesquisaUseCase
@@ -101,6 +220,9 @@ func main() {
 			cryptoSvc,
 			cfg.Crypto.HashSalt,
 		)
+		if repos.Pergunta != nil && repos.Resposta != nil {
+			submissaoUseCase.SetCompletude(repos.Pergunta, repos.Resposta)
+		}
 	}
 
 	// MODIFICADO: RespostaUseCase agora depende de SubmissaoUseCase
@@ -112,6 +234,7 @@ func main() {
 			repos.Pesquisa,
 			submissaoUseCase,
 		)
+		respostaUseCase.SetAnonimato(anonimato)
 	}
 	
 	var logUseCase *usecase.LogAuditoriaUseCase
@@ -120,11 +243,67 @@ func main() {
 	}
 
 	var dashboardUseCase *usecase.DashboardUseCase
-	if repos.Dashboard != nil && repos.Pesquisa != nil && repos.Empresa != nil && repos.LogAuditoria != nil {
-		dashboardUseCase = usecase.NewDashboardUseCase(repos.Dashboard, repos.Pesquisa, repos.Empresa, repos.LogAuditoria)
+	if repos.Dashboard != nil && repos.Pesquisa != nil && repos.Pergunta != nil && repos.Resposta != nil &&
+		repos.SubmissaoPesquisa != nil && repos.Empresa != nil && repos.LogAuditoria != nil {
+		dashboardUseCase = usecase.NewDashboardUseCase(
+			repos.Dashboard,
+			repos.Pesquisa,
+			repos.Pergunta,
+			repos.Resposta,
+			repos.SubmissaoPesquisa,
+			repos.Empresa,
+			repos.LogAuditoria,
+		)
+		if repos.Setor != nil && repos.PesquisaCiclo != nil {
+			dashboardUseCase.SetSegmentacao(repos.Setor, repos.PesquisaCiclo)
+		}
+		if repos.Dimensao != nil {
+			dashboardUseCase.SetDimensoes(repos.Dimensao)
+		}
+		if repos.AnaliseTexto != nil {
+			dashboardUseCase.SetAnaliseTexto(repos.AnaliseTexto)
+		}
+		if repos.PesquisaClone != nil {
+			dashboardUseCase.SetClonagem(repos.PesquisaClone)
+		}
+		dashboardUseCase.SetAnonimato(anonimato)
+	}
+
+	var analiseTextoUseCase *usecase.AnaliseTextoUseCase
+	if repos.AnaliseTexto != nil && repos.Pesquisa != nil && repos.Pergunta != nil && repos.Resposta != nil {
+		analiseTextoUseCase = usecase.NewAnaliseTextoUseCase(repos.AnaliseTexto, repos.Pesquisa, repos.Pergunta, repos.Resposta)
+		analiseTextoUseCase.SetAnonimato(anonimato)
+	}
+
+	var analyticsUseCase *usecase.AnalyticsUseCase
+	if repos.Analytics != nil && repos.Pesquisa != nil && repos.LogAuditoria != nil {
+		analyticsUseCase = usecase.NewAnalyticsUseCase(repos.Analytics, repos.Pesquisa, repos.LogAuditoria)
+		analyticsUseCase.SetAnonimato(anonimato)
+		if repos.PesquisaClone != nil {
+			analyticsUseCase.SetClonagem(repos.PesquisaClone)
+		}
 	}
 	log.Println("✅ Use cases inicializados")
 
+	// Rate limiting (armazenamento em memória, válido para uma instância)
+	var rateLimiter *middleware.RateLimiter
+	if cfg.RateLimit.Enabled {
+		limits := map[string]ratelimit.Limit{
+			middleware.RateLimitPublic:        cfg.RateLimit.Public,
+			middleware.RateLimitAuth:          cfg.RateLimit.Auth,
+			middleware.RateLimitSubmission:    cfg.RateLimit.Submission,
+			middleware.RateLimitAuthenticated: cfg.RateLimit.Authenticated,
+		}
+		idleTTL := time.Hour
+		for _, l := range limits {
+			if l.Period > idleTTL {
+				idleTTL = l.Period
+			}
+		}
+		rateLimiter = middleware.NewRateLimiter(ratelimit.NewMemoryStore(idleTTL), limits, cfg.RateLimit.TrustedProxies, logger.New(nil))
+		log.Println("✅ Rate limiting habilitado")
+	}
+
 	// Configuração do router HTTP
 	routerConfig := &httpRouter.RouterConfig{
 		EmpresaUseCase:              empresaUseCase,
@@ -132,13 +311,22 @@ func main() {
 		SetorUseCase:                setorUseCase,
 		PesquisaUseCase:             pesquisaUseCase,
 		PerguntaUseCase:             perguntaUseCase,
+		ModeloPesquisaUseCase:       modeloPesquisaUseCase,
+		DimensaoUseCase:             dimensaoUseCase,
 		RespostaUseCase:             respostaUseCase,
 		SubmissaoUseCase:            submissaoUseCase, 
 		DashboardUseCase:            dashboardUseCase,
+		AnalyticsUseCase:            analyticsUseCase,
 		LogAuditoriaUseCase:         logUseCase,
+		SessaoUseCase:               sessaoUseCase,
+		MFAUseCase:                  mfaUseCase,
+		FormularioPublicoUseCase:    formularioUseCase,
 		PesquisaRepo:                repos.Pesquisa,   
 		JWTSecret:                   cfg.JWT.Secret,
+		AccessTokenTTL:              cfg.JWT.AccessTTL,
 		BootstrapUseCase: 			 bootstrapUseCase, 
+		RateLimiter:                 rateLimiter,
+		TrustedProxies:              cfg.RateLimit.TrustedProxies,
 	}
 	router := httpRouter.SetupRouter(routerConfig)
 	log.Println("✅ Router configurado")
@@ -156,5 +344,56 @@ func main() {
 		fmt.Printf("📚 Documentação: http://localhost:%s/docs/\n", cfg.App.Port)
 	}
 
-	log.Fatal(server.ListenAndServe())
+	// Contexto cancelado em SIGINT/SIGTERM para encerramento gracioso
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Agendador de ciclos recorrentes, abertura/fechamento de pesquisas, análise de respostas abertas e limpeza de submissões
+	var sched *scheduler.Scheduler
+	if cfg.Scheduler.Enabled {
+		sched = scheduler.New(
+			scheduler.Config{
+				Interval:        cfg.Scheduler.Interval,
+				CleanupInterval: cfg.Scheduler.CleanupInterval,
+			},
+			pesquisaUseCase,
+			recorrenciaUseCase,
+			submissaoUseCase,
+			usuarioUseCase,
+			sessaoUseCase,
+			analiseTextoUseCase,
+			logUseCase,
+			logger.New(nil),
+		)
+		sched.Start(ctx)
+		log.Println("✅ Agendador iniciado")
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		log.Fatalf("Erro no servidor HTTP: %v", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Encerrando servidor...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Erro ao encerrar servidor HTTP: %v", err)
+	}
+	if sched != nil {
+		sched.Wait()
+	}
+	if err := mailQueue.Close(shutdownCtx); err != nil {
+		log.Printf("Erro ao encerrar fila de emails: %v", err)
+	}
+	log.Println("✅ Servidor encerrado")
 }
\ No newline at end of file
@@ -3,7 +3,14 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/pkg/qrcode"
+	"organizational-climate-survey/backend/pkg/ratelimit"
 )
 
 // Config agrupa todas as configurações da aplicação, incluindo App, Database, JWT e Log.
@@ -22,7 +29,9 @@ type Config struct {
 		SSLMode  string // Modo SSL
 	}
 	JWT struct {
-		Secret string // Chave secreta para JWT
+		Secret     string        // Chave secreta para JWT
+		AccessTTL  time.Duration // Validade do access token
+		RefreshTTL time.Duration // Validade máxima de uma sessão (refresh tokens)
 	}
 	Crypto struct {
 		HashSalt string // Salt para hashes de IP/fingerprint (submissões anônimas)
@@ -30,6 +39,52 @@ type Config struct {
 	Log struct {
 		Level string // Nível de log (debug, info, etc.)
 	}
+	Scheduler struct {
+		Enabled         bool          // Executa tarefas agendadas (abertura/fechamento, limpeza)
+		Interval        time.Duration // Intervalo de verificação de abertura/fechamento de pesquisas
+		CleanupInterval time.Duration // Intervalo de limpeza de submissões expiradas
+	}
+	RateLimit struct {
+		Enabled        bool            // Aplica limitação de taxa nas rotas
+		TrustedProxies []*net.IPNet    // Proxies (IPs ou CIDRs) cujos X-Forwarded-For/X-Real-IP identificam o cliente (também no login)
+		Public         ratelimit.Limit // Rotas públicas
+		Auth           ratelimit.Limit // Rotas de autenticação (login, recuperação de senha)
+		Submission     ratelimit.Limit // Submissão de respostas
+		Authenticated  ratelimit.Limit // Rotas autenticadas, por usuário
+	}
+	Mail struct {
+		Driver       string // Transporte de email: smtp, file ou log
+		From         string // Remetente dos emails
+		SMTPHost     string // Host do servidor SMTP
+		SMTPPort     string // Porta do servidor SMTP
+		SMTPUser     string // Usuário SMTP
+		SMTPPassword string // Senha SMTP
+		Dir          string // Diretório dos arquivos .eml (driver file)
+	}
+	PasswordReset struct {
+		TokenTTL time.Duration // Validade do token de redefinição de senha
+		URL      string        // Página do frontend que recebe o token (?token=...)
+	}
+	MFA struct {
+		Issuer string // Emissor exibido nos aplicativos autenticadores
+	}
+	LoginProtection struct {
+		Enabled       bool          // Aplica atraso exponencial e bloqueio temporário após falhas de login
+		MaxFailures   int           // Falhas por conta até o bloqueio
+		IPMaxFailures int           // Falhas por IP até o bloqueio
+		LockDuration  time.Duration // Duração do bloqueio temporário
+		BackoffBase   time.Duration // Atraso após a primeira falha (dobra a cada falha)
+		BackoffMax    time.Duration // Limite do atraso exponencial
+		Window        time.Duration // Janela em que falhas consecutivas são somadas
+	}
+	Storage struct {
+		Dir string // Diretório raiz dos arquivos gerados (QR codes)
+	}
+	QRCode struct {
+		PublicURL string       // URL pública das pesquisas; o link de acesso é acrescentado ao final
+		Size      int          // Largura padrão das imagens em pixels
+		Level     qrcode.Level // Nível de correção de erros padrão
+	}
 }
 
 // LoadConfig lê as variáveis de ambiente e preenche a struct Config, aplicando defaults quando necessário.
@@ -48,11 +103,147 @@ func LoadConfig() (*Config, error) {
 	cfg.Database.SSLMode = getEnvWithDefault("DB_SSLMODE", "disable")
 
 	cfg.JWT.Secret = os.Getenv("JWT_SECRET")
-	
+
+	accessTTL, err := time.ParseDuration(getEnvWithDefault("JWT_ACCESS_TTL", "15m"))
+	if err != nil || accessTTL <= 0 {
+		return nil, fmt.Errorf("JWT_ACCESS_TTL inválido: %v", err)
+	}
+	cfg.JWT.AccessTTL = accessTTL
+
+	refreshTTL, err := time.ParseDuration(getEnvWithDefault("JWT_REFRESH_TTL", "720h"))
+	if err != nil || refreshTTL <= 0 {
+		return nil, fmt.Errorf("JWT_REFRESH_TTL inválido: %v", err)
+	}
+	cfg.JWT.RefreshTTL = refreshTTL
+
 	cfg.Crypto.HashSalt = getEnvWithDefault("HASH_SALT", "default-salt-change-in-production-12345")
-	
+
 	cfg.Log.Level = getEnvWithDefault("LOG_LEVEL", "debug")
 
+	cfg.Scheduler.Enabled = getEnvWithDefault("SCHEDULER_ENABLED", "true") == "true"
+
+	interval, err := time.ParseDuration(getEnvWithDefault("SCHEDULER_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_INTERVAL inválido: %v", err)
+	}
+	cfg.Scheduler.Interval = interval
+
+	cleanupInterval, err := time.ParseDuration(getEnvWithDefault("SCHEDULER_CLEANUP_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_CLEANUP_INTERVAL inválido: %v", err)
+	}
+	cfg.Scheduler.CleanupInterval = cleanupInterval
+
+	cfg.RateLimit.Enabled = getEnvWithDefault("RATE_LIMIT_ENABLED", "true") == "true"
+
+	trustedProxies, err := parseTrustedProxies(getEnvWithDefault("RATE_LIMIT_TRUSTED_PROXIES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT_TRUSTED_PROXIES inválido: %v", err)
+	}
+	cfg.RateLimit.TrustedProxies = trustedProxies
+
+	limits := []struct {
+		env, def string
+		dst      *ratelimit.Limit
+	}{
+		{"RATE_LIMIT_PUBLIC", "60/1m", &cfg.RateLimit.Public},
+		{"RATE_LIMIT_AUTH", "10/1m", &cfg.RateLimit.Auth},
+		{"RATE_LIMIT_SUBMISSION", "20/1m", &cfg.RateLimit.Submission},
+		{"RATE_LIMIT_AUTHENTICATED", "300/1m", &cfg.RateLimit.Authenticated},
+	}
+	for _, l := range limits {
+		limit, err := ratelimit.ParseLimit(getEnvWithDefault(l.env, l.def))
+		if err != nil {
+			return nil, fmt.Errorf("%s inválido: %v", l.env, err)
+		}
+		*l.dst = limit
+	}
+
+	// Em produção o email só sai por SMTP: log e file expõem o link de redefinição de senha
+	defaultMailDriver := "log"
+	if cfg.App.Env == "production" {
+		defaultMailDriver = "smtp"
+	}
+	cfg.Mail.Driver = getEnvWithDefault("MAIL_DRIVER", defaultMailDriver)
+	cfg.Mail.From = getEnvWithDefault("MAIL_FROM", "no-reply@localhost")
+	cfg.Mail.SMTPHost = os.Getenv("SMTP_HOST")
+	cfg.Mail.SMTPPort = getEnvWithDefault("SMTP_PORT", "587")
+	cfg.Mail.SMTPUser = os.Getenv("SMTP_USER")
+	cfg.Mail.SMTPPassword = os.Getenv("SMTP_PASS")
+	cfg.Mail.Dir = getEnvWithDefault("MAIL_DIR", "tmp/mail")
+
+	switch cfg.Mail.Driver {
+	case "smtp":
+		if cfg.Mail.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST não configurado para MAIL_DRIVER=smtp")
+		}
+	case "file", "log":
+		if cfg.App.Env == "production" {
+			return nil, fmt.Errorf("MAIL_DRIVER=%s não é permitido com APP_ENV=production (use smtp)", cfg.Mail.Driver)
+		}
+	default:
+		return nil, fmt.Errorf("MAIL_DRIVER inválido: %q (use smtp, file ou log)", cfg.Mail.Driver)
+	}
+
+	resetTTL, err := time.ParseDuration(getEnvWithDefault("PASSWORD_RESET_TTL", "1h"))
+	if err != nil || resetTTL <= 0 {
+		return nil, fmt.Errorf("PASSWORD_RESET_TTL inválido: %v", err)
+	}
+	cfg.PasswordReset.TokenTTL = resetTTL
+	cfg.PasswordReset.URL = getEnvWithDefault("PASSWORD_RESET_URL", "http://localhost:3000/redefinir-senha")
+
+	cfg.MFA.Issuer = getEnvWithDefault("MFA_ISSUER", cfg.App.Name)
+
+	cfg.LoginProtection.Enabled = getEnvWithDefault("LOGIN_PROTECTION_ENABLED", "true") == "true"
+
+	failures := []struct {
+		env, def string
+		dst      *int
+	}{
+		{"LOGIN_MAX_FAILURES", "5", &cfg.LoginProtection.MaxFailures},
+		{"LOGIN_IP_MAX_FAILURES", "20", &cfg.LoginProtection.IPMaxFailures},
+	}
+	for _, f := range failures {
+		n, err := strconv.Atoi(getEnvWithDefault(f.env, f.def))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%s inválido: %q", f.env, os.Getenv(f.env))
+		}
+		*f.dst = n
+	}
+
+	durations := []struct {
+		env, def string
+		dst      *time.Duration
+	}{
+		{"LOGIN_LOCK_DURATION", "15m", &cfg.LoginProtection.LockDuration},
+		{"LOGIN_BACKOFF_BASE", "1s", &cfg.LoginProtection.BackoffBase},
+		{"LOGIN_BACKOFF_MAX", "1m", &cfg.LoginProtection.BackoffMax},
+		{"LOGIN_FAILURE_WINDOW", "15m", &cfg.LoginProtection.Window},
+	}
+	for _, d := range durations {
+		value, err := time.ParseDuration(getEnvWithDefault(d.env, d.def))
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("%s inválido: %q", d.env, os.Getenv(d.env))
+		}
+		*d.dst = value
+	}
+
+	cfg.Storage.Dir = getEnvWithDefault("STORAGE_DIR", "tmp/storage")
+
+	cfg.QRCode.PublicURL = getEnvWithDefault("SURVEY_PUBLIC_URL", "http://localhost:3000/pesquisa")
+
+	qrSize, err := strconv.Atoi(getEnvWithDefault("QRCODE_SIZE", strconv.Itoa(qrcode.DefaultSize)))
+	if err != nil || qrSize < qrcode.MinSize || qrSize > qrcode.MaxSize {
+		return nil, fmt.Errorf("QRCODE_SIZE inválido: %q (use %d a %d)", os.Getenv("QRCODE_SIZE"), qrcode.MinSize, qrcode.MaxSize)
+	}
+	cfg.QRCode.Size = qrSize
+
+	qrLevel, err := qrcode.ParseLevel(getEnvWithDefault("QRCODE_LEVEL", "M"))
+	if err != nil {
+		return nil, fmt.Errorf("QRCODE_LEVEL inválido: %v", err)
+	}
+	cfg.QRCode.Level = qrLevel
+
 	// Validações obrigatórias
 	if cfg.Database.Password == "" {
 		return nil, fmt.Errorf("DB_PASS não configurado nas variáveis de ambiente")
@@ -71,4 +262,34 @@ func getEnvWithDefault(key, defaultValue string) string {
 		return defaultValue
 	}
 	return value
-}
\ No newline at end of file
+}
+
+// parseTrustedProxies converte uma lista separada por vírgulas de IPs ou CIDRs em redes
+// Um IP isolado vira uma rede de um único endereço
+func parseTrustedProxies(value string) ([]*net.IPNet, error) {
+	var redes []*net.IPNet
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("endereço inválido: %s", item)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			redes = append(redes, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, rede, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("rede inválida: %s", item)
+		}
+		redes = append(redes, rede)
+	}
+	return redes, nil
+}
@@ -0,0 +1,43 @@
+// Package dto contém estruturas de transferência de dados (Data Transfer Objects)
+// utilizadas para comunicação entre as camadas de entrada e o domínio.
+// Este arquivo define os DTOs de criação e atualização das dimensões do clima.
+
+package dto
+
+import (
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"strings"
+)
+
+// DimensaoCreateRequest representa os dados necessários para criar uma dimensão da empresa.
+type DimensaoCreateRequest struct {
+	IDEmpresa int    `json:"id_empresa" binding:"required,gt=0"`    // Empresa dona da dimensão (obrigatório)
+	Nome      string `json:"nome" binding:"required,min=2,max=100"` // Nome da dimensão (obrigatório)
+	Descricao string `json:"descricao" binding:"max=500"`           // O que a dimensão avalia (opcional)
+}
+
+// DimensaoUpdateRequest representa os campos permitidos para atualização parcial de uma dimensão.
+type DimensaoUpdateRequest struct {
+	Nome      *string `json:"nome,omitempty" binding:"omitempty,min=2,max=100"` // Novo nome (opcional)
+	Descricao *string `json:"descricao,omitempty" binding:"omitempty,max=500"`  // Nova descrição (opcional)
+}
+
+// ToEntity converte a requisição de criação em uma entidade de domínio Dimensao.
+func (r *DimensaoCreateRequest) ToEntity() *entity.Dimensao {
+	return &entity.Dimensao{
+		IDEmpresa: r.IDEmpresa,
+		Nome:      strings.TrimSpace(r.Nome),
+		Descricao: strings.TrimSpace(r.Descricao),
+	}
+}
+
+// ApplyToEntity aplica os campos informados na requisição de atualização
+// sobre uma instância existente da entidade Dimensao.
+func (r *DimensaoUpdateRequest) ApplyToEntity(dimensao *entity.Dimensao) {
+	if r.Nome != nil {
+		dimensao.Nome = strings.TrimSpace(*r.Nome)
+	}
+	if r.Descricao != nil {
+		dimensao.Descricao = strings.TrimSpace(*r.Descricao)
+	}
+}
@@ -44,3 +44,14 @@ func (r *EmpresaUpdateRequest) ApplyToEntity(empresa *entity.Empresa) {
 		empresa.RazaoSocial = strings.TrimSpace(*r.RazaoSocial)
 	}
 }
+
+// EmpresaPoliticaMFARequest define a política de segundo fator da empresa.
+// O campo é ponteiro para distinguir "false" de ausente.
+type EmpresaPoliticaMFARequest struct {
+	MFAObrigatorio *bool `json:"mfa_obrigatorio"` // Exige segundo fator de todos os administradores
+}
+
+// EmpresaPoliticaAnonimatoRequest define o mínimo de respondentes exibido nos resultados da empresa.
+type EmpresaPoliticaAnonimatoRequest struct {
+	MinRespondentes *int `json:"min_respondentes"` // Menor grupo de respondentes exibido (3 a 50)
+}
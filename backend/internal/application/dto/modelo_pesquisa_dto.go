@@ -0,0 +1,93 @@
+// Package dto contém estruturas de transferência de dados (Data Transfer Objects)
+// utilizadas para comunicação entre as camadas de entrada e o domínio.
+// Este arquivo define os DTOs de modelos de pesquisa e da criação de pesquisas a partir de modelos.
+
+package dto
+
+import (
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"strings"
+)
+
+// PerguntaModeloRequest representa uma pergunta de um modelo de pesquisa.
+type PerguntaModeloRequest struct {
+	TextoPergunta      string  `json:"texto_pergunta" binding:"required,min=5,max=500"`                                             // Enunciado da pergunta (obrigatório)
+	TipoPergunta       string  `json:"tipo_pergunta" binding:"required,oneof=MultiplaEscolha RespostaAberta EscalaNumerica SimNao"` // Tipo da pergunta, restringido a opções válidas
+	OpcoesResposta     *string `json:"opcoes_resposta,omitempty"`                                                                   // Opções de múltipla escolha ou configuração da escala numérica, em JSON
+	Obrigatoria        *bool   `json:"obrigatoria,omitempty"`                                                                       // Resposta exigida quando exibida (opcional, padrão verdadeiro)
+	Dimensao           string  `json:"dimensao,omitempty" binding:"max=50"`                                                         // Dimensão do clima avaliada pela pergunta (opcional)
+	PontuacaoInvertida bool    `json:"pontuacao_invertida,omitempty"`                                                               // Inverte a pontuação da dimensão (opcional, escala e Sim/Não)
+}
+
+// ModeloPesquisaCreateRequest representa os dados necessários para criar um modelo privado da empresa.
+type ModeloPesquisaCreateRequest struct {
+	IDEmpresa int                     `json:"id_empresa" binding:"required,gt=0"`              // Empresa dona do modelo (obrigatório)
+	Nome      string                  `json:"nome" binding:"required,min=3,max=255"`           // Nome do modelo (obrigatório)
+	Descricao string                  `json:"descricao" binding:"max=1000"`                    // Finalidade do modelo (opcional)
+	Perguntas []PerguntaModeloRequest `json:"perguntas" binding:"required,min=1,max=100,dive"` // Perguntas em ordem de exibição (obrigatório)
+}
+
+// ModeloPesquisaUpdateRequest representa os campos permitidos para atualização parcial de um modelo.
+// Enviar as perguntas substitui a lista inteira e gera uma nova versão quando há alteração.
+type ModeloPesquisaUpdateRequest struct {
+	Nome      *string                  `json:"nome,omitempty" binding:"omitempty,min=3,max=255"`      // Novo nome (opcional)
+	Descricao *string                  `json:"descricao,omitempty" binding:"omitempty,max=1000"`      // Nova descrição (opcional)
+	Perguntas *[]PerguntaModeloRequest `json:"perguntas,omitempty" binding:"omitempty,min=1,max=100"` // Nova lista de perguntas (opcional)
+}
+
+// PesquisaFromModeloRequest representa os dados da pesquisa criada a partir de um modelo.
+// As perguntas vêm do modelo; versao escolhe uma versão anterior (0 ou ausente: versão atual).
+type PesquisaFromModeloRequest struct {
+	PesquisaCreateRequest
+	Versao int `json:"versao,omitempty" binding:"omitempty,gte=1"` // Versão do modelo a usar (opcional)
+}
+
+// ToEntity converte a pergunta da requisição em uma pergunta de modelo, sanitizando entradas textuais.
+func (r *PerguntaModeloRequest) ToEntity() entity.PerguntaModelo {
+	obrigatoria := true
+	if r.Obrigatoria != nil {
+		obrigatoria = *r.Obrigatoria
+	}
+	return entity.PerguntaModelo{
+		TextoPergunta:      strings.TrimSpace(r.TextoPergunta),
+		TipoPergunta:       r.TipoPergunta,
+		OpcoesResposta:     r.OpcoesResposta,
+		Obrigatoria:        obrigatoria,
+		Dimensao:           strings.TrimSpace(r.Dimensao),
+		PontuacaoInvertida: r.PontuacaoInvertida,
+	}
+}
+
+// perguntasModelo converte a lista de perguntas da requisição.
+func perguntasModelo(reqs []PerguntaModeloRequest) []entity.PerguntaModelo {
+	perguntas := make([]entity.PerguntaModelo, len(reqs))
+	for i := range reqs {
+		perguntas[i] = reqs[i].ToEntity()
+	}
+	return perguntas
+}
+
+// ToEntity converte a requisição de criação em uma entidade de domínio ModeloPesquisa.
+func (r *ModeloPesquisaCreateRequest) ToEntity() *entity.ModeloPesquisa {
+	empresaID := r.IDEmpresa
+	return &entity.ModeloPesquisa{
+		IDEmpresa: &empresaID,
+		Nome:      strings.TrimSpace(r.Nome),
+		Descricao: strings.TrimSpace(r.Descricao),
+		Perguntas: perguntasModelo(r.Perguntas),
+	}
+}
+
+// ApplyToEntity aplica os campos informados na requisição de atualização
+// sobre uma instância existente da entidade ModeloPesquisa.
+func (r *ModeloPesquisaUpdateRequest) ApplyToEntity(modelo *entity.ModeloPesquisa) {
+	if r.Nome != nil {
+		modelo.Nome = strings.TrimSpace(*r.Nome)
+	}
+	if r.Descricao != nil {
+		modelo.Descricao = strings.TrimSpace(*r.Descricao)
+	}
+	if r.Perguntas != nil {
+		modelo.Perguntas = perguntasModelo(*r.Perguntas)
+	}
+}
@@ -16,7 +16,11 @@ type PerguntaCreateRequest struct {
 	TextoPergunta  string  `json:"texto_pergunta" binding:"required,min=5,max=500"`                                          // Enunciado da pergunta (obrigatório)
 	TipoPergunta   string  `json:"tipo_pergunta" binding:"required,oneof=MultiplaEscolha RespostaAberta EscalaNumerica SimNao"` // Tipo da pergunta, restringido a opções válidas
 	OrdemExibicao  int     `json:"ordem_exibicao" binding:"required,gte=1"`                                                  // Posição de exibição da pergunta (obrigatório)
-	OpcoesResposta *string `json:"opcoes_resposta,omitempty"`                                                                // Opções disponíveis para múltipla escolha ou escala (opcional)
+	OpcoesResposta *string `json:"opcoes_resposta,omitempty"`                                                                // Opções de múltipla escolha (obrigatório) ou configuração da escala numérica, em JSON
+	CondicaoExibicao *string `json:"condicao_exibicao,omitempty"`                                                            // Condição de exibição sobre respostas de perguntas anteriores, em JSON (opcional)
+	Obrigatoria    *bool   `json:"obrigatoria,omitempty"`                                                                    // Resposta exigida quando exibida (opcional, padrão verdadeiro)
+	IDDimensao     *int    `json:"id_dimensao,omitempty" binding:"omitempty,gt=0"`                                           // Dimensão do clima avaliada pela pergunta (opcional)
+	PontuacaoInvertida bool `json:"pontuacao_invertida,omitempty"`                                                           // Inverte a pontuação no cálculo da dimensão (opcional)
 }
 
 // PerguntaUpdateRequest representa os campos permitidos para atualização parcial
@@ -26,17 +30,29 @@ type PerguntaUpdateRequest struct {
 	TipoPergunta   *string `json:"tipo_pergunta,omitempty" binding:"omitempty,oneof=MultiplaEscolha RespostaAberta EscalaNumerica SimNao"` // Novo tipo da pergunta (opcional)
 	OrdemExibicao  *int    `json:"ordem_exibicao,omitempty" binding:"omitempty,gte=1"`                                                  // Nova ordem de exibição (opcional)
 	OpcoesResposta *string `json:"opcoes_resposta,omitempty"`                                                                           // Novas opções de resposta (opcional)
+	CondicaoExibicao *string `json:"condicao_exibicao,omitempty"`                                                                       // Nova condição de exibição (opcional; vazia remove a condição)
+	Obrigatoria    *bool   `json:"obrigatoria,omitempty"`                                                                               // Torna a pergunta obrigatória ou opcional (opcional)
+	IDDimensao     *int    `json:"id_dimensao,omitempty" binding:"omitempty,gte=0"`                                                     // Nova dimensão (opcional; 0 remove a dimensão)
+	PontuacaoInvertida *bool `json:"pontuacao_invertida,omitempty"`                                                                    // Ativa ou desativa a pontuação invertida (opcional)
 }
 
 // ToEntity converte a requisição de criação em uma entidade de domínio Pergunta,
 // sanitizando entradas textuais.
 func (r *PerguntaCreateRequest) ToEntity() *entity.Pergunta {
+	obrigatoria := true
+	if r.Obrigatoria != nil {
+		obrigatoria = *r.Obrigatoria
+	}
 	return &entity.Pergunta{
 		IDPesquisa:     r.IDPesquisa,
 		TextoPergunta:  strings.TrimSpace(r.TextoPergunta),
 		TipoPergunta:   r.TipoPergunta,
 		OrdemExibicao:  r.OrdemExibicao,
 		OpcoesResposta: r.OpcoesResposta,
+		CondicaoExibicao: r.CondicaoExibicao,
+		Obrigatoria:    obrigatoria,
+		IDDimensao:     r.IDDimensao,
+		PontuacaoInvertida: r.PontuacaoInvertida,
 	}
 }
 
@@ -55,4 +71,19 @@ func (r *PerguntaUpdateRequest) ApplyToEntity(pergunta *entity.Pergunta) {
 	if r.OpcoesResposta != nil {
 		pergunta.OpcoesResposta = r.OpcoesResposta
 	}
+	if r.CondicaoExibicao != nil {
+		pergunta.CondicaoExibicao = r.CondicaoExibicao
+	}
+	if r.Obrigatoria != nil {
+		pergunta.Obrigatoria = *r.Obrigatoria
+	}
+	if r.IDDimensao != nil {
+		pergunta.IDDimensao = r.IDDimensao
+		if *r.IDDimensao == 0 {
+			pergunta.IDDimensao = nil
+		}
+	}
+	if r.PontuacaoInvertida != nil {
+		pergunta.PontuacaoInvertida = *r.PontuacaoInvertida
+	}
 }
@@ -103,3 +103,44 @@ func (r *PesquisaUpdateRequest) ApplyToEntity(pesquisa *entity.Pesquisa) error {
 
 	return nil
 }
+
+// PesquisaQRCodeRequest define as opções de geração do QR code da pesquisa.
+// Campos omitidos mantêm as opções da última geração (ou os padrões da aplicação).
+type PesquisaQRCodeRequest struct {
+	Tamanho       int    `json:"tamanho,omitempty"`        // Largura da imagem em pixels (64 a 2048)
+	NivelCorrecao string `json:"nivel_correcao,omitempty"` // Nível de correção de erros: L, M, Q ou H
+	Logo          []byte `json:"logo,omitempty"`           // Logo da empresa em PNG ou JPEG, codificado em base64
+	RemoverLogo   bool   `json:"remover_logo,omitempty"`   // Remove o logo usado nas gerações anteriores
+}
+
+// PesquisaCloneRequest define os campos que podem ser substituídos ao clonar uma pesquisa.
+// Campos omitidos mantêm os valores da pesquisa de origem; o período da origem não é copiado.
+type PesquisaCloneRequest struct {
+	Titulo         *string `json:"titulo,omitempty" binding:"omitempty,min=3,max=255"` // Novo título (opcional)
+	IDSetor        *int    `json:"id_setor,omitempty" binding:"omitempty,gt=0"`        // Novo setor alvo (opcional)
+	DataAbertura   *string `json:"data_abertura,omitempty"`                            // Data de início no formato RFC3339 (opcional)
+	DataFechamento *string `json:"data_fechamento,omitempty"`                          // Data de término no formato RFC3339 (opcional)
+}
+
+// ParseDatas converte as datas informadas na requisição de clonagem.
+func (r *PesquisaCloneRequest) ParseDatas() (*time.Time, *time.Time, error) {
+	var abertura, fechamento *time.Time
+
+	if r.DataAbertura != nil {
+		t, err := time.Parse(time.RFC3339, *r.DataAbertura)
+		if err != nil {
+			return nil, nil, fmt.Errorf("data_abertura inválida: %v", err)
+		}
+		abertura = &t
+	}
+
+	if r.DataFechamento != nil {
+		t, err := time.Parse(time.RFC3339, *r.DataFechamento)
+		if err != nil {
+			return nil, nil, fmt.Errorf("data_fechamento inválida: %v", err)
+		}
+		fechamento = &t
+	}
+
+	return abertura, fechamento, nil
+}
@@ -5,9 +5,30 @@ import "time"
 
 // LoginResponse representa a resposta enviada após login bem-sucedido.
 type LoginResponse struct {
-    Token     string   `json:"token"`       // JWT ou token de autenticação
-    ExpiresIn int64    `json:"expires_in"`  // Tempo de expiração em segundos
-    User      UserInfo `json:"user"`        // Informações básicas do usuário logado
+    Token              string   `json:"token"`                         // Access token JWT
+    ExpiresIn          int64    `json:"expires_in"`                    // Tempo de expiração do access token em segundos
+    RefreshToken       string   `json:"refresh_token,omitempty"`       // Refresh token opaco, trocado a cada renovação
+    User               UserInfo `json:"user"`                          // Informações básicas do usuário logado
+    CodigosRecuperacao []string `json:"codigos_recuperacao,omitempty"` // Códigos de recuperação, quando o segundo fator é cadastrado no login
+}
+
+// MFAChallengeResponse é retornado pelo login quando o segundo fator é exigido.
+type MFAChallengeResponse struct {
+    MFARequired        bool   `json:"mfa_required"`        // Sempre true: o login depende do segundo fator
+    MFAToken           string `json:"mfa_token"`           // Token pendente para /auth/mfa/enroll e /auth/mfa/verify
+    EnrollmentRequired bool   `json:"enrollment_required"` // Política exige cadastro antes da verificação
+    ExpiresIn          int64  `json:"expires_in"`          // Validade do token pendente em segundos
+}
+
+// MFAEnrollmentResponse traz o segredo TOTP para cadastro no aplicativo autenticador.
+type MFAEnrollmentResponse struct {
+    Segredo string `json:"segredo"` // Segredo em base32 para digitação manual
+    URI     string `json:"uri"`     // URI otpauth:// para QR code
+}
+
+// MFARecoveryCodesResponse traz códigos de recuperação, exibidos uma única vez.
+type MFARecoveryCodesResponse struct {
+    CodigosRecuperacao []string `json:"codigos_recuperacao"` // Códigos de uso único
 }
 
 // UserInfo mantém dados do usuário.
@@ -17,12 +38,25 @@ type UserInfo struct {
     Email     string `json:"email"`       // E-mail
     EmpresaID int    `json:"empresa_id"`  // Empresa associada
     Status    string `json:"status"`      // Status do usuário (Ativo/Inativo)
+    Papel     string `json:"papel"`       // Papel que define as permissões
 }
 
 // RefreshTokenResponse representa a resposta ao renovar um token.
 type RefreshTokenResponse struct {
-    Token     string `json:"token"`       // Novo token de autenticação
-    ExpiresIn int64  `json:"expires_in"`  // Expiração em segundos
+    Token        string `json:"token"`         // Novo access token
+    ExpiresIn    int64  `json:"expires_in"`    // Expiração em segundos
+    RefreshToken string `json:"refresh_token"` // Novo refresh token (o anterior deixa de valer)
+}
+
+// SessaoResponse representa uma sessão de login ativa.
+type SessaoResponse struct {
+    ID            int       `json:"id"`              // ID da sessão
+    DataCriacao   time.Time `json:"data_criacao"`    // Login que originou a sessão
+    DataUltimoUso time.Time `json:"data_ultimo_uso"` // Última renovação de token
+    DataExpiracao time.Time `json:"data_expiracao"`  // Expiração da sessão
+    EnderecoIP    string    `json:"endereco_ip"`     // IP do login
+    UserAgent     string    `json:"user_agent"`      // Navegador/cliente do login
+    Atual         bool      `json:"atual"`           // Sessão do token usado na requisição
 }
 
 // TokenValidationResponse é retornado ao validar um token.
@@ -66,6 +66,18 @@ func NewPaginatedResponse(data interface{}, pagination PaginationInfo, message .
 	}
 }
 
+// WriteErrorWithData escreve resposta JSON de erro com dados adicionais (ex.: erros por campo).
+func WriteErrorWithData(w http.ResponseWriter, status int, message, err string, data interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(APIResponse{
+        Success: false,
+        Message: message,
+        Data:    data,
+        Error:   err,
+    })
+}
+
 // WriteSuccess escreve resposta JSON de sucesso no ResponseWriter HTTP.
 func WriteSuccess(w http.ResponseWriter, status int, message string, data interface{}) {
     w.Header().Set("Content-Type", "application/json")
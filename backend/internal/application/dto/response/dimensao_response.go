@@ -0,0 +1,14 @@
+// Package response contém structs usadas para enviar dados da API como respostas.
+// DimensaoResponse representa a estrutura de resposta de uma dimensão do clima.
+package response
+
+import "time"
+
+// DimensaoResponse retorna os dados de uma dimensão da empresa.
+type DimensaoResponse struct {
+	ID          int       `json:"id_dimensao"`  // ID único da dimensão
+	IDEmpresa   int       `json:"id_empresa"`   // Empresa dona da dimensão
+	Nome        string    `json:"nome"`         // Nome da dimensão
+	Descricao   string    `json:"descricao"`    // O que a dimensão avalia
+	DataCriacao time.Time `json:"data_criacao"` // Data de criação
+}
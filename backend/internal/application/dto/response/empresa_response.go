@@ -13,6 +13,8 @@ type EmpresaResponse struct {
     RazaoSocial    string    `json:"razao_social"`          // Razão social da empresa
     CNPJ           string    `json:"cnpj"`                  // CNPJ da empresa
     DataCadastro   time.Time `json:"data_cadastro"`         // Data de cadastro da empresa
+    MFAObrigatorio bool      `json:"mfa_obrigatorio"`       // Segundo fator obrigatório para os administradores
+    MinRespondentes int      `json:"min_respondentes"`      // Menor grupo de respondentes exibido nos resultados
     TotalSetores   int       `json:"total_setores,omitempty"`  // Número de setores, preenchido opcionalmente
     TotalAdmins    int       `json:"total_admins,omitempty"`   // Número de administradores, opcional
     TotalPesquisas int       `json:"total_pesquisas,omitempty"` // Número de pesquisas, opcional
@@ -21,11 +23,13 @@ type EmpresaResponse struct {
 // ToEmpresaResponse converte a entidade Empresa para a struct de resposta
 func ToEmpresaResponse(empresa *entity.Empresa) EmpresaResponse {
     return EmpresaResponse{
-        ID:           empresa.ID,
-        NomeFantasia: empresa.NomeFantasia,
-        RazaoSocial:  empresa.RazaoSocial,
-        CNPJ:         empresa.CNPJ,
-        DataCadastro: empresa.DataCadastro,
+        ID:             empresa.ID,
+        NomeFantasia:   empresa.NomeFantasia,
+        RazaoSocial:    empresa.RazaoSocial,
+        CNPJ:           empresa.CNPJ,
+        DataCadastro:   empresa.DataCadastro,
+        MFAObrigatorio: empresa.MFAObrigatorio,
+        MinRespondentes: empresa.MinRespondentes,
         // Campos opcionais podem ser preenchidos posteriormente
     }
 }
@@ -0,0 +1,34 @@
+// Package response contém structs usadas para enviar dados da API como respostas.
+// FormularioPublicoResponse representa o formulário de uma pesquisa exposto ao respondente anônimo.
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FormularioPublicoResponse retorna apenas os dados necessários para responder a pesquisa.
+// Campos administrativos (administrador criador, empresa, setor, QR code) não são expostos.
+type FormularioPublicoResponse struct {
+	IDPesquisa     int                          `json:"id_pesquisa"`               // ID usado para solicitar o token de resposta
+	Titulo         string                       `json:"titulo"`                    // Título da pesquisa
+	Descricao      string                       `json:"descricao"`                 // Descrição da pesquisa
+	Anonimato      bool                         `json:"anonimato"`                 // Indica se as respostas são anônimas
+	Situacao       string                       `json:"situacao"`                  // aberta, agendada ou encerrada
+	Aberta         bool                         `json:"aberta"`                    // Indica se a pesquisa aceita respostas agora
+	Mensagem       string                       `json:"mensagem,omitempty"`        // Explicação para pesquisas fora do período
+	DataAbertura   *time.Time                   `json:"data_abertura,omitempty"`   // Início do período de respostas
+	DataFechamento *time.Time                   `json:"data_fechamento,omitempty"` // Fim do período de respostas
+	Perguntas      []PerguntaFormularioResponse `json:"perguntas,omitempty"`       // Perguntas em ordem de exibição (somente aberta)
+}
+
+// PerguntaFormularioResponse representa uma pergunta do formulário público.
+type PerguntaFormularioResponse struct {
+	ID               int             `json:"id_pergunta"`                 // ID usado na submissão da resposta
+	TextoPergunta    string          `json:"texto_pergunta"`              // Texto exibido ao respondente
+	TipoPergunta     string          `json:"tipo_pergunta"`               // MultiplaEscolha, RespostaAberta, EscalaNumerica ou SimNao
+	OrdemExibicao    int             `json:"ordem_exibicao"`              // Posição da pergunta no formulário
+	OpcoesResposta   json.RawMessage `json:"opcoes_resposta,omitempty"`   // Opções de resposta já interpretadas
+	CondicaoExibicao json.RawMessage `json:"condicao_exibicao,omitempty"` // Condição para exibir a pergunta, avaliada com as respostas anteriores
+	Obrigatoria      bool            `json:"obrigatoria"`                 // Resposta exigida quando a pergunta é exibida
+}
@@ -0,0 +1,35 @@
+// Package response contém structs usadas para enviar dados da API como respostas.
+// ModeloPesquisaResponse representa a estrutura de resposta de um modelo de pesquisa.
+package response
+
+import "time"
+
+// ModeloPesquisaResponse retorna um modelo da biblioteca e, quando carregadas, as perguntas da versão consultada.
+type ModeloPesquisaResponse struct {
+	ID              int                      `json:"id_modelo"`           // ID único do modelo
+	IDEmpresa       *int                     `json:"id_empresa"`          // Empresa dona do modelo (nulo nos modelos do sistema)
+	DoSistema       bool                     `json:"do_sistema"`          // Modelo do sistema, somente leitura
+	Nome            string                   `json:"nome"`                // Nome do modelo
+	Descricao       string                   `json:"descricao"`           // Finalidade do modelo
+	Versao          int                      `json:"versao"`              // Versão das perguntas retornadas (ou a atual, na listagem)
+	DataCriacao     time.Time                `json:"data_criacao"`        // Data de criação do modelo
+	DataAtualizacao time.Time                `json:"data_atualizacao"`    // Data da última alteração
+	Perguntas       []PerguntaModeloResponse `json:"perguntas,omitempty"` // Perguntas em ordem de exibição, opcional
+}
+
+// PerguntaModeloResponse retorna uma pergunta de um modelo de pesquisa.
+type PerguntaModeloResponse struct {
+	TextoPergunta      string  `json:"texto_pergunta"`      // Texto da pergunta
+	TipoPergunta       string  `json:"tipo_pergunta"`       // Tipo da pergunta (MultiplaEscolha, RespostaAberta, EscalaNumerica, SimNao)
+	OpcoesResposta     *string `json:"opcoes_resposta"`     // Opções de resposta ou configuração da escala, em JSON
+	Obrigatoria        bool    `json:"obrigatoria"`         // Resposta exigida quando a pergunta é exibida
+	Dimensao           string  `json:"dimensao,omitempty"`  // Dimensão do clima avaliada pela pergunta
+	PontuacaoInvertida bool    `json:"pontuacao_invertida"` // Pontuação da dimensão invertida para a pergunta
+}
+
+// VersaoModeloResponse retorna uma entrada do histórico de versões de um modelo.
+type VersaoModeloResponse struct {
+	Versao      int       `json:"versao"`        // Número da versão
+	IDUserAdmin *int      `json:"id_user_admin"` // Administrador que gerou a versão (nulo nos modelos do sistema)
+	DataCriacao time.Time `json:"data_criacao"`  // Quando a versão foi gerada
+}
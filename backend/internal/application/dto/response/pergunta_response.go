@@ -9,6 +9,10 @@ type PerguntaResponse struct {
 	TipoPergunta   string                 `json:"tipo_pergunta"`            // Tipo da pergunta (MultiplaEscolha, RespostaAberta, EscalaNumerica, SimNao)
 	OrdemExibicao  int                    `json:"ordem_exibicao"`           // Posição da pergunta na pesquisa
 	OpcoesResposta *string                `json:"opcoes_resposta"`          // Opções de resposta, se aplicável (para múltipla escolha)
+	CondicaoExibicao *string              `json:"condicao_exibicao"`        // Condição de exibição em JSON (nula quando a pergunta é sempre exibida)
+	Obrigatoria    bool                   `json:"obrigatoria"`              // Resposta exigida quando a pergunta é exibida
+	IDDimensao     *int                   `json:"id_dimensao"`              // Dimensão do clima avaliada pela pergunta (nula quando sem dimensão)
+	PontuacaoInvertida bool               `json:"pontuacao_invertida"`      // Pontuação invertida no cálculo da dimensão
 	TotalRespostas int                    `json:"total_respostas,omitempty"`// Total de respostas recebidas, opcional
 	Estatisticas   map[string]interface{} `json:"estatisticas,omitempty"`   // Estatísticas agregadas da pergunta, opcional
 }
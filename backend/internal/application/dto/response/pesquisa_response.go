@@ -19,6 +19,7 @@ type PesquisaResponse struct {
 	LinkAcesso           string                        `json:"link_acesso"`                        // Link de acesso à pesquisa
 	QRCodePath           string                        `json:"qrcode_path"`                        // Caminho para QR Code da pesquisa
 	Anonimato            bool                          `json:"anonimato"`                          // Indica se a pesquisa é anônima
+	IDPesquisaOrigem     *int                          `json:"id_pesquisa_origem,omitempty"`       // Pesquisa copiada, quando criada por clonagem
 	TotalPerguntas       int                           `json:"total_perguntas,omitempty"`          // Número total de perguntas, opcional
 	TotalRespostas       int                           `json:"total_respostas,omitempty"`          // Número total de respostas, opcional
 	TaxaParticipacao     float64                       `json:"taxa_participacao,omitempty"`        // Taxa média de participação, opcional
@@ -26,3 +27,19 @@ type PesquisaResponse struct {
 	Setor                *SetorResponse                `json:"setor,omitempty"`                    // Informações do setor da pesquisa, opcional
 	Perguntas            []PerguntaResponse            `json:"perguntas,omitempty"`                // Lista de perguntas da pesquisa, opcional
 }
+
+// PesquisaQRCodeResponse retorna o QR code gerado e os endereços para download das imagens.
+type PesquisaQRCodeResponse struct {
+	QRCodePath  string `json:"qr_code_path"` // Caminho da imagem no armazenamento
+	LinkAcesso  string `json:"link_acesso"`  // Link de acesso codificado
+	URLPublica  string `json:"url_publica"`  // URL completa codificada no QR code
+	DownloadPNG string `json:"download_png"` // Endpoint autenticado para download em PNG
+	DownloadSVG string `json:"download_svg"` // Endpoint autenticado para download em SVG
+}
+
+// PesquisaLinkResponse retorna o novo link de acesso da pesquisa.
+type PesquisaLinkResponse struct {
+	LinkAcesso string `json:"link_acesso"`            // Novo link de acesso
+	URLPublica string `json:"url_publica"`            // URL completa de resposta
+	QRCodePath string `json:"qr_code_path,omitempty"` // QR code regenerado para o novo link, se havia um
+}
@@ -12,5 +12,15 @@ type UsuarioAdministradorResponse struct {
 	Email        string           `json:"email"`              // E-mail de login do administrador
 	DataCadastro time.Time        `json:"data_cadastro"`      // Data e hora de criação do registro
 	Status       string           `json:"status"`             // Status atual do administrador (Ativo, Inativo, Pendente)
+	Papel        string           `json:"papel"`              // Papel que define as permissões do administrador
 	Empresa      *EmpresaResponse `json:"empresa,omitempty"`  // Empresa associada ao usuário, se aplicável
 }
+
+// BloqueioLoginResponse representa uma conta com login temporariamente bloqueado.
+type BloqueioLoginResponse struct {
+	IDUserAdmin  int       `json:"id_user_admin"` // Usuário bloqueado
+	Email        string    `json:"email"`         // Email usado nas tentativas
+	Falhas       int       `json:"falhas"`        // Falhas consecutivas registradas
+	UltimaFalha  time.Time `json:"ultima_falha"`  // Momento da última falha
+	BloqueadoAte time.Time `json:"bloqueado_ate"` // Fim do bloqueio temporário
+}
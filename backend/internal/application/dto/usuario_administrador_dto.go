@@ -19,6 +19,7 @@ type UsuarioAdministradorCreateRequest struct {
 	Email     string `json:"email" binding:"required,email,max=255"`                   // E-mail válido do administrador
 	Senha     string `json:"senha" binding:"required,min=8,max=128"`                   // Senha em texto plano (antes do hash)
 	Status    string `json:"status" binding:"required,oneof=Ativo Inativo Pendente"`   // Estado atual do usuário no sistema
+	Papel     string `json:"papel,omitempty"`                                          // Papel do usuário (padrão: analista)
 }
 
 // UsuarioAdministradorUpdateRequest representa os campos opcionais
@@ -46,6 +47,7 @@ func (r *UsuarioAdministradorCreateRequest) ToEntity(senhaHash string) *entity.U
 		Email:     strings.ToLower(strings.TrimSpace(r.Email)),
 		SenhaHash: senhaHash,
 		Status:    r.Status,
+		Papel:     r.Papel,
 	}
 }
 
@@ -0,0 +1,177 @@
+// Package handler implementa os controladores HTTP do módulo de Analytics.
+// Expõe métricas por pesquisa, comparações entre pesquisas e setores e tendências temporais.
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"organizational-climate-survey/backend/internal/application/dto/response"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+// AnalyticsHandler gerencia as rotas HTTP de análise de dados
+type AnalyticsHandler struct {
+	analyticsUseCase *usecase.AnalyticsUseCase // Caso de uso de análises
+	log              logger.Logger             // Logger para registrar eventos e erros
+}
+
+// NewAnalyticsHandler instancia um novo handler de analytics com dependências injetadas
+func NewAnalyticsHandler(analyticsUseCase *usecase.AnalyticsUseCase, log logger.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+		log:              log,
+	}
+}
+
+// GetPesquisaMetrics retorna métricas agregadas de uma pesquisa
+func (h *AnalyticsHandler) GetPesquisaMetrics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pesquisaID, err := strconv.Atoi(vars["pesquisa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da pesquisa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	metrics, err := h.analyticsUseCase.GetPesquisaMetrics(r.Context(), pesquisaID, h.getUserAdminIDFromContext(r), h.getClientIP(r))
+	if err != nil {
+		h.writeUseCaseError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Métricas da pesquisa obtidas com sucesso", metrics)
+}
+
+// GetComparisonData compara métricas entre pesquisas informadas em ?pesquisas=1,2,3
+func (h *AnalyticsHandler) GetComparisonData(w http.ResponseWriter, r *http.Request) {
+	pesquisaIDs, err := h.parseIDList(r.URL.Query().Get("pesquisas"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Parâmetro inválido", err.Error())
+		return
+	}
+
+	if len(pesquisaIDs) < 2 {
+		response.WriteError(w, http.StatusBadRequest, "Parâmetro inválido", "Informe ao menos duas pesquisas em 'pesquisas' (ex: ?pesquisas=1,2)")
+		return
+	}
+
+	comparison, err := h.analyticsUseCase.GetComparisonData(r.Context(), pesquisaIDs, h.getUserAdminIDFromContext(r), h.getClientIP(r))
+	if err != nil {
+		h.writeUseCaseError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Comparação entre pesquisas gerada com sucesso", comparison)
+}
+
+// GetSetorComparison compara uma pesquisa com as pesquisas dos demais setores da empresa
+func (h *AnalyticsHandler) GetSetorComparison(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	empresaID, err := strconv.Atoi(vars["empresa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da empresa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	pesquisaID, err := strconv.Atoi(vars["pesquisa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da pesquisa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	comparison, err := h.analyticsUseCase.GetSetorComparison(r.Context(), empresaID, pesquisaID, h.getUserAdminIDFromContext(r), h.getClientIP(r))
+	if err != nil {
+		h.writeUseCaseError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Comparação por setor gerada com sucesso", comparison)
+}
+
+// GetTrendAnalysis retorna a série temporal da empresa para o período em ?period= (padrão 30days)
+func (h *AnalyticsHandler) GetTrendAnalysis(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	empresaID, err := strconv.Atoi(vars["empresa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da empresa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "30days"
+	}
+
+	trends, err := h.analyticsUseCase.GetTrendAnalysis(r.Context(), empresaID, period, h.getUserAdminIDFromContext(r), h.getClientIP(r))
+	if err != nil {
+		h.writeUseCaseError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Análise de tendências gerada com sucesso", trends)
+}
+
+// writeUseCaseError traduz erros do caso de uso para o status HTTP adequado
+func (h *AnalyticsHandler) writeUseCaseError(w http.ResponseWriter, r *http.Request, err error) {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "não encontrad"):
+		response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", msg)
+	case strings.Contains(msg, "erro ao"):
+		h.log.WithContext(r.Context()).Error("Erro ao gerar análise: %v", err)
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", msg)
+	default:
+		// Demais erros são violações de regra de negócio (status, empresa, limites)
+		response.WriteError(w, http.StatusBadRequest, "Análise indisponível", msg)
+	}
+}
+
+// parseIDList converte uma lista separada por vírgulas em IDs inteiros
+func (h *AnalyticsHandler) parseIDList(raw string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// getUserAdminIDFromContext obtém o ID do usuário administrativo autenticado
+func (h *AnalyticsHandler) getUserAdminIDFromContext(r *http.Request) int {
+	if userID := r.Context().Value("user_admin_id"); userID != nil {
+		if id, ok := userID.(int); ok {
+			return id
+		}
+	}
+	return 0
+}
+
+// getClientIP identifica o IP real do cliente, considerando cabeçalhos de proxy
+func (h *AnalyticsHandler) getClientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		return strings.Split(ip, ",")[0]
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// RegisterRoutes associa todas as rotas HTTP deste handler
+func (h *AnalyticsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/analytics", h.GetPesquisaMetrics).Methods("GET")
+	router.HandleFunc("/analytics/comparison", h.GetComparisonData).Methods("GET")
+	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/pesquisas/{pesquisa_id:[0-9]+}/analytics/setores", h.GetSetorComparison).Methods("GET")
+	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/analytics/trends", h.GetTrendAnalysis).Methods("GET")
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+// novoRouterAnalytics registra as rotas de analytics com pesquisas das empresas 1 e 2
+func novoRouterAnalytics(analyticsRepo *fakeAnalyticsRepo) *mux.Router {
+	pesquisaRepo := &fakePesquisaRepo{pesquisas: map[int]*entity.Pesquisa{
+		1: {ID: 1, IDEmpresa: 1, Titulo: "Clima 2025", Status: "Concluída"},
+		2: {ID: 2, IDEmpresa: 1, Titulo: "Clima 2026", Status: "Concluída"},
+		3: {ID: 3, IDEmpresa: 1, Titulo: "Rascunho", Status: "Rascunho"},
+		4: {ID: 4, IDEmpresa: 2, Titulo: "Outra empresa", Status: "Concluída"},
+		5: {ID: 5, IDEmpresa: 1, Titulo: "Em andamento", Status: "Ativa"},
+	}}
+	uc := usecase.NewAnalyticsUseCase(analyticsRepo, pesquisaRepo, &fakeLogRepo{})
+
+	router := mux.NewRouter()
+	NewAnalyticsHandler(uc, logger.New(nil)).RegisterRoutes(router)
+	return router
+}
+
+// getAnalytics executa a requisição como administrador da empresa 1
+func getAnalytics(router *mux.Router, caminho string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", caminho, nil)
+	ctx := usecase.WithEmpresaScope(r.Context(), 1)
+	r = r.WithContext(context.WithValue(ctx, "user_admin_id", 1))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestParseIDList(t *testing.T) {
+	casos := []struct {
+		raw  string
+		ids  []int
+		erro bool
+	}{
+		{"1,2", []int{1, 2}, false},
+		{" 1 , 2 ,", []int{1, 2}, false},
+		{"", nil, false},
+		{",", nil, false},
+		{"1,abc", nil, true},
+		{"1;2", nil, true},
+	}
+
+	h := NewAnalyticsHandler(nil, logger.New(nil))
+	for _, c := range casos {
+		t.Run(c.raw, func(t *testing.T) {
+			ids, err := h.parseIDList(c.raw)
+			if (err != nil) != c.erro {
+				t.Fatalf("erro = %v, esperado erro %v", err, c.erro)
+			}
+			if !reflect.DeepEqual(ids, c.ids) {
+				t.Errorf("IDs %v, esperado %v", ids, c.ids)
+			}
+		})
+	}
+}
+
+func TestAnalyticsRotas(t *testing.T) {
+	casos := []struct {
+		nome    string
+		caminho string
+		status  int
+	}{
+		// Métricas da pesquisa
+		{"métricas", "/pesquisas/1/analytics", http.StatusOK},
+		{"métricas de outra empresa", "/pesquisas/4/analytics", http.StatusNotFound},
+		{"métricas de pesquisa inexistente", "/pesquisas/99/analytics", http.StatusNotFound},
+		{"métricas de rascunho", "/pesquisas/3/analytics", http.StatusBadRequest},
+
+		// Comparação entre pesquisas
+		{"comparação", "/analytics/comparison?pesquisas=1,2", http.StatusOK},
+		{"comparação com espaços e vírgula final", "/analytics/comparison?pesquisas=1,%202%20,", http.StatusOK},
+		{"comparação sem parâmetro", "/analytics/comparison", http.StatusBadRequest},
+		{"comparação com lista vazia", "/analytics/comparison?pesquisas=", http.StatusBadRequest},
+		{"comparação com uma pesquisa", "/analytics/comparison?pesquisas=1", http.StatusBadRequest},
+		{"comparação com ID não numérico", "/analytics/comparison?pesquisas=1,abc", http.StatusBadRequest},
+		{"comparação com ID negativo", "/analytics/comparison?pesquisas=1,-2", http.StatusBadRequest},
+		{"comparação com pesquisa de outra empresa", "/analytics/comparison?pesquisas=1,4", http.StatusNotFound},
+		{"comparação com pesquisa não concluída", "/analytics/comparison?pesquisas=1,5", http.StatusBadRequest},
+
+		// Comparação por setor
+		{"setores", "/empresas/1/pesquisas/1/analytics/setores", http.StatusOK},
+		{"setores de outra empresa", "/empresas/2/pesquisas/4/analytics/setores", http.StatusNotFound},
+		{"setores com pesquisa de outra empresa", "/empresas/1/pesquisas/4/analytics/setores", http.StatusNotFound},
+		{"setores de pesquisa não concluída", "/empresas/1/pesquisas/5/analytics/setores", http.StatusBadRequest},
+
+		// Tendências
+		{"tendências", "/empresas/1/analytics/trends", http.StatusOK},
+		{"tendências de um ano", "/empresas/1/analytics/trends?period=1year", http.StatusOK},
+		{"tendências com período inválido", "/empresas/1/analytics/trends?period=2weeks", http.StatusBadRequest},
+		{"tendências de outra empresa", "/empresas/2/analytics/trends", http.StatusNotFound},
+	}
+
+	router := novoRouterAnalytics(&fakeAnalyticsRepo{})
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if w := getAnalytics(router, c.caminho); w.Code != c.status {
+				t.Errorf("status %d, esperado %d: %s", w.Code, c.status, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAnalyticsFalhaDoRepositorio(t *testing.T) {
+	router := novoRouterAnalytics(&fakeAnalyticsRepo{falha: fmt.Errorf("conexão recusada")})
+
+	for _, caminho := range []string{
+		"/pesquisas/1/analytics",
+		"/analytics/comparison?pesquisas=1,2",
+		"/empresas/1/pesquisas/1/analytics/setores",
+		"/empresas/1/analytics/trends",
+	} {
+		if w := getAnalytics(router, caminho); w.Code != http.StatusInternalServerError {
+			t.Errorf("%s: status %d, esperado 500", caminho, w.Code)
+		}
+	}
+}
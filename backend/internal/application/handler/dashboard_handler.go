@@ -60,6 +60,10 @@ func (h *DashboardHandler) CreateDashboard(w http.ResponseWriter, r *http.Reques
 			response.WriteError(w, http.StatusConflict, "Dashboard já existe", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -81,7 +85,7 @@ func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request)
 	// Busca o dashboard pelo ID
 	dashboard, err := h.dashboardUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -103,7 +107,7 @@ func (h *DashboardHandler) GetDashboardByPesquisa(w http.ResponseWriter, r *http
 
 	dashboard, err := h.dashboardUseCase.GetByPesquisaID(r.Context(), pesquisaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado para esta pesquisa", err.Error())
 			return
 		}
@@ -125,6 +129,10 @@ func (h *DashboardHandler) ListDashboardsByEmpresa(w http.ResponseWriter, r *htt
 
 	dashboards, err := h.dashboardUseCase.ListByEmpresa(r.Context(), empresaID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -156,7 +164,7 @@ func (h *DashboardHandler) UpdateDashboard(w http.ResponseWriter, r *http.Reques
 	// Recupera o dashboard atual
 	dashboard, err := h.dashboardUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -171,6 +179,10 @@ func (h *DashboardHandler) UpdateDashboard(w http.ResponseWriter, r *http.Reques
 	clientIP := h.getClientIP(r)
 
 	if err := h.dashboardUseCase.Update(r.Context(), dashboard, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -191,7 +203,7 @@ func (h *DashboardHandler) DeleteDashboard(w http.ResponseWriter, r *http.Reques
 	clientIP := h.getClientIP(r)
 
 	if err := h.dashboardUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -215,7 +227,7 @@ func (h *DashboardHandler) GetDashboardData(w http.ResponseWriter, r *http.Reque
 
 	dashboardData, err := h.dashboardUseCase.GetDashboardData(r.Context(), id, filters)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -239,7 +251,7 @@ func (h *DashboardHandler) RefreshDashboard(w http.ResponseWriter, r *http.Reque
 	clientIP := h.getClientIP(r)
 
 	if err := h.dashboardUseCase.RefreshDashboard(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -265,38 +277,35 @@ func (h *DashboardHandler) ExportDashboard(w http.ResponseWriter, r *http.Reques
 	}
 
 	if !h.isValidExportFormat(format) {
-		response.WriteError(w, http.StatusBadRequest, "Formato inválido", "Formato deve ser: pdf ou excel")
+		response.WriteError(w, http.StatusBadRequest, "Formato inválido", "Formato deve ser: pdf, xlsx ou csv")
 		return
 	}
 
 	userAdminID := h.getUserAdminIDFromContext(r)
 	clientIP := h.getClientIP(r)
 
-	exportData, err := h.dashboardUseCase.GenerateReport(r.Context(), id, format, userAdminID, clientIP)
+	file, err := h.dashboardUseCase.GenerateReport(r.Context(), id, format, userAdminID, clientIP)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "rascunho") {
+			response.WriteError(w, http.StatusBadRequest, "Relatório indisponível", err.Error())
+			return
+		}
+		h.log.WithFields(map[string]interface{}{"dashboard_id": id, "format": format}).Error("Erro ao gerar relatório: %v", err)
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
 
-	// Configuração dos headers de resposta conforme o tipo de exportação
-	switch format {
-	case "pdf":
-		w.Header().Set("Content-Type", "application/pdf")
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=dashboard_%d.pdf", id))
-	case "xlsx":
-		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=dashboard_%d.xlsx", id))
-	case "csv":
-		w.Header().Set("Content-Type", "text/csv")
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=dashboard_%d.csv", id))
-	}
+	// Headers de download conforme o arquivo gerado
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.Name))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
 
 	w.WriteHeader(http.StatusOK)
-	w.Write(exportData)
+	w.Write(file.Data)
 }
 
 // GetDashboardMetrics retorna métricas resumidas de um dashboard
@@ -310,7 +319,7 @@ func (h *DashboardHandler) GetDashboardMetrics(w http.ResponseWriter, r *http.Re
 
 	metrics, err := h.dashboardUseCase.GetDashboardMetrics(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
 			return
 		}
@@ -321,6 +330,28 @@ func (h *DashboardHandler) GetDashboardMetrics(w http.ResponseWriter, r *http.Re
 	response.WriteSuccess(w, http.StatusOK, "Métricas do dashboard obtidas com sucesso", metrics)
 }
 
+// GetDashboardTextAnalysis retorna a análise das respostas abertas da pesquisa do dashboard
+func (h *DashboardHandler) GetDashboardTextAnalysis(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	analise, err := h.dashboardUseCase.GetAnaliseTexto(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Dashboard não encontrado", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Análise das respostas abertas obtida com sucesso", analise)
+}
+
 // validateDashboardCreateRequest valida regras de negócio e obrigatoriedade de campos
 func (h *DashboardHandler) validateDashboardCreateRequest(req *dto.DashboardCreateRequest) error {
 	if req.IDPesquisa <= 0 {
@@ -380,6 +411,7 @@ func (h *DashboardHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/dashboards/{id:[0-9]+}/refresh", h.RefreshDashboard).Methods("POST")
 	router.HandleFunc("/dashboards/{id:[0-9]+}/export", h.ExportDashboard).Methods("GET")
 	router.HandleFunc("/dashboards/{id:[0-9]+}/metrics", h.GetDashboardMetrics).Methods("GET")
+	router.HandleFunc("/dashboards/{id:[0-9]+}/analise-texto", h.GetDashboardTextAnalysis).Methods("GET")
 	router.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/dashboard", h.GetDashboardByPesquisa).Methods("GET")
 	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/dashboards", h.ListDashboardsByEmpresa).Methods("GET")
 }
@@ -0,0 +1,228 @@
+// Package handler implementa os controladores HTTP da aplicação.
+// Processa requisições, valida entrada e coordena a execução de casos de uso.
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"organizational-climate-survey/backend/internal/application/dto"
+	"organizational-climate-survey/backend/internal/application/dto/response"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// DimensaoHandler gerencia requisições HTTP das dimensões do clima
+type DimensaoHandler struct {
+	dimensaoUseCase *usecase.DimensaoUseCase
+	log             logger.Logger
+}
+
+// NewDimensaoHandler cria nova instância do handler de dimensões
+func NewDimensaoHandler(dimensaoUseCase *usecase.DimensaoUseCase, log logger.Logger) *DimensaoHandler {
+	return &DimensaoHandler{
+		dimensaoUseCase: dimensaoUseCase,
+		log:             log,
+	}
+}
+
+// CreateDimensao cria nova dimensão da empresa
+func (h *DimensaoHandler) CreateDimensao(w http.ResponseWriter, r *http.Request) {
+	var req dto.DimensaoCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log.WithContext(r.Context()).Warn("Decode erro: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if err := h.validateDimensaoCreateRequest(&req); err != nil {
+		h.log.WithContext(r.Context()).Info("Validação falhou: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+		return
+	}
+
+	dimensao := req.ToEntity()
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.dimensaoUseCase.Create(r.Context(), dimensao, userAdminID, clientIP); err != nil {
+		h.log.WithFields(map[string]interface{}{"user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao criar dimensão: %v", err)
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	h.log.WithFields(map[string]interface{}{"dimensao_id": dimensao.ID, "user_admin_id": userAdminID}).Info("Dimensão criada com sucesso")
+	response.WriteSuccess(w, http.StatusCreated, "Dimensão criada com sucesso", h.toDimensaoResponse(dimensao))
+}
+
+// GetDimensao busca dimensão por ID
+func (h *DimensaoHandler) GetDimensao(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	dimensao, err := h.dimensaoUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Dimensão encontrada", h.toDimensaoResponse(dimensao))
+}
+
+// ListDimensoesByEmpresa lista as dimensões de uma empresa
+func (h *DimensaoHandler) ListDimensoesByEmpresa(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	empresaID, err := strconv.Atoi(vars["empresa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da empresa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	dimensoes, err := h.dimensaoUseCase.ListByEmpresa(r.Context(), empresaID)
+	if err != nil {
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	// Converter entidades para DTOs de resposta
+	dimensoesResponse := make([]response.DimensaoResponse, len(dimensoes))
+	for i, dimensao := range dimensoes {
+		dimensoesResponse[i] = h.toDimensaoResponse(dimensao)
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Dimensões listadas com sucesso", dimensoesResponse)
+}
+
+// UpdateDimensao atualiza nome e descrição de uma dimensão
+func (h *DimensaoHandler) UpdateDimensao(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req dto.DimensaoUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	// Buscar dimensão existente
+	dimensao, err := h.dimensaoUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	// Aplicar alterações parciais à entidade
+	req.ApplyToEntity(dimensao)
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.dimensaoUseCase.Update(r.Context(), dimensao, userAdminID, clientIP); err != nil {
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Dimensão atualizada com sucesso", h.toDimensaoResponse(dimensao))
+}
+
+// DeleteDimensao remove dimensão; as perguntas vinculadas ficam sem dimensão
+func (h *DimensaoHandler) DeleteDimensao(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.dimensaoUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
+		h.writeDimensaoError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Dimensão removida com sucesso", nil)
+}
+
+// writeDimensaoError converte erros dos casos de uso de dimensões em respostas HTTP
+func (h *DimensaoHandler) writeDimensaoError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "não encontrad"):
+		response.WriteError(w, http.StatusNotFound, "Dimensão não encontrada", msg)
+	case strings.Contains(msg, "já existe"):
+		response.WriteError(w, http.StatusConflict, "Dimensão já existe", msg)
+	case strings.Contains(msg, "inválid") || strings.Contains(msg, "obrigatório") || strings.Contains(msg, "maior que zero"):
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", msg)
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", msg)
+	}
+}
+
+// validateDimensaoCreateRequest valida campos obrigatórios e regras de negócio para criação
+func (h *DimensaoHandler) validateDimensaoCreateRequest(req *dto.DimensaoCreateRequest) error {
+	if req.IDEmpresa <= 0 {
+		return fmt.Errorf("ID da empresa é obrigatório")
+	}
+	if strings.TrimSpace(req.Nome) == "" {
+		return fmt.Errorf("nome da dimensão é obrigatório")
+	}
+	if len([]rune(req.Nome)) > entity.MaxNomeDimensao {
+		return fmt.Errorf("nome da dimensão não pode exceder %d caracteres", entity.MaxNomeDimensao)
+	}
+	return nil
+}
+
+// getUserAdminIDFromContext extrai ID do usuário administrativo do contexto da requisição
+func (h *DimensaoHandler) getUserAdminIDFromContext(r *http.Request) int {
+	if userID := r.Context().Value("user_admin_id"); userID != nil {
+		if id, ok := userID.(int); ok {
+			return id
+		}
+	}
+	return 0
+}
+
+// getClientIP extrai endereço IP do cliente considerando proxies
+func (h *DimensaoHandler) getClientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		return strings.Split(ip, ",")[0]
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// toDimensaoResponse converte entidade de domínio para DTO de resposta
+func (h *DimensaoHandler) toDimensaoResponse(dimensao *entity.Dimensao) response.DimensaoResponse {
+	return response.DimensaoResponse{
+		ID:          dimensao.ID,
+		IDEmpresa:   dimensao.IDEmpresa,
+		Nome:        dimensao.Nome,
+		Descricao:   dimensao.Descricao,
+		DataCriacao: dimensao.DataCriacao,
+	}
+}
+
+// RegisterRoutes registra todas as rotas HTTP do handler no roteador
+func (h *DimensaoHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/dimensoes", h.CreateDimensao).Methods("POST")
+	router.HandleFunc("/dimensoes/{id:[0-9]+}", h.GetDimensao).Methods("GET")
+	router.HandleFunc("/dimensoes/{id:[0-9]+}", h.UpdateDimensao).Methods("PUT")
+	router.HandleFunc("/dimensoes/{id:[0-9]+}", h.DeleteDimensao).Methods("DELETE")
+	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/dimensoes", h.ListDimensoesByEmpresa).Methods("GET")
+}
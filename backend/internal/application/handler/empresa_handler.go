@@ -68,6 +68,10 @@ func (h *EmpresaHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request) {
 			response.WriteError(w, http.StatusConflict, "Empresa já existe", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -87,7 +91,7 @@ func (h *EmpresaHandler) GetEmpresa(w http.ResponseWriter, r *http.Request) {
 
 	empresa, err := h.empresaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
 			return
 		}
@@ -143,7 +147,7 @@ func (h *EmpresaHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request) {
 	// Buscar empresa existente
 	empresa, err := h.empresaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
 			return
 		}
@@ -162,6 +166,10 @@ func (h *EmpresaHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request) {
 			response.WriteError(w, http.StatusConflict, "CNPJ já em uso", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -182,7 +190,7 @@ func (h *EmpresaHandler) DeleteEmpresa(w http.ResponseWriter, r *http.Request) {
 	clientIP := h.getClientIP(r)
 
 	if err := h.empresaUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
 			return
 		}
@@ -197,6 +205,90 @@ func (h *EmpresaHandler) DeleteEmpresa(w http.ResponseWriter, r *http.Request) {
 	response.WriteSuccess(w, http.StatusOK, "Empresa deletada com sucesso", nil)
 }
 
+// UpdatePoliticaMFA define se o segundo fator é obrigatório para os administradores da empresa
+func (h *EmpresaHandler) UpdatePoliticaMFA(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req dto.EmpresaPoliticaMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if req.MFAObrigatorio == nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", "mfa_obrigatorio é obrigatório")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.empresaUseCase.UpdatePoliticaMFA(r.Context(), id, *req.MFAObrigatorio, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	empresa, err := h.empresaUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Política MFA atualizada com sucesso", response.ToEmpresaResponse(empresa))
+}
+
+// UpdatePoliticaAnonimato define o mínimo de respondentes para exibir resultados agregados da empresa
+func (h *EmpresaHandler) UpdatePoliticaAnonimato(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req dto.EmpresaPoliticaAnonimatoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if req.MinRespondentes == nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", "min_respondentes é obrigatório")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.empresaUseCase.UpdatePoliticaAnonimato(r.Context(), id, *req.MinRespondentes, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "deve estar entre") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	empresa, err := h.empresaUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Política de anonimato atualizada com sucesso", response.ToEmpresaResponse(empresa))
+}
+
 // GetEmpresaByCNPJ busca empresa por CNPJ
 func (h *EmpresaHandler) GetEmpresaByCNPJ(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -216,7 +308,7 @@ func (h *EmpresaHandler) GetEmpresaByCNPJ(w http.ResponseWriter, r *http.Request
 
 	empresa, err := h.empresaUseCase.GetByCNPJ(r.Context(), cnpj)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Empresa não encontrada", err.Error())
 			return
 		}
@@ -291,5 +383,7 @@ func (h *EmpresaHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/empresas/{id:[0-9]+}", h.GetEmpresa).Methods("GET")
 	router.HandleFunc("/empresas/{id:[0-9]+}", h.UpdateEmpresa).Methods("PUT")
 	router.HandleFunc("/empresas/{id:[0-9]+}", h.DeleteEmpresa).Methods("DELETE")
+	router.HandleFunc("/empresas/{id:[0-9]+}/politica-mfa", h.UpdatePoliticaMFA).Methods("PUT")
+	router.HandleFunc("/empresas/{id:[0-9]+}/politica-anonimato", h.UpdatePoliticaAnonimato).Methods("PUT")
 	router.HandleFunc("/empresas/cnpj/{cnpj:.+}", h.GetEmpresaByCNPJ).Methods("GET")
 }
\ No newline at end of file
@@ -0,0 +1,155 @@
+// Package handler implementa os controladores HTTP da aplicação.
+// Fornece o formulário público de pesquisas para respondentes anônimos.
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/internal/application/dto/response"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+// Tempo máximo de cache do formulário público
+const (
+	formularioMaxAge          = 60 * time.Second  // Pesquisas abertas ou agendadas
+	formularioEncerradoMaxAge = 300 * time.Second // Pesquisas encerradas (não voltam a abrir)
+)
+
+// FormularioPublicoHandler gerencia a consulta anônima do formulário de pesquisas
+type FormularioPublicoHandler struct {
+	formularioUseCase *usecase.FormularioPublicoUseCase
+	log               logger.Logger
+}
+
+// NewFormularioPublicoHandler cria nova instância do handler do formulário público
+func NewFormularioPublicoHandler(formularioUseCase *usecase.FormularioPublicoUseCase, log logger.Logger) *FormularioPublicoHandler {
+	return &FormularioPublicoHandler{
+		formularioUseCase: formularioUseCase,
+		log:               log,
+	}
+}
+
+// GetFormulario retorna o formulário da pesquisa pelo link de acesso
+// GET /pesquisas/link/{link}/formulario
+// Responde 304 quando If-None-Match coincide com o ETag atual
+func (h *FormularioPublicoHandler) GetFormulario(w http.ResponseWriter, r *http.Request) {
+	link := mux.Vars(r)["link"]
+	now := time.Now()
+
+	formulario, err := h.formularioUseCase.GetByLink(r.Context(), link, now)
+	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "obrigatório") {
+			response.WriteError(w, http.StatusBadRequest, "Link inválido", err.Error())
+			return
+		}
+		h.log.WithContext(r.Context()).Error("Erro ao carregar formulário: %v", err)
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", "Erro ao carregar formulário")
+		return
+	}
+
+	body, err := json.Marshal(response.NewSuccessResponse(h.toFormularioResponse(formulario), "Formulário obtido com sucesso"))
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", "Erro ao carregar formulário")
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", h.maxAge(formulario, now)))
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
+// maxAge limita o cache para que a resposta não sobreviva à próxima abertura ou fechamento
+func (h *FormularioPublicoHandler) maxAge(formulario *usecase.FormularioPublico, now time.Time) int {
+	maxAge := formularioMaxAge
+	if formulario.Situacao == usecase.FormularioEncerrado {
+		maxAge = formularioEncerradoMaxAge
+	}
+	if formulario.ProximaMudanca != nil {
+		if until := formulario.ProximaMudanca.Sub(now); until < maxAge {
+			maxAge = until
+		}
+	}
+	if maxAge < 0 {
+		return 0
+	}
+	return int(maxAge / time.Second)
+}
+
+// toFormularioResponse converte o formulário para o DTO público, omitindo campos administrativos
+func (h *FormularioPublicoHandler) toFormularioResponse(formulario *usecase.FormularioPublico) *response.FormularioPublicoResponse {
+	pesquisa := formulario.Pesquisa
+	resp := &response.FormularioPublicoResponse{
+		IDPesquisa:     pesquisa.ID,
+		Titulo:         pesquisa.Titulo,
+		Descricao:      pesquisa.Descricao,
+		Anonimato:      pesquisa.Anonimato,
+		Situacao:       formulario.Situacao,
+		Aberta:         formulario.Situacao == usecase.FormularioAberto,
+		DataAbertura:   pesquisa.DataAbertura,
+		DataFechamento: pesquisa.DataFechamento,
+	}
+
+	switch formulario.Situacao {
+	case usecase.FormularioAgendado:
+		resp.Mensagem = "Esta pesquisa ainda não foi aberta"
+	case usecase.FormularioEncerrado:
+		resp.Mensagem = "Esta pesquisa foi encerrada e não aceita mais respostas"
+	}
+
+	for _, item := range formulario.Perguntas {
+		resp.Perguntas = append(resp.Perguntas, response.PerguntaFormularioResponse{
+			ID:               item.Pergunta.ID,
+			TextoPergunta:    item.Pergunta.TextoPergunta,
+			TipoPergunta:     item.Pergunta.TipoPergunta,
+			OrdemExibicao:    item.Pergunta.OrdemExibicao,
+			OpcoesResposta:   item.Opcoes,
+			CondicaoExibicao: item.Condicao,
+			Obrigatoria:      item.Pergunta.Obrigatoria,
+		})
+	}
+
+	return resp
+}
+
+// etagMatches compara o cabeçalho If-None-Match com o ETag atual (comparação fraca, RFC 7232)
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterRoutes registra as rotas públicas do formulário
+func (h *FormularioPublicoHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/pesquisas/link/{link}/formulario", h.GetFormulario).Methods("GET")
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"abc123"`
+
+	casos := []struct {
+		nome   string
+		header string
+		match  bool
+	}{
+		{"sem cabeçalho", "", false},
+		{"igual", `"abc123"`, true},
+		{"fraco", `W/"abc123"`, true},
+		{"lista separada por vírgulas", `"outro", W/"abc123"`, true},
+		{"lista sem correspondência", `"outro", "mais-um"`, false},
+		{"curinga", "*", true},
+		{"sem aspas", "abc123", false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if match := etagMatches(c.header, etag); match != c.match {
+				t.Errorf("etagMatches(%q) = %v, esperado %v", c.header, match, c.match)
+			}
+		})
+	}
+}
+
+func TestFormularioMaxAge(t *testing.T) {
+	agora := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	em := func(d time.Duration) *time.Time {
+		instante := agora.Add(d)
+		return &instante
+	}
+
+	casos := []struct {
+		nome           string
+		situacao       string
+		proximaMudanca *time.Time
+		maxAge         int
+	}{
+		{"aberta sem fechamento", usecase.FormularioAberto, nil, 60},
+		{"fechamento depois do cache", usecase.FormularioAberto, em(time.Hour), 60},
+		{"fechamento antes do cache", usecase.FormularioAberto, em(20 * time.Second), 20},
+		{"abertura antes do cache", usecase.FormularioAgendado, em(1500 * time.Millisecond), 1},
+		{"mudança já ocorrida", usecase.FormularioAberto, em(-time.Second), 0},
+		{"encerrada", usecase.FormularioEncerrado, nil, 300},
+	}
+
+	h := NewFormularioPublicoHandler(nil, logger.New(nil))
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			formulario := &usecase.FormularioPublico{Situacao: c.situacao, ProximaMudanca: c.proximaMudanca}
+			if maxAge := h.maxAge(formulario, agora); maxAge != c.maxAge {
+				t.Errorf("max-age %d, esperado %d", maxAge, c.maxAge)
+			}
+		})
+	}
+}
+
+func TestGetFormularioRespondeNaoModificado(t *testing.T) {
+	pesquisa := &entity.Pesquisa{ID: 10, IDEmpresa: 1, Titulo: "Clima", Status: "Ativa", LinkAcesso: "link-10"}
+	uc := usecase.NewFormularioPublicoUseCase(&fakePesquisaRepo{pesquisas: map[int]*entity.Pesquisa{pesquisa.ID: pesquisa}}, &fakePerguntaRepo{})
+	h := NewFormularioPublicoHandler(uc, logger.New(nil))
+
+	get := func(link, ifNoneMatch string) *httptest.ResponseRecorder {
+		r := mux.SetURLVars(httptest.NewRequest("GET", "/pesquisas/link/"+link+"/formulario", nil), map[string]string{"link": link})
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		h.GetFormulario(w, r)
+		return w
+	}
+
+	primeira := get("link-10", "")
+	etag := primeira.Header().Get("ETag")
+	if primeira.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status %d com ETag %q, esperado 200 com ETag", primeira.Code, etag)
+	}
+	if cache := primeira.Header().Get("Cache-Control"); cache != "public, max-age=60" {
+		t.Errorf("Cache-Control %q, esperado public, max-age=60", cache)
+	}
+
+	for _, header := range []string{etag, "W/" + etag, `"outro", ` + etag, "*"} {
+		if w := get("link-10", header); w.Code != http.StatusNotModified || w.Body.Len() > 0 {
+			t.Errorf("If-None-Match %s: status %d com %d bytes, esperado 304 sem corpo", header, w.Code, w.Body.Len())
+		}
+	}
+	if w := get("link-10", `"outro"`); w.Code != http.StatusOK {
+		t.Errorf("ETag diferente: status %d, esperado 200", w.Code)
+	}
+
+	pesquisa.Status = "Rascunho"
+	if w := get("link-10", ""); w.Code != http.StatusNotFound {
+		t.Errorf("rascunho: status %d, esperado 404", w.Code)
+	}
+}
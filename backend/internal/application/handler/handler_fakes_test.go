@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// Repositórios em memória usados nos testes dos handlers
+// Cada fake embute a interface: métodos não implementados causam panic e falham o teste
+
+type fakePesquisaRepo struct {
+	repository.PesquisaRepository
+	pesquisas map[int]*entity.Pesquisa
+}
+
+func (r *fakePesquisaRepo) GetByID(ctx context.Context, id int) (*entity.Pesquisa, error) {
+	if pesquisa, ok := r.pesquisas[id]; ok {
+		return pesquisa, nil
+	}
+	return nil, fmt.Errorf("pesquisa com ID %d não encontrada", id)
+}
+
+func (r *fakePesquisaRepo) GetByLinkAcesso(ctx context.Context, link string) (*entity.Pesquisa, error) {
+	for _, pesquisa := range r.pesquisas {
+		if pesquisa.LinkAcesso == link {
+			return pesquisa, nil
+		}
+	}
+	return nil, fmt.Errorf("pesquisa com link %s não encontrada", link)
+}
+
+type fakePerguntaRepo struct {
+	repository.PerguntaRepository
+}
+
+func (r *fakePerguntaRepo) ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error) {
+	return []*entity.Pergunta{{ID: 1, IDPesquisa: pesquisaID, OrdemExibicao: 1, TipoPergunta: "SimNao"}}, nil
+}
+
+// fakeAnalyticsRepo retorna métricas sem respondentes, ou erro quando falha está definido
+type fakeAnalyticsRepo struct {
+	repository.AnalyticsRepository
+	falha error
+}
+
+func (r *fakeAnalyticsRepo) GetPesquisaMetrics(ctx context.Context, pesquisaID int) (map[string]interface{}, error) {
+	if r.falha != nil {
+		return nil, r.falha
+	}
+	return map[string]interface{}{"pesquisa_id": pesquisaID}, nil
+}
+
+func (r *fakeAnalyticsRepo) GetComparisonData(ctx context.Context, pesquisaIDs []int) (map[string]interface{}, error) {
+	if r.falha != nil {
+		return nil, r.falha
+	}
+	return map[string]interface{}{"pesquisas": []map[string]interface{}{}}, nil
+}
+
+func (r *fakeAnalyticsRepo) GetSetorComparison(ctx context.Context, empresaID int, pesquisaID int) (map[string]interface{}, error) {
+	if r.falha != nil {
+		return nil, r.falha
+	}
+	return map[string]interface{}{"setores": []map[string]interface{}{}}, nil
+}
+
+func (r *fakeAnalyticsRepo) GetTrendData(ctx context.Context, empresaID int, since time.Time, granularidade string) ([]map[string]interface{}, error) {
+	if r.falha != nil {
+		return nil, r.falha
+	}
+	return []map[string]interface{}{}, nil
+}
+
+type fakeLogRepo struct {
+	repository.LogAuditoriaRepository
+}
+
+func (r *fakeLogRepo) Create(ctx context.Context, log *entity.LogAuditoria) error {
+	return nil
+}
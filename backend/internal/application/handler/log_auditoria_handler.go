@@ -46,6 +46,10 @@ func (h *LogAuditoriaHandler) CreateLogAuditoria(w http.ResponseWriter, r *http.
 	logEntity := req.ToEntity()
 	if err := h.logAuditoriaUseCase.Create(r.Context(), logEntity); err != nil {
 		h.log.WithContext(r.Context()).Error("Erro ao criar log auditoria: %v", err)
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -64,7 +68,7 @@ func (h *LogAuditoriaHandler) GetLogAuditoria(w http.ResponseWriter, r *http.Req
 
 	log, err := h.logAuditoriaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Log não encontrado", err.Error())
 			return
 		}
@@ -88,6 +92,10 @@ func (h *LogAuditoriaHandler) ListLogsByEmpresa(w http.ResponseWriter, r *http.R
 
 	logs, err := h.logAuditoriaUseCase.ListByEmpresa(r.Context(), empresaID, limit, offset)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -141,6 +149,10 @@ func (h *LogAuditoriaHandler) ListLogsByUsuario(w http.ResponseWriter, r *http.R
 
 	logs, err := h.logAuditoriaUseCase.ListByUsuarioAdmin(r.Context(), userAdminID, limit, offset)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -203,6 +215,10 @@ func (h *LogAuditoriaHandler) ListLogsByDateRange(w http.ResponseWriter, r *http
 			response.WriteError(w, http.StatusBadRequest, "Formato de data inválido", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -235,6 +251,10 @@ func (h *LogAuditoriaHandler) ListLogsByAction(w http.ResponseWriter, r *http.Re
 
 	logs, err := h.logAuditoriaUseCase.ListByAction(r.Context(), empresaID, acao, limit, offset)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -297,6 +317,10 @@ func (h *LogAuditoriaHandler) GetAuditSummary(w http.ResponseWriter, r *http.Req
 			response.WriteError(w, http.StatusBadRequest, "Formato de data inválido", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -374,6 +398,10 @@ func (h *LogAuditoriaHandler) ExportLogs(w http.ResponseWriter, r *http.Request)
 			response.WriteError(w, http.StatusBadRequest, "Formato de data inválido", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
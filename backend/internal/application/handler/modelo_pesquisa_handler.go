@@ -0,0 +1,306 @@
+// Package handler implementa os controladores HTTP da aplicação.
+// Processa requisições, valida entrada e coordena a execução de casos de uso.
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"organizational-climate-survey/backend/internal/application/dto"
+	"organizational-climate-survey/backend/internal/application/dto/response"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// ModeloPesquisaHandler gerencia requisições HTTP da biblioteca de modelos de pesquisa
+type ModeloPesquisaHandler struct {
+	modeloUseCase *usecase.ModeloPesquisaUseCase
+	log           logger.Logger
+}
+
+// NewModeloPesquisaHandler cria nova instância do handler de modelos de pesquisa
+func NewModeloPesquisaHandler(modeloUseCase *usecase.ModeloPesquisaUseCase, log logger.Logger) *ModeloPesquisaHandler {
+	return &ModeloPesquisaHandler{
+		modeloUseCase: modeloUseCase,
+		log:           log,
+	}
+}
+
+// CreateModelo cria novo modelo de pesquisa privado da empresa
+func (h *ModeloPesquisaHandler) CreateModelo(w http.ResponseWriter, r *http.Request) {
+	var req dto.ModeloPesquisaCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log.WithContext(r.Context()).Warn("Decode erro: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if err := h.validateModeloCreateRequest(&req); err != nil {
+		h.log.WithContext(r.Context()).Info("Validação falhou: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+		return
+	}
+
+	modelo := req.ToEntity()
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.modeloUseCase.Create(r.Context(), modelo, userAdminID, clientIP); err != nil {
+		h.log.WithFields(map[string]interface{}{"user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao criar modelo de pesquisa: %v", err)
+		h.writeModeloError(w, err)
+		return
+	}
+
+	h.log.WithFields(map[string]interface{}{"modelo_id": modelo.ID, "user_admin_id": userAdminID}).Info("Modelo de pesquisa criado com sucesso")
+	response.WriteSuccess(w, http.StatusCreated, "Modelo de pesquisa criado com sucesso", h.toModeloResponse(modelo))
+}
+
+// GetModelo busca modelo de pesquisa por ID, com as perguntas da versão atual ou da informada em ?versao=
+func (h *ModeloPesquisaHandler) GetModelo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	versao := 0
+	if v := r.URL.Query().Get("versao"); v != "" {
+		versao, err = strconv.Atoi(v)
+		if err != nil || versao <= 0 {
+			response.WriteError(w, http.StatusBadRequest, "Versão inválida", "versao deve ser um número inteiro maior que zero")
+			return
+		}
+	}
+
+	modelo, err := h.modeloUseCase.GetByID(r.Context(), id, versao)
+	if err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Modelo de pesquisa encontrado", h.toModeloResponse(modelo))
+}
+
+// ListModelosByEmpresa lista os modelos disponíveis para a empresa (do sistema e privados)
+func (h *ModeloPesquisaHandler) ListModelosByEmpresa(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	empresaID, err := strconv.Atoi(vars["empresa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da empresa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	modelos, err := h.modeloUseCase.ListByEmpresa(r.Context(), empresaID)
+	if err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	// Converter entidades para DTOs de resposta
+	modelosResponse := make([]response.ModeloPesquisaResponse, len(modelos))
+	for i, modelo := range modelos {
+		modelosResponse[i] = h.toModeloResponse(modelo)
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Modelos de pesquisa listados com sucesso", modelosResponse)
+}
+
+// ListVersoesModelo lista o histórico de versões de um modelo de pesquisa
+func (h *ModeloPesquisaHandler) ListVersoesModelo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	versoes, err := h.modeloUseCase.ListVersoes(r.Context(), id)
+	if err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	versoesResponse := make([]response.VersaoModeloResponse, len(versoes))
+	for i, v := range versoes {
+		versoesResponse[i] = response.VersaoModeloResponse{
+			Versao:      v.Versao,
+			IDUserAdmin: v.IDUserAdmin,
+			DataCriacao: v.DataCriacao,
+		}
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Versões do modelo listadas com sucesso", versoesResponse)
+}
+
+// UpdateModelo atualiza modelo de pesquisa da empresa; alterar as perguntas gera uma nova versão
+func (h *ModeloPesquisaHandler) UpdateModelo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req dto.ModeloPesquisaUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if req.Perguntas != nil {
+		if err := h.validatePerguntasModelo(*req.Perguntas); err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
+	}
+
+	// Buscar modelo existente
+	modelo, err := h.modeloUseCase.GetByID(r.Context(), id, 0)
+	if err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	// Aplicar alterações parciais à entidade
+	req.ApplyToEntity(modelo)
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.modeloUseCase.Update(r.Context(), modelo, userAdminID, clientIP); err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Modelo de pesquisa atualizado com sucesso", h.toModeloResponse(modelo))
+}
+
+// DeleteModelo remove modelo de pesquisa da empresa da biblioteca
+func (h *ModeloPesquisaHandler) DeleteModelo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.modeloUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
+		h.writeModeloError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Modelo de pesquisa removido com sucesso", nil)
+}
+
+// writeModeloError converte erros dos casos de uso de modelos em respostas HTTP
+func (h *ModeloPesquisaHandler) writeModeloError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "não encontrad"):
+		response.WriteError(w, http.StatusNotFound, "Modelo de pesquisa não encontrado", msg)
+	case strings.Contains(msg, "somente leitura"):
+		response.WriteError(w, http.StatusForbidden, "Modelo do sistema não pode ser alterado", msg)
+	case strings.Contains(msg, "inválid") || strings.Contains(msg, "obrigatório") || strings.Contains(msg, "maior que zero"):
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", msg)
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", msg)
+	}
+}
+
+// validateModeloCreateRequest valida campos obrigatórios e regras de negócio para criação
+func (h *ModeloPesquisaHandler) validateModeloCreateRequest(req *dto.ModeloPesquisaCreateRequest) error {
+	if req.IDEmpresa <= 0 {
+		return fmt.Errorf("ID da empresa é obrigatório")
+	}
+	if strings.TrimSpace(req.Nome) == "" {
+		return fmt.Errorf("nome do modelo é obrigatório")
+	}
+	if len(req.Nome) > 255 {
+		return fmt.Errorf("nome do modelo não pode exceder 255 caracteres")
+	}
+	return h.validatePerguntasModelo(req.Perguntas)
+}
+
+// validatePerguntasModelo valida a quantidade de perguntas e os campos de cada uma
+func (h *ModeloPesquisaHandler) validatePerguntasModelo(perguntas []dto.PerguntaModeloRequest) error {
+	if len(perguntas) == 0 {
+		return fmt.Errorf("o modelo deve ter pelo menos uma pergunta")
+	}
+	if len(perguntas) > entity.MaxPerguntasModelo {
+		return fmt.Errorf("o modelo pode ter no máximo %d perguntas", entity.MaxPerguntasModelo)
+	}
+	for i, p := range perguntas {
+		if len(strings.TrimSpace(p.TextoPergunta)) < 5 {
+			return fmt.Errorf("pergunta %d: texto da pergunta deve ter pelo menos 5 caracteres", i+1)
+		}
+		if len(p.TextoPergunta) > entity.MaxTextoPergunta {
+			return fmt.Errorf("pergunta %d: texto da pergunta não pode exceder %d caracteres", i+1, entity.MaxTextoPergunta)
+		}
+	}
+	return nil
+}
+
+// getUserAdminIDFromContext extrai ID do usuário administrativo do contexto da requisição
+func (h *ModeloPesquisaHandler) getUserAdminIDFromContext(r *http.Request) int {
+	if userID := r.Context().Value("user_admin_id"); userID != nil {
+		if id, ok := userID.(int); ok {
+			return id
+		}
+	}
+	return 0
+}
+
+// getClientIP extrai endereço IP do cliente considerando proxies
+func (h *ModeloPesquisaHandler) getClientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		return strings.Split(ip, ",")[0]
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// toModeloResponse converte entidade de domínio para DTO de resposta
+func (h *ModeloPesquisaHandler) toModeloResponse(modelo *entity.ModeloPesquisa) response.ModeloPesquisaResponse {
+	resp := response.ModeloPesquisaResponse{
+		ID:              modelo.ID,
+		IDEmpresa:       modelo.IDEmpresa,
+		DoSistema:       modelo.DoSistema(),
+		Nome:            modelo.Nome,
+		Descricao:       modelo.Descricao,
+		Versao:          modelo.Versao,
+		DataCriacao:     modelo.DataCriacao,
+		DataAtualizacao: modelo.DataAtualizacao,
+	}
+
+	for _, p := range modelo.Perguntas {
+		resp.Perguntas = append(resp.Perguntas, response.PerguntaModeloResponse{
+			TextoPergunta:      p.TextoPergunta,
+			TipoPergunta:       p.TipoPergunta,
+			OpcoesResposta:     p.OpcoesResposta,
+			Obrigatoria:        p.Obrigatoria,
+			Dimensao:           p.Dimensao,
+			PontuacaoInvertida: p.PontuacaoInvertida,
+		})
+	}
+
+	return resp
+}
+
+// RegisterRoutes registra todas as rotas HTTP do handler no roteador
+func (h *ModeloPesquisaHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/modelos-pesquisa", h.CreateModelo).Methods("POST")
+	router.HandleFunc("/modelos-pesquisa/{id:[0-9]+}", h.GetModelo).Methods("GET")
+	router.HandleFunc("/modelos-pesquisa/{id:[0-9]+}", h.UpdateModelo).Methods("PUT")
+	router.HandleFunc("/modelos-pesquisa/{id:[0-9]+}", h.DeleteModelo).Methods("DELETE")
+	router.HandleFunc("/modelos-pesquisa/{id:[0-9]+}/versoes", h.ListVersoesModelo).Methods("GET")
+	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/modelos-pesquisa", h.ListModelosByEmpresa).Methods("GET")
+}
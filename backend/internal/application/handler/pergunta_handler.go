@@ -50,6 +50,22 @@ func (h *PerguntaHandler) CreatePergunta(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 	if err := h.perguntaUseCase.Create(r.Context(), pergunta, userAdminID, clientIP); err != nil {
 		h.log.WithFields(map[string]interface{}{"user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao criar pergunta: %v", err)
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "opções de resposta inválidas") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "condição de exibição inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Condição de exibição inválida", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "dimensão da pergunta inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -60,6 +76,10 @@ func (h *PerguntaHandler) CreatePergunta(w http.ResponseWriter, r *http.Request)
 		TipoPergunta:   pergunta.TipoPergunta,
 		OrdemExibicao:  pergunta.OrdemExibicao,
 		OpcoesResposta: pergunta.OpcoesResposta,
+		CondicaoExibicao: pergunta.CondicaoExibicao,
+		Obrigatoria:    pergunta.Obrigatoria,
+		IDDimensao:     pergunta.IDDimensao,
+		PontuacaoInvertida: pergunta.PontuacaoInvertida,
 	}
 
 	h.log.WithFields(map[string]interface{}{"pergunta_id": pergunta.ID, "user_admin_id": userAdminID}).Info("Pergunta criada com sucesso")
@@ -99,6 +119,22 @@ func (h *PerguntaHandler) CreatePerguntasBatch(w http.ResponseWriter, r *http.Re
 	clientIP := h.getClientIP(r)
 
 	if err := h.perguntaUseCase.CreateBatch(r.Context(), perguntas, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "opções de resposta inválidas") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "condição de exibição inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Condição de exibição inválida", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "dimensão da pergunta inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -112,6 +148,10 @@ func (h *PerguntaHandler) CreatePerguntasBatch(w http.ResponseWriter, r *http.Re
 			TipoPergunta:   pergunta.TipoPergunta,
 			OrdemExibicao:  pergunta.OrdemExibicao,
 			OpcoesResposta: pergunta.OpcoesResposta,
+			CondicaoExibicao: pergunta.CondicaoExibicao,
+			Obrigatoria:    pergunta.Obrigatoria,
+			IDDimensao:     pergunta.IDDimensao,
+			PontuacaoInvertida: pergunta.PontuacaoInvertida,
 		}
 	}
 
@@ -129,7 +169,7 @@ func (h *PerguntaHandler) GetPergunta(w http.ResponseWriter, r *http.Request) {
 
 	pergunta, err := h.perguntaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
@@ -143,6 +183,10 @@ func (h *PerguntaHandler) GetPergunta(w http.ResponseWriter, r *http.Request) {
 		TipoPergunta:   pergunta.TipoPergunta,
 		OrdemExibicao:  pergunta.OrdemExibicao,
 		OpcoesResposta: pergunta.OpcoesResposta,
+		CondicaoExibicao: pergunta.CondicaoExibicao,
+		Obrigatoria:    pergunta.Obrigatoria,
+		IDDimensao:     pergunta.IDDimensao,
+		PontuacaoInvertida: pergunta.PontuacaoInvertida,
 	}
 
 	response.WriteSuccess(w, http.StatusOK, "Pergunta encontrada", perguntaResponse)
@@ -159,6 +203,10 @@ func (h *PerguntaHandler) ListPerguntasByPesquisa(w http.ResponseWriter, r *http
 
 	perguntas, err := h.perguntaUseCase.ListByPesquisa(r.Context(), pesquisaID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -172,6 +220,10 @@ func (h *PerguntaHandler) ListPerguntasByPesquisa(w http.ResponseWriter, r *http
 			TipoPergunta:   pergunta.TipoPergunta,
 			OrdemExibicao:  pergunta.OrdemExibicao,
 			OpcoesResposta: pergunta.OpcoesResposta,
+			CondicaoExibicao: pergunta.CondicaoExibicao,
+			Obrigatoria:    pergunta.Obrigatoria,
+			IDDimensao:     pergunta.IDDimensao,
+			PontuacaoInvertida: pergunta.PontuacaoInvertida,
 		}
 	}
 
@@ -196,7 +248,7 @@ func (h *PerguntaHandler) UpdatePergunta(w http.ResponseWriter, r *http.Request)
 	// Buscar pergunta existente
 	pergunta, err := h.perguntaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
@@ -211,6 +263,22 @@ func (h *PerguntaHandler) UpdatePergunta(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 
 	if err := h.perguntaUseCase.Update(r.Context(), pergunta, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "opções de resposta inválidas") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "condição de exibição inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Condição de exibição inválida", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "dimensão da pergunta inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -221,6 +289,10 @@ func (h *PerguntaHandler) UpdatePergunta(w http.ResponseWriter, r *http.Request)
 		TipoPergunta:   pergunta.TipoPergunta,
 		OrdemExibicao:  pergunta.OrdemExibicao,
 		OpcoesResposta: pergunta.OpcoesResposta,
+		CondicaoExibicao: pergunta.CondicaoExibicao,
+		Obrigatoria:    pergunta.Obrigatoria,
+		IDDimensao:     pergunta.IDDimensao,
+		PontuacaoInvertida: pergunta.PontuacaoInvertida,
 	}
 
 	response.WriteSuccess(w, http.StatusOK, "Pergunta atualizada com sucesso", perguntaResponse)
@@ -253,10 +325,14 @@ func (h *PerguntaHandler) UpdateOrdemPergunta(w http.ResponseWriter, r *http.Req
 	clientIP := h.getClientIP(r)
 
 	if err := h.perguntaUseCase.UpdateOrdem(r.Context(), id, req.NovaOrdem, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "condição de exibição inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Condição de exibição inválida", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -277,7 +353,7 @@ func (h *PerguntaHandler) DeletePergunta(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 
 	if err := h.perguntaUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
@@ -285,6 +361,10 @@ func (h *PerguntaHandler) DeletePergunta(w http.ResponseWriter, r *http.Request)
 			response.WriteError(w, http.StatusConflict, "Pergunta possui respostas", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "condição de exibição") {
+			response.WriteError(w, http.StatusConflict, "Pergunta usada em condição de exibição", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -319,6 +399,14 @@ func (h *PerguntaHandler) ReorderPerguntas(w http.ResponseWriter, r *http.Reques
 	clientIP := h.getClientIP(r)
 
 	if err := h.perguntaUseCase.ReorderPerguntas(r.Context(), pesquisaID, req.PerguntaIDs, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "condição de exibição inválida") {
+			response.WriteError(w, http.StatusBadRequest, "Condição de exibição inválida", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -337,6 +425,10 @@ func (h *PerguntaHandler) GetPerguntasWithStats(w http.ResponseWriter, r *http.R
 
 	perguntasWithStats, err := h.perguntaUseCase.GetPerguntasWithStats(r.Context(), pesquisaID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
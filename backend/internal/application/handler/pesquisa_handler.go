@@ -4,6 +4,8 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -54,6 +56,10 @@ func (h *PesquisaHandler) CreatePesquisa(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 	if err := h.pesquisaUseCase.Create(r.Context(), pesquisa, userAdminID, clientIP); err != nil {
 		h.log.WithFields(map[string]interface{}{"user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao criar pesquisa: %v", err)
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -61,6 +67,153 @@ func (h *PesquisaHandler) CreatePesquisa(w http.ResponseWriter, r *http.Request)
 	response.WriteSuccess(w, http.StatusCreated, "Pesquisa criada com sucesso", h.toPesquisaResponse(pesquisa))
 }
 
+// CreatePesquisaFromModelo cria nova pesquisa com as perguntas de um modelo da biblioteca
+func (h *PesquisaHandler) CreatePesquisaFromModelo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	modeloID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID do modelo inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req dto.PesquisaFromModeloRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log.WithContext(r.Context()).Warn("Decode erro: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	// A pesquisa sempre nasce como rascunho; o status pode ser omitido
+	if req.Status == "" {
+		req.Status = "Rascunho"
+	}
+	if err := h.validatePesquisaCreateRequest(&req.PesquisaCreateRequest); err != nil {
+		h.log.WithContext(r.Context()).Info("Validação falhou: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+		return
+	}
+	if req.Versao < 0 {
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", "versão do modelo não pode ser negativa")
+		return
+	}
+	pesquisa, err := req.ToEntity()
+	if err != nil {
+		h.log.WithContext(r.Context()).Warn("Conversão entidade erro: %v", err)
+		response.WriteError(w, http.StatusBadRequest, "Erro de conversão", err.Error())
+		return
+	}
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+	if err := h.pesquisaUseCase.CreateFromModelo(r.Context(), pesquisa, modeloID, req.Versao, userAdminID, clientIP); err != nil {
+		h.log.WithFields(map[string]interface{}{"modelo_id": modeloID, "user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao criar pesquisa a partir do modelo: %v", err)
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "não configurados") {
+			response.WriteError(w, http.StatusServiceUnavailable, "Modelos de pesquisa indisponíveis", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	pesquisaResponse := h.toPesquisaResponse(pesquisa)
+	pesquisaResponse.TotalPerguntas = len(pesquisa.Perguntas)
+	for _, pergunta := range pesquisa.Perguntas {
+		pesquisaResponse.Perguntas = append(pesquisaResponse.Perguntas, response.PerguntaResponse{
+			ID:               pergunta.ID,
+			TextoPergunta:    pergunta.TextoPergunta,
+			TipoPergunta:     pergunta.TipoPergunta,
+			OrdemExibicao:    pergunta.OrdemExibicao,
+			OpcoesResposta:   pergunta.OpcoesResposta,
+			CondicaoExibicao: pergunta.CondicaoExibicao,
+			Obrigatoria:      pergunta.Obrigatoria,
+			IDDimensao:       pergunta.IDDimensao,
+			PontuacaoInvertida: pergunta.PontuacaoInvertida,
+		})
+	}
+
+	h.log.WithFields(map[string]interface{}{"pesquisa_id": pesquisa.ID, "modelo_id": modeloID, "user_admin_id": userAdminID}).Info("Pesquisa criada a partir de modelo com sucesso")
+	response.WriteSuccess(w, http.StatusCreated, "Pesquisa criada a partir do modelo com sucesso", pesquisaResponse)
+}
+
+// ClonePesquisa cria um rascunho a partir de uma pesquisa existente, com novo link de acesso
+func (h *PesquisaHandler) ClonePesquisa(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	// Corpo opcional: sem ele, o clone mantém título (com sufixo), setor e perguntas da origem
+	var req dto.PesquisaCloneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+	if req.Titulo != nil && len(strings.TrimSpace(*req.Titulo)) < 3 {
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", "título deve ter pelo menos 3 caracteres")
+		return
+	}
+	if req.IDSetor != nil && *req.IDSetor <= 0 {
+		response.WriteError(w, http.StatusBadRequest, "Validação falhou", "ID do setor deve ser maior que zero")
+		return
+	}
+	abertura, fechamento, err := req.ParseDatas()
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Erro de conversão", err.Error())
+		return
+	}
+
+	opcoes := usecase.OpcoesClonagem{DataAbertura: abertura, DataFechamento: fechamento}
+	if req.Titulo != nil {
+		opcoes.Titulo = *req.Titulo
+	}
+	if req.IDSetor != nil {
+		opcoes.IDSetor = *req.IDSetor
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+	pesquisa, err := h.pesquisaUseCase.Clone(r.Context(), id, opcoes, userAdminID, clientIP)
+	if err != nil {
+		h.log.WithFields(map[string]interface{}{"pesquisa_id": id, "user_admin_id": userAdminID, "client_ip": clientIP}).Error("Erro ao clonar pesquisa: %v", err)
+		switch {
+		case strings.Contains(err.Error(), "não encontrad"):
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+		case strings.Contains(err.Error(), "data de fechamento") ||
+			strings.Contains(err.Error(), "período máximo") || strings.Contains(err.Error(), "recorrência"):
+			response.WriteError(w, http.StatusBadRequest, "Validação falhou", err.Error())
+		case strings.Contains(err.Error(), "não configurada"):
+			response.WriteError(w, http.StatusServiceUnavailable, "Clonagem indisponível", err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		}
+		return
+	}
+
+	pesquisaResponse := h.toPesquisaResponse(pesquisa)
+	pesquisaResponse.IDPesquisaOrigem = &id
+	pesquisaResponse.TotalPerguntas = len(pesquisa.Perguntas)
+	for _, pergunta := range pesquisa.Perguntas {
+		pesquisaResponse.Perguntas = append(pesquisaResponse.Perguntas, response.PerguntaResponse{
+			ID:               pergunta.ID,
+			TextoPergunta:    pergunta.TextoPergunta,
+			TipoPergunta:     pergunta.TipoPergunta,
+			OrdemExibicao:    pergunta.OrdemExibicao,
+			OpcoesResposta:   pergunta.OpcoesResposta,
+			CondicaoExibicao: pergunta.CondicaoExibicao,
+			Obrigatoria:      pergunta.Obrigatoria,
+			IDDimensao:       pergunta.IDDimensao,
+			PontuacaoInvertida: pergunta.PontuacaoInvertida,
+		})
+	}
+
+	h.log.WithFields(map[string]interface{}{"pesquisa_id": pesquisa.ID, "origem_id": id, "user_admin_id": userAdminID}).Info("Pesquisa clonada com sucesso")
+	response.WriteSuccess(w, http.StatusCreated, "Pesquisa clonada com sucesso", pesquisaResponse)
+}
+
 // GetPesquisa busca pesquisa de clima por ID
 func (h *PesquisaHandler) GetPesquisa(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -72,7 +225,7 @@ func (h *PesquisaHandler) GetPesquisa(w http.ResponseWriter, r *http.Request) {
 
 	pesquisa, err := h.pesquisaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -81,6 +234,14 @@ func (h *PesquisaHandler) GetPesquisa(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pesquisaResponse := h.toPesquisaResponse(pesquisa)
+	origemID, err := h.pesquisaUseCase.GetOrigem(r.Context(), pesquisa.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+	if origemID > 0 {
+		pesquisaResponse.IDPesquisaOrigem = &origemID
+	}
 	response.WriteSuccess(w, http.StatusOK, "Pesquisa encontrada", pesquisaResponse)
 }
 
@@ -96,7 +257,7 @@ func (h *PesquisaHandler) GetPesquisaByLink(w http.ResponseWriter, r *http.Reque
 
 	pesquisa, err := h.pesquisaUseCase.GetByLinkAcesso(r.Context(), link)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -128,6 +289,10 @@ func (h *PesquisaHandler) ListPesquisasByEmpresa(w http.ResponseWriter, r *http.
 	}
 
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -152,6 +317,10 @@ func (h *PesquisaHandler) ListPesquisasBySetor(w http.ResponseWriter, r *http.Re
 
 	pesquisas, err := h.pesquisaUseCase.ListBySetor(r.Context(), setorID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -176,6 +345,10 @@ func (h *PesquisaHandler) ListPesquisasActive(w http.ResponseWriter, r *http.Req
 
 	pesquisas, err := h.pesquisaUseCase.ListActive(r.Context(), empresaID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -207,7 +380,7 @@ func (h *PesquisaHandler) UpdatePesquisa(w http.ResponseWriter, r *http.Request)
 	// Buscar pesquisa existente
 	pesquisa, err := h.pesquisaUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -225,6 +398,10 @@ func (h *PesquisaHandler) UpdatePesquisa(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 
 	if err := h.pesquisaUseCase.Update(r.Context(), pesquisa, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -258,7 +435,7 @@ func (h *PesquisaHandler) UpdateStatusPesquisa(w http.ResponseWriter, r *http.Re
 	clientIP := h.getClientIP(r)
 
 	if err := h.pesquisaUseCase.UpdateStatus(r.Context(), id, req.Status, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -282,7 +459,7 @@ func (h *PesquisaHandler) DeletePesquisa(w http.ResponseWriter, r *http.Request)
 	clientIP := h.getClientIP(r)
 
 	if err := h.pesquisaUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -297,7 +474,8 @@ func (h *PesquisaHandler) DeletePesquisa(w http.ResponseWriter, r *http.Request)
 	response.WriteSuccess(w, http.StatusOK, "Pesquisa deletada com sucesso", nil)
 }
 
-// GenerateQRCode gera código QR para acesso público à pesquisa
+// GenerateQRCode gera as imagens PNG e SVG do QR code de acesso público à pesquisa
+// O corpo é opcional: tamanho, nível de correção e logo da empresa
 func (h *PesquisaHandler) GenerateQRCode(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
@@ -306,37 +484,118 @@ func (h *PesquisaHandler) GenerateQRCode(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Verificar existência da pesquisa
-	pesquisa, err := h.pesquisaUseCase.GetByID(r.Context(), id)
+	var req dto.PesquisaQRCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	opts := usecase.QRCodeOptions{
+		Tamanho:     req.Tamanho,
+		Nivel:       req.NivelCorrecao,
+		Logo:        req.Logo,
+		RemoverLogo: req.RemoverLogo,
+	}
+	pesquisa, err := h.pesquisaUseCase.GenerateQRCode(r.Context(), id, opts, userAdminID, clientIP)
+	if err != nil {
+		h.writeQRCodeError(w, err)
+		return
+	}
+
+	qrResponse := &response.PesquisaQRCodeResponse{
+		QRCodePath:  pesquisa.QRCodePath,
+		LinkAcesso:  pesquisa.LinkAcesso,
+		URLPublica:  h.pesquisaUseCase.PublicURL(pesquisa),
+		DownloadPNG: fmt.Sprintf("/api/v1/pesquisas/%d/qrcode?formato=png", pesquisa.ID),
+		DownloadSVG: fmt.Sprintf("/api/v1/pesquisas/%d/qrcode?formato=svg", pesquisa.ID),
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "QR Code gerado com sucesso", qrResponse)
+}
+
+// DownloadQRCode retorna a imagem do QR code da pesquisa (?formato=png ou svg)
+func (h *PesquisaHandler) DownloadQRCode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	file, err := h.pesquisaUseCase.GetQRCode(r.Context(), id, r.URL.Query().Get("formato"))
+	if err != nil {
+		h.writeQRCodeError(w, err)
+		return
+	}
+
+	// Headers de download conforme o formato pedido
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.Name))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Data)
+}
+
+// RegenerateLinkAcesso gera um novo link de acesso, invalidando o anterior e seu QR code
+func (h *PesquisaHandler) RegenerateLinkAcesso(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if _, err := h.pesquisaUseCase.RegenerateLinkAcesso(r.Context(), id, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "pesquisa ativa") {
+			response.WriteError(w, http.StatusConflict, "Link não pode ser alterado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
 
-	// Gerar caminho do QR Code baseado no link de acesso
-	qrCodePath := fmt.Sprintf("/qrcodes/%s.png", pesquisa.LinkAcesso)
-	
-	//Atualizar no banco
-	pesquisa.QRCodePath = qrCodePath
-	
-	userAdminID := h.getUserAdminIDFromContext(r)
-	clientIP := h.getClientIP(r)
-	
-	if err := h.pesquisaUseCase.Update(r.Context(), pesquisa, userAdminID, clientIP); err != nil {
-		response.WriteError(w, http.StatusInternalServerError, "Erro ao salvar QR Code", err.Error())
+	pesquisa, err := h.pesquisaUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
 
-	qrResponse := map[string]string{
-		"qr_code_path": qrCodePath,
-		"link_acesso":  pesquisa.LinkAcesso,
+	linkResponse := &response.PesquisaLinkResponse{
+		LinkAcesso: pesquisa.LinkAcesso,
+		URLPublica: h.pesquisaUseCase.PublicURL(pesquisa),
+		QRCodePath: pesquisa.QRCodePath,
 	}
 
-	response.WriteSuccess(w, http.StatusOK, "QR Code gerado com sucesso", qrResponse)
+	response.WriteSuccess(w, http.StatusOK, "Link de acesso regenerado com sucesso", linkResponse)
+}
+
+// writeQRCodeError converte erros de geração e download de QR code em respostas HTTP
+func (h *PesquisaHandler) writeQRCodeError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "QR code não encontrado"):
+		response.WriteError(w, http.StatusNotFound, "QR Code não encontrado", msg)
+	case strings.Contains(msg, "não encontrad"):
+		response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", msg)
+	case strings.Contains(msg, "não configurada"):
+		response.WriteError(w, http.StatusServiceUnavailable, "QR Code indisponível", msg)
+	case strings.Contains(msg, "inválid"), strings.Contains(msg, "deve "), strings.Contains(msg, "sem link"):
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", msg)
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar QR Code", msg)
+	}
 }
 
 // validatePesquisaCreateRequest valida campos obrigatórios e regras de negócio para criação
@@ -417,7 +676,11 @@ func (h *PesquisaHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/pesquisas/{id:[0-9]+}", h.DeletePesquisa).Methods("DELETE")
 	router.HandleFunc("/pesquisas/{id:[0-9]+}/status", h.UpdateStatusPesquisa).Methods("PUT")
 	router.HandleFunc("/pesquisas/{id:[0-9]+}/qrcode", h.GenerateQRCode).Methods("POST")
+	router.HandleFunc("/pesquisas/{id:[0-9]+}/qrcode", h.DownloadQRCode).Methods("GET")
+	router.HandleFunc("/pesquisas/{id:[0-9]+}/link-acesso", h.RegenerateLinkAcesso).Methods("POST")
+	router.HandleFunc("/pesquisas/{id:[0-9]+}/clone", h.ClonePesquisa).Methods("POST")
 	router.HandleFunc("/pesquisas/link/{link}", h.GetPesquisaByLink).Methods("GET")
+	router.HandleFunc("/modelos-pesquisa/{id:[0-9]+}/pesquisas", h.CreatePesquisaFromModelo).Methods("POST")
 	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/pesquisas", h.ListPesquisasByEmpresa).Methods("GET")
 	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/pesquisas/active", h.ListPesquisasActive).Methods("GET")
 	router.HandleFunc("/setores/{setor_id:[0-9]+}/pesquisas", h.ListPesquisasBySetor).Methods("GET")
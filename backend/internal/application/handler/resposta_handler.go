@@ -4,6 +4,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -69,6 +70,12 @@ func (h *RespostaHandler) SubmitRespostas(w http.ResponseWriter, r *http.Request
 	
 	// MODIFICADO: Passar token para usecase
 	if err := h.respostaUseCase.CreateBatch(r.Context(), respostas, req.TokenAcesso); err != nil {
+		var invalida *usecase.SubmissaoInvalidaError
+		if errors.As(err, &invalida) {
+			h.log.WithContext(r.Context()).Info("Submissão rejeitada: %v", err)
+			response.WriteErrorWithData(w, http.StatusBadRequest, "Respostas inválidas", err.Error(), map[string]interface{}{"erros": invalida.Erros})
+			return
+		}
 		h.log.WithContext(r.Context()).Error("Erro ao salvar respostas: %v", err)
 		
 		// Tratamento de erros específicos
@@ -80,8 +87,8 @@ func (h *RespostaHandler) SubmitRespostas(w http.ResponseWriter, r *http.Request
 			response.WriteError(w, http.StatusBadRequest, "Pesquisa inativa", err.Error())
 			return
 		}
-		if strings.Contains(err.Error(), "não pertence à pesquisa") {
-			response.WriteError(w, http.StatusBadRequest, "Pergunta inválida", err.Error())
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
 			return
 		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
@@ -103,10 +110,14 @@ func (h *RespostaHandler) GetRespostaStats(w http.ResponseWriter, r *http.Reques
 
 	stats, err := h.respostaUseCase.GetAggregatedByPesquisa(r.Context(), pesquisaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "resultado suprimido") {
+			response.WriteError(w, http.StatusForbidden, "Resultado suprimido", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -125,10 +136,14 @@ func (h *RespostaHandler) GetRespostasByPergunta(w http.ResponseWriter, r *http.
 
 	aggregatedData, err := h.respostaUseCase.GetAggregatedByPergunta(r.Context(), perguntaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "resultado suprimido") {
+			response.WriteError(w, http.StatusForbidden, "Resultado suprimido", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -147,10 +162,14 @@ func (h *RespostaHandler) GetRespostasByPesquisa(w http.ResponseWriter, r *http.
 
 	aggregatedData, err := h.respostaUseCase.GetAggregatedByPesquisa(r.Context(), pesquisaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "resultado suprimido") {
+			response.WriteError(w, http.StatusForbidden, "Resultado suprimido", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -182,10 +201,14 @@ func (h *RespostaHandler) GetRespostasByDateRange(w http.ResponseWriter, r *http
 
 	respostas, err := h.respostaUseCase.GetResponsesByDateRange(r.Context(), pesquisaID, startDate, endDate)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "resultado suprimido") {
+			response.WriteError(w, http.StatusForbidden, "Resultado suprimido", err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "formato de data") {
 			response.WriteError(w, http.StatusBadRequest, "Formato de data inválido", err.Error())
 			return
@@ -208,7 +231,7 @@ func (h *RespostaHandler) CountRespostasByPesquisa(w http.ResponseWriter, r *htt
 
 	count, err := h.respostaUseCase.CountByPesquisa(r.Context(), pesquisaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -235,7 +258,7 @@ func (h *RespostaHandler) CountRespostasByPergunta(w http.ResponseWriter, r *htt
 
 	count, err := h.respostaUseCase.CountByPergunta(r.Context(), perguntaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
@@ -264,7 +287,7 @@ func (h *RespostaHandler) DeleteRespostasByPesquisa(w http.ResponseWriter, r *ht
 	motivo := "Exclusão solicitada pelo administrador"
 
 	if err := h.respostaUseCase.DeleteByPesquisa(r.Context(), pesquisaID, userAdminID, motivo); err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -286,10 +309,14 @@ func (h *RespostaHandler) GetStatsByPergunta(w http.ResponseWriter, r *http.Requ
 
 	stats, err := h.respostaUseCase.GetStatisticsByPergunta(r.Context(), perguntaID)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pergunta não encontrada", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "resultado suprimido") {
+			response.WriteError(w, http.StatusForbidden, "Resultado suprimido", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
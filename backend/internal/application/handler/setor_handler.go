@@ -59,6 +59,10 @@ func (h *SetorHandler) CreateSetor(w http.ResponseWriter, r *http.Request) {
 			response.WriteError(w, http.StatusConflict, "Setor já existe", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -78,7 +82,7 @@ func (h *SetorHandler) GetSetor(w http.ResponseWriter, r *http.Request) {
 
 	setor, err := h.setorUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Setor não encontrado", err.Error())
 			return
 		}
@@ -101,6 +105,10 @@ func (h *SetorHandler) ListSetoresByEmpresa(w http.ResponseWriter, r *http.Reque
 
 	setores, err := h.setorUseCase.ListByEmpresa(r.Context(), empresaID)
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -132,7 +140,7 @@ func (h *SetorHandler) UpdateSetor(w http.ResponseWriter, r *http.Request) {
 	// Buscar setor existente
 	setor, err := h.setorUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Setor não encontrado", err.Error())
 			return
 		}
@@ -152,6 +160,10 @@ func (h *SetorHandler) UpdateSetor(w http.ResponseWriter, r *http.Request) {
 			response.WriteError(w, http.StatusConflict, "Nome do setor já em uso", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -173,7 +185,7 @@ func (h *SetorHandler) DeleteSetor(w http.ResponseWriter, r *http.Request) {
 	clientIP := h.getClientIP(r)
 
 	if err := h.setorUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Setor não encontrado", err.Error())
 			return
 		}
@@ -205,7 +217,7 @@ func (h *SetorHandler) GetSetorByNome(w http.ResponseWriter, r *http.Request) {
 
 	setor, err := h.setorUseCase.GetByNome(r.Context(), empresaID, nome)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Setor não encontrado", err.Error())
 			return
 		}
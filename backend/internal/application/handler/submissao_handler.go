@@ -73,7 +73,7 @@ func (h *SubmissaoHandler) GenerateAccessToken(w http.ResponseWriter, r *http.Re
 			response.WriteError(w, http.StatusTooManyRequests, "Limite excedido", err.Error())
 			return
 		}
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -109,7 +109,7 @@ func (h *SubmissaoHandler) GetSubmissionStats(w http.ResponseWriter, r *http.Req
 	if err != nil {
 		h.log.WithContext(r.Context()).Error("Erro ao buscar stats pesquisa ID=%d: %v", pesquisaID, err)
 		
-		if strings.Contains(err.Error(), "não encontrada") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Pesquisa não encontrada", err.Error())
 			return
 		}
@@ -141,8 +141,6 @@ func (h *SubmissaoHandler) getClientIP(r *http.Request) string {
 // RegisterRoutes registra todas as rotas HTTP do handler no roteador
 func (h *SubmissaoHandler) RegisterRoutes(router *mux.Router) {
 	// Rota pública - gerar token
+	// As estatísticas (admin) são registradas em SetupRouter com autenticação e permissão
 	router.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/token", h.GenerateAccessToken).Methods("POST")
-	
-	// Rota protegida - estatísticas (admin)
-	router.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/submissions/stats", h.GetSubmissionStats).Methods("GET")
 }
\ No newline at end of file
@@ -44,6 +44,11 @@ type StatusUpdateRequest struct {
 	Status string `json:"status"` // Novo status do usuário
 }
 
+// PapelUpdateRequest representa requisição de alteração de papel
+type PapelUpdateRequest struct {
+	Papel string `json:"papel"` // Novo papel do usuário
+}
+
 // CreateUsuarioAdministrador cria novo usuário administrativo no sistema
 func (h *UsuarioAdministradorHandler) CreateUsuarioAdministrador(w http.ResponseWriter, r *http.Request) {
 	var req dto.UsuarioAdministradorCreateRequest
@@ -88,6 +93,10 @@ func (h *UsuarioAdministradorHandler) CreateUsuarioAdministrador(w http.Response
 			response.WriteError(w, http.StatusConflict, "Email já em uso", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -107,7 +116,7 @@ func (h *UsuarioAdministradorHandler) GetUsuarioAdministrador(w http.ResponseWri
 
 	usuario, err := h.usuarioUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
@@ -140,6 +149,10 @@ func (h *UsuarioAdministradorHandler) ListUsuariosByEmpresa(w http.ResponseWrite
 	}
 
 	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -180,7 +193,7 @@ func (h *UsuarioAdministradorHandler) UpdateUsuarioAdministrador(w http.Response
 	// Buscar usuário existente
 	usuario, err := h.usuarioUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
@@ -200,6 +213,10 @@ func (h *UsuarioAdministradorHandler) UpdateUsuarioAdministrador(w http.Response
 			response.WriteError(w, http.StatusConflict, "Email já em uso", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -234,7 +251,7 @@ func (h *UsuarioAdministradorHandler) UpdatePassword(w http.ResponseWriter, r *h
 	clientIP := h.getClientIP(r)
 
 	if err := h.usuarioUseCase.UpdatePassword(r.Context(), id, req.NovaSenha, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
@@ -271,10 +288,14 @@ func (h *UsuarioAdministradorHandler) UpdateStatus(w http.ResponseWriter, r *htt
 	clientIP := h.getClientIP(r)
 
 	if err := h.usuarioUseCase.UpdateStatus(r.Context(), id, req.Status, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "proprietário") {
+			response.WriteError(w, http.StatusConflict, "Último proprietário", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -282,6 +303,105 @@ func (h *UsuarioAdministradorHandler) UpdateStatus(w http.ResponseWriter, r *htt
 	response.WriteSuccess(w, http.StatusOK, "Status atualizado com sucesso", nil)
 }
 
+// UpdatePapel altera o papel (e as permissões) de usuário administrativo
+func (h *UsuarioAdministradorHandler) UpdatePapel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	var req PapelUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	if !entity.PapelValido(req.Papel) {
+		response.WriteError(w, http.StatusBadRequest, "Papel inválido", "Use: proprietario, gestor_pesquisas, analista ou auditor")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.usuarioUseCase.UpdatePapel(r.Context(), id, req.Papel, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "proprietário") {
+			response.WriteError(w, http.StatusConflict, "Último proprietário", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Papel atualizado com sucesso", nil)
+}
+
+// ListLoginLocks lista as contas da empresa com login temporariamente bloqueado
+func (h *UsuarioAdministradorHandler) ListLoginLocks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	empresaID, err := strconv.Atoi(vars["empresa_id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID da empresa inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	bloqueios, err := h.usuarioUseCase.ListLoginLocks(r.Context(), empresaID)
+	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Recurso não encontrado", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	bloqueiosResponse := make([]response.BloqueioLoginResponse, 0, len(bloqueios))
+	for _, bloqueio := range bloqueios {
+		if bloqueio.IDUserAdmin == nil || bloqueio.BloqueadoAte == nil {
+			continue
+		}
+		bloqueiosResponse = append(bloqueiosResponse, response.BloqueioLoginResponse{
+			IDUserAdmin:  *bloqueio.IDUserAdmin,
+			Email:        bloqueio.Chave,
+			Falhas:       bloqueio.Falhas,
+			UltimaFalha:  bloqueio.UltimaFalha,
+			BloqueadoAte: *bloqueio.BloqueadoAte,
+		})
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Bloqueios de login listados com sucesso", bloqueiosResponse)
+}
+
+// UnlockLogin libera o login de um usuário temporariamente bloqueado
+func (h *UsuarioAdministradorHandler) UnlockLogin(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID deve ser um número inteiro")
+		return
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	clientIP := h.getClientIP(r)
+
+	if err := h.usuarioUseCase.UnlockLogin(r.Context(), id, userAdminID, clientIP); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Bloqueio não encontrado", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Login desbloqueado com sucesso", nil)
+}
+
 // DeleteUsuarioAdministrador inativa usuário administrativo (soft delete)
 func (h *UsuarioAdministradorHandler) DeleteUsuarioAdministrador(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -295,7 +415,7 @@ func (h *UsuarioAdministradorHandler) DeleteUsuarioAdministrador(w http.Response
 	clientIP := h.getClientIP(r)
 
 	if err := h.usuarioUseCase.Delete(r.Context(), id, userAdminID, clientIP); err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
@@ -303,6 +423,10 @@ func (h *UsuarioAdministradorHandler) DeleteUsuarioAdministrador(w http.Response
 			response.WriteError(w, http.StatusConflict, "Usuário já inativo", err.Error())
 			return
 		}
+		if strings.Contains(err.Error(), "proprietário") {
+			response.WriteError(w, http.StatusConflict, "Último proprietário", err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
@@ -322,7 +446,7 @@ func (h *UsuarioAdministradorHandler) GetUsuarioByEmail(w http.ResponseWriter, r
 
 	usuario, err := h.usuarioUseCase.GetByEmail(r.Context(), email)
 	if err != nil {
-		if strings.Contains(err.Error(), "não encontrado") {
+		if strings.Contains(err.Error(), "não encontrad") {
 			response.WriteError(w, http.StatusNotFound, "Usuário não encontrado", err.Error())
 			return
 		}
@@ -342,6 +466,9 @@ func (h *UsuarioAdministradorHandler) validateUsuarioCreateRequest(req *dto.Usua
 	if strings.TrimSpace(req.NomeAdmin) == "" {
 		return validator.ValidationError{Field: "nome_admin", Message: "obrigatório"}
 	}
+	if req.Papel != "" && !entity.PapelValido(req.Papel) {
+		return validator.ValidationError{Field: "papel", Message: "papel inválido"}
+	}
 	validStatuses := []string{"Ativo", "Inativo", "Pendente"}
 	return h.validator.IsValidStatus(req.Status, validStatuses)
 }
@@ -375,6 +502,7 @@ func (h *UsuarioAdministradorHandler) toUsuarioResponse(usuario *entity.UsuarioA
 		Email:        usuario.Email,
 		DataCadastro: usuario.DataCadastro,
 		Status:       usuario.Status,
+		Papel:        usuario.Papel,
 	}
 
 	// Incluir dados da empresa se carregada
@@ -400,6 +528,9 @@ func (h *UsuarioAdministradorHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/usuarios-administradores/{id:[0-9]+}", h.DeleteUsuarioAdministrador).Methods("DELETE")
 	router.HandleFunc("/usuarios-administradores/{id:[0-9]+}/password", h.UpdatePassword).Methods("PUT")
 	router.HandleFunc("/usuarios-administradores/{id:[0-9]+}/status", h.UpdateStatus).Methods("PUT")
+	router.HandleFunc("/usuarios-administradores/{id:[0-9]+}/papel", h.UpdatePapel).Methods("PUT")
+	router.HandleFunc("/usuarios-administradores/{id:[0-9]+}/bloqueio", h.UnlockLogin).Methods("DELETE")
 	router.HandleFunc("/usuarios-administradores/email/{email}", h.GetUsuarioByEmail).Methods("GET")
 	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/usuarios-administradores", h.ListUsuariosByEmpresa).Methods("GET")
+	router.HandleFunc("/empresas/{empresa_id:[0-9]+}/usuarios-administradores/bloqueios", h.ListLoginLocks).Methods("GET")
 }
\ No newline at end of file
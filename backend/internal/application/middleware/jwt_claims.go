@@ -8,8 +8,11 @@ import (
 
 // JWTClaims define os dados de autenticação contidos no token JWT
 type JWTClaims struct {
-    UserID    int    `json:"user_id"`    // ID do usuário autenticado
-    EmpresaID int    `json:"empresa_id"` // ID da empresa vinculada
-    Email     string `json:"email"`      // Email do usuário
-    jwt.RegisteredClaims                 // Claims padrão JWT (exp, iat, iss)
+    UserID     int      `json:"user_id"`    // ID do usuário autenticado
+    EmpresaID  int      `json:"empresa_id"` // ID da empresa vinculada
+    Email      string   `json:"email"`      // Email do usuário
+    Papel      string   `json:"papel"`      // Papel do usuário (proprietario, gestor_pesquisas, analista, auditor)
+    Permissoes []string `json:"permissoes"` // Permissões concedidas pelo papel no momento da emissão
+    SessaoID   int      `json:"sid"`        // Sessão de login que emitiu o token (0 se não houver)
+    jwt.RegisteredClaims                    // Claims padrão JWT (exp, iat, iss, jti)
 }
\ No newline at end of file
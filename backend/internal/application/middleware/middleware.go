@@ -5,6 +5,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +13,9 @@ import (
 
 	"organizational-climate-survey/backend/internal/application/dto/response"
 	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+	"organizational-climate-survey/backend/pkg/ratelimit"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
@@ -24,7 +28,7 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
-		w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
 		// Responder requisições preflight
@@ -52,8 +56,14 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// TokenRevocationChecker consulta a denylist de access tokens
+type TokenRevocationChecker interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
 // JWTAuthMiddleware valida tokens JWT e injeta dados do usuário no contexto
-func JWTAuthMiddleware(jwtSecret []byte) func(http.Handler) http.Handler {
+// Com revocation informado, tokens sem jti ou com jti revogado (logout, sessão revogada) são rejeitados
+func JWTAuthMiddleware(jwtSecret []byte, revocation TokenRevocationChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extrair token do header Authorization
@@ -86,10 +96,33 @@ func JWTAuthMiddleware(jwtSecret []byte) func(http.Handler) http.Handler {
 			}
 
 			if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
+				if revocation != nil {
+					if claims.ID == "" {
+						response.WriteError(w, http.StatusUnauthorized, "Token inválido", "Token sem identificador; faça login novamente")
+						return
+					}
+					revoked, err := revocation.IsRevoked(r.Context(), claims.ID)
+					if err != nil {
+						response.WriteError(w, http.StatusInternalServerError, "Erro interno", "Não foi possível validar o token")
+						return
+					}
+					if revoked {
+						response.WriteError(w, http.StatusUnauthorized, "Token revogado", "Sessão encerrada; faça login novamente")
+						return
+					}
+				}
+
 				// Injetar dados do usuário no contexto da requisição
 				ctx := context.WithValue(r.Context(), "user_admin_id", claims.UserID)
 				ctx = context.WithValue(ctx, "empresa_id", claims.EmpresaID)
 				ctx = context.WithValue(ctx, "user_email", claims.Email)
+				ctx = context.WithValue(ctx, "user_papel", claims.Papel)
+				ctx = context.WithValue(ctx, "user_permissions", claims.Permissoes)
+				ctx = context.WithValue(ctx, "session_id", claims.SessaoID)
+				ctx = context.WithValue(ctx, "token_jti", claims.ID)
+				if claims.ExpiresAt != nil {
+					ctx = context.WithValue(ctx, "token_expires_at", claims.ExpiresAt.Time)
+				}
 				
 				next.ServeHTTP(w, r.WithContext(ctx))
 			} else {
@@ -101,29 +134,167 @@ func JWTAuthMiddleware(jwtSecret []byte) func(http.Handler) http.Handler {
 }
 
 // EmpresaAuthMiddleware valida autorização de acesso a recursos da empresa
+// Restringe o contexto à empresa do token; os casos de uso verificam a propriedade de cada recurso
 func EmpresaAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verificar presença de informações de empresa no contexto
-		userEmpresaID := r.Context().Value("empresa_id")
-		if userEmpresaID == nil {
+		userEmpresaID, ok := r.Context().Value("empresa_id").(int)
+		if !ok || userEmpresaID <= 0 {
 			response.WriteError(w, http.StatusUnauthorized, "Contexto inválido", "Informações de empresa não encontradas")
 			return
 		}
 
-		// Ponto de extensão para validações adicionais de autorização
-		
-		next.ServeHTTP(w, r)
+		// Recursos de outras empresas passam a ser tratados como inexistentes
+		ctx := usecase.WithEmpresaScope(r.Context(), userEmpresaID)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// RateLimitMiddleware implementa controle de taxa de requisições
-func RateLimitMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Implementação placeholder para rate limiting
-		// Produção requer solução robusta com Redis ou similar
-		
-		next.ServeHTTP(w, r)
-	})
+// RequirePermission exige que o token do usuário conceda a permissão informada
+// Deve ser executado após JWTAuthMiddleware, que injeta as permissões no contexto
+func RequirePermission(permissao string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permissoes, _ := r.Context().Value("user_permissions").([]string)
+			for _, p := range permissoes {
+				if p == permissao {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			response.WriteError(w, http.StatusForbidden, "Acesso negado", fmt.Sprintf("Permissão necessária: %s", permissao))
+		})
+	}
+}
+
+// Classes de rota com limites de taxa independentes
+const (
+	RateLimitPublic        = "public"        // Rotas públicas em geral
+	RateLimitAuth          = "auth"          // Login, recuperação de senha e tokens
+	RateLimitSubmission    = "submission"    // Submissão de respostas
+	RateLimitAuthenticated = "authenticated" // Rotas autenticadas (chave por usuário)
+)
+
+// RateLimiter aplica limites por classe de rota usando um ratelimit.Store
+type RateLimiter struct {
+	store          ratelimit.Store            // Armazenamento dos buckets
+	limits         map[string]ratelimit.Limit // Limite por classe de rota
+	trustedProxies []*net.IPNet               // Proxies cujos X-Forwarded-For/X-Real-IP identificam o cliente
+	logger         logger.Logger              // Logger das falhas do armazenamento
+}
+
+// NewRateLimiter cria um limitador com os limites de cada classe de rota
+// Classes sem limite configurado não são limitadas; sem proxies confiáveis o cliente é o endereço da conexão
+func NewRateLimiter(store ratelimit.Store, limits map[string]ratelimit.Limit, trustedProxies []*net.IPNet, log logger.Logger) *RateLimiter {
+	if log == nil {
+		log = logger.NoopLogger{}
+	}
+	return &RateLimiter{
+		store:          store,
+		limits:         limits,
+		trustedProxies: trustedProxies,
+		logger:         log,
+	}
+}
+
+// RateLimitMiddleware implementa controle de taxa de requisições por token bucket
+// A chave é o usuário do JWT quando autenticado, ou o IP do cliente; limitador nil desativa o controle
+func RateLimitMiddleware(rl *RateLimiter, class string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if rl == nil {
+			return next
+		}
+		limit, ok := rl.limits[class]
+		if !ok || !limit.Valid() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := class + ":ip:" + ClientIP(r, rl.trustedProxies)
+			if userID, ok := r.Context().Value("user_admin_id").(int); ok && userID > 0 {
+				key = class + ":user:" + strconv.Itoa(userID)
+			}
+
+			result, err := rl.store.Take(r.Context(), key, limit, time.Now())
+			if err != nil {
+				// Falha do armazenamento não deve derrubar a API
+				rl.logger.Warn("erro no rate limit (%s): %v", class, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				response.WriteError(w, http.StatusTooManyRequests, "Muitas requisições", "Limite de requisições excedido. Tente novamente mais tarde")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientIP identifica o cliente pelo endereço da conexão, sem a porta
+// Headers de proxy só são lidos quando a conexão vem de um proxy confiável. No X-Forwarded-For o cliente é
+// o primeiro endereço não confiável a partir da direita: as entradas à esquerda são enviadas pelo próprio cliente
+func ClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	if !proxyConfiavel(remote, trustedProxies) {
+		return remote
+	}
+
+	var saltos []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		saltos = append(saltos, strings.Split(header, ",")...)
+	}
+	cliente := ""
+	for i := len(saltos) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(saltos[i])
+		if net.ParseIP(ip) == nil {
+			// Entrada inválida interrompe a cadeia; vale o último salto reconhecido
+			break
+		}
+		cliente = ip
+		if !proxyConfiavel(ip, trustedProxies) {
+			return ip
+		}
+	}
+	if cliente != "" {
+		return cliente
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	return remote
+}
+
+// proxyConfiavel indica se o endereço pertence a um dos proxies configurados
+func proxyConfiavel(endereco string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(endereco)
+	if ip == nil {
+		return false
+	}
+	for _, rede := range trustedProxies {
+		if rede.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ceilSeconds converte uma duração em segundos inteiros, arredondando para cima
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
 }
 
 // ContentTypeMiddleware valida Content-Type JSON em requisições de escrita
@@ -167,6 +338,14 @@ func PublicRouteMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// SystemScopeMiddleware libera o acesso dos casos de uso a todas as empresas
+// Usado nas rotas sem usuário autenticado (login, formulário público, submissão), que não têm empresa no token
+func SystemScopeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(usecase.WithSystemScope(r.Context())))
+	})
+}
+
 // ActiveSurveyMiddleware valida se pesquisa está ativa e no período correto
 // Requer PesquisaRepository como dependência
 func ActiveSurveyMiddleware(pesquisaRepo repository.PesquisaRepository) func(http.Handler) http.Handler {
@@ -235,50 +414,66 @@ func ChainMiddleware(middlewares ...func(http.Handler) http.Handler) func(http.H
 }
 
 // PublicMiddlewares retorna cadeia de middlewares para rotas públicas
-func PublicMiddlewares() func(http.Handler) http.Handler {
+func PublicMiddlewares(rl *RateLimiter) func(http.Handler) http.Handler {
 	return ChainMiddleware(
 		RecoveryMiddleware,
 		CORSMiddleware,
 		LoggingMiddleware,
-		RateLimitMiddleware,
+		RateLimitMiddleware(rl, RateLimitPublic),
 		PublicRouteMiddleware,
+		SystemScopeMiddleware,
+	)
+}
+
+// AuthRouteMiddlewares retorna cadeia de middlewares para rotas públicas de autenticação
+// Usa um limite mais restrito para dificultar ataques de força bruta
+func AuthRouteMiddlewares(rl *RateLimiter) func(http.Handler) http.Handler {
+	return ChainMiddleware(
+		RecoveryMiddleware,
+		CORSMiddleware,
+		LoggingMiddleware,
+		RateLimitMiddleware(rl, RateLimitAuth),
+		PublicRouteMiddleware,
+		SystemScopeMiddleware,
 	)
 }
 
 // AuthenticatedMiddlewares retorna cadeia de middlewares para rotas autenticadas
-func AuthenticatedMiddlewares(jwtSecret []byte) func(http.Handler) http.Handler {
+// O rate limit vem após o JWT para usar o usuário como chave
+func AuthenticatedMiddlewares(jwtSecret []byte, revocation TokenRevocationChecker, rl *RateLimiter) func(http.Handler) http.Handler {
 	return ChainMiddleware(
 		RecoveryMiddleware,
 		CORSMiddleware,
 		LoggingMiddleware,
-		RateLimitMiddleware,
 		ContentTypeMiddleware,
-		JWTAuthMiddleware(jwtSecret),
+		JWTAuthMiddleware(jwtSecret, revocation),
+		RateLimitMiddleware(rl, RateLimitAuthenticated),
 		EmpresaAuthMiddleware,
 	)
 }
 
 // AdminMiddlewares retorna cadeia de middlewares para rotas administrativas
-func AdminMiddlewares(jwtSecret []byte) func(http.Handler) http.Handler {
+func AdminMiddlewares(jwtSecret []byte, revocation TokenRevocationChecker, rl *RateLimiter) func(http.Handler) http.Handler {
 	return ChainMiddleware(
 		RecoveryMiddleware,
 		CORSMiddleware,
 		LoggingMiddleware,
-		RateLimitMiddleware,
 		ContentTypeMiddleware,
-		JWTAuthMiddleware(jwtSecret),
+		JWTAuthMiddleware(jwtSecret, revocation),
+		RateLimitMiddleware(rl, RateLimitAuthenticated),
 		EmpresaAuthMiddleware,
 	)
 }
 
 // SurveySubmissionMiddlewares retorna cadeia de middlewares para submissão de respostas
-func SurveySubmissionMiddlewares(pesquisaRepo repository.PesquisaRepository) func(http.Handler) http.Handler {
+func SurveySubmissionMiddlewares(pesquisaRepo repository.PesquisaRepository, rl *RateLimiter) func(http.Handler) http.Handler {
 	return ChainMiddleware(
 		RecoveryMiddleware,
 		CORSMiddleware,
 		LoggingMiddleware,
-		RateLimitMiddleware,
+		RateLimitMiddleware(rl, RateLimitSubmission),
 		ContentTypeMiddleware,
+		SystemScopeMiddleware,
 		ActiveSurveyMiddleware(pesquisaRepo),
 	)
-}
\ No newline at end of file
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	confiaveis := []*net.IPNet{proxies}
+
+	casos := []struct {
+		nome     string
+		remote   string
+		xff      []string
+		realIP   string
+		proxies  []*net.IPNet
+		esperado string
+	}{
+		{"sem proxies configurados ignora headers", "203.0.113.7:5000", []string{"198.51.100.1"}, "198.51.100.2", nil, "203.0.113.7"},
+		{"conexão fora dos proxies ignora headers", "203.0.113.7:5000", []string{"198.51.100.1"}, "", confiaveis, "203.0.113.7"},
+		{"proxy confiável usa o salto anterior", "10.0.0.1:5000", []string{"198.51.100.1"}, "", confiaveis, "198.51.100.1"},
+		{"entrada forjada à esquerda é ignorada", "10.0.0.1:5000", []string{"1.2.3.4, 198.51.100.1"}, "", confiaveis, "198.51.100.1"},
+		{"proxies encadeados são pulados", "10.0.0.1:5000", []string{"1.2.3.4, 198.51.100.1, 10.0.0.2"}, "", confiaveis, "198.51.100.1"},
+		{"vários headers formam uma única cadeia", "10.0.0.1:5000", []string{"1.2.3.4", "198.51.100.1, 10.0.0.2"}, "", confiaveis, "198.51.100.1"},
+		{"entrada inválida interrompe a cadeia", "10.0.0.1:5000", []string{"198.51.100.1, lixo, 10.0.0.2"}, "", confiaveis, "10.0.0.2"},
+		{"sem X-Forwarded-For usa X-Real-IP", "10.0.0.1:5000", nil, "198.51.100.2", confiaveis, "198.51.100.2"},
+		{"X-Real-IP inválido usa a conexão", "10.0.0.1:5000", nil, "lixo", confiaveis, "10.0.0.1"},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = c.remote
+			for _, xff := range c.xff {
+				r.Header.Add("X-Forwarded-For", xff)
+			}
+			if c.realIP != "" {
+				r.Header.Set("X-Real-IP", c.realIP)
+			}
+
+			if ip := ClientIP(r, c.proxies); ip != c.esperado {
+				t.Errorf("ClientIP = %q, esperado %q", ip, c.esperado)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece o resultado da análise das respostas abertas, calculado após o encerramento da pesquisa.
+package entity
+
+import "time"
+
+// SentimentoTexto resume a polaridade das respostas abertas
+type SentimentoTexto struct {
+	Positivas int     `json:"positivas"` // Respostas com saldo positivo no léxico de sentimento
+	Neutras   int     `json:"neutras"`   // Respostas sem saldo
+	Negativas int     `json:"negativas"` // Respostas com saldo negativo
+	Indice    float64 `json:"indice"`    // (positivas - negativas) / respostas * 100, de -100 a 100
+}
+
+// PalavraChave é um termo (uma ou duas palavras) frequente nas respostas abertas
+type PalavraChave struct {
+	Termo       string `json:"termo"`       // Forma mais usada do termo, em minúsculas
+	Palavras    int    `json:"palavras"`    // 1 para termos simples, 2 para expressões
+	Ocorrencias int    `json:"ocorrencias"` // Ocorrências no total das respostas
+	Respostas   int    `json:"respostas"`   // Respostas distintas que citam o termo
+}
+
+// TopicoTexto é um grupo de respostas que compartilham um termo
+type TopicoTexto struct {
+	Termos     []string        `json:"termos"`     // Termos que descrevem o tópico
+	Respostas  int             `json:"respostas"`  // Respostas do tópico
+	Sentimento SentimentoTexto `json:"sentimento"` // Sentimento das respostas do tópico
+}
+
+// AnaliseTexto é o resultado da análise das respostas de uma pergunta aberta
+// Guarda apenas agregados: termos e tópicos citados por menos de MinRespondentes respostas não são gravados
+type AnaliseTexto struct {
+	ID              int             `json:"id_analise"`
+	IDPergunta      int             `json:"id_pergunta"`
+	IDPesquisa      int             `json:"id_pesquisa"`
+	Respostas       int             `json:"respostas"`        // Respostas não vazias da pergunta
+	MinRespondentes int             `json:"min_respondentes"` // Mínimo da empresa no momento da análise
+	Sentimento      SentimentoTexto `json:"sentimento"`
+	PalavrasChave   []PalavraChave  `json:"palavras_chave"`
+	Topicos         []TopicoTexto   `json:"topicos"`
+	SemTopico       int             `json:"sem_topico"` // Respostas fora dos tópicos
+	DataAnalise     time.Time       `json:"data_analise"`
+}
+
+// Suprimido indica se a pergunta tem menos respostas que o mínimo para exibir a análise
+func (a *AnaliseTexto) Suprimido(minimo int) bool {
+	return a.Respostas < minimo
+}
@@ -0,0 +1,495 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as condições de exibição de perguntas (saltos e ramificações) e sua avaliação.
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operadores de comparação das condições de exibição
+const (
+	OperadorIgual      = "="  // Resposta igual ao valor (nas múltiplas escolhas, opção selecionada)
+	OperadorDiferente  = "!=" // Pergunta respondida com valor diferente
+	OperadorEm         = "em" // Resposta igual a um dos valores
+	OperadorMaiorIgual = ">=" // Escala numérica maior ou igual ao valor
+	OperadorMenorIgual = "<=" // Escala numérica menor ou igual ao valor
+)
+
+// Limites das condições de exibição
+const (
+	maxProfundidadeCondicao = 5  // Níveis de grupos aninhados
+	maxComparacoesCondicao  = 20 // Comparações por pergunta
+	maxValoresCondicao      = 50 // Valores do operador "em"
+)
+
+// CondicaoExibicao define quando uma pergunta é exibida, a partir das respostas a perguntas anteriores
+// É uma comparação ({"pergunta": 3, "operador": ">=", "valor": "4"}) ou um grupo de condições
+// combinadas com E ({"todas": [...]}) ou com OU ({"alguma": [...]})
+// Gravada em Pergunta.CondicaoExibicao; perguntas sem condição são sempre exibidas
+type CondicaoExibicao struct {
+	Todas    []CondicaoExibicao `json:"todas,omitempty"`    // Todas as condições precisam ser atendidas (E)
+	Alguma   []CondicaoExibicao `json:"alguma,omitempty"`   // Basta uma condição ser atendida (OU)
+	Pergunta int                `json:"pergunta,omitempty"` // ID da pergunta comparada
+	Operador string             `json:"operador,omitempty"` // =, !=, em, >= ou <=
+	Valor    string             `json:"valor,omitempty"`    // Valor comparado (=, !=, >=, <=)
+	Valores  []string           `json:"valores,omitempty"`  // Valores aceitos (em)
+}
+
+// ParseCondicaoExibicao interpreta e valida a estrutura de uma condição de exibição
+// Condição ausente resulta em nil; as perguntas referenciadas são validadas por ValidarCondicoesExibicao
+func ParseCondicaoExibicao(raw *string) (*CondicaoExibicao, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+
+	var condicao CondicaoExibicao
+	if err := json.Unmarshal([]byte(strings.TrimSpace(*raw)), &condicao); err != nil {
+		return nil, fmt.Errorf("use uma comparação {\"pergunta\", \"operador\", \"valor\"} ou um grupo {\"todas\"} / {\"alguma\"}")
+	}
+
+	comparacoes := 0
+	if err := condicao.validate(1, &comparacoes); err != nil {
+		return nil, err
+	}
+	return &condicao, nil
+}
+
+// validate verifica a estrutura da condição e de seus grupos
+func (c *CondicaoExibicao) validate(profundidade int, comparacoes *int) error {
+	if profundidade > maxProfundidadeCondicao {
+		return fmt.Errorf("grupos podem ter no máximo %d níveis", maxProfundidadeCondicao)
+	}
+
+	grupos := 0
+	if c.Todas != nil {
+		grupos++
+	}
+	if c.Alguma != nil {
+		grupos++
+	}
+	comparacao := c.Pergunta != 0 || c.Operador != "" || c.Valor != "" || c.Valores != nil
+
+	switch {
+	case grupos+boolToInt(comparacao) != 1:
+		return fmt.Errorf("cada condição deve ser uma comparação ou um único grupo (\"todas\" ou \"alguma\")")
+	case grupos == 1:
+		filhas := c.Todas
+		if c.Alguma != nil {
+			filhas = c.Alguma
+		}
+		if len(filhas) == 0 {
+			return fmt.Errorf("grupo de condições não pode estar vazio")
+		}
+		for i := range filhas {
+			if err := filhas[i].validate(profundidade+1, comparacoes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	*comparacoes++
+	if *comparacoes > maxComparacoesCondicao {
+		return fmt.Errorf("condição pode ter no máximo %d comparações", maxComparacoesCondicao)
+	}
+	if c.Pergunta <= 0 {
+		return fmt.Errorf("comparação deve informar o ID da pergunta")
+	}
+
+	switch c.Operador {
+	case OperadorIgual, OperadorDiferente, OperadorMaiorIgual, OperadorMenorIgual:
+		if strings.TrimSpace(c.Valor) == "" || c.Valores != nil {
+			return fmt.Errorf("operador %q exige \"valor\"", c.Operador)
+		}
+	case OperadorEm:
+		if len(c.Valores) == 0 || len(c.Valores) > maxValoresCondicao || c.Valor != "" {
+			return fmt.Errorf("operador \"em\" exige \"valores\" com 1 a %d itens", maxValoresCondicao)
+		}
+	default:
+		return fmt.Errorf("operador desconhecido: %q (use =, !=, em, >= ou <=)", c.Operador)
+	}
+	return nil
+}
+
+// JSON retorna a condição serializada para gravação em Pergunta.CondicaoExibicao
+// Os operadores >= e <= são gravados sem escape, como enviados
+func (c *CondicaoExibicao) JSON() string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(c)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Referencias retorna os IDs das perguntas comparadas pela condição
+func (c *CondicaoExibicao) Referencias() []int {
+	var ids []int
+	c.visit(func(comparacao *CondicaoExibicao) {
+		ids = append(ids, comparacao.Pergunta)
+	})
+	return ids
+}
+
+// visit percorre as comparações da condição
+func (c *CondicaoExibicao) visit(fn func(*CondicaoExibicao)) {
+	switch {
+	case c.Todas != nil:
+		for i := range c.Todas {
+			c.Todas[i].visit(fn)
+		}
+	case c.Alguma != nil:
+		for i := range c.Alguma {
+			c.Alguma[i].visit(fn)
+		}
+	default:
+		fn(c)
+	}
+}
+
+// ValidarCondicoesExibicao valida as condições de todas as perguntas de uma pesquisa e grava a forma canônica
+// Cada comparação deve referenciar uma pergunta da mesma pesquisa, exibida antes (sem ciclos nem referências
+// adiante), que não seja de resposta aberta; os valores são convertidos para a forma gravada nas respostas
+// Perguntas novas (ID 0) podem ter condição, mas ainda não podem ser referenciadas
+func ValidarCondicoesExibicao(perguntas []*Pergunta) error {
+	ordenadas := OrdenarPerguntas(perguntas)
+
+	posicao := make(map[int]int, len(ordenadas))
+	porID := make(map[int]*Pergunta, len(ordenadas))
+	for i, pergunta := range ordenadas {
+		if pergunta.ID > 0 {
+			posicao[pergunta.ID] = i
+			porID[pergunta.ID] = pergunta
+		}
+	}
+
+	condicoes := make(map[*Pergunta]*CondicaoExibicao)
+	for _, pergunta := range ordenadas {
+		condicao, err := ParseCondicaoExibicao(pergunta.CondicaoExibicao)
+		if err != nil {
+			return fmt.Errorf("%s: %v", descreverPergunta(pergunta), err)
+		}
+		if condicao != nil {
+			condicoes[pergunta] = condicao
+		}
+	}
+
+	if ciclo := encontrarCiclo(condicoes, porID); ciclo != nil {
+		ids := make([]string, len(ciclo))
+		for i, id := range ciclo {
+			ids[i] = strconv.Itoa(id)
+		}
+		return fmt.Errorf("as condições formam um ciclo entre as perguntas %s", strings.Join(ids, " -> "))
+	}
+
+	for i, pergunta := range ordenadas {
+		condicao, ok := condicoes[pergunta]
+		if !ok {
+			pergunta.CondicaoExibicao = nil
+			continue
+		}
+
+		var erro error
+		condicao.visit(func(comparacao *CondicaoExibicao) {
+			if erro != nil {
+				return
+			}
+			referenciada, existe := porID[comparacao.Pergunta]
+			switch {
+			case !existe:
+				erro = fmt.Errorf("pergunta %d não pertence à pesquisa", comparacao.Pergunta)
+			case referenciada == pergunta:
+				erro = fmt.Errorf("a pergunta não pode depender da própria resposta")
+			case posicao[comparacao.Pergunta] > i:
+				erro = fmt.Errorf("pergunta %d é exibida depois; condições só podem usar perguntas anteriores", comparacao.Pergunta)
+			default:
+				erro = comparacao.normalize(referenciada)
+			}
+		})
+		if erro != nil {
+			return fmt.Errorf("%s: %v", descreverPergunta(pergunta), erro)
+		}
+
+		normalizada := condicao.JSON()
+		pergunta.CondicaoExibicao = &normalizada
+	}
+	return nil
+}
+
+// normalize valida os valores da comparação contra a pergunta referenciada e os converte para a forma gravada
+func (c *CondicaoExibicao) normalize(referenciada *Pergunta) error {
+	if referenciada.TipoPergunta == "RespostaAberta" {
+		return fmt.Errorf("pergunta %d é de resposta aberta e não pode ser usada em condições", referenciada.ID)
+	}
+
+	if c.Operador == OperadorMaiorIgual || c.Operador == OperadorMenorIgual {
+		if referenciada.TipoPergunta != TipoEscalaNumerica {
+			return fmt.Errorf("operador %q só se aplica a perguntas de escala numérica", c.Operador)
+		}
+		valor, err := normalizarValorCondicao(referenciada, c.Valor)
+		if err != nil {
+			return err
+		}
+		if valor == ValorNaoSeAplica {
+			return fmt.Errorf("operador %q exige um valor numérico", c.Operador)
+		}
+		c.Valor = valor
+		return nil
+	}
+
+	if c.Operador == OperadorEm {
+		for i, v := range c.Valores {
+			valor, err := normalizarValorCondicao(referenciada, v)
+			if err != nil {
+				return err
+			}
+			c.Valores[i] = valor
+		}
+		return nil
+	}
+
+	valor, err := normalizarValorCondicao(referenciada, c.Valor)
+	if err != nil {
+		return err
+	}
+	c.Valor = valor
+	return nil
+}
+
+// normalizarValorCondicao converte um valor de comparação para a forma gravada nas respostas da pergunta
+// Nas múltiplas escolhas o valor é uma opção (ID ou rótulo), convertida para o ID
+func normalizarValorCondicao(pergunta *Pergunta, valor string) (string, error) {
+	valor = strings.TrimSpace(valor)
+
+	switch pergunta.TipoPergunta {
+	case TipoEscalaNumerica:
+		normalizado, err := EscalaDaPergunta(pergunta).NormalizarResposta(valor)
+		if err != nil {
+			return "", fmt.Errorf("valor %q inválido para a pergunta %d: %v", valor, pergunta.ID, err)
+		}
+		return normalizado, nil
+
+	case TipoMultiplaEscolha:
+		opcoes, err := ParseOpcoesMultiplaEscolha(pergunta.OpcoesResposta)
+		if err != nil {
+			return valor, nil // Perguntas antigas, com opções fora do esquema, comparam o valor gravado
+		}
+		opcao := opcoes.resolve(valor)
+		if opcao == nil {
+			return "", fmt.Errorf("opção %q não existe na pergunta %d", valor, pergunta.ID)
+		}
+		return opcao.ID, nil
+
+	case "SimNao":
+		if valor != "Sim" && valor != "Não" {
+			return "", fmt.Errorf("valor da pergunta %d deve ser 'Sim' ou 'Não'", pergunta.ID)
+		}
+	}
+	return valor, nil
+}
+
+// encontrarCiclo procura um ciclo de dependências entre as condições e retorna os IDs envolvidos
+func encontrarCiclo(condicoes map[*Pergunta]*CondicaoExibicao, porID map[int]*Pergunta) []int {
+	const (
+		naoVisitada = iota
+		emVisita
+		visitada
+	)
+	estado := make(map[int]int)
+	var caminho []int
+	var ciclo []int
+
+	var visitar func(id int) bool
+	visitar = func(id int) bool {
+		estado[id] = emVisita
+		caminho = append(caminho, id)
+
+		if condicao, ok := condicoes[porID[id]]; ok {
+			for _, ref := range condicao.Referencias() {
+				if _, existe := porID[ref]; !existe || ref == id {
+					continue // Referências inexistentes e a si mesma têm mensagens próprias
+				}
+				switch estado[ref] {
+				case emVisita:
+					for i, passo := range caminho {
+						if passo == ref {
+							ciclo = append(append([]int{}, caminho[i:]...), ref)
+							return true
+						}
+					}
+				case naoVisitada:
+					if visitar(ref) {
+						return true
+					}
+				}
+			}
+		}
+
+		caminho = caminho[:len(caminho)-1]
+		estado[id] = visitada
+		return false
+	}
+
+	ids := make([]int, 0, len(porID))
+	for id := range porID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if estado[id] == naoVisitada && visitar(id) {
+			return ciclo
+		}
+	}
+	return nil
+}
+
+// Avaliar verifica se a condição é atendida pelas respostas (valores já na forma gravada, por ID da pergunta)
+// Comparações com perguntas sem resposta nunca são atendidas
+func (c *CondicaoExibicao) Avaliar(respostas map[int]string, perguntas map[int]*Pergunta) bool {
+	switch {
+	case c.Todas != nil:
+		for i := range c.Todas {
+			if !c.Todas[i].Avaliar(respostas, perguntas) {
+				return false
+			}
+		}
+		return true
+	case c.Alguma != nil:
+		for i := range c.Alguma {
+			if c.Alguma[i].Avaliar(respostas, perguntas) {
+				return true
+			}
+		}
+		return false
+	}
+
+	resposta, respondida := respostas[c.Pergunta]
+	pergunta, existe := perguntas[c.Pergunta]
+	if !respondida || !existe {
+		return false
+	}
+
+	switch c.Operador {
+	case OperadorIgual:
+		return respostaContem(pergunta, resposta, c.Valor)
+	case OperadorDiferente:
+		return !respostaContem(pergunta, resposta, c.Valor)
+	case OperadorEm:
+		for _, valor := range c.Valores {
+			if respostaContem(pergunta, resposta, valor) {
+				return true
+			}
+		}
+		return false
+	case OperadorMaiorIgual, OperadorMenorIgual:
+		escala := EscalaDaPergunta(pergunta)
+		v, ok := escala.Valor(resposta)
+		limite, err := strconv.ParseFloat(c.Valor, 64)
+		if !ok || err != nil {
+			return false
+		}
+		if c.Operador == OperadorMaiorIgual {
+			return v >= limite-toleranciaEscala
+		}
+		return v <= limite+toleranciaEscala
+	}
+	return false
+}
+
+// respostaContem compara uma resposta gravada com um valor da condição
+// Nas múltiplas escolhas verifica se a opção foi selecionada; nas escalas compara numericamente
+func respostaContem(pergunta *Pergunta, resposta, valor string) bool {
+	switch pergunta.TipoPergunta {
+	case TipoMultiplaEscolha:
+		for _, opcao := range OpcoesDaPergunta(pergunta).SelecoesResposta(resposta) {
+			if opcao.ID == valor {
+				return true
+			}
+		}
+		return false
+	case TipoEscalaNumerica:
+		if resposta == ValorNaoSeAplica || valor == ValorNaoSeAplica {
+			return resposta == valor
+		}
+		escala := EscalaDaPergunta(pergunta)
+		a, okA := escala.Valor(resposta)
+		b, okB := escala.Valor(valor)
+		return okA && okB && a == b
+	}
+	return resposta == valor
+}
+
+// PerguntasVisiveis determina quais perguntas são exibidas ao respondente, dadas as respostas enviadas
+// As perguntas são avaliadas em ordem de exibição; respostas a perguntas ocultas são desconsideradas
+// nas condições seguintes. Condições gravadas inválidas não ocultam a pergunta
+func PerguntasVisiveis(perguntas []*Pergunta, respostas map[int]string) map[int]bool {
+	porID := make(map[int]*Pergunta, len(perguntas))
+	for _, pergunta := range perguntas {
+		porID[pergunta.ID] = pergunta
+	}
+
+	consideradas := make(map[int]string, len(respostas))
+	visiveis := make(map[int]bool, len(perguntas))
+	for _, pergunta := range OrdenarPerguntas(perguntas) {
+		condicao, err := ParseCondicaoExibicao(pergunta.CondicaoExibicao)
+		visivel := err != nil || condicao == nil || condicao.Avaliar(consideradas, porID)
+		visiveis[pergunta.ID] = visivel
+		if resposta, ok := respostas[pergunta.ID]; ok && visivel {
+			consideradas[pergunta.ID] = resposta
+		}
+	}
+	return visiveis
+}
+
+// RemapearCondicaoExibicao troca os IDs das perguntas referenciadas, usada ao copiar perguntas para outra pesquisa
+// Referências sem correspondência são mantidas; condições inválidas são copiadas sem alteração
+func RemapearCondicaoExibicao(raw *string, ids map[int]int) *string {
+	condicao, err := ParseCondicaoExibicao(raw)
+	if err != nil || condicao == nil {
+		return raw
+	}
+	condicao.visit(func(comparacao *CondicaoExibicao) {
+		if novo, ok := ids[comparacao.Pergunta]; ok {
+			comparacao.Pergunta = novo
+		}
+	})
+	remapeada := condicao.JSON()
+	return &remapeada
+}
+
+// OrdenarPerguntas retorna uma cópia das perguntas em ordem de exibição
+// Empates são desfeitos pelo ID; perguntas ainda não gravadas (ID 0) ficam por último, como ficarão ao receber o ID
+func OrdenarPerguntas(perguntas []*Pergunta) []*Pergunta {
+	ordenadas := append([]*Pergunta(nil), perguntas...)
+	sort.SliceStable(ordenadas, func(i, j int) bool {
+		a, b := ordenadas[i], ordenadas[j]
+		switch {
+		case a.OrdemExibicao != b.OrdemExibicao:
+			return a.OrdemExibicao < b.OrdemExibicao
+		case a.ID == 0 || b.ID == 0:
+			return b.ID == 0 && a.ID != 0
+		}
+		return a.ID < b.ID
+	})
+	return ordenadas
+}
+
+// descreverPergunta identifica a pergunta nas mensagens de erro
+func descreverPergunta(pergunta *Pergunta) string {
+	if pergunta.ID > 0 {
+		return fmt.Sprintf("pergunta %d", pergunta.ID)
+	}
+	return fmt.Sprintf("pergunta na posição %d", pergunta.OrdemExibicao)
+}
+
+// boolToInt converte verdadeiro em 1
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
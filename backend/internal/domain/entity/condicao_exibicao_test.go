@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+const opcoesAreas = `{"opcoes":[{"id":"1","rotulo":"Vendas"},{"id":"2","rotulo":"TI"},{"id":"3","rotulo":"RH"}],"multipla_selecao":true}`
+
+// perguntasCondicao monta uma pesquisa com escala (1), sim/não (2 e 5), múltipla escolha (3) e resposta aberta (4)
+func perguntasCondicao() []*Pergunta {
+	opcoes := opcoesAreas
+	return []*Pergunta{
+		{ID: 1, OrdemExibicao: 1, TipoPergunta: TipoEscalaNumerica},
+		{ID: 2, OrdemExibicao: 2, TipoPergunta: "SimNao"},
+		{ID: 3, OrdemExibicao: 3, TipoPergunta: TipoMultiplaEscolha, OpcoesResposta: &opcoes},
+		{ID: 4, OrdemExibicao: 4, TipoPergunta: "RespostaAberta"},
+		{ID: 5, OrdemExibicao: 5, TipoPergunta: "SimNao"},
+	}
+}
+
+func comCondicao(pergunta *Pergunta, raw string) {
+	pergunta.CondicaoExibicao = &raw
+}
+
+func TestValidarCondicoesExibicao(t *testing.T) {
+	casos := []struct {
+		nome     string
+		preparar func(p []*Pergunta)
+		erro     string // Trecho esperado da mensagem; vazio quando a validação passa
+		canonica map[int]string
+	}{
+		{
+			nome: "referência a pergunta anterior",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"pergunta": 1, "operador": ">=", "valor": "4"}`)
+			},
+			canonica: map[int]string{2: `{"pergunta":1,"operador":">=","valor":"4"}`},
+		},
+		{
+			nome: "opção referida pelo rótulo é gravada pelo ID",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[4], `{"alguma": [{"pergunta": 3, "operador": "=", "valor": "ti"}, {"pergunta": 3, "operador": "em", "valores": ["RH", "1"]}]}`)
+			},
+			canonica: map[int]string{5: `{"alguma":[{"pergunta":3,"operador":"=","valor":"2"},{"pergunta":3,"operador":"em","valores":["3","1"]}]}`},
+		},
+		{
+			nome: "autorreferência",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"pergunta": 2, "operador": "=", "valor": "Sim"}`)
+			},
+			erro: "própria resposta",
+		},
+		{
+			nome: "autorreferência dentro de grupo",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"todas": [{"pergunta": 1, "operador": ">=", "valor": "3"}, {"pergunta": 2, "operador": "=", "valor": "Sim"}]}`)
+			},
+			erro: "própria resposta",
+		},
+		{
+			nome: "ciclo entre duas perguntas",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[0], `{"pergunta": 2, "operador": "=", "valor": "Sim"}`)
+				comCondicao(p[1], `{"pergunta": 1, "operador": ">=", "valor": "4"}`)
+			},
+			erro: "ciclo entre as perguntas 1 -> 2 -> 1",
+		},
+		{
+			nome: "referência adiante",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"pergunta": 5, "operador": "=", "valor": "Sim"}`)
+			},
+			erro: "pergunta 5 é exibida depois",
+		},
+		{
+			nome: "referência adiante após reordenar",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"pergunta": 1, "operador": ">=", "valor": "4"}`)
+				p[0].OrdemExibicao = 6 // A pergunta referenciada passa a ser exibida por último
+			},
+			erro: "pergunta 1 é exibida depois",
+		},
+		{
+			nome: "pergunta de outra pesquisa",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[1], `{"pergunta": 99, "operador": "=", "valor": "Sim"}`)
+			},
+			erro: "não pertence à pesquisa",
+		},
+		{
+			nome: "pergunta de resposta aberta",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[4], `{"pergunta": 4, "operador": "=", "valor": "ok"}`)
+			},
+			erro: "resposta aberta",
+		},
+		{
+			nome: "opção inexistente",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[4], `{"pergunta": 3, "operador": "=", "valor": "Financeiro"}`)
+			},
+			erro: "não existe",
+		},
+		{
+			nome: "operador de escala em pergunta sim/não",
+			preparar: func(p []*Pergunta) {
+				comCondicao(p[4], `{"pergunta": 2, "operador": ">=", "valor": "1"}`)
+			},
+			erro: "só se aplica",
+		},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			perguntas := perguntasCondicao()
+			c.preparar(perguntas)
+
+			err := ValidarCondicoesExibicao(perguntas)
+			if c.erro != "" {
+				if err == nil || !strings.Contains(err.Error(), c.erro) {
+					t.Fatalf("erro = %v, esperado contendo %q", err, c.erro)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			for _, pergunta := range perguntas {
+				if esperado, ok := c.canonica[pergunta.ID]; ok {
+					if pergunta.CondicaoExibicao == nil || *pergunta.CondicaoExibicao != esperado {
+						t.Errorf("pergunta %d: condição %v, esperado %s", pergunta.ID, pergunta.CondicaoExibicao, esperado)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAvaliarCondicaoExibicao(t *testing.T) {
+	porID := make(map[int]*Pergunta)
+	for _, pergunta := range perguntasCondicao() {
+		porID[pergunta.ID] = pergunta
+	}
+
+	const (
+		e  = `{"todas": [{"pergunta": 1, "operador": ">=", "valor": "4"}, {"pergunta": 2, "operador": "=", "valor": "Sim"}]}`
+		ou = `{"alguma": [{"pergunta": 1, "operador": "<=", "valor": "2"}, {"pergunta": 3, "operador": "em", "valores": ["2", "3"]}]}`
+		// (escala >= 4 E Sim) OU não respondeu Sim
+		aninhada = `{"alguma": [` + e + `, {"pergunta": 2, "operador": "!=", "valor": "Sim"}]}`
+	)
+
+	casos := []struct {
+		nome      string
+		condicao  string
+		respostas map[int]string
+		atendida  bool
+	}{
+		{"E: ambas atendidas", e, map[int]string{1: "4", 2: "Sim"}, true},
+		{"E: apenas uma atendida", e, map[int]string{1: "5", 2: "Não"}, false},
+		{"E: limite abaixo", e, map[int]string{1: "3", 2: "Sim"}, false},
+		{"E: uma sem resposta", e, map[int]string{1: "5"}, false},
+		{"OU: primeira atendida", ou, map[int]string{1: "2", 3: `["1"]`}, true},
+		{"OU: segunda atendida", ou, map[int]string{1: "5", 3: `["1","3"]`}, true},
+		{"OU: nenhuma atendida", ou, map[int]string{1: "3", 3: `["1"]`}, false},
+		{"OU: não se aplica não satisfaz escala", ou, map[int]string{1: ValorNaoSeAplica}, false},
+		{"aninhada: grupo E atendido", aninhada, map[int]string{1: "5", 2: "Sim"}, true},
+		{"aninhada: diferente atendido", aninhada, map[int]string{1: "1", 2: "Não"}, true},
+		{"aninhada: nenhum atendido", aninhada, map[int]string{1: "1", 2: "Sim"}, false},
+		{"diferente exige resposta", aninhada, map[int]string{1: "1"}, false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			raw := c.condicao
+			condicao, err := ParseCondicaoExibicao(&raw)
+			if err != nil {
+				t.Fatalf("condição inválida: %v", err)
+			}
+			if atendida := condicao.Avaliar(c.respostas, porID); atendida != c.atendida {
+				t.Errorf("atendida = %v, esperado %v", atendida, c.atendida)
+			}
+		})
+	}
+}
+
+func TestPerguntasVisiveisDesconsideraRespostasOcultas(t *testing.T) {
+	perguntas := perguntasCondicao()
+	comCondicao(perguntas[1], `{"pergunta": 1, "operador": ">=", "valor": "4"}`)
+	comCondicao(perguntas[4], `{"pergunta": 2, "operador": "=", "valor": "Sim"}`)
+
+	casos := []struct {
+		nome      string
+		respostas map[int]string
+		visiveis  map[int]bool
+	}{
+		{"cadeia exibida", map[int]string{1: "5", 2: "Sim"}, map[int]bool{2: true, 5: true}},
+		{"pergunta intermediária oculta", map[int]string{1: "2"}, map[int]bool{2: false, 5: false}},
+		// A resposta enviada para a pergunta 2 oculta não pode exibir a pergunta 5
+		{"resposta a pergunta oculta", map[int]string{1: "2", 2: "Sim"}, map[int]bool{2: false, 5: false}},
+		{"sem respostas", map[int]string{}, map[int]bool{1: true, 2: false, 5: false}},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			visiveis := PerguntasVisiveis(perguntas, c.respostas)
+			for id, esperado := range c.visiveis {
+				if visiveis[id] != esperado {
+					t.Errorf("pergunta %d: visível = %v, esperado %v", id, visiveis[id], esperado)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as dimensões do clima e o cálculo da pontuação das perguntas agrupadas em cada dimensão.
+package entity
+
+import "time"
+
+// Limites das dimensões
+const (
+	MaxNomeDimensao      = 100 // Tamanho máximo do nome de uma dimensão
+	MaxDescricaoDimensao = 500 // Tamanho máximo da descrição de uma dimensão
+)
+
+// Dimensao agrupa perguntas que avaliam o mesmo aspecto do clima (liderança, comunicação, reconhecimento)
+// As dimensões são da empresa e valem para todas as suas pesquisas, permitindo comparar setores e ciclos
+type Dimensao struct {
+	ID          int       `json:"id_dimensao"`  // Identificador único da dimensão
+	IDEmpresa   int       `json:"id_empresa"`   // Empresa dona da dimensão
+	Nome        string    `json:"nome"`         // Nome exibido nos resultados
+	Descricao   string    `json:"descricao"`    // O que a dimensão avalia
+	DataCriacao time.Time `json:"data_criacao"` // Data de criação
+}
+
+// PontuacaoDimensao é o resultado das perguntas de uma dimensão em uma pesquisa
+type PontuacaoDimensao struct {
+	Perguntas           int     `json:"perguntas"`            // Perguntas pontuáveis da dimensão
+	Respondentes        int     `json:"respondentes"`         // Submissões com ao menos uma resposta pontuável
+	Respostas           int     `json:"respostas"`            // Respostas consideradas (sem "Não se aplica")
+	Media               float64 `json:"media"`                // Média das pontuações de 0 a 100
+	PercentualFavoravel float64 `json:"percentual_favoravel"` // Respostas favoráveis (ver ClassificarResposta) / respostas * 100
+}
+
+// Suprimido indica se o resultado tem menos respondentes que o mínimo da empresa para ser exibido
+func (p PontuacaoDimensao) Suprimido(minimo int) bool {
+	return p.Respondentes < minimo
+}
+
+// PerguntaPontuavel indica se as respostas da pergunta entram na pontuação de dimensão
+// Entram as escalas numéricas e Sim/Não; o eNPS tem cálculo próprio e as demais respostas não são ordenáveis
+func PerguntaPontuavel(pergunta *Pergunta) bool {
+	switch pergunta.TipoPergunta {
+	case TipoEscalaNumerica:
+		return !PerguntaENPS(pergunta)
+	case "SimNao":
+		return true
+	default:
+		return false
+	}
+}
+
+// PontuacaoResposta converte uma resposta gravada em pontuação de 0 a 100, invertida quando a pergunta tem pontuação invertida
+// Retorna false para perguntas não pontuáveis, "Não se aplica" e valores inválidos
+func PontuacaoResposta(pergunta *Pergunta, valor string) (float64, bool) {
+	if !PerguntaPontuavel(pergunta) {
+		return 0, false
+	}
+
+	var pontuacao float64
+	if pergunta.TipoPergunta == TipoEscalaNumerica {
+		escala := EscalaDaPergunta(pergunta)
+		v, ok := escala.Valor(valor)
+		if !ok {
+			return 0, false
+		}
+		pontuacao = escala.Normalizar(v)
+	} else {
+		switch valor {
+		case "Sim":
+			pontuacao = 100
+		case "Não":
+			pontuacao = 0
+		default:
+			return 0, false
+		}
+	}
+
+	if pergunta.PontuacaoInvertida {
+		pontuacao = 100 - pontuacao
+	}
+	return pontuacao, true
+}
+
+// CalcularPontuacaoDimensao calcula a pontuação das perguntas de uma dimensão
+// valores segue o formato map[id_submissao]map[id_pergunta]valor_resposta; a média e a favorabilidade
+// consideram todas as respostas pontuáveis, de modo que cada resposta tem o mesmo peso
+func CalcularPontuacaoDimensao(perguntas []*Pergunta, valores map[int]map[int]string) PontuacaoDimensao {
+	var resultado PontuacaoDimensao
+
+	pontuaveis := make([]*Pergunta, 0, len(perguntas))
+	for _, pergunta := range perguntas {
+		if PerguntaPontuavel(pergunta) {
+			pontuaveis = append(pontuaveis, pergunta)
+		}
+	}
+	resultado.Perguntas = len(pontuaveis)
+
+	var soma float64
+	var favoraveis int
+	for _, respostas := range valores {
+		respondeu := false
+		for _, pergunta := range pontuaveis {
+			valor, ok := respostas[pergunta.ID]
+			if !ok {
+				continue
+			}
+			pontuacao, ok := PontuacaoResposta(pergunta, valor)
+			if !ok {
+				continue
+			}
+			soma += pontuacao
+			resultado.Respostas++
+			if classe, _ := ClassificarResposta(pergunta, valor); classe == RespostaFavoravel {
+				favoraveis++
+			}
+			respondeu = true
+		}
+		if respondeu {
+			resultado.Respondentes++
+		}
+	}
+
+	if resultado.Respostas == 0 {
+		return resultado
+	}
+
+	total := float64(resultado.Respostas)
+	resultado.Media = arredondar2(soma / total)
+	resultado.PercentualFavoravel = arredondar2(float64(favoraveis) / total * 100)
+	return resultado
+}
@@ -0,0 +1,73 @@
+package entity
+
+import "testing"
+
+func TestPontuacaoResposta(t *testing.T) {
+	const cinco = `{"min": 1, "max": 5, "passo": 1, "permite_na": true}`
+
+	casos := []struct {
+		nome      string
+		pergunta  *Pergunta
+		valor     string
+		pontuacao float64
+		valida    bool
+	}{
+		{"escala: mínimo", perguntaEscala(cinco, false), "1", 0, true},
+		{"escala: meio", perguntaEscala(cinco, false), "3", 50, true},
+		{"escala: máximo", perguntaEscala(cinco, false), "5", 100, true},
+		{"escala invertida: mínimo", perguntaEscala(cinco, true), "1", 100, true},
+		{"escala invertida: 4", perguntaEscala(cinco, true), "4", 25, true},
+		{"escala: não se aplica", perguntaEscala(cinco, false), ValorNaoSeAplica, 0, false},
+		{"escala: fora da escala", perguntaEscala(cinco, false), "6", 0, false},
+		{"sim/não: Sim", &Pergunta{TipoPergunta: "SimNao"}, "Sim", 100, true},
+		{"sim/não: Não", &Pergunta{TipoPergunta: "SimNao"}, "Não", 0, true},
+		{"sim/não invertida: Sim", &Pergunta{TipoPergunta: "SimNao", PontuacaoInvertida: true}, "Sim", 0, true},
+		{"sim/não: inválido", &Pergunta{TipoPergunta: "SimNao"}, "Talvez", 0, false},
+		{"eNPS não é pontuável", perguntaEscala(`{"variante": "enps"}`, false), "10", 0, false},
+		{"resposta aberta não é pontuável", &Pergunta{TipoPergunta: "RespostaAberta"}, "5", 0, false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			pontuacao, valida := PontuacaoResposta(c.pergunta, c.valor)
+			if valida != c.valida || pontuacao != c.pontuacao {
+				t.Errorf("pontuação = %v (válida %v), esperado %v (válida %v)", pontuacao, valida, c.pontuacao, c.valida)
+			}
+		})
+	}
+}
+
+func TestCalcularPontuacaoDimensao(t *testing.T) {
+	cinco := `{"min": 1, "max": 5, "passo": 1, "permite_na": true}`
+	enps := `{"variante": "enps"}`
+	perguntas := []*Pergunta{
+		{ID: 1, TipoPergunta: TipoEscalaNumerica, OpcoesResposta: &cinco},
+		{ID: 2, TipoPergunta: TipoEscalaNumerica, OpcoesResposta: &cinco, PontuacaoInvertida: true},
+		{ID: 3, TipoPergunta: "SimNao"},
+		{ID: 4, TipoPergunta: TipoEscalaNumerica, OpcoesResposta: &enps},
+		{ID: 5, TipoPergunta: "RespostaAberta"},
+	}
+
+	resultado := CalcularPontuacaoDimensao(perguntas, map[int]map[int]string{
+		1: {1: "5", 2: "1", 3: "Sim", 4: "10", 5: "Ótimo"}, // 100 + 100 + 100, todas favoráveis
+		2: {1: "3", 2: "4", 3: "Não"},                      // 50 + 25 + 0, nenhuma favorável
+		3: {1: ValorNaoSeAplica, 4: "0"},                   // Sem resposta pontuável: não conta como respondente
+		4: {2: "5"},                                        // 0, desfavorável
+	})
+
+	esperado := PontuacaoDimensao{Perguntas: 3, Respondentes: 3, Respostas: 7, Media: 53.57, PercentualFavoravel: 42.86}
+	if resultado != esperado {
+		t.Errorf("pontuação %+v, esperado %+v", resultado, esperado)
+	}
+
+	if resultado.Suprimido(3) {
+		t.Error("suprimido com exatamente o mínimo de respondentes")
+	}
+	if !resultado.Suprimido(4) {
+		t.Error("exibido com menos respondentes que o mínimo")
+	}
+
+	if vazio := CalcularPontuacaoDimensao(perguntas, map[int]map[int]string{1: {4: "9"}}); vazio != (PontuacaoDimensao{Perguntas: 3}) {
+		t.Errorf("apenas eNPS: %+v", vazio)
+	}
+}
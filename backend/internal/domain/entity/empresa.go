@@ -6,13 +6,18 @@ import "time"
 
 // Empresa representa uma organização cliente do sistema
 type Empresa struct {
-    ID           int       `json:"id_empresa"`      // Identificador único da empresa
-    NomeFantasia string    `json:"nome_fantasia"`   // Nome comercial
-    RazaoSocial  string    `json:"razao_social"`    // Nome jurídico registrado
-    CNPJ         string    `json:"cnpj"`            // Cadastro Nacional de Pessoa Jurídica
-    DataCadastro time.Time `json:"data_cadastro"`   // Data de registro no sistema
-    
-    // Relacionamentos organizacionais (carregamento opcional)
-    Setores                 []Setor                 `json:"setores,omitempty"`                  // Setores da empresa
-    UsuariosAdministradores []UsuarioAdministrador  `json:"usuarios_administradores,omitempty"` // Administradores vinculados
-}
\ No newline at end of file
+    ID             int       `json:"id_empresa"`      // Identificador único da empresa
+    NomeFantasia   string    `json:"nome_fantasia"`   // Nome comercial
+    RazaoSocial    string    `json:"razao_social"`    // Nome jurídico registrado
+    CNPJ           string    `json:"cnpj"`            // Cadastro Nacional de Pessoa Jurídica
+    DataCadastro   time.Time `json:"data_cadastro"`   // Data de registro no sistema
+    MFAObrigatorio bool      `json:"mfa_obrigatorio"` // Exige segundo fator de todos os administradores
+    MinRespondentes int      `json:"min_respondentes"` // Menor grupo de respondentes exibido nos resultados
+}
+
+// Limites do mínimo de respondentes (anonimato) configurável pela empresa
+const (
+    MinRespondentesPadrao = 5  // Valor inicial de toda empresa
+    MinRespondentesMinimo = 3  // Menor valor aceito
+    MinRespondentesMaximo = 50 // Maior valor aceito
+)
\ No newline at end of file
@@ -0,0 +1,80 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece o cálculo do Employee Net Promoter Score (eNPS).
+package entity
+
+import (
+	"math"
+	"strconv"
+)
+
+// VarianteENPS identifica, em EscalaNumerica.Variante, a pergunta de eNPS (escala fixa de 0 a 10)
+const VarianteENPS = "enps"
+
+// Faixas do eNPS
+const (
+	notaMinPromotor = 9 // 9 e 10
+	notaMinNeutro   = 7 // 7 e 8; abaixo disso, detrator
+)
+
+// ResultadoENPS é o eNPS calculado a partir das respostas de uma pergunta
+type ResultadoENPS struct {
+	Respondentes         int     `json:"respondentes"`          // Respostas válidas (sem "Não se aplica")
+	Promotores           int     `json:"promotores"`            // Notas 9 e 10
+	Neutros              int     `json:"neutros"`               // Notas 7 e 8
+	Detratores           int     `json:"detratores"`            // Notas 0 a 6
+	PercentualPromotores float64 `json:"percentual_promotores"` // Promotores / respondentes * 100
+	PercentualNeutros    float64 `json:"percentual_neutros"`    // Neutros / respondentes * 100
+	PercentualDetratores float64 `json:"percentual_detratores"` // Detratores / respondentes * 100
+	Score                float64 `json:"score"`                 // % promotores - % detratores, de -100 a 100
+}
+
+// PerguntaENPS verifica se a pergunta é de eNPS
+func PerguntaENPS(pergunta *Pergunta) bool {
+	if pergunta.TipoPergunta != TipoEscalaNumerica {
+		return false
+	}
+	escala, err := ParseEscalaNumerica(pergunta.OpcoesResposta)
+	return err == nil && escala.Variante == VarianteENPS
+}
+
+// CalcularENPS calcula o eNPS a partir das respostas agregadas por valor
+// Valores fora de 0 a 10 e "Não se aplica" são ignorados
+func CalcularENPS(distribuicao map[string]int) ResultadoENPS {
+	var resultado ResultadoENPS
+	for valor, count := range distribuicao {
+		nota, err := strconv.Atoi(valor)
+		if err != nil || nota < 0 || nota > 10 {
+			continue
+		}
+		switch {
+		case nota >= notaMinPromotor:
+			resultado.Promotores += count
+		case nota >= notaMinNeutro:
+			resultado.Neutros += count
+		default:
+			resultado.Detratores += count
+		}
+		resultado.Respondentes += count
+	}
+
+	if resultado.Respondentes == 0 {
+		return resultado
+	}
+
+	total := float64(resultado.Respondentes)
+	resultado.PercentualPromotores = arredondar2(float64(resultado.Promotores) / total * 100)
+	resultado.PercentualNeutros = arredondar2(float64(resultado.Neutros) / total * 100)
+	resultado.PercentualDetratores = arredondar2(float64(resultado.Detratores) / total * 100)
+	resultado.Score = arredondar2(float64(resultado.Promotores-resultado.Detratores) / total * 100)
+	return resultado
+}
+
+// Suprimido indica se o resultado tem menos respondentes que o mínimo da empresa para ser exibido
+func (r ResultadoENPS) Suprimido(minimo int) bool {
+	return r.Respondentes < minimo
+}
+
+// arredondar2 arredonda para duas casas decimais
+func arredondar2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
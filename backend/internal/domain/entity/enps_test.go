@@ -0,0 +1,75 @@
+package entity
+
+import "testing"
+
+func TestCalcularENPSNosLimitesDasFaixas(t *testing.T) {
+	casos := []struct {
+		nota                            string
+		promotores, neutros, detratores int
+	}{
+		{"0", 0, 0, 1},
+		{"6", 0, 0, 1},
+		{"7", 0, 1, 0},
+		{"8", 0, 1, 0},
+		{"9", 1, 0, 0},
+		{"10", 1, 0, 0},
+		// Valores fora de 0 a 10 e "Não se aplica" são ignorados
+		{"-1", 0, 0, 0},
+		{"11", 0, 0, 0},
+		{"6.5", 0, 0, 0},
+		{ValorNaoSeAplica, 0, 0, 0},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nota, func(t *testing.T) {
+			resultado := CalcularENPS(map[string]int{c.nota: 1})
+			if resultado.Promotores != c.promotores || resultado.Neutros != c.neutros || resultado.Detratores != c.detratores {
+				t.Errorf("promotores/neutros/detratores = %d/%d/%d, esperado %d/%d/%d",
+					resultado.Promotores, resultado.Neutros, resultado.Detratores, c.promotores, c.neutros, c.detratores)
+			}
+			if respondentes := c.promotores + c.neutros + c.detratores; resultado.Respondentes != respondentes {
+				t.Errorf("respondentes = %d, esperado %d", resultado.Respondentes, respondentes)
+			}
+		})
+	}
+}
+
+func TestCalcularENPSScore(t *testing.T) {
+	casos := []struct {
+		nome                            string
+		distribuicao                    map[string]int
+		score                           float64
+		promotores, neutros, detratores float64
+	}{
+		{"misto", map[string]int{"10": 5, "9": 1, "8": 2, "7": 1, "6": 2, "0": 1, ValorNaoSeAplica: 4}, 25, 50, 25, 25},
+		{"apenas promotores", map[string]int{"9": 3, "10": 2}, 100, 100, 0, 0},
+		{"apenas detratores", map[string]int{"0": 1, "6": 4}, -100, 0, 0, 100},
+		{"apenas neutros", map[string]int{"7": 2, "8": 2}, 0, 0, 100, 0},
+		{"arredondamento", map[string]int{"9": 1, "6": 2}, -33.33, 33.33, 0, 66.67},
+		{"sem respostas válidas", map[string]int{ValorNaoSeAplica: 5}, 0, 0, 0, 0},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			resultado := CalcularENPS(c.distribuicao)
+			if resultado.Score != c.score {
+				t.Errorf("score = %v, esperado %v", resultado.Score, c.score)
+			}
+			if resultado.PercentualPromotores != c.promotores || resultado.PercentualNeutros != c.neutros || resultado.PercentualDetratores != c.detratores {
+				t.Errorf("percentuais %v/%v/%v, esperado %v/%v/%v",
+					resultado.PercentualPromotores, resultado.PercentualNeutros, resultado.PercentualDetratores,
+					c.promotores, c.neutros, c.detratores)
+			}
+		})
+	}
+}
+
+func TestResultadoENPSSuprimido(t *testing.T) {
+	resultado := CalcularENPS(map[string]int{"10": 3, "5": 2, ValorNaoSeAplica: 3})
+	if resultado.Suprimido(5) {
+		t.Error("suprimido com exatamente o mínimo de respondentes")
+	}
+	if !resultado.Suprimido(6) {
+		t.Error("exibido com menos respondentes que o mínimo (\"Não se aplica\" não conta)")
+	}
+}
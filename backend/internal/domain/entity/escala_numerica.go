@@ -0,0 +1,223 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece a configuração das escalas numéricas e a interpretação das respostas.
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TipoEscalaNumerica é o tipo de pergunta que usa EscalaNumerica
+const TipoEscalaNumerica = "EscalaNumerica"
+
+// ValorNaoSeAplica é o valor gravado quando o respondente escolhe "Não se aplica"
+const ValorNaoSeAplica = "NA"
+
+// Limites da configuração de escala
+const (
+	EscalaMinPadrao  = 1    // Mínimo quando a pergunta não define escala (compatível com perguntas antigas)
+	EscalaMaxPadrao  = 10   // Máximo quando a pergunta não define escala
+	maxPontosEscala  = 101  // Maior quantidade de pontos selecionáveis (0 a 100 de 1 em 1)
+	maxLimiteEscala  = 1000 // Maior valor absoluto aceito para mínimo e máximo
+	maxRotuloEscala  = 100  // Tamanho máximo dos rótulos dos extremos
+	toleranciaEscala = 1e-9 // Tolerância para comparar valores com o passo
+)
+
+// EscalaNumerica é a configuração gravada em Pergunta.OpcoesResposta para perguntas de escala numérica
+type EscalaNumerica struct {
+	Min       float64 `json:"min"`                  // Menor valor da escala
+	Max       float64 `json:"max"`                  // Maior valor da escala
+	Passo     float64 `json:"passo"`                // Intervalo entre valores selecionáveis
+	RotuloMin string  `json:"rotulo_min,omitempty"` // Rótulo do menor valor (ex.: "Discordo totalmente")
+	RotuloMax string  `json:"rotulo_max,omitempty"` // Rótulo do maior valor (ex.: "Concordo totalmente")
+	PermiteNA bool    `json:"permite_na,omitempty"` // Exibe a opção "Não se aplica"
+	Variante  string  `json:"variante,omitempty"`   // Variante com cálculo próprio ("enps"); vazia para escalas comuns
+
+	Favoravel    *FaixaEscala `json:"favoravel,omitempty"`    // Valores favoráveis (ex.: 4 a 5 na escala de 1 a 5); ver FaixasFavorabilidade
+	Desfavoravel *FaixaEscala `json:"desfavoravel,omitempty"` // Valores desfavoráveis; os valores fora das duas faixas são neutros
+}
+
+// EscalaPadrao retorna a escala usada por perguntas sem configuração (1 a 10)
+func EscalaPadrao() *EscalaNumerica {
+	return &EscalaNumerica{Min: EscalaMinPadrao, Max: EscalaMaxPadrao, Passo: 1}
+}
+
+// ParseEscalaNumerica interpreta e valida a configuração de escala de uma pergunta
+// Configuração ausente resulta na escala padrão; campos omitidos usam mínimo 1, máximo 10 e passo 1
+// A variante "enps" fixa a escala em 0 a 10 e não aceita faixas de favorabilidade
+func ParseEscalaNumerica(raw *string) (*EscalaNumerica, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return EscalaPadrao(), nil
+	}
+
+	var campos struct {
+		Min       *float64 `json:"min"`
+		Max       *float64 `json:"max"`
+		Passo     *float64 `json:"passo"`
+		RotuloMin string   `json:"rotulo_min"`
+		RotuloMax string   `json:"rotulo_max"`
+		PermiteNA bool     `json:"permite_na"`
+		Variante  string   `json:"variante"`
+
+		Favoravel    *FaixaEscala `json:"favoravel"`
+		Desfavoravel *FaixaEscala `json:"desfavoravel"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(*raw)), &campos); err != nil {
+		return nil, fmt.Errorf("use um objeto com \"min\", \"max\" e \"passo\"")
+	}
+
+	escala := EscalaPadrao()
+	if campos.Min != nil {
+		escala.Min = *campos.Min
+	}
+	if campos.Max != nil {
+		escala.Max = *campos.Max
+	}
+	if campos.Passo != nil {
+		escala.Passo = *campos.Passo
+	}
+	escala.RotuloMin = strings.TrimSpace(campos.RotuloMin)
+	escala.RotuloMax = strings.TrimSpace(campos.RotuloMax)
+	escala.PermiteNA = campos.PermiteNA
+	escala.Variante = strings.ToLower(strings.TrimSpace(campos.Variante))
+	escala.Favoravel = campos.Favoravel
+	escala.Desfavoravel = campos.Desfavoravel
+
+	switch escala.Variante {
+	case "":
+	case VarianteENPS:
+		// A escala do eNPS é fixa; limites informados precisam coincidir
+		if (campos.Min != nil && *campos.Min != 0) || (campos.Max != nil && *campos.Max != 10) || (campos.Passo != nil && *campos.Passo != 1) {
+			return nil, fmt.Errorf("a variante eNPS usa a escala fixa de 0 a 10")
+		}
+		if escala.Favoravel != nil || escala.Desfavoravel != nil {
+			return nil, fmt.Errorf("a variante eNPS classifica as respostas em promotores e detratores; faixas de favorabilidade não se aplicam")
+		}
+		escala.Min, escala.Max, escala.Passo = 0, 10, 1
+	default:
+		return nil, fmt.Errorf("variante de escala desconhecida: %s", campos.Variante)
+	}
+
+	if err := escala.validate(); err != nil {
+		return nil, err
+	}
+	return escala, nil
+}
+
+// EscalaDaPergunta retorna a escala configurada na pergunta
+// Configurações inválidas gravadas antes da validação resultam na escala padrão
+func EscalaDaPergunta(pergunta *Pergunta) *EscalaNumerica {
+	escala, err := ParseEscalaNumerica(pergunta.OpcoesResposta)
+	if err != nil {
+		return EscalaPadrao()
+	}
+	return escala
+}
+
+// validate verifica limites, passo, rótulos e faixas de favorabilidade
+func (e *EscalaNumerica) validate() error {
+	if math.Abs(e.Min) > maxLimiteEscala || math.Abs(e.Max) > maxLimiteEscala {
+		return fmt.Errorf("mínimo e máximo devem estar entre -%d e %d", maxLimiteEscala, maxLimiteEscala)
+	}
+	if e.Max <= e.Min {
+		return fmt.Errorf("máximo deve ser maior que o mínimo")
+	}
+	if e.Passo <= 0 {
+		return fmt.Errorf("passo deve ser maior que zero")
+	}
+
+	intervalos := (e.Max - e.Min) / e.Passo
+	if math.Abs(intervalos-math.Round(intervalos)) > toleranciaEscala*math.Max(1, intervalos) {
+		return fmt.Errorf("o intervalo entre mínimo e máximo deve ser múltiplo do passo")
+	}
+	if math.Round(intervalos)+1 > maxPontosEscala {
+		return fmt.Errorf("escala pode ter no máximo %d valores", maxPontosEscala)
+	}
+
+	if len(e.RotuloMin) > maxRotuloEscala || len(e.RotuloMax) > maxRotuloEscala {
+		return fmt.Errorf("rótulos dos extremos devem ter até %d caracteres", maxRotuloEscala)
+	}
+
+	return e.validateFaixas()
+}
+
+// JSON retorna a configuração serializada para gravação em Pergunta.OpcoesResposta
+func (e *EscalaNumerica) JSON() string {
+	data, _ := json.Marshal(e)
+	return string(data)
+}
+
+// NormalizarResposta valida o valor enviado pelo respondente e retorna a forma canônica a gravar
+// O valor deve pertencer à escala (mínimo + múltiplo do passo); "NA" ou "N/A" só é aceito quando a escala permite
+func (e *EscalaNumerica) NormalizarResposta(valor string) (string, error) {
+	valor = strings.TrimSpace(valor)
+
+	if strings.EqualFold(valor, ValorNaoSeAplica) || strings.EqualFold(valor, "N/A") {
+		if !e.PermiteNA {
+			return "", fmt.Errorf("esta pergunta não aceita \"Não se aplica\"")
+		}
+		return ValorNaoSeAplica, nil
+	}
+
+	num, err := strconv.ParseFloat(strings.Replace(valor, ",", ".", 1), 64)
+	if err != nil || math.IsNaN(num) || math.IsInf(num, 0) || !e.contem(num) {
+		return "", fmt.Errorf("resposta deve ser um valor numérico entre %s e %s (passo %s)",
+			formatValorEscala(e.Min), formatValorEscala(e.Max), formatValorEscala(e.Passo))
+	}
+
+	return formatValorEscala(e.ponto(num)), nil
+}
+
+// contem verifica se o valor é um dos pontos da escala
+func (e *EscalaNumerica) contem(v float64) bool {
+	if v < e.Min-toleranciaEscala || v > e.Max+toleranciaEscala {
+		return false
+	}
+	passos := (v - e.Min) / e.Passo
+	return math.Abs(passos-math.Round(passos)) <= toleranciaEscala*math.Max(1, passos)
+}
+
+// ponto arredonda o valor para o ponto da escala mais próximo, removendo erros de ponto flutuante
+func (e *EscalaNumerica) ponto(v float64) float64 {
+	p := e.Min + math.Round((v-e.Min)/e.Passo)*e.Passo
+	return math.Round(p*1e6) / 1e6
+}
+
+// Valor converte uma resposta gravada em número
+// Retorna false para "Não se aplica" e para valores fora da escala
+func (e *EscalaNumerica) Valor(resposta string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(resposta), 64)
+	if err != nil || !e.contem(v) {
+		return 0, false
+	}
+	return v, true
+}
+
+// Normalizar converte um valor da escala para 0–100, permitindo comparar perguntas com escalas diferentes
+func (e *EscalaNumerica) Normalizar(v float64) float64 {
+	return (v - e.Min) / (e.Max - e.Min) * 100
+}
+
+// Media calcula a média das respostas agregadas por valor
+// Respostas "Não se aplica" e fora da escala não entram no cálculo; total é a quantidade considerada
+func (e *EscalaNumerica) Media(distribuicao map[string]int) (media float64, total int) {
+	var soma float64
+	for valor, count := range distribuicao {
+		if v, ok := e.Valor(valor); ok {
+			soma += v * float64(count)
+			total += count
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return soma / float64(total), total
+}
+
+// formatValorEscala formata o valor sem zeros à direita ("5", "2.5")
+func formatValorEscala(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
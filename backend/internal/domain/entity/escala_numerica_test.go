@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEscalaNumerica(t *testing.T) {
+	casos := []struct {
+		nome            string
+		raw             string
+		erro            string // Trecho esperado da mensagem; vazio quando a configuração é aceita
+		min, max, passo float64
+	}{
+		{nome: "sem configuração", raw: "", min: 1, max: 10, passo: 1},
+		{nome: "objeto vazio", raw: `{}`, min: 1, max: 10, passo: 1},
+		{nome: "apenas máximo", raw: `{"max": 5}`, min: 1, max: 5, passo: 1},
+		{nome: "apenas mínimo", raw: `{"min": 0}`, min: 0, max: 10, passo: 1},
+		{nome: "meio passo", raw: `{"min": 1, "max": 5, "passo": 0.5}`, min: 1, max: 5, passo: 0.5},
+		{nome: "passo decimal sem erro de arredondamento", raw: `{"min": 0, "max": 0.3, "passo": 0.1}`, min: 0, max: 0.3, passo: 0.1},
+		{nome: "intervalo não múltiplo do passo", raw: `{"min": 1, "max": 5, "passo": 1.5}`, erro: "múltiplo do passo"},
+		{nome: "101 pontos", raw: `{"min": 0, "max": 100, "passo": 1}`, min: 0, max: 100, passo: 1},
+		{nome: "102 pontos", raw: `{"min": 0, "max": 101, "passo": 1}`, erro: "no máximo 101 valores"},
+		{nome: "101 pontos com meio passo", raw: `{"min": 0, "max": 50, "passo": 0.5}`, min: 0, max: 50, passo: 0.5},
+		{nome: "102 pontos com meio passo", raw: `{"min": 0, "max": 50.5, "passo": 0.5}`, erro: "no máximo 101 valores"},
+		{nome: "máximo igual ao mínimo", raw: `{"min": 5, "max": 5}`, erro: "maior que o mínimo"},
+		{nome: "passo zero", raw: `{"passo": 0}`, erro: "passo deve ser maior que zero"},
+		{nome: "eNPS", raw: `{"variante": "enps"}`, min: 0, max: 10, passo: 1},
+		{nome: "eNPS com os limites fixos", raw: `{"variante": "ENPS", "min": 0, "max": 10, "passo": 1}`, min: 0, max: 10, passo: 1},
+		{nome: "eNPS com outro máximo", raw: `{"variante": "enps", "max": 5}`, erro: "escala fixa de 0 a 10"},
+		{nome: "eNPS com faixa favorável", raw: `{"variante": "enps", "favoravel": {"min": 9, "max": 10}}`, erro: "não se aplicam"},
+		{nome: "variante desconhecida", raw: `{"variante": "likert"}`, erro: "variante de escala desconhecida"},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			raw := c.raw
+			escala, err := ParseEscalaNumerica(&raw)
+			if c.erro != "" {
+				if err == nil || !strings.Contains(err.Error(), c.erro) {
+					t.Fatalf("erro = %v, esperado contendo %q", err, c.erro)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if escala.Min != c.min || escala.Max != c.max || escala.Passo != c.passo {
+				t.Errorf("escala %v a %v (passo %v), esperado %v a %v (passo %v)", escala.Min, escala.Max, escala.Passo, c.min, c.max, c.passo)
+			}
+		})
+	}
+}
+
+func TestNormalizarResposta(t *testing.T) {
+	const (
+		cinco     = `{"min": 1, "max": 5, "passo": 1}`
+		cincoNA   = `{"min": 1, "max": 5, "passo": 1, "permite_na": true}`
+		meioPasso = `{"min": 1, "max": 5, "passo": 0.5}`
+		decimos   = `{"min": 0, "max": 1, "passo": 0.1}`
+		enps      = `{"variante": "enps"}`
+	)
+
+	casos := []struct {
+		nome     string
+		escala   string
+		valor    string
+		esperado string // Valor gravado; vazio quando a resposta é rejeitada
+	}{
+		{"não se aplica permitido", cincoNA, "NA", ValorNaoSeAplica},
+		{"N/A permitido", cincoNA, " n/a ", ValorNaoSeAplica},
+		{"não se aplica não permitido", cinco, "NA", ""},
+		{"N/A não permitido", cinco, "N/A", ""},
+		{"inteiro", cinco, "3", "3"},
+		{"zeros à direita", cinco, "4.0", "4"},
+		{"abaixo do mínimo", cinco, "0", ""},
+		{"acima do máximo", cinco, "6", ""},
+		{"fora do passo", cinco, "2.5", ""},
+		{"não numérico", cinco, "três", ""},
+		{"NaN", cinco, "NaN", ""},
+		{"infinito", cinco, "+Inf", ""},
+		{"meio passo", meioPasso, "3.5", "3.5"},
+		{"meio passo com vírgula", meioPasso, "3,5", "3.5"},
+		{"meio passo fora do passo", meioPasso, "3,25", ""},
+		{"décimos acumulam erro de ponto flutuante", decimos, "0.3", "0.3"},
+		{"décimos com vírgula", decimos, "0,7", "0.7"},
+		{"décimos no máximo", decimos, "1", "1"},
+		{"eNPS: zero", enps, "0", "0"},
+		{"eNPS: dez", enps, "10", "10"},
+		{"eNPS: acima de dez", enps, "11", ""},
+		{"eNPS: negativo", enps, "-1", ""},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			raw := c.escala
+			escala, err := ParseEscalaNumerica(&raw)
+			if err != nil {
+				t.Fatalf("escala inválida: %v", err)
+			}
+
+			valor, err := escala.NormalizarResposta(c.valor)
+			if c.esperado == "" {
+				if err == nil {
+					t.Errorf("%q aceito como %q, esperado rejeitado", c.valor, valor)
+				}
+				return
+			}
+			if err != nil || valor != c.esperado {
+				t.Errorf("%q normalizado para %q (erro %v), esperado %q", c.valor, valor, err, c.esperado)
+			}
+		})
+	}
+}
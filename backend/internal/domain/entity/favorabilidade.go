@@ -0,0 +1,189 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as faixas de favorabilidade das escalas e a classificação das respostas em favoráveis, neutras e desfavoráveis.
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+// Faixas padrão, em pontuação de 0 a 100, das escalas sem faixas configuradas: 4–5 e 1–2 na escala de 1 a 5
+const (
+	PontuacaoFavoravelMinima    = 75.0 // Menor pontuação favorável
+	PontuacaoDesfavoravelMaxima = 25.0 // Maior pontuação desfavorável
+)
+
+// ClasseResposta classifica uma resposta quanto à favorabilidade
+type ClasseResposta string
+
+// Classes de resposta
+const (
+	RespostaFavoravel    ClasseResposta = "favoravel"
+	RespostaNeutra       ClasseResposta = "neutra"
+	RespostaDesfavoravel ClasseResposta = "desfavoravel"
+)
+
+// FaixaEscala é um intervalo fechado de valores de uma escala numérica
+type FaixaEscala struct {
+	Min float64 `json:"min"` // Menor valor da faixa
+	Max float64 `json:"max"` // Maior valor da faixa
+}
+
+// Contem verifica se o valor pertence à faixa
+func (f FaixaEscala) Contem(v float64) bool {
+	return v >= f.Min-toleranciaEscala && v <= f.Max+toleranciaEscala
+}
+
+// sobrepoe verifica se as faixas têm algum valor em comum
+func (f FaixaEscala) sobrepoe(outra FaixaEscala) bool {
+	return f.Min <= outra.Max+toleranciaEscala && outra.Min <= f.Max+toleranciaEscala
+}
+
+// FaixasFavorabilidade retorna as faixas favorável e desfavorável da escala
+// Faixas configuradas valem como estão, já considerando pontuação invertida; configurada apenas uma,
+// a outra é o seu espelho em relação ao centro da escala. Sem configuração, o quarto superior da escala
+// é favorável e o inferior desfavorável, trocados quando invertida
+func (e *EscalaNumerica) FaixasFavorabilidade(invertida bool) (favoravel, desfavoravel FaixaEscala) {
+	switch {
+	case e.Favoravel != nil && e.Desfavoravel != nil:
+		return *e.Favoravel, *e.Desfavoravel
+	case e.Favoravel != nil:
+		return *e.Favoravel, e.espelhar(*e.Favoravel)
+	case e.Desfavoravel != nil:
+		return e.espelhar(*e.Desfavoravel), *e.Desfavoravel
+	}
+
+	amplitude := e.Max - e.Min
+	superior := FaixaEscala{Min: e.Min + amplitude*PontuacaoFavoravelMinima/100, Max: e.Max}
+	inferior := FaixaEscala{Min: e.Min, Max: e.Min + amplitude*PontuacaoDesfavoravelMaxima/100}
+	if invertida {
+		return inferior, superior
+	}
+	return superior, inferior
+}
+
+// espelhar reflete a faixa em relação ao centro da escala (4–5 vira 1–2 na escala de 1 a 5)
+func (e *EscalaNumerica) espelhar(f FaixaEscala) FaixaEscala {
+	return FaixaEscala{Min: e.Min + e.Max - f.Max, Max: e.Min + e.Max - f.Min}
+}
+
+// validateFaixas verifica se as faixas configuradas estão dentro da escala e não se sobrepõem
+func (e *EscalaNumerica) validateFaixas() error {
+	if e.Favoravel == nil && e.Desfavoravel == nil {
+		return nil
+	}
+
+	for _, faixa := range []struct {
+		nome  string
+		valor *FaixaEscala
+	}{{"favorável", e.Favoravel}, {"desfavorável", e.Desfavoravel}} {
+		if faixa.valor == nil {
+			continue
+		}
+		if math.IsNaN(faixa.valor.Min) || math.IsNaN(faixa.valor.Max) || faixa.valor.Min > faixa.valor.Max {
+			return fmt.Errorf("faixa %s deve ter mínimo menor ou igual ao máximo", faixa.nome)
+		}
+		if faixa.valor.Min < e.Min-toleranciaEscala || faixa.valor.Max > e.Max+toleranciaEscala {
+			return fmt.Errorf("faixa %s deve estar entre %s e %s", faixa.nome, formatValorEscala(e.Min), formatValorEscala(e.Max))
+		}
+	}
+
+	favoravel, desfavoravel := e.FaixasFavorabilidade(false)
+	if favoravel.sobrepoe(desfavoravel) {
+		if e.Favoravel == nil || e.Desfavoravel == nil {
+			return fmt.Errorf("faixa de favorabilidade ultrapassa o centro da escala; informe também a faixa oposta")
+		}
+		return fmt.Errorf("faixas favorável e desfavorável não podem se sobrepor")
+	}
+	return nil
+}
+
+// ClassificarResposta classifica uma resposta gravada como favorável, neutra ou desfavorável
+// Escalas usam FaixasFavorabilidade; em Sim/Não, "Sim" é favorável (desfavorável com pontuação invertida).
+// Retorna false para perguntas não pontuáveis, "Não se aplica" e valores inválidos
+func ClassificarResposta(pergunta *Pergunta, valor string) (ClasseResposta, bool) {
+	if !PerguntaPontuavel(pergunta) {
+		return "", false
+	}
+
+	if pergunta.TipoPergunta != TipoEscalaNumerica {
+		if _, ok := PontuacaoResposta(pergunta, valor); !ok {
+			return "", false
+		}
+		if (valor == "Sim") != pergunta.PontuacaoInvertida {
+			return RespostaFavoravel, true
+		}
+		return RespostaDesfavoravel, true
+	}
+
+	escala := EscalaDaPergunta(pergunta)
+	v, ok := escala.Valor(valor)
+	if !ok {
+		return "", false
+	}
+
+	favoravel, desfavoravel := escala.FaixasFavorabilidade(pergunta.PontuacaoInvertida)
+	switch {
+	case favoravel.Contem(v):
+		return RespostaFavoravel, true
+	case desfavoravel.Contem(v):
+		return RespostaDesfavoravel, true
+	default:
+		return RespostaNeutra, true
+	}
+}
+
+// Favorabilidade é a divisão das respostas de uma pergunta em favoráveis, neutras e desfavoráveis
+type Favorabilidade struct {
+	FaixaFavoravel         *FaixaEscala `json:"faixa_favoravel,omitempty"`    // Faixa favorável aplicada (apenas escalas)
+	FaixaDesfavoravel      *FaixaEscala `json:"faixa_desfavoravel,omitempty"` // Faixa desfavorável aplicada (apenas escalas)
+	Respostas              int          `json:"respostas"`                    // Respostas classificadas (sem "Não se aplica")
+	Favoraveis             int          `json:"favoraveis"`                   // Respostas na faixa favorável
+	Neutras                int          `json:"neutras"`                      // Respostas fora das duas faixas
+	Desfavoraveis          int          `json:"desfavoraveis"`                // Respostas na faixa desfavorável
+	PercentualFavoravel    float64      `json:"percentual_favoravel"`         // Favoráveis / respostas * 100
+	PercentualNeutro       float64      `json:"percentual_neutro"`            // Neutras / respostas * 100
+	PercentualDesfavoravel float64      `json:"percentual_desfavoravel"`      // Desfavoráveis / respostas * 100
+}
+
+// Suprimido indica se as respostas válidas são menos que o mínimo da empresa para exibir os percentuais
+// "Não se aplica" não conta: com poucas respostas válidas os percentuais revelariam respostas individuais
+func (f Favorabilidade) Suprimido(minimo int) bool {
+	return f.Respostas < minimo
+}
+
+// CalcularFavorabilidade classifica as respostas agregadas por valor de uma pergunta pontuável
+// "Não se aplica" e valores fora da escala não entram no cálculo
+func CalcularFavorabilidade(pergunta *Pergunta, distribuicao map[string]int) Favorabilidade {
+	var resultado Favorabilidade
+	if pergunta.TipoPergunta == TipoEscalaNumerica && PerguntaPontuavel(pergunta) {
+		favoravel, desfavoravel := EscalaDaPergunta(pergunta).FaixasFavorabilidade(pergunta.PontuacaoInvertida)
+		resultado.FaixaFavoravel, resultado.FaixaDesfavoravel = &favoravel, &desfavoravel
+	}
+
+	for valor, count := range distribuicao {
+		classe, ok := ClassificarResposta(pergunta, valor)
+		if !ok {
+			continue
+		}
+		resultado.Respostas += count
+		switch classe {
+		case RespostaFavoravel:
+			resultado.Favoraveis += count
+		case RespostaDesfavoravel:
+			resultado.Desfavoraveis += count
+		default:
+			resultado.Neutras += count
+		}
+	}
+
+	if resultado.Respostas == 0 {
+		return resultado
+	}
+
+	total := float64(resultado.Respostas)
+	resultado.PercentualFavoravel = arredondar2(float64(resultado.Favoraveis) / total * 100)
+	resultado.PercentualNeutro = arredondar2(float64(resultado.Neutras) / total * 100)
+	resultado.PercentualDesfavoravel = arredondar2(float64(resultado.Desfavoraveis) / total * 100)
+	return resultado
+}
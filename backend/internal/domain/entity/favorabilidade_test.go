@@ -0,0 +1,111 @@
+package entity
+
+import "testing"
+
+func perguntaEscala(opcoes string, invertida bool) *Pergunta {
+	pergunta := &Pergunta{ID: 1, TipoPergunta: TipoEscalaNumerica, PontuacaoInvertida: invertida}
+	if opcoes != "" {
+		pergunta.OpcoesResposta = &opcoes
+	}
+	return pergunta
+}
+
+func TestClassificarRespostaNosLimitesDasFaixas(t *testing.T) {
+	const (
+		cinco      = `{"min": 1, "max": 5, "passo": 1, "permite_na": true}`
+		meioPasso  = `{"min": 1, "max": 5, "passo": 0.5}`
+		zeroADez   = `{"min": 0, "max": 10, "passo": 1, "favoravel": {"min": 7, "max": 10}}`
+		explicitas = `{"min": 1, "max": 5, "passo": 1, "favoravel": {"min": 5, "max": 5}, "desfavoravel": {"min": 1, "max": 3}}`
+	)
+
+	casos := []struct {
+		nome     string
+		pergunta *Pergunta
+		valor    string
+		classe   ClasseResposta
+		valida   bool
+	}{
+		// Escala de 1 a 5 sem faixas: 4–5 favorável, 1–2 desfavorável
+		{"1 a 5: 2", perguntaEscala(cinco, false), "2", RespostaDesfavoravel, true},
+		{"1 a 5: 3", perguntaEscala(cinco, false), "3", RespostaNeutra, true},
+		{"1 a 5: 4", perguntaEscala(cinco, false), "4", RespostaFavoravel, true},
+		{"1 a 5 invertida: 2", perguntaEscala(cinco, true), "2", RespostaFavoravel, true},
+		{"1 a 5 invertida: 3", perguntaEscala(cinco, true), "3", RespostaNeutra, true},
+		{"1 a 5 invertida: 4", perguntaEscala(cinco, true), "4", RespostaDesfavoravel, true},
+		{"1 a 5: não se aplica", perguntaEscala(cinco, false), ValorNaoSeAplica, "", false},
+		{"1 a 5: fora da escala", perguntaEscala(cinco, false), "6", "", false},
+
+		// Passo 0,5: os limites são 2 e 4
+		{"meio passo: 2", perguntaEscala(meioPasso, false), "2", RespostaDesfavoravel, true},
+		{"meio passo: 2,5", perguntaEscala(meioPasso, false), "2.5", RespostaNeutra, true},
+		{"meio passo: 3,5", perguntaEscala(meioPasso, false), "3.5", RespostaNeutra, true},
+		{"meio passo: 4", perguntaEscala(meioPasso, false), "4", RespostaFavoravel, true},
+
+		// Escala padrão (1 a 10): favorável a partir de 7,75 e desfavorável até 3,25
+		{"padrão: 3", perguntaEscala("", false), "3", RespostaDesfavoravel, true},
+		{"padrão: 4", perguntaEscala("", false), "4", RespostaNeutra, true},
+		{"padrão: 7", perguntaEscala("", false), "7", RespostaNeutra, true},
+		{"padrão: 8", perguntaEscala("", false), "8", RespostaFavoravel, true},
+
+		// Apenas a faixa favorável configurada: a desfavorável é o espelho (0 a 3)
+		{"espelho: 3", perguntaEscala(zeroADez, false), "3", RespostaDesfavoravel, true},
+		{"espelho: 4", perguntaEscala(zeroADez, false), "4", RespostaNeutra, true},
+		{"espelho: 6", perguntaEscala(zeroADez, false), "6", RespostaNeutra, true},
+		{"espelho: 7", perguntaEscala(zeroADez, false), "7", RespostaFavoravel, true},
+
+		// Faixas configuradas já consideram a inversão
+		{"explícitas: 3", perguntaEscala(explicitas, false), "3", RespostaDesfavoravel, true},
+		{"explícitas: 4", perguntaEscala(explicitas, false), "4", RespostaNeutra, true},
+		{"explícitas invertida: 5", perguntaEscala(explicitas, true), "5", RespostaFavoravel, true},
+
+		{"sim/não: Sim", &Pergunta{TipoPergunta: "SimNao"}, "Sim", RespostaFavoravel, true},
+		{"sim/não: Não", &Pergunta{TipoPergunta: "SimNao"}, "Não", RespostaDesfavoravel, true},
+		{"sim/não invertida: Sim", &Pergunta{TipoPergunta: "SimNao", PontuacaoInvertida: true}, "Sim", RespostaDesfavoravel, true},
+		{"sim/não: inválido", &Pergunta{TipoPergunta: "SimNao"}, "Talvez", "", false},
+		{"eNPS não é pontuável", perguntaEscala(`{"variante": "enps"}`, false), "10", "", false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			classe, valida := ClassificarResposta(c.pergunta, c.valor)
+			if valida != c.valida || classe != c.classe {
+				t.Errorf("classe = %q (válida %v), esperado %q (válida %v)", classe, valida, c.classe, c.valida)
+			}
+		})
+	}
+}
+
+func TestCalcularFavorabilidade(t *testing.T) {
+	pergunta := perguntaEscala(`{"min": 1, "max": 5, "passo": 1, "permite_na": true}`, false)
+	distribuicao := map[string]int{"1": 1, "2": 2, "3": 3, "4": 4, "5": 5, ValorNaoSeAplica: 6, "9": 1}
+
+	resultado := CalcularFavorabilidade(pergunta, distribuicao)
+	if resultado.Respostas != 15 || resultado.Favoraveis != 9 || resultado.Neutras != 3 || resultado.Desfavoraveis != 3 {
+		t.Errorf("contagens %d/%d/%d de %d, esperado 9/3/3 de 15",
+			resultado.Favoraveis, resultado.Neutras, resultado.Desfavoraveis, resultado.Respostas)
+	}
+	if resultado.PercentualFavoravel != 60 || resultado.PercentualNeutro != 20 || resultado.PercentualDesfavoravel != 20 {
+		t.Errorf("percentuais %v/%v/%v, esperado 60/20/20",
+			resultado.PercentualFavoravel, resultado.PercentualNeutro, resultado.PercentualDesfavoravel)
+	}
+	if resultado.FaixaFavoravel == nil || *resultado.FaixaFavoravel != (FaixaEscala{Min: 4, Max: 5}) {
+		t.Errorf("faixa favorável %v, esperado 4 a 5", resultado.FaixaFavoravel)
+	}
+
+	// "Não se aplica" não conta para o mínimo de respondentes
+	if resultado.Suprimido(15) {
+		t.Error("suprimido com exatamente o mínimo de respostas válidas")
+	}
+	if !resultado.Suprimido(16) {
+		t.Error("exibido com menos respostas válidas que o mínimo")
+	}
+
+	arredondado := CalcularFavorabilidade(pergunta, map[string]int{"5": 1, "1": 2})
+	if arredondado.PercentualFavoravel != 33.33 || arredondado.PercentualDesfavoravel != 66.67 {
+		t.Errorf("percentuais %v/%v, esperado 33.33/66.67", arredondado.PercentualFavoravel, arredondado.PercentualDesfavoravel)
+	}
+
+	if vazio := CalcularFavorabilidade(pergunta, map[string]int{ValorNaoSeAplica: 3}); vazio.Respostas != 0 || vazio.PercentualFavoravel != 0 {
+		t.Errorf("apenas \"Não se aplica\": %+v", vazio)
+	}
+}
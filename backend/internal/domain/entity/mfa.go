@@ -0,0 +1,26 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as estruturas de autenticação em dois fatores (TOTP) e códigos de recuperação.
+package entity
+
+import "time"
+
+// MFAUsuario representa a configuração de segundo fator (TOTP) de um usuário administrador
+// O segredo é gravado no cadastro e só passa a ser exigido após a confirmação com um código válido
+type MFAUsuario struct {
+	IDUserAdmin  int        `json:"id_user_admin"`           // Usuário dono da configuração
+	Segredo      string     `json:"-"`                       // Segredo TOTP em base32 (oculto em JSON)
+	Ativo        bool       `json:"ativo"`                   // Segundo fator confirmado e exigido no login
+	DataCriacao  time.Time  `json:"data_criacao"`            // Início do cadastro
+	DataAtivacao *time.Time `json:"data_ativacao,omitempty"` // Confirmação do cadastro
+	UltimoPasso  int64      `json:"-"`                       // Último passo TOTP aceito (impede reuso do código)
+}
+
+// CodigoRecuperacao representa um código de recuperação de uso único
+// Apenas o hash é persistido; os códigos em texto claro são exibidos uma única vez
+type CodigoRecuperacao struct {
+	ID          int        `json:"id_codigo"`          // Identificador único
+	IDUserAdmin int        `json:"id_user_admin"`      // Usuário dono do código
+	CodigoHash  string     `json:"-"`                  // Hash do código
+	DataCriacao time.Time  `json:"data_criacao"`       // Geração do código
+	DataUso     *time.Time `json:"data_uso,omitempty"` // Preenchida quando o código é utilizado
+}
@@ -0,0 +1,65 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as estruturas de dados para modelos reutilizáveis de pesquisa.
+package entity
+
+import "time"
+
+// Limites das perguntas de um modelo de pesquisa
+const (
+	MaxPerguntasModelo  = 100 // Maior quantidade de perguntas em um modelo
+	MaxTextoPergunta    = 500 // Tamanho máximo do texto de uma pergunta
+	MaxDimensaoPergunta = 50  // Tamanho máximo da dimensão de uma pergunta
+)
+
+// ModeloPesquisa é um conjunto de perguntas reutilizável na criação de pesquisas
+// Modelos do sistema (sem empresa) ficam disponíveis a todas as empresas e não podem ser alterados
+type ModeloPesquisa struct {
+	ID              int              `json:"id_modelo"`           // Identificador único do modelo
+	IDEmpresa       *int             `json:"id_empresa"`          // Empresa dona do modelo (nil: modelo do sistema)
+	Nome            string           `json:"nome"`                // Nome exibido na biblioteca de modelos
+	Descricao       string           `json:"descricao"`           // Finalidade do modelo
+	Versao          int              `json:"versao"`              // Versão atual das perguntas
+	Ativo           bool             `json:"ativo"`               // Modelos removidos ficam inativos
+	DataCriacao     time.Time        `json:"data_criacao"`        // Data de criação
+	DataAtualizacao time.Time        `json:"data_atualizacao"`    // Data da última alteração
+	Perguntas       []PerguntaModelo `json:"perguntas,omitempty"` // Perguntas da versão carregada
+}
+
+// DoSistema indica se o modelo pertence ao sistema (somente leitura)
+func (m *ModeloPesquisa) DoSistema() bool {
+	return m.IDEmpresa == nil
+}
+
+// PerguntaModelo é uma pergunta de um modelo de pesquisa, copiada para a pesquisa criada a partir dele
+type PerguntaModelo struct {
+	TextoPergunta      string  `json:"texto_pergunta"`                // Texto exibido ao respondente
+	TipoPergunta       string  `json:"tipo_pergunta"`                 // Tipo de resposta esperada
+	OpcoesResposta     *string `json:"opcoes_resposta"`               // JSON com opções ou escala, como em Pergunta.OpcoesResposta
+	Obrigatoria        bool    `json:"obrigatoria"`                   // Resposta exigida quando a pergunta é exibida
+	Dimensao           string  `json:"dimensao,omitempty"`            // Dimensão do clima avaliada pela pergunta (ex.: "reconhecimento")
+	PontuacaoInvertida bool    `json:"pontuacao_invertida,omitempty"` // Concordar indica clima desfavorável, como em Pergunta.PontuacaoInvertida
+}
+
+// ToPergunta cria a pergunta da pesquisa na posição informada
+// A dimensão do modelo é só um nome; o vínculo com a dimensão da empresa é feito na criação da pesquisa
+func (p PerguntaModelo) ToPergunta(pesquisaID, ordem int) *Pergunta {
+	return &Pergunta{
+		IDPesquisa:         pesquisaID,
+		TextoPergunta:      p.TextoPergunta,
+		TipoPergunta:       p.TipoPergunta,
+		OrdemExibicao:      ordem,
+		OpcoesResposta:     p.OpcoesResposta,
+		Obrigatoria:        p.Obrigatoria,
+		PontuacaoInvertida: p.PontuacaoInvertida,
+	}
+}
+
+// VersaoModelo guarda as perguntas de uma versão de um modelo de pesquisa
+type VersaoModelo struct {
+	ID          int              `json:"id_versao"`     // Identificador único da versão
+	IDModelo    int              `json:"id_modelo"`     // Modelo ao qual a versão pertence
+	Versao      int              `json:"versao"`        // Número sequencial da versão (a primeira é 1)
+	IDUserAdmin *int             `json:"id_user_admin"` // Administrador que gerou a versão (nil nos modelos do sistema)
+	DataCriacao time.Time        `json:"data_criacao"`  // Quando a versão foi gerada
+	Perguntas   []PerguntaModelo `json:"perguntas"`     // Perguntas da versão, em ordem de exibição
+}
@@ -0,0 +1,301 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece o esquema das opções de perguntas de múltipla escolha e a interpretação das respostas.
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TipoMultiplaEscolha é o tipo de pergunta que usa OpcoesMultiplaEscolha
+const TipoMultiplaEscolha = "MultiplaEscolha"
+
+// Limites do esquema de múltipla escolha
+const (
+	MinOpcoesMultiplaEscolha = 2   // Menor quantidade de opções
+	MaxOpcoesMultiplaEscolha = 50  // Maior quantidade de opções
+	maxIDOpcao               = 40  // Tamanho máximo do ID de uma opção
+	maxRotuloOpcao           = 200 // Tamanho máximo do rótulo de uma opção
+	MaxTextoOutro            = 500 // Tamanho máximo do texto livre da opção "Outro"
+)
+
+// OpcaoResposta é uma opção de uma pergunta de múltipla escolha
+type OpcaoResposta struct {
+	ID     string `json:"id"`              // Identificador estável, gravado nas respostas
+	Rotulo string `json:"rotulo"`          // Texto exibido ao respondente
+	Outro  bool   `json:"outro,omitempty"` // Opção "Outro", que aceita texto livre
+}
+
+// OpcoesMultiplaEscolha é o esquema gravado em Pergunta.OpcoesResposta para perguntas de múltipla escolha
+type OpcoesMultiplaEscolha struct {
+	Opcoes          []OpcaoResposta `json:"opcoes"`           // Opções na ordem de exibição
+	MultiplaSelecao bool            `json:"multipla_selecao"` // Permite selecionar mais de uma opção
+	MinSelecoes     int             `json:"min_selecoes"`     // Mínimo de opções selecionadas
+	MaxSelecoes     int             `json:"max_selecoes"`     // Máximo de opções selecionadas
+}
+
+// SelecaoResposta é o valor de uma resposta de múltipla escolha
+type SelecaoResposta struct {
+	Opcoes []string `json:"opcoes"`          // IDs das opções selecionadas
+	Outro  string   `json:"outro,omitempty"` // Texto livre da opção "Outro"
+}
+
+// ParseOpcoesMultiplaEscolha interpreta e valida as opções de uma pergunta de múltipla escolha
+// Aceita o esquema completo ou, por conveniência, uma lista de rótulos (["A", "B"]), que recebe IDs sequenciais
+// O resultado está normalizado: limites de seleção preenchidos e IDs sem espaços
+func ParseOpcoesMultiplaEscolha(raw *string) (*OpcoesMultiplaEscolha, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, fmt.Errorf("obrigatórias para múltipla escolha")
+	}
+	value := strings.TrimSpace(*raw)
+
+	var opcoes OpcoesMultiplaEscolha
+	if strings.HasPrefix(value, "[") {
+		var rotulos []string
+		if err := json.Unmarshal([]byte(value), &rotulos); err != nil {
+			return nil, fmt.Errorf("use uma lista de rótulos ou o esquema com \"opcoes\"")
+		}
+		for i, rotulo := range rotulos {
+			opcoes.Opcoes = append(opcoes.Opcoes, OpcaoResposta{ID: strconv.Itoa(i + 1), Rotulo: rotulo})
+		}
+	} else if err := json.Unmarshal([]byte(value), &opcoes); err != nil {
+		return nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+
+	if err := opcoes.normalize(); err != nil {
+		return nil, err
+	}
+	return &opcoes, nil
+}
+
+// normalize valida o esquema e preenche os limites de seleção omitidos
+func (o *OpcoesMultiplaEscolha) normalize() error {
+	if len(o.Opcoes) < MinOpcoesMultiplaEscolha || len(o.Opcoes) > MaxOpcoesMultiplaEscolha {
+		return fmt.Errorf("pergunta de múltipla escolha deve ter entre %d e %d opções", MinOpcoesMultiplaEscolha, MaxOpcoesMultiplaEscolha)
+	}
+
+	ids := make(map[string]bool, len(o.Opcoes))
+	outros := 0
+	for i := range o.Opcoes {
+		opcao := &o.Opcoes[i]
+		opcao.ID = strings.TrimSpace(opcao.ID)
+		opcao.Rotulo = strings.TrimSpace(opcao.Rotulo)
+
+		if !validIDOpcao(opcao.ID) {
+			return fmt.Errorf("opção %d: ID deve ter até %d caracteres entre letras, números, '-' e '_'", i+1, maxIDOpcao)
+		}
+		if ids[opcao.ID] {
+			return fmt.Errorf("opção %d: ID %q repetido", i+1, opcao.ID)
+		}
+		ids[opcao.ID] = true
+
+		if opcao.Rotulo == "" || len(opcao.Rotulo) > maxRotuloOpcao {
+			return fmt.Errorf("opção %d: rótulo é obrigatório e deve ter até %d caracteres", i+1, maxRotuloOpcao)
+		}
+		if opcao.Outro {
+			outros++
+		}
+	}
+	if outros > 1 {
+		return fmt.Errorf("apenas uma opção pode ser \"Outro\"")
+	}
+
+	if !o.MultiplaSelecao {
+		if o.MinSelecoes > 1 || o.MaxSelecoes > 1 {
+			return fmt.Errorf("limites de seleção exigem \"multipla_selecao\"")
+		}
+		o.MinSelecoes, o.MaxSelecoes = 1, 1
+		return nil
+	}
+
+	if o.MinSelecoes == 0 {
+		o.MinSelecoes = 1
+	}
+	if o.MaxSelecoes == 0 {
+		o.MaxSelecoes = len(o.Opcoes)
+	}
+	if o.MinSelecoes < 1 || o.MaxSelecoes > len(o.Opcoes) || o.MinSelecoes > o.MaxSelecoes {
+		return fmt.Errorf("limites de seleção inválidos: use 1 <= min_selecoes <= max_selecoes <= %d", len(o.Opcoes))
+	}
+	return nil
+}
+
+// JSON retorna o esquema serializado para gravação em Pergunta.OpcoesResposta
+func (o *OpcoesMultiplaEscolha) JSON() string {
+	data, _ := json.Marshal(o)
+	return string(data)
+}
+
+// Opcao retorna a opção com o ID informado
+func (o *OpcoesMultiplaEscolha) Opcao(id string) (*OpcaoResposta, bool) {
+	for i := range o.Opcoes {
+		if o.Opcoes[i].ID == id {
+			return &o.Opcoes[i], true
+		}
+	}
+	return nil, false
+}
+
+// NormalizarResposta valida o valor enviado pelo respondente e retorna a forma canônica a gravar
+// Formas aceitas: ID ou rótulo de uma opção ("a"), lista de IDs (["a","b"]) e objeto com texto livre
+// ({"opcoes":["outro"],"outro":"..."}). A forma canônica é o ID para seleção única sem texto,
+// a lista de IDs na ordem do esquema para múltipla seleção e o objeto quando há texto livre
+func (o *OpcoesMultiplaEscolha) NormalizarResposta(valor string) (string, error) {
+	selecao, err := parseSelecao(valor)
+	if err != nil {
+		return "", err
+	}
+
+	escolhidas := make(map[string]bool, len(selecao.Opcoes))
+	temOutro := false
+	for _, ref := range selecao.Opcoes {
+		opcao := o.resolve(ref)
+		if opcao == nil {
+			return "", fmt.Errorf("opção %q não existe nesta pergunta", ref)
+		}
+		if escolhidas[opcao.ID] {
+			return "", fmt.Errorf("opção %q selecionada mais de uma vez", opcao.ID)
+		}
+		escolhidas[opcao.ID] = true
+		temOutro = temOutro || opcao.Outro
+	}
+
+	switch {
+	case len(escolhidas) == 0:
+		return "", fmt.Errorf("uma opção deve ser selecionada")
+	case len(escolhidas) < o.MinSelecoes:
+		return "", fmt.Errorf("selecione pelo menos %d opções", o.MinSelecoes)
+	case len(escolhidas) > o.MaxSelecoes:
+		if o.MaxSelecoes == 1 {
+			return "", fmt.Errorf("apenas uma opção pode ser selecionada")
+		}
+		return "", fmt.Errorf("selecione no máximo %d opções", o.MaxSelecoes)
+	}
+
+	selecao.Outro = strings.TrimSpace(selecao.Outro)
+	if selecao.Outro != "" && !temOutro {
+		return "", fmt.Errorf("texto livre só é aceito com a opção \"Outro\"")
+	}
+	if len(selecao.Outro) > MaxTextoOutro {
+		return "", fmt.Errorf("texto da opção \"Outro\" não pode exceder %d caracteres", MaxTextoOutro)
+	}
+
+	canonica := SelecaoResposta{Outro: selecao.Outro}
+	for _, opcao := range o.Opcoes {
+		if escolhidas[opcao.ID] {
+			canonica.Opcoes = append(canonica.Opcoes, opcao.ID)
+		}
+	}
+
+	switch {
+	case canonica.Outro != "":
+		data, _ := json.Marshal(canonica)
+		return string(data), nil
+	case o.MultiplaSelecao:
+		data, _ := json.Marshal(canonica.Opcoes)
+		return string(data), nil
+	default:
+		return canonica.Opcoes[0], nil
+	}
+}
+
+// resolve encontra a opção pelo ID ou, para compatibilidade, pelo rótulo (sem diferenciar maiúsculas)
+func (o *OpcoesMultiplaEscolha) resolve(ref string) *OpcaoResposta {
+	ref = strings.TrimSpace(ref)
+	if opcao, ok := o.Opcao(ref); ok {
+		return opcao
+	}
+	for i := range o.Opcoes {
+		if strings.EqualFold(o.Opcoes[i].Rotulo, ref) {
+			return &o.Opcoes[i]
+		}
+	}
+	return nil
+}
+
+// OpcoesDaPergunta retorna o esquema de opções de uma pergunta de múltipla escolha
+// Retorna nil quando as opções gravadas estão fora do esquema (perguntas antigas); os métodos de leitura aceitam nil
+func OpcoesDaPergunta(pergunta *Pergunta) *OpcoesMultiplaEscolha {
+	opcoes, err := ParseOpcoesMultiplaEscolha(pergunta.OpcoesResposta)
+	if err != nil {
+		return nil
+	}
+	return opcoes
+}
+
+// SelecoesResposta retorna as opções contidas em uma resposta de múltipla escolha gravada
+// Cada valor é resolvido pelo ID ou, nas respostas antigas gravadas com o rótulo, pelo rótulo.
+// Valores que não correspondem a nenhuma opção são retornados como gravados, no ID e no rótulo
+func (o *OpcoesMultiplaEscolha) SelecoesResposta(valor string) []OpcaoResposta {
+	refs := []string{valor}
+	if selecao, err := parseSelecao(valor); err == nil && len(selecao.Opcoes) > 0 {
+		refs = selecao.Opcoes
+	}
+
+	selecionadas := make([]OpcaoResposta, 0, len(refs))
+	vistas := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		opcao := OpcaoResposta{ID: ref, Rotulo: ref}
+		if o != nil {
+			if resolvida := o.resolve(ref); resolvida != nil {
+				opcao = *resolvida
+			}
+		}
+		if vistas[opcao.ID] {
+			continue
+		}
+		vistas[opcao.ID] = true
+		selecionadas = append(selecionadas, opcao)
+	}
+	return selecionadas
+}
+
+// AgregarSelecoes converte a contagem por valor gravado em contagem pelo rótulo de cada opção
+// Respostas gravadas com o ID e respostas antigas gravadas com o rótulo caem na mesma opção.
+// Cada opção de uma resposta com múltipla seleção é contada separadamente; o texto livre de "Outro" é descartado
+func (o *OpcoesMultiplaEscolha) AgregarSelecoes(distribuicao map[string]int) map[string]int {
+	contagem := make(map[string]int, len(distribuicao))
+	for valor, count := range distribuicao {
+		for _, opcao := range o.SelecoesResposta(valor) {
+			contagem[opcao.Rotulo] += count
+		}
+	}
+	return contagem
+}
+
+// parseSelecao interpreta as três formas de valor aceitas
+func parseSelecao(valor string) (SelecaoResposta, error) {
+	valor = strings.TrimSpace(valor)
+	var selecao SelecaoResposta
+
+	switch {
+	case valor == "":
+		return selecao, fmt.Errorf("uma opção deve ser selecionada")
+	case strings.HasPrefix(valor, "{"):
+		if err := json.Unmarshal([]byte(valor), &selecao); err != nil {
+			return selecao, fmt.Errorf("resposta de múltipla escolha inválida")
+		}
+	case strings.HasPrefix(valor, "["):
+		if err := json.Unmarshal([]byte(valor), &selecao.Opcoes); err != nil {
+			return selecao, fmt.Errorf("resposta de múltipla escolha inválida")
+		}
+	default:
+		selecao.Opcoes = []string{valor}
+	}
+	return selecao, nil
+}
+
+// validIDOpcao verifica se o ID usa apenas caracteres seguros
+func validIDOpcao(id string) bool {
+	if id == "" || len(id) > maxIDOpcao {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
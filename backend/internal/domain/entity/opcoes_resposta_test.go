@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAgregarSelecoesResolveIDERotulo(t *testing.T) {
+	raw := `{"opcoes":[{"id":"1","rotulo":"Sim"},{"id":"2","rotulo":"Não"},{"id":"outro","rotulo":"Outro","outro":true}],"multipla_selecao":true}`
+	opcoes, err := ParseOpcoesMultiplaEscolha(&raw)
+	if err != nil {
+		t.Fatalf("erro ao interpretar opções: %v", err)
+	}
+
+	distribuicao := map[string]int{
+		"1":                                 3, // Forma canônica, gravada pelo ID
+		"Sim":                               2, // Resposta antiga, gravada pelo rótulo
+		"não":                               1, // Rótulo sem diferenciar maiúsculas
+		`["1","2"]`:                         4, // Múltipla seleção
+		`{"opcoes":["outro"],"outro":"RH"}`: 1, // Texto livre descartado
+		`["1","Sim"]`:                       1, // A mesma opção referida duas vezes conta uma vez
+		"Talvez":                            2, // Valor fora do esquema mantido como gravado
+	}
+
+	esperado := map[string]int{"Sim": 10, "Não": 5, "Outro": 1, "Talvez": 2}
+	if contagem := opcoes.AgregarSelecoes(distribuicao); !reflect.DeepEqual(contagem, esperado) {
+		t.Errorf("contagem %v, esperado %v", contagem, esperado)
+	}
+}
+
+func TestAgregarSelecoesSemEsquema(t *testing.T) {
+	invalido := "opções antigas"
+	pergunta := &Pergunta{TipoPergunta: TipoMultiplaEscolha, OpcoesResposta: &invalido}
+
+	distribuicao := map[string]int{"A": 2, `["A","B"]`: 1}
+	esperado := map[string]int{"A": 3, "B": 1}
+	if contagem := OpcoesDaPergunta(pergunta).AgregarSelecoes(distribuicao); !reflect.DeepEqual(contagem, esperado) {
+		t.Errorf("contagem %v, esperado %v", contagem, esperado)
+	}
+}
@@ -0,0 +1,70 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece os papéis de usuários administradores e as permissões concedidas a cada um.
+package entity
+
+// Papéis de usuário administrador
+const (
+	PapelProprietario    = "proprietario"     // Controle total da empresa, incluindo usuários e papéis
+	PapelGestorPesquisas = "gestor_pesquisas" // Cria e gerencia setores, pesquisas e dashboards
+	PapelAnalista        = "analista"         // Somente leitura de pesquisas e resultados
+	PapelAuditor         = "auditor"          // Somente leitura de usuários e logs de auditoria
+)
+
+// Permissões verificadas por rota
+const (
+	PermEmpresaLer          = "empresa:ler"
+	PermEmpresaGerenciar    = "empresa:gerenciar"
+	PermUsuariosLer         = "usuarios:ler"
+	PermUsuariosGerenciar   = "usuarios:gerenciar"
+	PermSetoresLer          = "setores:ler"
+	PermSetoresGerenciar    = "setores:gerenciar"
+	PermPesquisasLer        = "pesquisas:ler"
+	PermPesquisasGerenciar  = "pesquisas:gerenciar"
+	PermResultadosLer       = "resultados:ler"
+	PermDashboardsGerenciar = "dashboards:gerenciar"
+	PermRespostasExcluir    = "respostas:excluir"
+	PermLogsLer             = "logs:ler"
+	PermLogsGerenciar       = "logs:gerenciar"
+)
+
+// permissoesPorPapel define as permissões concedidas a cada papel
+var permissoesPorPapel = map[string][]string{
+	PapelProprietario: {
+		PermEmpresaLer, PermEmpresaGerenciar,
+		PermUsuariosLer, PermUsuariosGerenciar,
+		PermSetoresLer, PermSetoresGerenciar,
+		PermPesquisasLer, PermPesquisasGerenciar,
+		PermResultadosLer, PermDashboardsGerenciar, PermRespostasExcluir,
+		PermLogsLer, PermLogsGerenciar,
+	},
+	PapelGestorPesquisas: {
+		PermEmpresaLer,
+		PermSetoresLer, PermSetoresGerenciar,
+		PermPesquisasLer, PermPesquisasGerenciar,
+		PermResultadosLer, PermDashboardsGerenciar,
+	},
+	PapelAnalista: {
+		PermEmpresaLer,
+		PermSetoresLer,
+		PermPesquisasLer,
+		PermResultadosLer,
+	},
+	PapelAuditor: {
+		PermEmpresaLer,
+		PermUsuariosLer,
+		PermSetoresLer,
+		PermPesquisasLer,
+		PermLogsLer,
+	},
+}
+
+// PapelValido verifica se o papel informado existe
+func PapelValido(papel string) bool {
+	_, ok := permissoesPorPapel[papel]
+	return ok
+}
+
+// PermissoesDoPapel retorna uma cópia das permissões do papel (vazia para papel desconhecido)
+func PermissoesDoPapel(papel string) []string {
+	return append([]string(nil), permissoesPorPapel[papel]...)
+}
@@ -10,6 +10,10 @@ type Pergunta struct {
     TipoPergunta   string `json:"tipo_pergunta"`     // Tipo de resposta esperada
     OrdemExibicao  int    `json:"ordem_exibicao"`    // Sequência de apresentação
     OpcoesResposta *string `json:"opcoes_resposta"`  // JSON com opções para múltipla escolha
+    CondicaoExibicao *string `json:"condicao_exibicao"` // JSON com a condição de exibição (nil: sempre exibida)
+    Obrigatoria    bool    `json:"obrigatoria"`      // Resposta exigida quando a pergunta é exibida
+    IDDimensao     *int    `json:"id_dimensao"`      // Dimensão do clima avaliada pela pergunta (nil: sem dimensão)
+    PontuacaoInvertida bool `json:"pontuacao_invertida"` // Concordar indica clima desfavorável; a pontuação da dimensão é invertida
     
     // Relacionamento com respostas (carregamento opcional)
     Respostas []Resposta `json:"respostas,omitempty"` // Respostas coletadas
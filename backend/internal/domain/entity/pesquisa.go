@@ -27,3 +27,10 @@ type Pesquisa struct {
 	Setor                *Setor                `json:"setor,omitempty"`                 // Setor alvo
 	Dashboard            *Dashboard            `json:"dashboard,omitempty"`             // Dashboard associado
 }
+
+// ClonePesquisa liga uma pesquisa criada por clonagem à pesquisa de origem
+type ClonePesquisa struct {
+	IDPesquisa       int       `json:"id_pesquisa"`        // Pesquisa criada pela clonagem
+	IDPesquisaOrigem int       `json:"id_pesquisa_origem"` // Pesquisa copiada (0 quando removida)
+	DataCriacao      time.Time `json:"data_criacao"`       // Quando a clonagem foi feita
+}
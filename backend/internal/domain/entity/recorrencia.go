@@ -0,0 +1,48 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as estruturas de dados para recorrência e ciclos de pesquisas.
+package entity
+
+import "time"
+
+// Intervalos de recorrência suportados
+const (
+	IntervaloMensal     = "mensal"     // A cada mês
+	IntervaloTrimestral = "trimestral" // A cada 3 meses
+	IntervaloSemestral  = "semestral"  // A cada 6 meses
+	IntervaloCron       = "cron"       // Conforme expressão cron
+)
+
+// Condições de término da recorrência
+const (
+	TerminoNunca       = "nunca"       // Recorrência sem fim
+	TerminoData        = "data"        // Nenhum ciclo inicia após a data informada
+	TerminoOcorrencias = "ocorrencias" // Número máximo de ciclos (incluindo a pesquisa original)
+)
+
+// ConfigRecorrencia define o formato JSON armazenado em Pesquisa.ConfigRecorrencia
+type ConfigRecorrencia struct {
+	Intervalo          string             `json:"intervalo"`                // mensal, trimestral, semestral ou cron
+	ExpressaoCron      string             `json:"expressao_cron,omitempty"` // Obrigatória quando intervalo = cron
+	DuracaoDias        int                `json:"duracao_dias"`             // Duração de cada ciclo em dias
+	AntecedenciaDias   int                `json:"antecedencia_dias"`        // Dias de antecedência para criar o próximo ciclo
+	AtivacaoAutomatica bool               `json:"ativacao_automatica"`      // Se o ciclo recebe datas e abre sozinho
+	Termino            TerminoRecorrencia `json:"termino"`                  // Condição de término
+}
+
+// TerminoRecorrencia define quando a recorrência deixa de gerar ciclos
+type TerminoRecorrencia struct {
+	Tipo        string     `json:"tipo"`                  // nunca, data ou ocorrencias
+	Data        *time.Time `json:"data,omitempty"`        // Usado quando tipo = data
+	Ocorrencias int        `json:"ocorrencias,omitempty"` // Usado quando tipo = ocorrencias
+}
+
+// CicloPesquisa liga uma pesquisa gerada por recorrência ao ciclo anterior
+type CicloPesquisa struct {
+	ID                 int       `json:"id_ciclo"`             // Identificador único
+	IDPesquisa         int       `json:"id_pesquisa"`          // Pesquisa gerada neste ciclo
+	IDPesquisaAnterior int       `json:"id_pesquisa_anterior"` // Pesquisa do ciclo anterior
+	IDPesquisaOrigem   int       `json:"id_pesquisa_origem"`   // Primeira pesquisa da série
+	NumeroCiclo        int       `json:"numero_ciclo"`         // Posição na série (a original é o ciclo 1)
+	DataInicioPrevista time.Time `json:"data_inicio_prevista"` // Início planejado do ciclo
+	DataCriacao        time.Time `json:"data_criacao"`         // Quando o ciclo foi gerado
+}
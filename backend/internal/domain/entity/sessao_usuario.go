@@ -0,0 +1,35 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece as estruturas de sessões de login e refresh tokens.
+package entity
+
+import "time"
+
+// SessaoUsuario representa uma sessão de login de um usuário administrador
+// Cada sessão possui um refresh token ativo, trocado a cada renovação do access token
+type SessaoUsuario struct {
+	ID                  int        `json:"id_sessao"`                // Identificador único
+	IDUserAdmin         int        `json:"id_user_admin"`            // Dono da sessão
+	JTIAtual            string     `json:"-"`                        // jti do access token vigente
+	DataExpiracaoAcesso time.Time  `json:"-"`                        // Expiração do access token vigente
+	DataCriacao         time.Time  `json:"data_criacao"`             // Login que originou a sessão
+	DataUltimoUso       time.Time  `json:"data_ultimo_uso"`          // Última renovação
+	DataExpiracao       time.Time  `json:"data_expiracao"`           // Validade máxima dos refresh tokens da sessão
+	DataRevogacao       *time.Time `json:"data_revogacao,omitempty"` // Preenchida no logout, revogação ou reuso detectado
+	EnderecoIP          string     `json:"endereco_ip"`              // IP do login
+	UserAgent           string     `json:"user_agent"`               // Navegador/cliente do login
+}
+
+// Ativa verifica se a sessão ainda aceita renovações
+func (s *SessaoUsuario) Ativa(now time.Time) bool {
+	return s.DataRevogacao == nil && now.Before(s.DataExpiracao)
+}
+
+// RefreshToken representa um refresh token opaco emitido para uma sessão
+// Apenas o hash SHA-256 é persistido; tokens já trocados são mantidos para detectar reuso
+type RefreshToken struct {
+	ID          int        `json:"id_refresh_token"`   // Identificador único
+	IDSessao    int        `json:"id_sessao"`          // Sessão à qual o token pertence
+	TokenHash   string     `json:"-"`                  // Hash SHA-256 do token
+	DataCriacao time.Time  `json:"data_criacao"`       // Emissão do token
+	DataUso     *time.Time `json:"data_uso,omitempty"` // Preenchida quando o token é trocado por um novo
+}
@@ -0,0 +1,43 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece a estrutura de controle de tentativas de login malsucedidas.
+package entity
+
+import "time"
+
+// Tipos de chave controlados pela proteção contra força bruta
+const (
+	TentativaLoginConta   = "conta"   // Chave é o email informado no login (existente ou não)
+	TentativaLoginIP      = "ip"      // Chave é o endereço IP do cliente
+	TentativaLoginMFA     = "mfa"     // Chave é o email da conta; conta códigos de segundo fator inválidos
+	TentativaLoginDesafio = "desafio" // Chave é o identificador (jti) do login pendente de segundo fator
+)
+
+// TentativaLogin acumula falhas de login de uma conta ou de um IP
+// ProximaTentativa aplica o atraso exponencial; BloqueadoAte marca o bloqueio temporário
+type TentativaLogin struct {
+	ID               int        `json:"id_tentativa"`                // Identificador único
+	Tipo             string     `json:"tipo"`                        // conta ou ip
+	Chave            string     `json:"chave"`                       // Email normalizado ou IP
+	IDUserAdmin      *int       `json:"id_user_admin,omitempty"`     // Usuário da conta, quando o email existe
+	Falhas           int        `json:"falhas"`                      // Falhas consecutivas dentro da janela
+	UltimaFalha      time.Time  `json:"ultima_falha"`                // Momento da última falha
+	ProximaTentativa *time.Time `json:"proxima_tentativa,omitempty"` // Antes disso novas tentativas são recusadas
+	BloqueadoAte     *time.Time `json:"bloqueado_ate,omitempty"`     // Fim do bloqueio temporário
+}
+
+// Bloqueada indica se o bloqueio temporário está vigente
+func (t *TentativaLogin) Bloqueada(now time.Time) bool {
+	return t.BloqueadoAte != nil && now.Before(*t.BloqueadoAte)
+}
+
+// EsperaRestante retorna quanto falta para uma nova tentativa ser aceita (zero se já pode tentar)
+func (t *TentativaLogin) EsperaRestante(now time.Time) time.Duration {
+	var espera time.Duration
+	if t.ProximaTentativa != nil && now.Before(*t.ProximaTentativa) {
+		espera = t.ProximaTentativa.Sub(now)
+	}
+	if t.Bloqueada(now) && t.BloqueadoAte.Sub(now) > espera {
+		espera = t.BloqueadoAte.Sub(now)
+	}
+	return espera
+}
@@ -0,0 +1,22 @@
+// Package entity define as entidades principais do domínio da aplicação.
+// Fornece a estrutura de tokens de redefinição de senha.
+package entity
+
+import "time"
+
+// TokenRedefinicaoSenha representa um token de uso único para redefinição de senha
+// Apenas o hash SHA-256 do token é persistido; o valor original é enviado por email
+type TokenRedefinicaoSenha struct {
+	ID            int        `json:"id_token"`           // Identificador único
+	IDUserAdmin   int        `json:"id_user_admin"`      // Usuário que solicitou a redefinição
+	TokenHash     string     `json:"-"`                  // Hash SHA-256 do token (oculto em JSON)
+	DataCriacao   time.Time  `json:"data_criacao"`       // Quando o token foi emitido
+	DataExpiracao time.Time  `json:"data_expiracao"`     // Após esta data o token não é aceito
+	DataUso       *time.Time `json:"data_uso,omitempty"` // Preenchida quando o token é utilizado
+	IPSolicitacao string     `json:"ip_solicitacao"`     // IP de origem da solicitação
+}
+
+// Disponivel verifica se o token ainda pode ser utilizado
+func (t *TokenRedefinicaoSenha) Disponivel(now time.Time) bool {
+	return t.DataUso == nil && now.Before(t.DataExpiracao)
+}
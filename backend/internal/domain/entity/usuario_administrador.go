@@ -15,6 +15,7 @@ type UsuarioAdministrador struct {
 	SenhaHash    string    `json:"-"`             // Hash da senha (oculto em JSON)
 	DataCadastro time.Time `json:"data_cadastro"` // Data de criação do usuário
 	Status       string    `json:"status"`        // Estado atual (Ativo, Inativo, Pendente)
+	Papel        string    `json:"papel"`         // Papel que define as permissões (proprietario, gestor_pesquisas, analista, auditor)
 
 	// Relacionamento com Empresa (opcional, para carregamento sob demanda)
 	Empresa *Empresa `json:"empresa,omitempty"` // Dados da empresa associada
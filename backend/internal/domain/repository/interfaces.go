@@ -26,6 +26,10 @@ type EmpresaRepository interface {
 	List(ctx context.Context, limit, offset int) ([]*entity.Empresa, error) // Lista paginada
 	Update(ctx context.Context, empresa *entity.Empresa) error              // Atualiza empresa
 	Delete(ctx context.Context, id int) error                               // Remove empresa
+	// UpdatePoliticaMFA define se o segundo fator é obrigatório para os administradores da empresa
+	UpdatePoliticaMFA(ctx context.Context, id int, obrigatorio bool) error
+	// UpdateMinRespondentes define o menor grupo de respondentes exibido nos resultados da empresa
+	UpdateMinRespondentes(ctx context.Context, id int, minimo int) error
 }
 
 // LogAuditoriaRepository gerencia logs de auditoria
@@ -48,7 +52,58 @@ type PesquisaRepository interface {
 	ListActive(ctx context.Context, empresaID int) ([]*entity.Pesquisa, error)
 	Update(ctx context.Context, pesquisa *entity.Pesquisa) error
 	UpdateStatus(ctx context.Context, id int, status string) error
+	// UpdateLinkAcesso troca o link de acesso e o caminho do QR code correspondente
+	UpdateLinkAcesso(ctx context.Context, id int, link, qrcodePath string) error
+	// UpdateQRCodePath atualiza apenas o caminho do QR code
+	UpdateQRCodePath(ctx context.Context, id int, qrcodePath string) error
 	Delete(ctx context.Context, id int) error
+	// ListScheduledToOpen lista pesquisas em rascunho cuja data de abertura já chegou
+	ListScheduledToOpen(ctx context.Context, now time.Time) ([]*entity.Pesquisa, error)
+	// ListScheduledToClose lista pesquisas ativas cuja data de fechamento já passou
+	ListScheduledToClose(ctx context.Context, now time.Time) ([]*entity.Pesquisa, error)
+}
+
+// PesquisaCicloRepository gerencia os ciclos gerados por pesquisas recorrentes
+type PesquisaCicloRepository interface {
+	// GetByPesquisa retorna o ciclo de uma pesquisa gerada por recorrência (erro "não encontrado" para a original)
+	GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.CicloPesquisa, error)
+	// ListByOrigem lista os ciclos gerados a partir da pesquisa original, em ordem de ciclo
+	ListByOrigem(ctx context.Context, origemID int) ([]*entity.CicloPesquisa, error)
+	// ListRecurringHeads lista pesquisas recorrentes, já abertas, que ainda não geraram o próximo ciclo
+	ListRecurringHeads(ctx context.Context) ([]*entity.Pesquisa, error)
+	// CreateCycle cria a pesquisa do novo ciclo, suas perguntas e o vínculo com o ciclo anterior em uma transação
+	CreateCycle(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, ciclo *entity.CicloPesquisa) error
+}
+
+// PesquisaCloneRepository gerencia as pesquisas criadas por clonagem
+type PesquisaCloneRepository interface {
+	// Create cria a pesquisa, suas perguntas, o dashboard e o vínculo com a origem em uma transação
+	Create(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, dashboard *entity.Dashboard, clone *entity.ClonePesquisa) error
+	// GetByPesquisa retorna a origem de uma pesquisa clonada (erro "não encontrado" para pesquisas não clonadas)
+	GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.ClonePesquisa, error)
+	// ListByOrigem lista as pesquisas clonadas a partir da pesquisa informada, da mais antiga para a mais recente
+	ListByOrigem(ctx context.Context, origemID int) ([]*entity.ClonePesquisa, error)
+}
+
+// ModeloPesquisaRepository gerencia os modelos de pesquisa e suas versões
+type ModeloPesquisaRepository interface {
+	// Create insere o modelo e a sua versão 1 em uma transação
+	Create(ctx context.Context, modelo *entity.ModeloPesquisa, userAdminID int) error
+	// GetByID retorna o modelo com as perguntas da versão atual
+	GetByID(ctx context.Context, id int) (*entity.ModeloPesquisa, error)
+	// GetVersao retorna uma versão específica do modelo
+	GetVersao(ctx context.Context, modeloID, versao int) (*entity.VersaoModelo, error)
+	// ListVersoes lista as versões do modelo, da mais recente para a mais antiga, sem as perguntas
+	ListVersoes(ctx context.Context, modeloID int) ([]*entity.VersaoModelo, error)
+	// ListDisponiveis lista os modelos ativos do sistema e da empresa, sem as perguntas
+	ListDisponiveis(ctx context.Context, empresaID int) ([]*entity.ModeloPesquisa, error)
+	// Update altera nome e descrição; com novaVersao, grava as perguntas como a próxima versão na mesma transação
+	Update(ctx context.Context, modelo *entity.ModeloPesquisa, novaVersao bool, userAdminID int) error
+	// Deactivate remove o modelo da biblioteca, preservando as versões usadas por pesquisas existentes
+	Deactivate(ctx context.Context, id int) error
+	// CreatePesquisa cria as dimensões novas, a pesquisa, suas perguntas e o registro do modelo de origem em uma transação
+	// Retorna as dimensões efetivamente criadas; as que outra requisição já criou são reaproveitadas
+	CreatePesquisa(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, dimensoes []*entity.Dimensao, modeloID, versao int) ([]*entity.Dimensao, error)
 }
 
 // PerguntaRepository gerencia operações relacionadas às perguntas
@@ -57,6 +112,7 @@ type PerguntaRepository interface {
 	CreateBatch(ctx context.Context, perguntas []*entity.Pergunta) error // Para criar múltiplas perguntas
 	GetByID(ctx context.Context, id int) (*entity.Pergunta, error)
 	ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error)
+	ListByPesquisas(ctx context.Context, pesquisaIDs []int) ([]*entity.Pergunta, error) // Perguntas de várias pesquisas em uma consulta
 	Update(ctx context.Context, pergunta *entity.Pergunta) error
 	Delete(ctx context.Context, id int) error
 	UpdateOrdem(ctx context.Context, perguntaID int, novaOrdem int) error
@@ -78,10 +134,20 @@ type RespostaRepository interface {
 	// CountByPergunta retorna total de respostas de uma pergunta específica
 	CountByPergunta(ctx context.Context, perguntaID int) (int, error)
 	
+	// CountRespondentesByDia retorna, por dia (YYYY-MM-DD), o número de submissões distintas com respostas na pesquisa
+	CountRespondentesByDia(ctx context.Context, pesquisaID int) (map[string]int, error)
+	
 	// CountBySubmissao retorna total de respostas de uma submissão
-	// Útil para validar completude (todas perguntas respondidas)
+	// Não considera perguntas ocultas ou opcionais; a completude usa GetValoresBySubmissao
 	CountBySubmissao(ctx context.Context, submissaoID int) (int, error)
 	
+	// GetValoresBySubmissao retorna os valores das respostas da pesquisa por submissão e pergunta
+	// Formato: map[id_submissao]map[id_pergunta]valor_resposta; usado para medir a completude das submissões
+	GetValoresBySubmissao(ctx context.Context, pesquisaID int) (map[int]map[int]string, error)
+	
+	// GetValoresBySubmissaoPesquisas retorna o mesmo formato para várias pesquisas em uma única consulta
+	GetValoresBySubmissaoPesquisas(ctx context.Context, pesquisaIDs []int) (map[int]map[int]string, error)
+	
 	// GetAggregatedByPergunta retorna distribuição de respostas agregadas
 	// Exemplo: {"Sim": 45, "Não": 12}
 	GetAggregatedByPergunta(ctx context.Context, perguntaID int) (map[string]int, error)
@@ -91,6 +157,9 @@ type RespostaRepository interface {
 	// Exemplo: {1: {"Sim": 45, "Não": 12}, 2: {"8": 30, "9": 25}}
 	GetAggregatedByPesquisa(ctx context.Context, pesquisaID int) (map[int]map[string]int, error)
 	
+	// GetAggregatedByPesquisas retorna o mesmo formato para várias pesquisas em uma única consulta
+	GetAggregatedByPesquisas(ctx context.Context, pesquisaIDs []int) (map[int]map[string]int, error)
+	
 	// GetResponsesByDateRange retorna respostas em um período específico
 	// Datas no formato: "2006-01-02"
 	GetResponsesByDateRange(ctx context.Context, pesquisaID int, startDate, endDate string) ([]*entity.Resposta, error)
@@ -123,6 +192,32 @@ type SetorRepository interface {
 	Delete(ctx context.Context, id int) error
 }
 
+// AnaliseTextoRepository armazena a análise das respostas abertas de cada pergunta
+type AnaliseTextoRepository interface {
+	// ListPendingPesquisas lista pesquisas concluídas ou arquivadas com perguntas abertas ainda sem análise
+	// Pesquisas que falharam só voltam após a próxima tentativa agendada e ficam depois das que nunca falharam
+	// Ordenadas pela data de fechamento; limit limita quantas são processadas por execução
+	ListPendingPesquisas(ctx context.Context, limit int, now time.Time) ([]int, error)
+	// ReplaceByPesquisa grava as análises da pesquisa em uma única transação, substituindo as anteriores e zerando as falhas
+	ReplaceByPesquisa(ctx context.Context, pesquisaID int, analises []*entity.AnaliseTexto) error
+	// RegisterFailure incrementa as falhas consecutivas da análise da pesquisa e retorna o total
+	RegisterFailure(ctx context.Context, pesquisaID int, erro string, now time.Time) (int, error)
+	// ScheduleRetry define quando a pesquisa volta à fila de análise
+	ScheduleRetry(ctx context.Context, pesquisaID int, proximaTentativa time.Time) error
+	ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.AnaliseTexto, error)
+}
+
+// DimensaoRepository gerencia as dimensões do clima definidas pelas empresas
+type DimensaoRepository interface {
+	Create(ctx context.Context, dimensao *entity.Dimensao) error
+	GetByID(ctx context.Context, id int) (*entity.Dimensao, error)
+	GetByNome(ctx context.Context, empresaID int, nome string) (*entity.Dimensao, error)
+	ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Dimensao, error)
+	Update(ctx context.Context, dimensao *entity.Dimensao) error
+	// Delete remove a dimensão; as perguntas vinculadas ficam sem dimensão
+	Delete(ctx context.Context, id int) error
+}
+
 // UsuarioAdministradorRepository gerencia operações relacionadas aos usuários administradores
 type UsuarioAdministradorRepository interface {
 	Create(ctx context.Context, usuario *entity.UsuarioAdministrador) error
@@ -131,11 +226,76 @@ type UsuarioAdministradorRepository interface {
 	Update(ctx context.Context, usuario *entity.UsuarioAdministrador) error
 	UpdatePassword(ctx context.Context, userID int, hashedPassword string) error
 	UpdateStatus(ctx context.Context, id int, status string) error
+	UpdatePapel(ctx context.Context, id int, papel string) error
 	ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.UsuarioAdministrador, error)
 	ListByStatus(ctx context.Context, empresaID int, status string) ([]*entity.UsuarioAdministrador, error)
 	Count(ctx context.Context) (int, error)
 }
 
+// TokenRedefinicaoSenhaRepository define operações para tokens de redefinição de senha
+type TokenRedefinicaoSenhaRepository interface {
+	Create(ctx context.Context, token *entity.TokenRedefinicaoSenha) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.TokenRedefinicaoSenha, error)
+	// MarkUsed marca o token como utilizado; retorna erro se já tiver sido usado (uso único)
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+	DeleteByUsuario(ctx context.Context, userAdminID int) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SessaoRepository define operações para sessões de login e seus refresh tokens
+type SessaoRepository interface {
+	// Create insere a sessão e seu primeiro refresh token em uma única transação
+	Create(ctx context.Context, sessao *entity.SessaoUsuario, refreshHash string) error
+	GetByID(ctx context.Context, id int) (*entity.SessaoUsuario, error)
+	// GetByRefreshHash retorna a sessão e o refresh token correspondente ao hash, inclusive tokens já trocados
+	GetByRefreshHash(ctx context.Context, refreshHash string) (*entity.SessaoUsuario, *entity.RefreshToken, error)
+	// Rotate marca o refresh token como usado, emite o próximo e atualiza o access token vigente da sessão
+	// Retorna erro se o token já tiver sido trocado (requisições concorrentes)
+	Rotate(ctx context.Context, refreshID int, sessao *entity.SessaoUsuario, newRefreshHash string) error
+	ListActiveByUsuario(ctx context.Context, userAdminID int, now time.Time) ([]*entity.SessaoUsuario, error)
+	Revoke(ctx context.Context, id int, revokedAt time.Time) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TokenRevogadoRepository define a denylist de access tokens (jti) revogados
+type TokenRevogadoRepository interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// MFARepository define operações para segundo fator (TOTP) e códigos de recuperação
+type MFARepository interface {
+	GetByUsuario(ctx context.Context, userAdminID int) (*entity.MFAUsuario, error)
+	// Save grava (ou substitui) um cadastro pendente, removendo códigos de recuperação anteriores
+	// Nunca sobrescreve um segundo fator já ativo
+	Save(ctx context.Context, mfa *entity.MFAUsuario) error
+	// Activate confirma o cadastro e grava os códigos de recuperação em uma única transação
+	Activate(ctx context.Context, userAdminID int, step int64, activatedAt time.Time, codigoHashes []string) error
+	// UseStep registra o passo TOTP aceito; retorna erro se o passo não for posterior ao último usado
+	UseStep(ctx context.Context, userAdminID int, step int64) error
+	Delete(ctx context.Context, userAdminID int) error
+	ReplaceRecoveryCodes(ctx context.Context, userAdminID int, codigoHashes []string) error
+	ListUnusedRecoveryCodes(ctx context.Context, userAdminID int) ([]*entity.CodigoRecuperacao, error)
+	// MarkRecoveryCodeUsed marca o código como usado; retorna erro se já tiver sido usado
+	MarkRecoveryCodeUsed(ctx context.Context, id int, usedAt time.Time) error
+}
+
+// TentativaLoginRepository gerencia os contadores de falhas de login por conta e por IP
+type TentativaLoginRepository interface {
+	// Get busca o contador da chave; erro "não encontrada" quando não há falhas registradas
+	Get(ctx context.Context, tipo, chave string) (*entity.TentativaLogin, error)
+	// RegisterFailure incrementa o contador de forma atômica, reiniciando-o se a última falha for anterior a windowStart
+	RegisterFailure(ctx context.Context, tentativa *entity.TentativaLogin, now, windowStart time.Time) (*entity.TentativaLogin, error)
+	// UpdateBlock grava o atraso e o bloqueio calculados após a falha
+	UpdateBlock(ctx context.Context, id int, proximaTentativa, bloqueadoAte *time.Time) error
+	Delete(ctx context.Context, tipo, chave string) error
+	// ListLockedByEmpresa lista contas da empresa com bloqueio vigente
+	ListLockedByEmpresa(ctx context.Context, empresaID int, now time.Time) ([]*entity.TentativaLogin, error)
+	// DeleteExpired remove contadores sem falhas desde before e sem bloqueio vigente
+	DeleteExpired(ctx context.Context, before, now time.Time) (int64, error)
+}
+
 // Interfaces para operações mais complexas que podem envolver múltiplas entidades
 
 // AnalyticsRepository para operações de análise de dados
@@ -143,6 +303,9 @@ type AnalyticsRepository interface {
 	GetPesquisaMetrics(ctx context.Context, pesquisaID int) (map[string]interface{}, error)
 	GetComparisonData(ctx context.Context, pesquisaIDs []int) (map[string]interface{}, error)
 	GetSetorComparison(ctx context.Context, empresaID int, pesquisaID int) (map[string]interface{}, error)
+	// GetTrendData retorna uma série temporal da empresa desde a data informada
+	// granularidade deve ser "day", "week" ou "month"; períodos sem dados são incluídos zerados
+	GetTrendData(ctx context.Context, empresaID int, since time.Time, granularidade string) ([]map[string]interface{}, error)
 }
 
 type SubmissaoPesquisaRepository interface {
@@ -0,0 +1,193 @@
+// Package usecase implementa os casos de uso para a análise das respostas abertas.
+// Fornece o processamento, em segundo plano, do sentimento, das palavras-chave e dos tópicos das pesquisas encerradas.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/textanalysis"
+	"time"
+)
+
+// MaxPesquisasAnalisePorExecucao limita quantas pesquisas pendentes são analisadas em cada execução do agendador
+const MaxPesquisasAnalisePorExecucao = 10
+
+// Atraso até uma nova análise de pesquisa que falhou, dobrado a cada falha consecutiva
+const (
+	analiseTextoRetryBase = 10 * time.Minute
+	analiseTextoRetryMax  = 24 * time.Hour
+)
+
+// AnaliseTextoUseCase analisa as respostas abertas das pesquisas encerradas
+type AnaliseTextoUseCase struct {
+	repo         repository.AnaliseTextoRepository // Repositório das análises
+	pesquisaRepo repository.PesquisaRepository     // Repositório de pesquisas
+	perguntaRepo repository.PerguntaRepository     // Repositório de perguntas
+	respostaRepo repository.RespostaRepository     // Repositório de respostas
+	anonimato    *PoliticaAnonimato                // Mínimo de respostas por termo e tópico
+}
+
+// NewAnaliseTextoUseCase cria uma nova instância do caso de uso de análise de texto
+func NewAnaliseTextoUseCase(
+	repo repository.AnaliseTextoRepository,
+	pesquisaRepo repository.PesquisaRepository,
+	perguntaRepo repository.PerguntaRepository,
+	respostaRepo repository.RespostaRepository,
+) *AnaliseTextoUseCase {
+	return &AnaliseTextoUseCase{
+		repo:         repo,
+		pesquisaRepo: pesquisaRepo,
+		perguntaRepo: perguntaRepo,
+		respostaRepo: respostaRepo,
+	}
+}
+
+// SetAnonimato define a política de anonimato aplicada às análises
+// Sem esta configuração vale o mínimo padrão de respondentes
+func (uc *AnaliseTextoUseCase) SetAnonimato(anonimato *PoliticaAnonimato) {
+	uc.anonimato = anonimato
+}
+
+// AnaliseTextoResult resume as pesquisas processadas por uma execução
+type AnaliseTextoResult struct {
+	Analisadas []int          // IDs das pesquisas analisadas
+	Falhas     map[int]string // Erro da análise por ID de pesquisa, com a próxima tentativa
+}
+
+// ProcessPending analisa as pesquisas encerradas que têm perguntas abertas sem análise
+// Chamado pelo agendador; cada execução processa até MaxPesquisasAnalisePorExecucao pesquisas
+// Uma pesquisa que falha só volta à fila após um atraso exponencial, para não impedir a análise das demais
+func (uc *AnaliseTextoUseCase) ProcessPending(ctx context.Context) (*AnaliseTextoResult, error) {
+	ids, err := uc.repo.ListPendingPesquisas(ctx, MaxPesquisasAnalisePorExecucao, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pesquisas pendentes: %v", err)
+	}
+
+	result := &AnaliseTextoResult{Falhas: make(map[int]string)}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := uc.analisarPesquisa(ctx, id); err != nil {
+			result.Falhas[id] = uc.registrarFalha(ctx, id, err)
+			continue
+		}
+		result.Analisadas = append(result.Analisadas, id)
+	}
+
+	return result, nil
+}
+
+// registrarFalha conta a falha da pesquisa e agenda a próxima tentativa, retornando a descrição para o log
+func (uc *AnaliseTextoUseCase) registrarFalha(ctx context.Context, pesquisaID int, falha error) string {
+	now := time.Now()
+	tentativas, err := uc.repo.RegisterFailure(ctx, pesquisaID, falha.Error(), now)
+	if err != nil {
+		return fmt.Sprintf("%v (falha não registrada: %v)", falha, err)
+	}
+
+	proxima := now.Add(atrasoAnaliseTexto(tentativas))
+	if err := uc.repo.ScheduleRetry(ctx, pesquisaID, proxima); err != nil {
+		return fmt.Sprintf("%v (tentativa %d; nova tentativa não agendada: %v)", falha, tentativas, err)
+	}
+
+	return fmt.Sprintf("%v (tentativa %d; próxima em %s)", falha, tentativas, proxima.Format(time.RFC3339))
+}
+
+// atrasoAnaliseTexto retorna o atraso após a falha de número tentativas
+func atrasoAnaliseTexto(tentativas int) time.Duration {
+	atraso := analiseTextoRetryBase
+	for i := 1; i < tentativas && atraso < analiseTextoRetryMax; i++ {
+		atraso *= 2
+	}
+	if atraso > analiseTextoRetryMax {
+		atraso = analiseTextoRetryMax
+	}
+	return atraso
+}
+
+// analisarPesquisa analisa todas as perguntas abertas da pesquisa e substitui as análises anteriores
+// Perguntas com menos respostas que o mínimo da empresa são gravadas apenas com a contagem
+func (uc *AnaliseTextoUseCase) analisarPesquisa(ctx context.Context, pesquisaID int) error {
+	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	if err != nil {
+		return fmt.Errorf("pesquisa não encontrada: %v", err)
+	}
+
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return err
+	}
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	respostas, err := uc.respostaRepo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar respostas: %v", err)
+	}
+
+	textos := make(map[int][]string)
+	for _, resposta := range respostas {
+		textos[resposta.IDPergunta] = append(textos[resposta.IDPergunta], resposta.ValorResposta)
+	}
+
+	var analises []*entity.AnaliseTexto
+	for _, pergunta := range perguntas {
+		if pergunta.TipoPergunta != "RespostaAberta" {
+			continue
+		}
+		analises = append(analises, analisarRespostasAbertas(pergunta.ID, textos[pergunta.ID], k))
+	}
+
+	if err := uc.repo.ReplaceByPesquisa(ctx, pesquisaID, analises); err != nil {
+		return fmt.Errorf("erro ao gravar análise de texto: %v", err)
+	}
+	return nil
+}
+
+// analisarRespostasAbertas executa a análise de uma pergunta exigindo k respostas por termo e por tópico
+func analisarRespostasAbertas(perguntaID int, textos []string, k int) *entity.AnaliseTexto {
+	resultado := textanalysis.Analyze(textos, textanalysis.Options{MinDocuments: k})
+
+	analise := &entity.AnaliseTexto{
+		IDPergunta:      perguntaID,
+		Respostas:       resultado.Documents,
+		MinRespondentes: k,
+	}
+	if analise.Suprimido(k) {
+		return analise
+	}
+
+	analise.Sentimento = sentimentoTexto(resultado.Sentiment)
+	for _, keyword := range resultado.Keywords {
+		analise.PalavrasChave = append(analise.PalavrasChave, entity.PalavraChave{
+			Termo:       keyword.Term,
+			Palavras:    keyword.Words,
+			Ocorrencias: keyword.Occurrences,
+			Respostas:   keyword.Documents,
+		})
+	}
+	for _, topic := range resultado.Topics {
+		analise.Topicos = append(analise.Topicos, entity.TopicoTexto{
+			Termos:     topic.Terms,
+			Respostas:  topic.Documents,
+			Sentimento: sentimentoTexto(topic.Sentiment),
+		})
+	}
+	analise.SemTopico = resultado.Unclustered
+	return analise
+}
+
+func sentimentoTexto(s textanalysis.Sentiment) entity.SentimentoTexto {
+	return entity.SentimentoTexto{
+		Positivas: s.Positive,
+		Neutras:   s.Neutral,
+		Negativas: s.Negative,
+		Indice:    s.Index,
+	}
+}
@@ -5,16 +5,20 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"math"
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
+	"strings"
 	"time"
 )
 
 // AnalyticsUseCase implementa casos de uso para análise de dados
 type AnalyticsUseCase struct {
-	repo             repository.AnalyticsRepository    // Repositório de análises
-	pesquisaRepo     repository.PesquisaRepository     // Repositório de pesquisas
-	logAuditoriaRepo repository.LogAuditoriaRepository // Repositório de logs
+	repo             repository.AnalyticsRepository     // Repositório de análises
+	pesquisaRepo     repository.PesquisaRepository      // Repositório de pesquisas
+	logAuditoriaRepo repository.LogAuditoriaRepository  // Repositório de logs
+	anonimato        *PoliticaAnonimato                 // Mínimo de respondentes das métricas
+	cloneRepo        repository.PesquisaCloneRepository // Origem das pesquisas clonadas (opcional)
 }
 
 // NewAnalyticsUseCase cria uma nova instância do caso de uso de análises
@@ -30,6 +34,17 @@ func NewAnalyticsUseCase(
 	}
 }
 
+// SetClonagem habilita a identificação, na comparação, das pesquisas criadas por clonagem de outra
+func (uc *AnalyticsUseCase) SetClonagem(cloneRepo repository.PesquisaCloneRepository) {
+	uc.cloneRepo = cloneRepo
+}
+
+// SetAnonimato define a política de anonimato aplicada às métricas
+// Sem esta configuração vale o mínimo padrão de respondentes
+func (uc *AnalyticsUseCase) SetAnonimato(anonimato *PoliticaAnonimato) {
+	uc.anonimato = anonimato
+}
+
 // GetPesquisaMetrics retorna métricas agregadas de uma pesquisa específica
 func (uc *AnalyticsUseCase) GetPesquisaMetrics(ctx context.Context, pesquisaID int, userAdminID int, enderecoIP string) (map[string]interface{}, error) {
 	// Validações
@@ -37,10 +52,10 @@ func (uc *AnalyticsUseCase) GetPesquisaMetrics(ctx context.Context, pesquisaID i
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	// Verifica se pesquisa existe
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe e pertence à empresa
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+		return nil, err
 	}
 
 	// Só permite visualizar métricas de pesquisas ativas ou concluídas
@@ -53,6 +68,12 @@ func (uc *AnalyticsUseCase) GetPesquisaMetrics(ctx context.Context, pesquisaID i
 		return nil, fmt.Errorf("erro ao buscar métricas: %v", err)
 	}
 
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	suprimirMetricas(metrics, k)
+
 	// Log de auditoria para acesso às métricas
 	if userAdminID > 0 {
 		log := &entity.LogAuditoria{
@@ -68,7 +89,6 @@ func (uc *AnalyticsUseCase) GetPesquisaMetrics(ctx context.Context, pesquisaID i
 	return metrics, nil
 }
 
-// GetComparisonData compara métricas entre diferentes pesquisas
 // GetComparisonData compara métricas entre diferentes pesquisas
 func (uc *AnalyticsUseCase) GetComparisonData(ctx context.Context, pesquisaIDs []int, userAdminID int, enderecoIP string) (map[string]interface{}, error) {
 	// Validações
@@ -95,6 +115,9 @@ func (uc *AnalyticsUseCase) GetComparisonData(ctx context.Context, pesquisaIDs [
 		if err != nil {
 			return nil, fmt.Errorf("pesquisa ID %d não encontrada: %v", pesquisaID, err)
 		}
+		if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, fmt.Sprintf("pesquisa ID %d não encontrada", pesquisaID)); err != nil {
+			return nil, err
+		}
 
 		// Primeira pesquisa define a empresa
 		if i == 0 {
@@ -114,6 +137,17 @@ func (uc *AnalyticsUseCase) GetComparisonData(ctx context.Context, pesquisaIDs [
 		return nil, fmt.Errorf("erro ao gerar comparação: %v", err)
 	}
 
+	k, err := uc.anonimato.MinRespondentes(ctx, empresaID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.suprimirComparacao(ctx, comparison, pesquisaIDs, k); err != nil {
+		return nil, err
+	}
+	if err := uc.vincularOrigens(ctx, comparison); err != nil {
+		return nil, err
+	}
+
 	// Log de auditoria
 	if userAdminID > 0 {
 		log := &entity.LogAuditoria{
@@ -129,7 +163,7 @@ func (uc *AnalyticsUseCase) GetComparisonData(ctx context.Context, pesquisaIDs [
 	return comparison, nil
 }
 
-// GetSetorComparison compara métricas entre diferentes setores de uma pesquisa
+// GetSetorComparison compara a pesquisa com as pesquisas concluídas dos demais setores da empresa
 func (uc *AnalyticsUseCase) GetSetorComparison(ctx context.Context, empresaID int, pesquisaID int, userAdminID int, enderecoIP string) (map[string]interface{}, error) {
 	// Validações
 	if empresaID <= 0 {
@@ -140,21 +174,20 @@ func (uc *AnalyticsUseCase) GetSetorComparison(ctx context.Context, empresaID in
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	// Verifica se pesquisa existe e pertence à empresa
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se empresa está no escopo do usuário e se pesquisa existe e pertence a ela
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+		return nil, err
 	}
 
 	if pesquisa.IDEmpresa != empresaID {
 		return nil, fmt.Errorf("pesquisa não pertence à empresa informada")
 	}
 
-	// Só permite comparação de setores se pesquisa não tem setor específico
-	if pesquisa.IDSetor > 0 {
-		return nil, fmt.Errorf("pesquisa é específica de um setor, não é possível fazer comparação entre setores")
-	}
-
 	// Pesquisa deve estar concluída para comparação entre setores
 	if pesquisa.Status != "Concluída" {
 		return nil, fmt.Errorf("só é possível comparar setores em pesquisas concluídas")
@@ -165,6 +198,27 @@ func (uc *AnalyticsUseCase) GetSetorComparison(ctx context.Context, empresaID in
 		return nil, fmt.Errorf("erro ao gerar comparação por setor: %v", err)
 	}
 
+	// Setores com menos respondentes que o mínimo aparecem sem médias e sem diferença para a referência
+	k, err := uc.anonimato.MinRespondentes(ctx, empresaID)
+	if err != nil {
+		return nil, err
+	}
+	referenciaSuprimida := false
+	if referencia, ok := comparison["pesquisa_referencia"].(map[string]interface{}); ok {
+		referenciaSuprimida = suprimirResumo(referencia, k)
+	}
+	if setores, ok := comparison["setores"].([]map[string]interface{}); ok {
+		for _, setor := range setores {
+			resumo, ok := setor["pesquisa"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if suprimirResumo(resumo, k) || referenciaSuprimida {
+				setor["diferenca_media"] = nil
+			}
+		}
+	}
+
 	// Log de auditoria
 	if userAdminID > 0 {
 		log := &entity.LogAuditoria{
@@ -180,28 +234,72 @@ func (uc *AnalyticsUseCase) GetSetorComparison(ctx context.Context, empresaID in
 	return comparison, nil
 }
 
+// trendPeriods define a janela e a granularidade de cada período aceito na análise de tendências
+var trendPeriods = map[string]struct {
+	dias          int
+	granularidade string
+}{
+	"7days":  {7, "day"},
+	"30days": {30, "day"},
+	"90days": {90, "week"},
+	"1year":  {365, "month"},
+}
+
 // GetTrendAnalysis analisa tendências das respostas ao longo do tempo
+// Retorna uma série por dia, semana ou mês (conforme o período) com a variação em relação ao ponto anterior
 func (uc *AnalyticsUseCase) GetTrendAnalysis(ctx context.Context, empresaID int, period string, userAdminID int, enderecoIP string) (map[string]interface{}, error) {
 	if empresaID <= 0 {
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	validPeriods := map[string]bool{
-		"7days":  true,
-		"30days": true,
-		"90days": true,
-		"1year":  true,
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
 	}
 
-	if !validPeriods[period] {
+	config, ok := trendPeriods[period]
+	if !ok {
 		return nil, fmt.Errorf("período inválido: %s. Valores válidos: 7days, 30days, 90days, 1year", period)
 	}
 
-	// Este método precisaria ser implementado no repository
-	// trends, err := uc.repo.GetTrendAnalysis(ctx, empresaID, period)
-	// if err != nil {
-	//     return nil, fmt.Errorf("erro ao gerar análise de tendências: %v", err)
-	// }
+	since := time.Now().AddDate(0, 0, -config.dias)
+
+	series, err := uc.repo.GetTrendData(ctx, empresaID, since, config.granularidade)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar análise de tendências: %v", err)
+	}
+
+	// Médias de períodos com menos respondentes que o mínimo são omitidas antes do cálculo das variações
+	k, err := uc.anonimato.MinRespondentes(ctx, empresaID)
+	if err != nil {
+		return nil, err
+	}
+	suprimirTendencia(series, k)
+
+	totalSubmissoes, totalTokens := 0, 0
+	for i, ponto := range series {
+		submissoes, _ := ponto["submissoes"].(int)
+		tokens, _ := ponto["tokens_emitidos"].(int)
+		totalSubmissoes += submissoes
+		totalTokens += tokens
+
+		ponto["variacao_submissoes"] = nil
+		ponto["variacao_taxa_conclusao"] = nil
+		ponto["variacao_media_escala"] = nil
+		if i == 0 {
+			continue
+		}
+
+		anterior := series[i-1]
+		ponto["variacao_submissoes"] = submissoes - anterior["submissoes"].(int)
+		if tokens > 0 && anterior["tokens_emitidos"].(int) > 0 {
+			ponto["variacao_taxa_conclusao"] = roundTo2(ponto["taxa_conclusao"].(float64) - anterior["taxa_conclusao"].(float64))
+		}
+		if media, ok := ponto["media_escala"].(float64); ok {
+			if mediaAnterior, ok := anterior["media_escala"].(float64); ok {
+				ponto["variacao_media_escala"] = roundTo2(media - mediaAnterior)
+			}
+		}
+	}
 
 	// Log de auditoria
 	if userAdminID > 0 {
@@ -215,10 +313,167 @@ func (uc *AnalyticsUseCase) GetTrendAnalysis(ctx context.Context, empresaID int,
 		uc.logAuditoriaRepo.Create(ctx, log)
 	}
 
-	// Retorna estrutura básica por enquanto
 	return map[string]interface{}{
-		"period":     period,
-		"empresa_id": empresaID,
-		"message":    "Análise de tendências em desenvolvimento",
+		"period":           period,
+		"empresa_id":       empresaID,
+		"granularidade":    config.granularidade,
+		"inicio":           since,
+		"total_submissoes": totalSubmissoes,
+		"total_tokens":     totalTokens,
+		"series":           series,
 	}, nil
 }
+
+// suprimirMetricas omite as médias da pesquisa e das perguntas com menos de k respondentes
+func suprimirMetricas(metrics map[string]interface{}, k int) {
+	pesquisaSuprimida := suprimirResumo(metrics, k)
+
+	perguntas, _ := metrics["perguntas"].([]map[string]interface{})
+	for _, pergunta := range perguntas {
+		pergunta["supressao"] = nil
+		total, _ := pergunta["total_respostas"].(int)
+		if pesquisaSuprimida || total < k {
+			pergunta["media"] = nil
+			pergunta["media_normalizada"] = nil
+			pergunta["supressao"] = novaSupressao(k)
+		}
+	}
+}
+
+// suprimirResumo omite as médias do resumo de uma pesquisa com menos de k submissões completas
+// Retorna se o resumo foi suprimido
+func suprimirResumo(resumo map[string]interface{}, k int) bool {
+	resumo["supressao"] = nil
+	completas, _ := resumo["submissoes_completas"].(int)
+	if completas >= k {
+		return false
+	}
+
+	resumo["media_geral"] = nil
+	resumo["media_geral_normalizada"] = nil
+	resumo["supressao"] = novaSupressao(k)
+	return true
+}
+
+// suprimirComparacao omite, na comparação entre pesquisas, as médias de pesquisas e perguntas com menos de k respondentes
+// A variação em relação a uma pesquisa suprimida também é omitida, pois permitiria deduzir a média dela
+func (uc *AnalyticsUseCase) suprimirComparacao(ctx context.Context, comparison map[string]interface{}, pesquisaIDs []int, k int) error {
+	pesquisas, _ := comparison["pesquisas"].([]map[string]interface{})
+	for i, resumo := range pesquisas {
+		suprimida := suprimirResumo(resumo, k)
+		if suprimida || (i > 0 && pesquisas[i-1]["supressao"] != nil) {
+			resumo["variacao_media_geral"] = nil
+		}
+		if i+1 < len(pesquisas) && suprimida {
+			pesquisas[i+1]["variacao_media_geral"] = nil
+		}
+	}
+
+	// Perguntas abaixo do mínimo em cada pesquisa saem das médias das perguntas comuns
+	perguntasComuns, _ := comparison["perguntas_comuns"].([]map[string]interface{})
+	for _, pesquisaID := range pesquisaIDs {
+		metrics, err := uc.repo.GetPesquisaMetrics(ctx, pesquisaID)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar métricas: %v", err)
+		}
+		suprimirMetricas(metrics, k)
+
+		perguntas, _ := metrics["perguntas"].([]map[string]interface{})
+		for _, pergunta := range perguntas {
+			if pergunta["supressao"] == nil {
+				continue
+			}
+			for _, comum := range perguntasComuns {
+				if comum["texto_pergunta"] == pergunta["texto_pergunta"] {
+					if medias, ok := comum["medias"].(map[string]interface{}); ok {
+						delete(medias, fmt.Sprintf("%d", pesquisaID))
+					}
+				}
+			}
+		}
+	}
+
+	comuns := make([]map[string]interface{}, 0, len(perguntasComuns))
+	for _, comum := range perguntasComuns {
+		if medias, ok := comum["medias"].(map[string]interface{}); ok && len(medias) >= 2 {
+			comuns = append(comuns, comum)
+		}
+	}
+	comparison["perguntas_comuns"] = comuns
+	return nil
+}
+
+// vincularOrigens informa, em cada pesquisa comparada, a pesquisa da qual ela foi clonada
+// Quando a origem também está na comparação, variacao_media_origem compara as médias das duas aplicações
+// Deve ser chamada após suprimirComparacao: pesquisas suprimidas não têm média e não geram variação
+func (uc *AnalyticsUseCase) vincularOrigens(ctx context.Context, comparison map[string]interface{}) error {
+	pesquisas, _ := comparison["pesquisas"].([]map[string]interface{})
+	porID := make(map[int]map[string]interface{}, len(pesquisas))
+	for _, resumo := range pesquisas {
+		id, _ := resumo["pesquisa_id"].(int)
+		porID[id] = resumo
+	}
+
+	for _, resumo := range pesquisas {
+		resumo["id_pesquisa_origem"] = nil
+		resumo["variacao_media_origem"] = nil
+		if uc.cloneRepo == nil {
+			continue
+		}
+
+		id, _ := resumo["pesquisa_id"].(int)
+		clone, err := uc.cloneRepo.GetByPesquisa(ctx, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "não encontrad") {
+				continue
+			}
+			return err
+		}
+		if clone.IDPesquisaOrigem == 0 {
+			continue // Origem removida
+		}
+		resumo["id_pesquisa_origem"] = clone.IDPesquisaOrigem
+
+		origem, ok := porID[clone.IDPesquisaOrigem]
+		if !ok {
+			continue
+		}
+		atual, okAtual := resumo["media_geral"].(float64)
+		anterior, okAnterior := origem["media_geral"].(float64)
+		if okAtual && okAnterior {
+			resumo["variacao_media_origem"] = roundTo2(atual - anterior)
+		}
+	}
+	return nil
+}
+
+// suprimirTendencia omite as médias dos períodos com menos de k respondentes nas escalas
+// A contagem vem do mesmo período de resposta da média; as submissões são agrupadas pela data de conclusão
+// e não garantem que a média de um período venha de k respondentes
+// O primeiro período também é omitido: a janela começa no meio dele e o conteúdo mudaria a cada consulta,
+// permitindo isolar respondentes pela diferença entre consultas em momentos próximos
+func suprimirTendencia(series []map[string]interface{}, k int) {
+	for i, ponto := range series {
+		ponto["supressao"] = nil
+		respondentes, _ := ponto["respondentes_escala"].(int)
+
+		var supressao *Supressao
+		switch {
+		case i == 0:
+			supressao = &Supressao{Suprimido: true, MinimoRespondentes: k, Motivo: motivoPeriodoIncompleto}
+		case respondentes < k:
+			supressao = novaSupressao(k)
+		default:
+			continue
+		}
+
+		ponto["media_escala"] = nil
+		ponto["media_normalizada"] = nil
+		ponto["supressao"] = supressao
+	}
+}
+
+// roundTo2 arredonda para duas casas decimais
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import "testing"
+
+func TestSuprimirTendenciaUsaRespondentesDaMedia(t *testing.T) {
+	const k = 5
+	ponto := func(submissoes, respondentes int) map[string]interface{} {
+		return map[string]interface{}{
+			"submissoes":          submissoes,
+			"respondentes_escala": respondentes,
+			"media_escala":        3.5,
+			"media_normalizada":   62.5,
+		}
+	}
+
+	series := []map[string]interface{}{
+		ponto(20, 20), // Primeiro período, sempre incompleto
+		ponto(20, 4),  // Conclusões acima de k, mas a média vem de k-1 respondentes
+		ponto(2, k),   // Poucas conclusões no período, média de k respondentes
+		ponto(20, k+1),
+	}
+	suprimirTendencia(series, k)
+
+	esperado := []bool{true, true, false, false}
+	for i, ponto := range series {
+		suprimido := ponto["supressao"] != nil
+		if suprimido != esperado[i] {
+			t.Errorf("período %d: suprimido = %v, esperado %v", i, suprimido, esperado[i])
+		}
+		if suprimido && (ponto["media_escala"] != nil || ponto["media_normalizada"] != nil) {
+			t.Errorf("período %d: média publicada apesar da supressão", i)
+		}
+		if !suprimido && ponto["media_escala"] != 3.5 {
+			t.Errorf("período %d: média omitida sem supressão", i)
+		}
+	}
+}
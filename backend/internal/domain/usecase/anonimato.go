@@ -0,0 +1,149 @@
+// Package usecase implementa a política de anonimato dos resultados.
+// Fornece o mínimo de respondentes configurado pela empresa e as regras de supressão aplicadas por todos os casos de uso de resultados.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// PoliticaAnonimato aplica o mínimo de respondentes (k-anonimato) aos resultados agregados
+// Grupos com menos de k respondentes não são exibidos, nem podem ser isolados pela diferença entre consultas
+type PoliticaAnonimato struct {
+	empresaRepo repository.EmpresaRepository // Repositório de empresas (mínimo configurado)
+}
+
+// NewPoliticaAnonimato cria a política de anonimato a partir da configuração das empresas
+func NewPoliticaAnonimato(empresaRepo repository.EmpresaRepository) *PoliticaAnonimato {
+	return &PoliticaAnonimato{empresaRepo: empresaRepo}
+}
+
+// MinRespondentes retorna o mínimo de respondentes da empresa
+// Sem política configurada vale o padrão; valores fora dos limites (registros antigos) são ajustados
+func (p *PoliticaAnonimato) MinRespondentes(ctx context.Context, empresaID int) (int, error) {
+	if p == nil || p.empresaRepo == nil {
+		return entity.MinRespondentesPadrao, nil
+	}
+
+	empresa, err := p.empresaRepo.GetByID(ctx, empresaID)
+	if err != nil {
+		// Sem o mínimo não é possível decidir o que exibir: a consulta falha em vez de expor grupos pequenos
+		return 0, fmt.Errorf("erro ao buscar política de anonimato: %v", err)
+	}
+
+	switch {
+	case empresa.MinRespondentes < entity.MinRespondentesMinimo:
+		return entity.MinRespondentesMinimo, nil
+	case empresa.MinRespondentes > entity.MinRespondentesMaximo:
+		return entity.MinRespondentesMaximo, nil
+	}
+	return empresa.MinRespondentes, nil
+}
+
+// Motivos de supressão informados nas respostas
+const (
+	motivoPoucosRespondentes = "menos de %d respondentes"
+	motivoComplementar       = "exibir o resultado permitiria deduzir um grupo com menos de %d respondentes"
+	motivoPeriodoIncompleto  = "o primeiro período está incompleto na janela consultada e mudaria a cada consulta"
+)
+
+// Supressao descreve um resultado omitido para preservar o anonimato dos respondentes
+type Supressao struct {
+	Suprimido          bool   `json:"suprimido"`
+	MinimoRespondentes int    `json:"minimo_respondentes"`
+	Motivo             string `json:"motivo"`
+}
+
+// novaSupressao cria a descrição de um resultado com menos de k respondentes
+func novaSupressao(k int) *Supressao {
+	return &Supressao{Suprimido: true, MinimoRespondentes: k, Motivo: fmt.Sprintf(motivoPoucosRespondentes, k)}
+}
+
+// supressaoComplementar cria a descrição de um resultado omitido para impedir a dedução de outro grupo pequeno
+func supressaoComplementar(k int) *Supressao {
+	return &Supressao{Suprimido: true, MinimoRespondentes: k, Motivo: fmt.Sprintf(motivoComplementar, k)}
+}
+
+// erroSupressao é o erro retornado quando o resultado inteiro de uma consulta é suprimido
+// Os handlers identificam o erro pelo prefixo "resultado suprimido"
+func erroSupressao(supressao *Supressao) error {
+	return fmt.Errorf("resultado suprimido para preservar o anonimato: %s", supressao.Motivo)
+}
+
+// respondentesPorPergunta conta os respondentes de cada pergunta a partir das respostas agregadas por valor
+// Deve ser usada antes de AgregarSelecoes, enquanto cada resposta gravada corresponde a um respondente
+func respondentesPorPergunta(agregados map[int]map[string]int) map[int]int {
+	respondentes := make(map[int]int, len(agregados))
+	for perguntaID, distribuicao := range agregados {
+		respondentes[perguntaID] = sumCounts(distribuicao)
+	}
+	return respondentes
+}
+
+// suprimirPerguntas remove as distribuições das perguntas com menos de k respondentes
+// Retorna a supressão de cada pergunta removida
+func suprimirPerguntas(agregados map[int]map[string]int, k int) map[int]*Supressao {
+	suprimidas := make(map[int]*Supressao)
+	for perguntaID, total := range respondentesPorPergunta(agregados) {
+		if total < k {
+			delete(agregados, perguntaID)
+			suprimidas[perguntaID] = novaSupressao(k)
+		}
+	}
+	return suprimidas
+}
+
+// verificarPeriodo garante que o período [inicio, fim) não isola grupos com menos de k respondentes
+// Os dias com respostas formam, em ordem, blocos de pelo menos k respondentes (o restante se junta ao último bloco)
+// e o período deve conter cada bloco por inteiro ou não conter nada dele. Assim dois períodos quaisquer diferem
+// por blocos inteiros, e a diferença entre consultas sobrepostas nunca revela menos de k respondentes
+func verificarPeriodo(respondentesPorDia map[string]int, inicio, fim string, k int) error {
+	dias := make([]string, 0, len(respondentesPorDia))
+	for dia := range respondentesPorDia {
+		dias = append(dias, dia)
+	}
+	sort.Strings(dias) // Datas no formato YYYY-MM-DD ordenam como texto
+
+	var blocos [][]string
+	var atual []string
+	acumulado := 0
+	for _, dia := range dias {
+		atual = append(atual, dia)
+		acumulado += respondentesPorDia[dia]
+		if acumulado >= k {
+			blocos = append(blocos, atual)
+			atual, acumulado = nil, 0
+		}
+	}
+	if len(atual) > 0 {
+		if len(blocos) == 0 {
+			blocos = append(blocos, atual)
+		} else {
+			blocos[len(blocos)-1] = append(blocos[len(blocos)-1], atual...)
+		}
+	}
+
+	for _, bloco := range blocos {
+		dentro, total := 0, 0
+		for _, dia := range bloco {
+			total += respondentesPorDia[dia]
+			if dia >= inicio && dia < fim {
+				dentro++
+			}
+		}
+		switch {
+		case dentro == 0:
+			continue
+		case total < k:
+			return erroSupressao(novaSupressao(k))
+		case dentro < len(bloco):
+			return fmt.Errorf("%v; o período deve incluir ou excluir por completo as respostas de %s a %s",
+				erroSupressao(supressaoComplementar(k)), bloco[0], bloco[len(bloco)-1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuprimirPerguntasNoLimiteDeK(t *testing.T) {
+	const k = 5
+	casos := []struct {
+		nome         string
+		distribuicao map[string]int
+		suprimida    bool
+	}{
+		{"k-1 respondentes", map[string]int{"1": 2, "5": 2}, true},
+		{"k respondentes", map[string]int{"1": 2, "5": 3}, false},
+		{"k+1 respondentes", map[string]int{"1": 3, "5": 3}, false},
+		{"sem respondentes", map[string]int{}, true},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			agregados := map[int]map[string]int{1: c.distribuicao}
+			suprimidas := suprimirPerguntas(agregados, k)
+
+			_, removida := suprimidas[1]
+			if removida != c.suprimida {
+				t.Fatalf("suprimida = %v, esperado %v", removida, c.suprimida)
+			}
+			if _, presente := agregados[1]; presente == c.suprimida {
+				t.Errorf("distribuição presente = %v após supressão = %v", presente, c.suprimida)
+			}
+			if c.suprimida && suprimidas[1].MinimoRespondentes != k {
+				t.Errorf("mínimo informado %d, esperado %d", suprimidas[1].MinimoRespondentes, k)
+			}
+		})
+	}
+}
+
+func TestVerificarPeriodoNoLimiteDeK(t *testing.T) {
+	const k = 5
+	casos := []struct {
+		nome         string
+		respondentes int
+		erro         bool
+	}{
+		{"k-1 respondentes", k - 1, true},
+		{"k respondentes", k, false},
+		{"k+1 respondentes", k + 1, false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			dias := map[string]int{"2026-03-02": c.respondentes}
+			err := verificarPeriodo(dias, "2026-03-01", "2026-04-01", k)
+			if (err != nil) != c.erro {
+				t.Fatalf("erro = %v, esperado erro: %v", err, c.erro)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "resultado suprimido") {
+				t.Errorf("erro sem o prefixo de supressão: %v", err)
+			}
+
+			// Um período sem nenhum dia com respostas não expõe ninguém
+			if err := verificarPeriodo(dias, "2026-04-01", "2026-05-01", k); err != nil {
+				t.Errorf("período vazio rejeitado: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerificarPeriodoBlocosInteiros(t *testing.T) {
+	const k = 5
+	// Blocos: [02, 03] com 6 respondentes e [04, 05, 06] com 3+3+1 (o resto se junta ao último bloco)
+	dias := map[string]int{
+		"2026-03-02": 3,
+		"2026-03-03": 3,
+		"2026-03-04": 3,
+		"2026-03-05": 3,
+		"2026-03-06": 1,
+	}
+
+	casos := []struct {
+		nome        string
+		inicio, fim string
+		erro        string
+	}{
+		{"primeiro bloco inteiro", "2026-03-01", "2026-03-04", ""},
+		{"segundo bloco inteiro", "2026-03-04", "2026-03-07", ""},
+		{"todos os blocos", "2026-03-01", "2026-04-01", ""},
+		{"metade do primeiro bloco", "2026-03-01", "2026-03-03", "deduzir"},
+		{"bloco inteiro e metade do seguinte", "2026-03-01", "2026-03-05", "deduzir"},
+		{"apenas o resto do último bloco", "2026-03-06", "2026-03-07", "deduzir"},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			err := verificarPeriodo(dias, c.inicio, c.fim, k)
+			switch {
+			case c.erro == "" && err != nil:
+				t.Errorf("período rejeitado: %v", err)
+			case c.erro != "" && err == nil:
+				t.Errorf("período aceito, esperado erro contendo %q", c.erro)
+			case c.erro != "" && !strings.Contains(err.Error(), c.erro):
+				t.Errorf("erro %q não contém %q", err, c.erro)
+			}
+		})
+	}
+}
+
+func TestVerificarPeriodoDiferencaEntrePeriodos(t *testing.T) {
+	const k = 5
+	dias := []string{"2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-09", "2026-03-10"}
+	respondentes := map[string]int{
+		"2026-03-02": 1,
+		"2026-03-03": 4,
+		"2026-03-04": 2,
+		"2026-03-05": 2,
+		"2026-03-06": 2,
+		"2026-03-09": 6,
+		"2026-03-10": 1,
+	}
+	limites := append(append([]string{"2026-03-01"}, dias...), "2026-03-11")
+
+	// Todos os períodos aceitos entre os limites possíveis
+	type periodo struct{ inicio, fim string }
+	var aceitos []periodo
+	for i := range limites {
+		for j := i + 1; j < len(limites); j++ {
+			if verificarPeriodo(respondentes, limites[i], limites[j], k) == nil {
+				aceitos = append(aceitos, periodo{limites[i], limites[j]})
+			}
+		}
+	}
+	if len(aceitos) == 0 {
+		t.Fatal("nenhum período aceito")
+	}
+
+	// Consultas aceitas, adjacentes ou sobrepostas, só podem diferir por 0 ou pelo menos k respondentes
+	soma := func(p periodo, excluir periodo) int {
+		total := 0
+		for _, dia := range dias {
+			if dia >= p.inicio && dia < p.fim && !(dia >= excluir.inicio && dia < excluir.fim) {
+				total += respondentes[dia]
+			}
+		}
+		return total
+	}
+	for _, a := range aceitos {
+		for _, b := range aceitos {
+			if diferenca := soma(a, b); diferenca > 0 && diferenca < k {
+				t.Errorf("[%s, %s) menos [%s, %s) isola %d respondentes", a.inicio, a.fim, b.inicio, b.fim, diferenca)
+			}
+		}
+	}
+
+	// Períodos adjacentes que partem um bloco são recusados
+	if err := verificarPeriodo(respondentes, "2026-03-01", "2026-03-03", k); err == nil {
+		t.Error("período que parte o primeiro bloco foi aceito")
+	}
+	if err := verificarPeriodo(respondentes, "2026-03-03", "2026-03-07", k); err == nil {
+		t.Error("período adjacente que parte o primeiro bloco foi aceito")
+	}
+}
@@ -181,6 +181,7 @@ func (uc *BootstrapUseCase) createAdministrador(ctx context.Context, usuario *en
     usuario.SenhaHash = hashedPassword
     usuario.DataCadastro = time.Now()
     usuario.Status = "Ativo"
+    usuario.Papel = entity.PapelProprietario // Primeiro admin é o proprietário da empresa
 
     if err := uc.usuarioRepo.Create(ctx, usuario); err != nil {
         return fmt.Errorf("erro ao criar administrador: %w", err)
@@ -200,11 +201,12 @@ func (uc *BootstrapUseCase) logBootstrapSuccess(ctx context.Context, data *Boots
         TimeStamp:     time.Now(),
         AcaoRealizada: "Bootstrap - Sistema Inicializado",
         Detalhes: fmt.Sprintf(
-            "Empresa: %s (CNPJ: %s) | Admin: %s (%s)",
+            "Empresa: %s (CNPJ: %s) | Admin: %s (%s) | Papel: %s",
             data.Empresa.NomeFantasia,
             data.Empresa.CNPJ,
             data.Usuario.NomeAdmin,
             data.Usuario.Email,
+            data.Usuario.Papel,
         ),
         EnderecoIP: "bootstrap",
     }
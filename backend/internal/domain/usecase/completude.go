@@ -0,0 +1,77 @@
+// Package usecase implementa a medição de completude das submissões.
+// Fornece a contagem de submissões integrais e parciais e as taxas de omissão por pergunta.
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// Completude resume o preenchimento das submissões de uma pesquisa
+// Cada submissão é avaliada com as condições de exibição: só contam as perguntas exibidas ao respondente
+type Completude struct {
+	Integrais int         // Submissões com todas as perguntas exibidas respondidas
+	Parciais  int         // Submissões com alguma pergunta opcional exibida sem resposta
+	Exibicoes map[int]int // Por pergunta, submissões em que foi exibida
+	Omissoes  map[int]int // Por pergunta, submissões em que foi exibida e ficou sem resposta
+}
+
+// calcularCompletude avalia as respostas de cada submissão (por submissão e pergunta) contra as perguntas da pesquisa
+func calcularCompletude(perguntas []*entity.Pergunta, valores map[int]map[int]string) *Completude {
+	completude := &Completude{
+		Exibicoes: make(map[int]int, len(perguntas)),
+		Omissoes:  make(map[int]int, len(perguntas)),
+	}
+
+	for _, respostas := range valores {
+		visiveis := entity.PerguntasVisiveis(perguntas, respostas)
+		omitidas := 0
+		for _, pergunta := range perguntas {
+			if !visiveis[pergunta.ID] {
+				continue
+			}
+			completude.Exibicoes[pergunta.ID]++
+			if _, respondida := respostas[pergunta.ID]; !respondida {
+				completude.Omissoes[pergunta.ID]++
+				omitidas++
+			}
+		}
+
+		if omitidas == 0 {
+			completude.Integrais++
+		} else {
+			completude.Parciais++
+		}
+	}
+
+	return completude
+}
+
+// carregarCompletude busca as perguntas e as respostas por submissão e calcula a completude da pesquisa
+func carregarCompletude(ctx context.Context, perguntaRepo repository.PerguntaRepository, respostaRepo repository.RespostaRepository, pesquisaID int) (*Completude, error) {
+	perguntas, err := perguntaRepo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	valores, err := respostaRepo.GetValoresBySubmissao(ctx, pesquisaID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas por submissão: %v", err)
+	}
+
+	return calcularCompletude(perguntas, valores), nil
+}
+
+// TaxaOmissao retorna o percentual de exibições da pergunta que ficaram sem resposta
+// Retorna nil quando a pergunta foi exibida a menos de k respondentes
+func (c *Completude) TaxaOmissao(perguntaID, k int) *float64 {
+	exibicoes := c.Exibicoes[perguntaID]
+	if exibicoes == 0 || exibicoes < k {
+		return nil
+	}
+	taxa := float64(c.Omissoes[perguntaID]) / float64(exibicoes) * 100
+	return &taxa
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import "testing"
+
+func TestCalcularCompletude(t *testing.T) {
+	completude := calcularCompletude(perguntasCompletude(), map[int]map[int]string{
+		1: {1: "5", 2: "Sim", 3: "Sim"}, // Integral
+		2: {1: "2", 2: "Não"},           // Integral: a pergunta 3 fica oculta
+		3: {1: "5", 3: "Não"},           // Parcial: opcional exibida sem resposta
+		4: {1: "1"},                     // Parcial: opcional sem resposta e pergunta 3 oculta
+	})
+
+	if completude.Integrais != 2 || completude.Parciais != 2 {
+		t.Errorf("%d integrais e %d parciais, esperado 2 e 2", completude.Integrais, completude.Parciais)
+	}
+	for id, esperado := range map[int]int{1: 4, 2: 4, 3: 2} {
+		if completude.Exibicoes[id] != esperado {
+			t.Errorf("pergunta %d: %d exibições, esperado %d", id, completude.Exibicoes[id], esperado)
+		}
+	}
+	for id, esperado := range map[int]int{1: 0, 2: 2, 3: 0} {
+		if completude.Omissoes[id] != esperado {
+			t.Errorf("pergunta %d: %d omissões, esperado %d", id, completude.Omissoes[id], esperado)
+		}
+	}
+
+	casos := []struct {
+		nome     string
+		pergunta int
+		k        int
+		taxa     *float64 // nil quando a taxa é suprimida
+	}{
+		{"exibições no mínimo", 2, 4, ptrFloat(50)},
+		{"exibições abaixo do mínimo", 2, 5, nil},
+		{"condicional sem omissões", 3, 2, ptrFloat(0)},
+		{"condicional abaixo do mínimo", 3, 3, nil},
+		{"pergunta nunca exibida", 99, 0, nil},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			taxa := completude.TaxaOmissao(c.pergunta, c.k)
+			if (taxa == nil) != (c.taxa == nil) || (taxa != nil && *taxa != *c.taxa) {
+				t.Errorf("taxa %v, esperado %v", derefFloat(taxa), derefFloat(c.taxa))
+			}
+		})
+	}
+}
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
+
+// derefFloat formata a taxa para as mensagens de erro
+func derefFloat(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
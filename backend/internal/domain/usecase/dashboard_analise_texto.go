@@ -0,0 +1,124 @@
+// Package usecase implementa a consulta da análise de texto nos dashboards.
+// Fornece o sentimento, as palavras-chave e os tópicos das respostas abertas, filtrados pelo mínimo de respondentes.
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// Situação da análise de texto informada pelo dashboard
+const (
+	AnaliseTextoAguardando = "aguardando_encerramento" // Pesquisa ainda recebe respostas
+	AnaliseTextoPendente   = "pendente"                // Pesquisa encerrada, análise ainda não executada pelo agendador
+	AnaliseTextoConcluida  = "concluida"               // Todas as perguntas abertas analisadas
+)
+
+// SetAnaliseTexto habilita a consulta da análise das respostas abertas no dashboard
+func (uc *DashboardUseCase) SetAnaliseTexto(analiseTextoRepo repository.AnaliseTextoRepository) {
+	uc.analiseTextoRepo = analiseTextoRepo
+}
+
+// GetAnaliseTexto retorna sentimento, palavras-chave e tópicos das perguntas abertas da pesquisa do dashboard
+// O mínimo de respondentes vigente é reaplicado: perguntas, termos e tópicos abaixo dele não são exibidos
+func (uc *DashboardUseCase) GetAnaliseTexto(ctx context.Context, dashboardID int) (map[string]interface{}, error) {
+	if dashboardID <= 0 {
+		return nil, fmt.Errorf("ID do dashboard inválido")
+	}
+	if uc.analiseTextoRepo == nil {
+		return nil, fmt.Errorf("análise de texto não configurada")
+	}
+
+	dashboard, pesquisa, err := uc.getInScope(ctx, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, dashboard.IDPesquisa)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	analises, err := uc.analiseTextoRepo.ListByPesquisa(ctx, dashboard.IDPesquisa)
+	if err != nil {
+		return nil, err
+	}
+	porPergunta := make(map[int]*entity.AnaliseTexto, len(analises))
+	for _, analise := range analises {
+		porPergunta[analise.IDPergunta] = analise
+	}
+
+	status := AnaliseTextoConcluida
+	if pesquisa.Status != "Concluída" && pesquisa.Status != "Arquivada" {
+		status = AnaliseTextoAguardando
+	}
+
+	resultado := make([]map[string]interface{}, 0)
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		if pergunta.TipoPergunta != "RespostaAberta" {
+			continue
+		}
+
+		analise, ok := porPergunta[pergunta.ID]
+		if !ok && status == AnaliseTextoConcluida {
+			status = AnaliseTextoPendente
+		}
+
+		item := map[string]interface{}{
+			"pergunta_id":    pergunta.ID,
+			"texto_pergunta": pergunta.TextoPergunta,
+			"analise":        nil,
+		}
+		if ok && analise.Suprimido(k) {
+			item["supressao"] = novaSupressao(k)
+		} else if ok {
+			item["analise"] = dadosAnaliseTexto(analise, k)
+		}
+		resultado = append(resultado, item)
+	}
+
+	return map[string]interface{}{
+		"dashboard_id": dashboardID,
+		"pesquisa_id":  dashboard.IDPesquisa,
+		"status":       status,
+		"perguntas":    resultado,
+	}, nil
+}
+
+// dadosAnaliseTexto formata a análise de uma pergunta aberta
+// Termos e tópicos citados por menos de k respostas são removidos, e as respostas desses tópicos passam a contar como sem tópico
+func dadosAnaliseTexto(analise *entity.AnaliseTexto, k int) map[string]interface{} {
+	palavras := make([]entity.PalavraChave, 0, len(analise.PalavrasChave))
+	for _, palavra := range analise.PalavrasChave {
+		if palavra.Respostas >= k {
+			palavras = append(palavras, palavra)
+		}
+	}
+
+	topicos := make([]entity.TopicoTexto, 0, len(analise.Topicos))
+	semTopico := analise.SemTopico
+	for _, topico := range analise.Topicos {
+		if topico.Respostas < k {
+			semTopico += topico.Respostas
+			continue
+		}
+		topicos = append(topicos, topico)
+	}
+
+	return map[string]interface{}{
+		"respostas":      analise.Respostas,
+		"sentimento":     analise.Sentimento,
+		"palavras_chave": palavras,
+		"topicos":        topicos,
+		"sem_topico":     semTopico,
+		"data_analise":   analise.DataAnalise,
+	}
+}
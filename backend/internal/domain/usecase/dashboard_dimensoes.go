@@ -0,0 +1,242 @@
+// Package usecase implementa a pontuação das dimensões nos dashboards.
+// Fornece a média e o percentual favorável por dimensão do clima, com o detalhamento por setor.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/report"
+)
+
+// resultadoDimensao é a pontuação de uma dimensão na pesquisa e em cada setor da empresa
+type resultadoDimensao struct {
+	Dimensao  *entity.Dimensao
+	Perguntas []int
+	Pontuacao entity.PontuacaoDimensao
+	PorSetor  []dimensaoSetor // Preenchido apenas com a segmentação por setor habilitada
+}
+
+// dimensaoSetor é a pontuação de uma dimensão em um setor; Pontuacao é nil quando nenhuma pesquisa do setor avalia a dimensão
+type dimensaoSetor struct {
+	Setor      *entity.Setor
+	PesquisaID int
+	Referencia bool
+	Pontuacao  *entity.PontuacaoDimensao
+}
+
+// SetDimensoes habilita a pontuação por dimensão nos dados e nos relatórios do dashboard
+// Sem esta configuração as perguntas são analisadas apenas individualmente
+func (uc *DashboardUseCase) SetDimensoes(dimensaoRepo repository.DimensaoRepository) {
+	uc.dimensaoRepo = dimensaoRepo
+}
+
+// calcularDimensoes calcula a pontuação de cada dimensão avaliada pelas perguntas da pesquisa
+// Retorna nil quando as dimensões não estão configuradas ou nenhuma pergunta tem dimensão
+func (uc *DashboardUseCase) calcularDimensoes(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta) ([]*resultadoDimensao, error) {
+	if uc.dimensaoRepo == nil {
+		return nil, nil
+	}
+
+	porDimensao := perguntasPorDimensao(perguntas)
+	if len(porDimensao) == 0 {
+		return nil, nil
+	}
+
+	dimensoes, err := uc.dimensaoRepo.ListByEmpresa(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar dimensões: %v", err)
+	}
+
+	valores, err := uc.respostaRepo.GetValoresBySubmissao(ctx, pesquisa.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas: %v", err)
+	}
+
+	var resultados []*resultadoDimensao
+	for _, dimensao := range dimensoes {
+		perguntasDimensao, ok := porDimensao[dimensao.ID]
+		if !ok {
+			continue
+		}
+		ids := make([]int, len(perguntasDimensao))
+		for i, pergunta := range perguntasDimensao {
+			ids[i] = pergunta.ID
+		}
+		resultados = append(resultados, &resultadoDimensao{
+			Dimensao:  dimensao,
+			Perguntas: ids,
+			Pontuacao: entity.CalcularPontuacaoDimensao(perguntasDimensao, valores),
+		})
+	}
+
+	if uc.setorRepo != nil && len(resultados) > 0 {
+		if err := uc.dimensoesPorSetor(ctx, pesquisa, perguntas, resultados); err != nil {
+			return nil, err
+		}
+	}
+
+	return resultados, nil
+}
+
+// dimensoesPorSetor preenche a pontuação das dimensões em cada setor da empresa
+// O setor da pesquisa usa a própria pesquisa; os demais, a pesquisa aberta mais recente do setor com perguntas nas dimensões
+func (uc *DashboardUseCase) dimensoesPorSetor(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, resultados []*resultadoDimensao) error {
+	avaliadas := make(map[int]bool, len(resultados))
+	for _, resultado := range resultados {
+		avaliadas[resultado.Dimensao.ID] = true
+	}
+
+	referencias, err := uc.referenciasPorSetor(ctx, pesquisa, perguntas, func(perguntas []*entity.Pergunta) bool {
+		for dimensaoID := range perguntasPorDimensao(perguntas) {
+			if avaliadas[dimensaoID] {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	// A própria pesquisa já foi pontuada; as demais são carregadas em uma única consulta
+	valores, err := uc.respostaRepo.GetValoresBySubmissaoPesquisas(ctx, idsReferencias(referencias, pesquisa.ID))
+	if err != nil {
+		return fmt.Errorf("erro ao buscar respostas: %v", err)
+	}
+
+	for _, referencia := range referencias {
+		if referencia.Referencia {
+			for _, resultado := range resultados {
+				pontuacao := resultado.Pontuacao
+				resultado.PorSetor = append(resultado.PorSetor, dimensaoSetor{
+					Setor: referencia.Setor, PesquisaID: pesquisa.ID, Referencia: true, Pontuacao: &pontuacao,
+				})
+			}
+			continue
+		}
+
+		porDimensao := perguntasPorDimensao(referencia.Perguntas)
+		for _, resultado := range resultados {
+			item := dimensaoSetor{Setor: referencia.Setor}
+			if perguntasDimensao, ok := porDimensao[resultado.Dimensao.ID]; ok && referencia.Pesquisa != nil {
+				pontuacao := entity.CalcularPontuacaoDimensao(perguntasDimensao, valores)
+				item.PesquisaID = referencia.Pesquisa.ID
+				item.Pontuacao = &pontuacao
+			}
+			resultado.PorSetor = append(resultado.PorSetor, item)
+		}
+	}
+
+	return nil
+}
+
+// perguntasPorDimensao agrupa as perguntas com dimensão pelo ID da dimensão, em ordem de exibição
+func perguntasPorDimensao(perguntas []*entity.Pergunta) map[int][]*entity.Pergunta {
+	porDimensao := make(map[int][]*entity.Pergunta)
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		if pergunta.IDDimensao != nil {
+			porDimensao[*pergunta.IDDimensao] = append(porDimensao[*pergunta.IDDimensao], pergunta)
+		}
+	}
+	return porDimensao
+}
+
+// dadosDimensoes formata as pontuações das dimensões para os dados do dashboard
+func dadosDimensoes(resultados []*resultadoDimensao, k int) []map[string]interface{} {
+	if resultados == nil {
+		return nil
+	}
+
+	dados := make([]map[string]interface{}, 0, len(resultados))
+	for _, resultado := range resultados {
+		item := map[string]interface{}{
+			"id_dimensao":  resultado.Dimensao.ID,
+			"nome":         resultado.Dimensao.Nome,
+			"id_perguntas": resultado.Perguntas,
+			"pontuacao":    segmentoDimensao(resultado.Pontuacao, k),
+		}
+
+		if resultado.PorSetor != nil {
+			porSetor := make([]map[string]interface{}, 0, len(resultado.PorSetor))
+			for _, s := range resultado.PorSetor {
+				segmento := map[string]interface{}{
+					"id_setor":    s.Setor.ID,
+					"nome_setor":  s.Setor.NomeSetor,
+					"pesquisa_id": nil,
+					"referencia":  s.Referencia,
+					"pontuacao":   nil,
+				}
+				if s.Pontuacao != nil {
+					segmento["pesquisa_id"] = s.PesquisaID
+					segmento["pontuacao"] = segmentoDimensao(*s.Pontuacao, k)
+				}
+				porSetor = append(porSetor, segmento)
+			}
+			item["por_setor"] = porSetor
+		}
+
+		dados = append(dados, item)
+	}
+
+	return dados
+}
+
+// segmentoDimensao formata a pontuação da dimensão, omitindo os números de segmentos com menos de k respondentes
+func segmentoDimensao(pontuacao entity.PontuacaoDimensao, k int) map[string]interface{} {
+	if pontuacao.Suprimido(k) {
+		return map[string]interface{}{
+			"suprimido":           true,
+			"minimo_respondentes": k,
+			"motivo":              fmt.Sprintf(motivoPoucosRespondentes, k),
+		}
+	}
+
+	return map[string]interface{}{
+		"suprimido":            false,
+		"perguntas":            pontuacao.Perguntas,
+		"respondentes":         pontuacao.Respondentes,
+		"respostas":            pontuacao.Respostas,
+		"media":                pontuacao.Media,
+		"percentual_favoravel": pontuacao.PercentualFavoravel,
+	}
+}
+
+// buildDimensionSection lista a pontuação de cada dimensão na pesquisa e nos setores
+// Segmentos com menos de k respondentes aparecem sem resultados
+func buildDimensionSection(pesquisaID int, resultados []*resultadoDimensao, k int) report.Section {
+	section := report.Section{
+		Title:   "Dimensões",
+		Headers: []string{"Dimensão", "Segmento", "Pesquisa", "Respondentes", "Média (0-100)", "Favorável (%)"},
+	}
+
+	linha := func(nome, segmento string, pesquisaID int, pontuacao *entity.PontuacaoDimensao) []string {
+		if pontuacao == nil {
+			return []string{nome, segmento, "-", "(sem pesquisa com a dimensão)", "-", "-"}
+		}
+		if pontuacao.Suprimido(k) {
+			return []string{nome, segmento, strconv.Itoa(pesquisaID), "(suprimido: " + fmt.Sprintf(motivoPoucosRespondentes, k) + ")", "-", "-"}
+		}
+		return []string{
+			nome,
+			segmento,
+			strconv.Itoa(pesquisaID),
+			strconv.Itoa(pontuacao.Respondentes),
+			formatDecimal(pontuacao.Media),
+			formatDecimal(pontuacao.PercentualFavoravel),
+		}
+	}
+
+	for _, resultado := range resultados {
+		pontuacao := resultado.Pontuacao
+		section.Rows = append(section.Rows, linha(resultado.Dimensao.Nome, "Pesquisa", pesquisaID, &pontuacao))
+		for _, s := range resultado.PorSetor {
+			section.Rows = append(section.Rows, linha(resultado.Dimensao.Nome, "Setor: "+s.Setor.NomeSetor, s.PesquisaID, s.Pontuacao))
+		}
+	}
+
+	return section
+}
@@ -0,0 +1,283 @@
+// Package usecase implementa o eNPS dos dashboards.
+// Fornece o índice eNPS da pesquisa, o detalhamento por setor e a tendência entre ciclos.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// SetSegmentacao habilita o detalhamento das métricas por setor e a tendência entre ciclos de pesquisas recorrentes
+// Sem esta configuração GetDashboardMetrics traz apenas o eNPS da própria pesquisa
+func (uc *DashboardUseCase) SetSegmentacao(setorRepo repository.SetorRepository, cicloRepo repository.PesquisaCicloRepository) {
+	uc.setorRepo = setorRepo
+	uc.cicloRepo = cicloRepo
+}
+
+// SetClonagem habilita a tendência do eNPS entre a pesquisa original e suas cópias quando a pesquisa não é recorrente
+func (uc *DashboardUseCase) SetClonagem(cloneRepo repository.PesquisaCloneRepository) {
+	uc.cloneRepo = cloneRepo
+}
+
+// buildENPSMetrics monta o eNPS da pesquisa, por setor e ao longo dos ciclos
+// Retorna nil quando a pesquisa não tem pergunta de eNPS; segmentos com menos de k respondentes são suprimidos
+func (uc *DashboardUseCase) buildENPSMetrics(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, k int) (map[string]interface{}, error) {
+	pergunta := perguntaENPS(perguntas)
+	if pergunta == nil {
+		return nil, nil
+	}
+
+	distribuicao, err := uc.respostaRepo.GetAggregatedByPergunta(ctx, pergunta.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas de eNPS: %v", err)
+	}
+	resultado := entity.CalcularENPS(distribuicao)
+
+	metrics := segmentoENPS(&resultado, k)
+	metrics["pergunta_id"] = pergunta.ID
+
+	if uc.setorRepo != nil {
+		porSetor, err := uc.enpsPorSetor(ctx, pesquisa, perguntas, k)
+		if err != nil {
+			return nil, err
+		}
+		metrics["por_setor"] = porSetor
+	}
+
+	if uc.cicloRepo != nil || uc.cloneRepo != nil {
+		tendencia, err := uc.enpsTendencia(ctx, pesquisa, k)
+		if err != nil {
+			return nil, err
+		}
+		metrics["tendencia"] = tendencia
+	}
+
+	return metrics, nil
+}
+
+// perguntaENPS retorna a primeira pergunta de eNPS na ordem de exibição, ou nil
+func perguntaENPS(perguntas []*entity.Pergunta) *entity.Pergunta {
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		if entity.PerguntaENPS(pergunta) {
+			return pergunta
+		}
+	}
+	return nil
+}
+
+// enpsDaPesquisa calcula o eNPS da primeira pergunta de eNPS da pesquisa (na ordem de exibição)
+func (uc *DashboardUseCase) enpsDaPesquisa(ctx context.Context, pesquisaID int) (*entity.Pergunta, *entity.ResultadoENPS, error) {
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	pergunta := perguntaENPS(perguntas)
+	if pergunta == nil {
+		return nil, nil, nil
+	}
+
+	distribuicao, err := uc.respostaRepo.GetAggregatedByPergunta(ctx, pergunta.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao buscar respostas de eNPS: %v", err)
+	}
+	resultado := entity.CalcularENPS(distribuicao)
+	return pergunta, &resultado, nil
+}
+
+// enpsPorSetor calcula o eNPS de cada setor da empresa
+// O setor da pesquisa usa a própria pesquisa; os demais, a pesquisa aberta mais recente do setor com pergunta de eNPS
+func (uc *DashboardUseCase) enpsPorSetor(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, k int) ([]map[string]interface{}, error) {
+	referencias, err := uc.referenciasPorSetor(ctx, pesquisa, perguntas, func(perguntas []*entity.Pergunta) bool {
+		return perguntaENPS(perguntas) != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	agregados, err := uc.respostaRepo.GetAggregatedByPesquisas(ctx, idsReferencias(referencias, 0))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas de eNPS: %v", err)
+	}
+
+	resultado := make([]map[string]interface{}, 0, len(referencias))
+	for _, referencia := range referencias {
+		item := map[string]interface{}{
+			"id_setor":    referencia.Setor.ID,
+			"nome_setor":  referencia.Setor.NomeSetor,
+			"pesquisa_id": nil,
+			"referencia":  referencia.Referencia,
+			"enps":        nil,
+		}
+
+		if pergunta := perguntaENPS(referencia.Perguntas); referencia.Pesquisa != nil && pergunta != nil {
+			enps := entity.CalcularENPS(agregados[pergunta.ID])
+			item["pesquisa_id"] = referencia.Pesquisa.ID
+			item["enps"] = segmentoENPS(&enps, k)
+		}
+
+		resultado = append(resultado, item)
+	}
+
+	return resultado, nil
+}
+
+// enpsTendencia calcula o eNPS de cada aplicação da série da pesquisa (ver serieAplicacoes), em ordem cronológica
+// Pesquisas sem ciclos nem cópias resultam em um único ponto; a variação compara com o último ponto não suprimido
+func (uc *DashboardUseCase) enpsTendencia(ctx context.Context, pesquisa *entity.Pesquisa, k int) ([]map[string]interface{}, error) {
+	serie, err := uc.serieAplicacoes(ctx, pesquisa)
+	if err != nil {
+		return nil, err
+	}
+
+	tendencia := make([]map[string]interface{}, 0, len(serie))
+	var anterior *entity.ResultadoENPS
+	for _, c := range serie {
+		p := pesquisa
+		if c.IDPesquisa != pesquisa.ID {
+			if p, err = uc.pesquisaRepo.GetByID(ctx, c.IDPesquisa); err != nil {
+				continue // Pesquisa do ciclo removida
+			}
+		}
+		if p.Status == "Rascunho" {
+			continue
+		}
+
+		pergunta, enps, err := uc.enpsDaPesquisa(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		if pergunta == nil {
+			continue
+		}
+
+		ponto := map[string]interface{}{
+			"pesquisa_id":     p.ID,
+			"titulo":          p.Titulo,
+			"numero_ciclo":    c.NumeroCiclo,
+			"data_referencia": dataReferencia(p),
+			"atual":           p.ID == pesquisa.ID,
+			"enps":            segmentoENPS(enps, k),
+			"variacao":        nil,
+		}
+		if !enps.Suprimido(k) {
+			if anterior != nil {
+				ponto["variacao"] = math.Round((enps.Score-anterior.Score)*100) / 100
+			}
+			anterior = enps
+		}
+		tendencia = append(tendencia, ponto)
+	}
+
+	return tendencia, nil
+}
+
+// serieAplicacoes retorna as aplicações comparáveis da pesquisa, a original como ciclo 1
+// Os ciclos de recorrência têm prioridade; sem recorrência, a série segue a cadeia de clonagem
+func (uc *DashboardUseCase) serieAplicacoes(ctx context.Context, pesquisa *entity.Pesquisa) ([]*entity.CicloPesquisa, error) {
+	if uc.cicloRepo != nil {
+		origemID := pesquisa.ID
+		ciclo, err := uc.cicloRepo.GetByPesquisa(ctx, pesquisa.ID)
+		if err != nil && !strings.Contains(err.Error(), "não encontrad") {
+			return nil, err
+		}
+		if err == nil && ciclo.IDPesquisaOrigem > 0 {
+			origemID = ciclo.IDPesquisaOrigem
+		}
+
+		ciclos, err := uc.cicloRepo.ListByOrigem(ctx, origemID)
+		if err != nil {
+			return nil, err
+		}
+		if origemID != pesquisa.ID || len(ciclos) > 0 || uc.cloneRepo == nil {
+			return append([]*entity.CicloPesquisa{{IDPesquisa: origemID, NumeroCiclo: 1}}, ciclos...), nil
+		}
+	}
+
+	return uc.serieClonagem(ctx, pesquisa.ID)
+}
+
+// serieClonagem sobe pela cadeia de clonagem até a pesquisa original e retorna ela e todas as suas cópias (diretas ou
+// de cópias), em ordem de criação
+func (uc *DashboardUseCase) serieClonagem(ctx context.Context, pesquisaID int) ([]*entity.CicloPesquisa, error) {
+	raiz := pesquisaID
+	vistas := map[int]bool{raiz: true}
+	for {
+		clone, err := uc.cloneRepo.GetByPesquisa(ctx, raiz)
+		if err != nil {
+			if strings.Contains(err.Error(), "não encontrad") {
+				break
+			}
+			return nil, err
+		}
+		if clone.IDPesquisaOrigem == 0 || vistas[clone.IDPesquisaOrigem] {
+			break // Origem removida
+		}
+		raiz = clone.IDPesquisaOrigem
+		vistas[raiz] = true
+	}
+
+	var copias []*entity.ClonePesquisa
+	visitadas := map[int]bool{raiz: true}
+	for fila := []int{raiz}; len(fila) > 0; fila = fila[1:] {
+		clones, err := uc.cloneRepo.ListByOrigem(ctx, fila[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, clone := range clones {
+			if !visitadas[clone.IDPesquisa] {
+				visitadas[clone.IDPesquisa] = true
+				copias = append(copias, clone)
+				fila = append(fila, clone.IDPesquisa)
+			}
+		}
+	}
+
+	sort.SliceStable(copias, func(i, j int) bool {
+		return copias[i].DataCriacao.Before(copias[j].DataCriacao)
+	})
+
+	serie := []*entity.CicloPesquisa{{IDPesquisa: raiz, NumeroCiclo: 1}}
+	for i, copia := range copias {
+		serie = append(serie, &entity.CicloPesquisa{IDPesquisa: copia.IDPesquisa, NumeroCiclo: i + 2})
+	}
+	return serie, nil
+}
+
+// segmentoENPS formata o resultado do eNPS, omitindo os números de segmentos com menos de k respondentes
+func segmentoENPS(resultado *entity.ResultadoENPS, k int) map[string]interface{} {
+	if resultado.Suprimido(k) {
+		return map[string]interface{}{
+			"suprimido":           true,
+			"minimo_respondentes": k,
+			"motivo":              fmt.Sprintf(motivoPoucosRespondentes, k),
+		}
+	}
+
+	return map[string]interface{}{
+		"suprimido":             false,
+		"respondentes":          resultado.Respondentes,
+		"promotores":            resultado.Promotores,
+		"neutros":               resultado.Neutros,
+		"detratores":            resultado.Detratores,
+		"percentual_promotores": resultado.PercentualPromotores,
+		"percentual_neutros":    resultado.PercentualNeutros,
+		"percentual_detratores": resultado.PercentualDetratores,
+		"score":                 resultado.Score,
+	}
+}
+
+// dataReferencia é a data de abertura da pesquisa ou, sem ela, a de criação
+func dataReferencia(pesquisa *entity.Pesquisa) time.Time {
+	if pesquisa.DataAbertura != nil {
+		return *pesquisa.DataAbertura
+	}
+	return pesquisa.DataCriacao
+}
@@ -0,0 +1,165 @@
+// Package usecase implementa a favorabilidade das perguntas nos dashboards.
+// Fornece o mapa de calor do percentual favorável por pergunta e setor usado nos relatórios.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/pkg/report"
+)
+
+// mapaFavorabilidade é o percentual favorável de cada pergunta de escala da pesquisa em cada setor da empresa
+type mapaFavorabilidade struct {
+	Setores []colunaFavorabilidade
+	Linhas  []linhaFavorabilidade
+}
+
+// colunaFavorabilidade é um setor do mapa e a pesquisa usada para ele (PesquisaID 0 quando nenhuma tem as perguntas)
+type colunaFavorabilidade struct {
+	Setor      *entity.Setor
+	PesquisaID int
+}
+
+// linhaFavorabilidade é uma pergunta do mapa; Celulas segue a ordem de Setores e é nil onde o setor não tem a pergunta
+type linhaFavorabilidade struct {
+	Pergunta *entity.Pergunta
+	Celulas  []*celulaFavorabilidade
+}
+
+// favorabilidadeVisivel retorna a favorabilidade ou, com menos de k respostas válidas, a descrição da supressão
+func favorabilidadeVisivel(favorabilidade entity.Favorabilidade, k int) interface{} {
+	if favorabilidade.Suprimido(k) {
+		return novaSupressao(k)
+	}
+	return favorabilidade
+}
+
+// celulaFavorabilidade é o resultado de uma pergunta em um setor
+type celulaFavorabilidade struct {
+	Respondentes   int // Respostas gravadas, incluindo "Não se aplica"
+	Favorabilidade entity.Favorabilidade
+}
+
+// perguntaComFavorabilidade indica se a pergunta entra no mapa de favorabilidade (escalas, exceto eNPS)
+func perguntaComFavorabilidade(pergunta *entity.Pergunta) bool {
+	return pergunta.TipoPergunta == entity.TipoEscalaNumerica && !entity.PerguntaENPS(pergunta)
+}
+
+// chavePergunta identifica a mesma pergunta em pesquisas diferentes do setor pelo texto, sem diferenciar maiúsculas e espaços
+func chavePergunta(pergunta *entity.Pergunta) string {
+	return strings.ToLower(strings.Join(strings.Fields(pergunta.TextoPergunta), " "))
+}
+
+// calcularMapaFavorabilidade cruza as perguntas de escala da pesquisa com os setores da empresa
+// O setor da pesquisa usa a própria pesquisa; os demais, a pesquisa aberta mais recente do setor com alguma das perguntas.
+// Retorna nil sem a segmentação por setor habilitada ou sem perguntas de escala
+func (uc *DashboardUseCase) calcularMapaFavorabilidade(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta) (*mapaFavorabilidade, error) {
+	if uc.setorRepo == nil {
+		return nil, nil
+	}
+
+	mapa := &mapaFavorabilidade{}
+	chaves := make(map[string]bool)
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		if perguntaComFavorabilidade(pergunta) && !chaves[chavePergunta(pergunta)] {
+			chaves[chavePergunta(pergunta)] = true
+			mapa.Linhas = append(mapa.Linhas, linhaFavorabilidade{Pergunta: pergunta})
+		}
+	}
+	if len(mapa.Linhas) == 0 {
+		return nil, nil
+	}
+
+	referencias, err := uc.referenciasPorSetor(ctx, pesquisa, perguntas, func(perguntas []*entity.Pergunta) bool {
+		return len(perguntasPorChave(perguntas, chaves)) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	agregados, err := uc.respostaRepo.GetAggregatedByPesquisas(ctx, idsReferencias(referencias, 0))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas agregadas: %v", err)
+	}
+
+	for _, referencia := range referencias {
+		coluna := colunaFavorabilidade{Setor: referencia.Setor}
+		var porChave map[string]*entity.Pergunta
+		if referencia.Pesquisa != nil {
+			coluna.PesquisaID = referencia.Pesquisa.ID
+			porChave = perguntasPorChave(referencia.Perguntas, chaves)
+		}
+		mapa.Setores = append(mapa.Setores, coluna)
+
+		for i := range mapa.Linhas {
+			var celula *celulaFavorabilidade
+			if pergunta, ok := porChave[chavePergunta(mapa.Linhas[i].Pergunta)]; ok {
+				celula = &celulaFavorabilidade{
+					Respondentes:   sumCounts(agregados[pergunta.ID]),
+					Favorabilidade: entity.CalcularFavorabilidade(pergunta, agregados[pergunta.ID]),
+				}
+			}
+			mapa.Linhas[i].Celulas = append(mapa.Linhas[i].Celulas, celula)
+		}
+	}
+
+	if len(mapa.Setores) == 0 {
+		return nil, nil
+	}
+	return mapa, nil
+}
+
+// perguntasPorChave indexa as perguntas de escala cuja chave está entre as procuradas (a primeira na ordem de exibição)
+func perguntasPorChave(perguntas []*entity.Pergunta, chaves map[string]bool) map[string]*entity.Pergunta {
+	porChave := make(map[string]*entity.Pergunta)
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		chave := chavePergunta(pergunta)
+		if _, existe := porChave[chave]; !existe && chaves[chave] && perguntaComFavorabilidade(pergunta) {
+			porChave[chave] = pergunta
+		}
+	}
+	return porChave
+}
+
+// buildFavorabilityHeatMapSection monta o mapa de calor perguntas × setores com o percentual favorável
+// Células com menos de k respostas válidas (sem "Não se aplica") são suprimidas ("*"); "-" indica setor sem a pergunta ou sem respostas
+func buildFavorabilityHeatMapSection(mapa *mapaFavorabilidade, k int) report.Section {
+	section := report.Section{
+		Title:   "Mapa de calor (favorável %)",
+		Headers: []string{"Ordem", "Pergunta"},
+		HeatMap: &report.HeatMap{FirstColumn: 2, Min: 0, Max: 100},
+	}
+
+	referencias := []string{"", "Pesquisa utilizada"}
+	for _, coluna := range mapa.Setores {
+		section.Headers = append(section.Headers, coluna.Setor.NomeSetor)
+		referencia := "-"
+		if coluna.PesquisaID != 0 {
+			referencia = "#" + strconv.Itoa(coluna.PesquisaID)
+		}
+		referencias = append(referencias, referencia)
+	}
+	section.Rows = append(section.Rows, referencias)
+
+	for _, linha := range mapa.Linhas {
+		row := []string{strconv.Itoa(linha.Pergunta.OrdemExibicao), linha.Pergunta.TextoPergunta}
+		for _, celula := range linha.Celulas {
+			switch {
+			case celula == nil || celula.Respondentes == 0:
+				row = append(row, "-")
+			case celula.Favorabilidade.Suprimido(k):
+				row = append(row, "*")
+			default:
+				row = append(row, formatDecimal(celula.Favorabilidade.PercentualFavoravel))
+			}
+		}
+		section.Rows = append(section.Rows, row)
+	}
+
+	section.Rows = append(section.Rows, []string{"", "* " + fmt.Sprintf(motivoPoucosRespondentes, k) + "; - setor sem a pergunta ou sem respostas"})
+	return section
+}
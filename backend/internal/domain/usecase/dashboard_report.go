@@ -0,0 +1,317 @@
+// Package usecase implementa a geração dos relatórios de dashboards.
+// Fornece a montagem das seções exportadas em CSV, XLSX e PDF a partir dos resultados agregados.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/pkg/report"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// GenerateReport gera o arquivo de relatório do dashboard no formato solicitado (pdf, xlsx ou csv)
+// O relatório contém participação, médias e favorabilidade das escalas numéricas, pontuação das dimensões,
+// mapa de calor da favorabilidade por setor e distribuição das respostas por pergunta
+func (uc *DashboardUseCase) GenerateReport(ctx context.Context, dashboardID int, format string, userAdminID int, enderecoIP string) (*report.File, error) {
+	if dashboardID <= 0 {
+		return nil, fmt.Errorf("ID do dashboard inválido")
+	}
+
+	reportFormat, err := report.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verifica se dashboard existe e pertence à empresa
+	dashboard, pesquisa, err := uc.getInScope(ctx, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pesquisa.Status == "Rascunho" {
+		return nil, fmt.Errorf("não é possível gerar relatório de pesquisa em rascunho")
+	}
+
+	doc, err := uc.buildReportDocument(ctx, dashboard, pesquisa)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := report.Build(doc, reportFormat, fmt.Sprintf("relatorio_%s_%s", pesquisa.Titulo, time.Now().Format("20060102")))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar relatório: %v", err)
+	}
+
+	// Log de auditoria
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Relatório Gerado",
+			Detalhes:      fmt.Sprintf("Relatório gerado (%s) do dashboard: %s (ID: %d)", reportFormat, dashboard.Titulo, dashboard.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return file, nil
+}
+
+// buildReportDocument monta as seções do relatório a partir dos dados agregados da pesquisa
+func (uc *DashboardUseCase) buildReportDocument(ctx context.Context, dashboard *entity.Dashboard, pesquisa *entity.Pesquisa) (*report.Document, error) {
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, pesquisa.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	sort.SliceStable(perguntas, func(i, j int) bool {
+		return perguntas[i].OrdemExibicao < perguntas[j].OrdemExibicao
+	})
+
+	agregados, err := uc.respostaRepo.GetAggregatedByPesquisa(ctx, pesquisa.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar respostas agregadas: %v", err)
+	}
+
+	// Perguntas com menos respondentes que o mínimo da empresa aparecem sem resultados
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	suprimidas := suprimirPerguntas(agregados, k)
+	agregarSelecoesPorPergunta(perguntas, agregados)
+
+	totalRespostas, err := uc.respostaRepo.CountByPesquisa(ctx, pesquisa.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contar respostas: %v", err)
+	}
+
+	participacao, err := uc.buildParticipationSection(ctx, pesquisa, len(perguntas), totalRespostas)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []report.Section{
+		participacao,
+		buildLikertSection(perguntas, agregados, suprimidas, k),
+	}
+
+	// Seção de dimensões apenas quando a pesquisa tem perguntas com dimensão
+	dimensoes, err := uc.calcularDimensoes(ctx, pesquisa, perguntas)
+	if err != nil {
+		return nil, err
+	}
+	if len(dimensoes) > 0 {
+		sections = append(sections, buildDimensionSection(pesquisa.ID, dimensoes, k))
+	}
+
+	// Mapa de calor apenas com a segmentação por setor habilitada e perguntas de escala na pesquisa
+	mapa, err := uc.calcularMapaFavorabilidade(ctx, pesquisa, perguntas)
+	if err != nil {
+		return nil, err
+	}
+	if mapa != nil {
+		sections = append(sections, buildFavorabilityHeatMapSection(mapa, k))
+	}
+
+	sections = append(sections, buildDistributionSection(perguntas, agregados, suprimidas))
+
+	return &report.Document{
+		Title:    fmt.Sprintf("Relatório: %s", dashboard.Titulo),
+		Subtitle: fmt.Sprintf("Pesquisa \"%s\" - gerado em %s", pesquisa.Titulo, time.Now().Format("02/01/2006 15:04")),
+		Sections: sections,
+	}, nil
+}
+
+// buildParticipationSection resume período, tokens emitidos e taxa de conclusão da pesquisa
+func (uc *DashboardUseCase) buildParticipationSection(ctx context.Context, pesquisa *entity.Pesquisa, totalPerguntas, totalRespostas int) (report.Section, error) {
+	submissoes, err := uc.submissaoRepo.ListByPesquisa(ctx, pesquisa.ID)
+	if err != nil {
+		return report.Section{}, fmt.Errorf("erro ao buscar submissões: %v", err)
+	}
+
+	completas := 0
+	for _, s := range submissoes {
+		if s.Status == "completa" {
+			completas++
+		}
+	}
+
+	taxaConclusao := 0.0
+	if len(submissoes) > 0 {
+		taxaConclusao = float64(completas) / float64(len(submissoes)) * 100
+	}
+
+	return report.Section{
+		Title:   "Participação",
+		Headers: []string{"Indicador", "Valor"},
+		Rows: [][]string{
+			{"Pesquisa", pesquisa.Titulo},
+			{"Status", pesquisa.Status},
+			{"Período", formatPeriodo(pesquisa.DataAbertura, pesquisa.DataFechamento)},
+			{"Tokens emitidos", strconv.Itoa(len(submissoes))},
+			{"Submissões completas", strconv.Itoa(completas)},
+			{"Taxa de conclusão (%)", formatDecimal(taxaConclusao)},
+			{"Total de perguntas", strconv.Itoa(totalPerguntas)},
+			{"Total de respostas", strconv.Itoa(totalRespostas)},
+		},
+	}, nil
+}
+
+// buildLikertSection calcula a média de cada pergunta de escala numérica, também normalizada para 0-100,
+// e a divisão das respostas entre as faixas de favorabilidade (exceto eNPS), omitida com menos de k respostas válidas
+func buildLikertSection(perguntas []*entity.Pergunta, agregados map[int]map[string]int, suprimidas map[int]*Supressao, k int) report.Section {
+	section := report.Section{
+		Title:   "Médias (escala)",
+		Headers: []string{"Ordem", "Pergunta", "Escala", "Respostas", "Média", "Média (0-100)", "Favorável (%)", "Neutro (%)", "Desfavorável (%)"},
+	}
+
+	for _, pergunta := range perguntas {
+		if pergunta.TipoPergunta != entity.TipoEscalaNumerica {
+			continue
+		}
+
+		escala := entity.EscalaDaPergunta(pergunta)
+		faixa := strconv.FormatFloat(escala.Min, 'f', -1, 64) + " a " + strconv.FormatFloat(escala.Max, 'f', -1, 64)
+		if supressao, ok := suprimidas[pergunta.ID]; ok {
+			section.Rows = append(section.Rows, []string{
+				strconv.Itoa(pergunta.OrdemExibicao), pergunta.TextoPergunta, faixa, "(suprimido: " + supressao.Motivo + ")", "-", "-", "-", "-", "-",
+			})
+			continue
+		}
+
+		distribuicao := agregados[pergunta.ID]
+		media, normalizada := "-", "-"
+		if valor, validas := escala.Media(distribuicao); validas > 0 {
+			media = formatDecimal(valor)
+			normalizada = formatDecimal(escala.Normalizar(valor))
+		}
+
+		favoravel, neutro, desfavoravel := "-", "-", "-"
+		if perguntaComFavorabilidade(pergunta) {
+			switch f := entity.CalcularFavorabilidade(pergunta, distribuicao); {
+			case f.Respostas == 0:
+			case f.Suprimido(k):
+				favoravel = "(suprimido: " + novaSupressao(k).Motivo + ")"
+			default:
+				favoravel = formatDecimal(f.PercentualFavoravel)
+				neutro = formatDecimal(f.PercentualNeutro)
+				desfavoravel = formatDecimal(f.PercentualDesfavoravel)
+			}
+		}
+
+		section.Rows = append(section.Rows, []string{
+			strconv.Itoa(pergunta.OrdemExibicao),
+			pergunta.TextoPergunta,
+			faixa,
+			strconv.Itoa(sumCounts(distribuicao)),
+			media,
+			normalizada,
+			favoravel,
+			neutro,
+			desfavoravel,
+		})
+	}
+
+	return section
+}
+
+// buildDistributionSection lista a frequência de cada valor de resposta por pergunta
+// Respostas abertas não são listadas individualmente, apenas contabilizadas
+func buildDistributionSection(perguntas []*entity.Pergunta, agregados map[int]map[string]int, suprimidas map[int]*Supressao) report.Section {
+	section := report.Section{
+		Title:   "Distribuição",
+		Headers: []string{"Ordem", "Pergunta", "Tipo", "Resposta", "Quantidade", "Percentual (%)"},
+	}
+
+	for _, pergunta := range perguntas {
+		distribuicao := agregados[pergunta.ID]
+		total := sumCounts(distribuicao)
+		ordem := strconv.Itoa(pergunta.OrdemExibicao)
+
+		if supressao, ok := suprimidas[pergunta.ID]; ok {
+			section.Rows = append(section.Rows, []string{
+				ordem, pergunta.TextoPergunta, pergunta.TipoPergunta, "(suprimido: " + supressao.Motivo + ")", "-", "",
+			})
+			continue
+		}
+
+		if pergunta.TipoPergunta == "RespostaAberta" {
+			section.Rows = append(section.Rows, []string{
+				ordem, pergunta.TextoPergunta, pergunta.TipoPergunta, "(respostas abertas)", strconv.Itoa(total), "",
+			})
+			continue
+		}
+
+		if total == 0 {
+			section.Rows = append(section.Rows, []string{
+				ordem, pergunta.TextoPergunta, pergunta.TipoPergunta, "(sem respostas)", "0", "",
+			})
+			continue
+		}
+
+		for _, valor := range sortedValues(pergunta.TipoPergunta, distribuicao) {
+			count := distribuicao[valor]
+			section.Rows = append(section.Rows, []string{
+				ordem,
+				pergunta.TextoPergunta,
+				pergunta.TipoPergunta,
+				valor,
+				strconv.Itoa(count),
+				formatDecimal(float64(count) / float64(total) * 100),
+			})
+		}
+	}
+
+	return section
+}
+
+// sortedValues ordena os valores numericamente nas escalas e por frequência nos demais tipos
+func sortedValues(tipoPergunta string, distribuicao map[string]int) []string {
+	valores := make([]string, 0, len(distribuicao))
+	for valor := range distribuicao {
+		valores = append(valores, valor)
+	}
+
+	sort.Slice(valores, func(i, j int) bool {
+		if tipoPergunta == "EscalaNumerica" {
+			vi, errI := strconv.ParseFloat(valores[i], 64)
+			vj, errJ := strconv.ParseFloat(valores[j], 64)
+			if errI == nil && errJ == nil {
+				return vi < vj
+			}
+		}
+		if distribuicao[valores[i]] != distribuicao[valores[j]] {
+			return distribuicao[valores[i]] > distribuicao[valores[j]]
+		}
+		return valores[i] < valores[j]
+	})
+
+	return valores
+}
+
+func sumCounts(distribuicao map[string]int) int {
+	total := 0
+	for _, count := range distribuicao {
+		total += count
+	}
+	return total
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatPeriodo(abertura, fechamento *time.Time) string {
+	inicio, fim := "-", "-"
+	if abertura != nil {
+		inicio = abertura.Format("02/01/2006")
+	}
+	if fechamento != nil {
+		fim = fechamento.Format("02/01/2006")
+	}
+	return fmt.Sprintf("%s a %s", inicio, fim)
+}
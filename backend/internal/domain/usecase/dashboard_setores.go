@@ -0,0 +1,89 @@
+// Package usecase implementa a escolha das pesquisas comparadas entre setores nos dashboards.
+// Fornece a pesquisa de referência de cada setor usada pelo eNPS, pelas dimensões e pela favorabilidade.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+)
+
+// referenciaSetor é a pesquisa usada para um setor da empresa nas comparações entre setores
+type referenciaSetor struct {
+	Setor      *entity.Setor
+	Pesquisa   *entity.Pesquisa   // nil quando nenhuma pesquisa do setor atende ao critério
+	Perguntas  []*entity.Pergunta // Perguntas da pesquisa de referência
+	Referencia bool               // Setor da própria pesquisa do dashboard
+}
+
+// referenciasPorSetor escolhe a pesquisa de cada setor da empresa
+// O setor da pesquisa usa a própria pesquisa; os demais, a pesquisa Ativa ou Concluída mais recente do setor
+// cujas perguntas atendem a aceita. As perguntas das candidatas são carregadas em uma única consulta
+func (uc *DashboardUseCase) referenciasPorSetor(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, aceita func([]*entity.Pergunta) bool) ([]referenciaSetor, error) {
+	setores, err := uc.setorRepo.ListByEmpresa(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar setores: %v", err)
+	}
+
+	pesquisas, err := uc.pesquisaRepo.ListByEmpresa(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pesquisas: %v", err)
+	}
+
+	// Mais recentes primeiro
+	sort.SliceStable(pesquisas, func(i, j int) bool {
+		return dataReferencia(pesquisas[i]).After(dataReferencia(pesquisas[j]))
+	})
+
+	var candidatas []*entity.Pesquisa
+	var ids []int
+	for _, p := range pesquisas {
+		if p.IDSetor != pesquisa.IDSetor && (p.Status == "Ativa" || p.Status == "Concluída") {
+			candidatas = append(candidatas, p)
+			ids = append(ids, p.ID)
+		}
+	}
+
+	perguntasCandidatas, err := uc.perguntaRepo.ListByPesquisas(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+	porPesquisa := make(map[int][]*entity.Pergunta, len(candidatas))
+	for _, pergunta := range perguntasCandidatas {
+		porPesquisa[pergunta.IDPesquisa] = append(porPesquisa[pergunta.IDPesquisa], pergunta)
+	}
+
+	referencias := make([]referenciaSetor, 0, len(setores))
+	for _, setor := range setores {
+		referencia := referenciaSetor{Setor: setor}
+		if setor.ID == pesquisa.IDSetor {
+			referencia.Pesquisa, referencia.Perguntas, referencia.Referencia = pesquisa, perguntas, true
+		} else {
+			for _, candidata := range candidatas {
+				if candidata.IDSetor == setor.ID && aceita(porPesquisa[candidata.ID]) {
+					referencia.Pesquisa, referencia.Perguntas = candidata, porPesquisa[candidata.ID]
+					break
+				}
+			}
+		}
+		referencias = append(referencias, referencia)
+	}
+
+	return referencias, nil
+}
+
+// idsReferencias retorna os IDs distintos das pesquisas escolhidas, sem a pesquisa informada em ignorar (0 para nenhuma)
+func idsReferencias(referencias []referenciaSetor, ignorar int) []int {
+	vistos := make(map[int]bool, len(referencias))
+	var ids []int
+	for _, referencia := range referencias {
+		if referencia.Pesquisa == nil || referencia.Pesquisa.ID == ignorar || vistos[referencia.Pesquisa.ID] {
+			continue
+		}
+		vistos[referencia.Pesquisa.ID] = true
+		ids = append(ids, referencia.Pesquisa.ID)
+	}
+	return ids
+}
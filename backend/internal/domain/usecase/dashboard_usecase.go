@@ -15,27 +15,46 @@ import (
 
 // DashboardUseCase implementa casos de uso para gerenciamento de dashboards
 type DashboardUseCase struct {
-	repo             repository.DashboardRepository    // Repositório de dashboards
-	pesquisaRepo     repository.PesquisaRepository     // Repositório de pesquisas
-	perguntaRepo     repository.PerguntaRepository     // Repositório de perguntas
-	respostaRepo     repository.RespostaRepository     // Repositório de respostas
-	empresaRepo      repository.EmpresaRepository      // Repositório de empresas
-	logAuditoriaRepo repository.LogAuditoriaRepository // Repositório de logs
+	repo             repository.DashboardRepository         // Repositório de dashboards
+	pesquisaRepo     repository.PesquisaRepository          // Repositório de pesquisas
+	perguntaRepo     repository.PerguntaRepository          // Repositório de perguntas
+	respostaRepo     repository.RespostaRepository          // Repositório de respostas
+	submissaoRepo    repository.SubmissaoPesquisaRepository // Repositório de submissões
+	empresaRepo      repository.EmpresaRepository           // Repositório de empresas
+	logAuditoriaRepo repository.LogAuditoriaRepository      // Repositório de logs
+	setorRepo        repository.SetorRepository             // Repositório de setores (opcional, eNPS por setor)
+	cicloRepo        repository.PesquisaCicloRepository     // Repositório de ciclos (opcional, tendência do eNPS)
+	cloneRepo        repository.PesquisaCloneRepository     // Repositório de pesquisas clonadas (opcional, tendência do eNPS)
+	anonimato        *PoliticaAnonimato                     // Mínimo de respondentes dos resultados
+	dimensaoRepo     repository.DimensaoRepository          // Repositório de dimensões (opcional, pontuação por dimensão)
+	analiseTextoRepo repository.AnaliseTextoRepository      // Repositório de análises das respostas abertas (opcional)
 }
 
 // NewDashboardUseCase cria uma nova instância do caso de uso de dashboards
 func NewDashboardUseCase(repo repository.DashboardRepository,
 	pesquisaRepo repository.PesquisaRepository,
+	perguntaRepo repository.PerguntaRepository,
+	respostaRepo repository.RespostaRepository,
+	submissaoRepo repository.SubmissaoPesquisaRepository,
 	empresaRepo repository.EmpresaRepository,
 	logRepo repository.LogAuditoriaRepository) *DashboardUseCase {
 	return &DashboardUseCase{
 		repo:             repo,
 		pesquisaRepo:     pesquisaRepo,
+		perguntaRepo:     perguntaRepo,
+		respostaRepo:     respostaRepo,
+		submissaoRepo:    submissaoRepo,
 		empresaRepo:      empresaRepo,
 		logAuditoriaRepo: logRepo,
 	}
 }
 
+// SetAnonimato define a política de anonimato aplicada aos dados, métricas e relatórios
+// Sem esta configuração vale o mínimo padrão de respondentes
+func (uc *DashboardUseCase) SetAnonimato(anonimato *PoliticaAnonimato) {
+	uc.anonimato = anonimato
+}
+
 // ValidateConfigFiltros valida o JSON de configuração de filtros
 func (uc *DashboardUseCase) ValidateConfigFiltros(configFiltros *string) error {
 	if configFiltros != nil && strings.TrimSpace(*configFiltros) != "" {
@@ -58,10 +77,10 @@ func (uc *DashboardUseCase) Create(ctx context.Context, dashboard *entity.Dashbo
 		return fmt.Errorf("título do dashboard é obrigatório")
 	}
 
-	// Verifica se pesquisa existe
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, dashboard.IDPesquisa)
+	// Verifica se pesquisa existe e pertence à empresa
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, dashboard.IDPesquisa)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Verifica se já existe dashboard para esta pesquisa (relação 1:1)
@@ -109,7 +128,26 @@ func (uc *DashboardUseCase) GetByID(ctx context.Context, id int) (*entity.Dashbo
 		return nil, fmt.Errorf("ID do dashboard deve ser maior que zero")
 	}
 
-	return uc.repo.GetByID(ctx, id)
+	dashboard, _, err := uc.getInScope(ctx, id)
+	return dashboard, err
+}
+
+// getInScope busca um dashboard e sua pesquisa, verificando se pertencem à empresa do contexto
+func (uc *DashboardUseCase) getInScope(ctx context.Context, id int) (*entity.Dashboard, *entity.Pesquisa, error) {
+	dashboard, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dashboard não encontrado: %v", err)
+	}
+
+	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, dashboard.IDPesquisa)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pesquisa associada não encontrada: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, "dashboard não encontrado"); err != nil {
+		return nil, nil, err
+	}
+
+	return dashboard, pesquisa, nil
 }
 
 // GetByPesquisaID busca um dashboard pelo ID da pesquisa
@@ -118,10 +156,9 @@ func (uc *DashboardUseCase) GetByPesquisaID(ctx context.Context, pesquisaID int)
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	// Verifica se pesquisa existe
-	_, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
-	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+	// Verifica se pesquisa existe e pertence à empresa
+	if _, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID); err != nil {
+		return nil, err
 	}
 
 	return uc.repo.GetByPesquisaID(ctx, pesquisaID)
@@ -133,7 +170,10 @@ func (uc *DashboardUseCase) ListByEmpresa(ctx context.Context, empresaID int) ([
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -153,16 +193,10 @@ func (uc *DashboardUseCase) Update(ctx context.Context, dashboard *entity.Dashbo
 		return fmt.Errorf("título do dashboard é obrigatório")
 	}
 
-	// Verifica se dashboard existe
-	existing, err := uc.repo.GetByID(ctx, dashboard.ID)
+	// Verifica se dashboard existe e pertence à empresa
+	existing, pesquisa, err := uc.getInScope(ctx, dashboard.ID)
 	if err != nil {
-		return fmt.Errorf("dashboard não encontrado: %v", err)
-	}
-
-	// Verifica se pesquisa existe (não permite alterar a pesquisa)
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, existing.IDPesquisa)
-	if err != nil {
-		return fmt.Errorf("pesquisa associada não encontrada: %v", err)
+		return err
 	}
 
 	// Não permite alterar a pesquisa associada
@@ -201,10 +235,10 @@ func (uc *DashboardUseCase) UpdateConfig(ctx context.Context, dashboardID int, c
 		return fmt.Errorf("ID do dashboard inválido")
 	}
 
-	// Verifica se dashboard existe
-	dashboard, err := uc.repo.GetByID(ctx, dashboardID)
+	// Verifica se dashboard existe e pertence à empresa
+	dashboard, _, err := uc.getInScope(ctx, dashboardID)
 	if err != nil {
-		return fmt.Errorf("dashboard não encontrado: %v", err)
+		return err
 	}
 
 	// Valida nova configuração
@@ -240,16 +274,10 @@ func (uc *DashboardUseCase) Delete(ctx context.Context, id int, userAdminID int,
 		return fmt.Errorf("ID do dashboard inválido")
 	}
 
-	// Busca dashboard para log e validações
-	dashboard, err := uc.repo.GetByID(ctx, id)
-	if err != nil {
-		return fmt.Errorf("dashboard não encontrado: %v", err)
-	}
-
-	// Busca pesquisa associada
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, dashboard.IDPesquisa)
+	// Busca dashboard e pesquisa associada para log e validações
+	dashboard, pesquisa, err := uc.getInScope(ctx, id)
 	if err != nil {
-		return fmt.Errorf("pesquisa associada não encontrada: %v", err)
+		return err
 	}
 
 	// Não permite deletar dashboard de pesquisa ativa
@@ -276,64 +304,17 @@ func (uc *DashboardUseCase) Delete(ctx context.Context, id int, userAdminID int,
 	return nil
 }
 
-// GenerateReport gera relatório com dados do dashboard
-func (uc *DashboardUseCase) GenerateReport(ctx context.Context, dashboardID int, format string, userAdminID int, enderecoIP string) ([]byte, error) {
-	if dashboardID <= 0 {
-		return nil, fmt.Errorf("ID do dashboard inválido")
-	}
-
-	validFormats := map[string]bool{
-		"pdf":  true,
-		"xlsx": true,
-		"csv":  true,
-	}
-
-	if !validFormats[format] {
-		return nil, fmt.Errorf("formato inválido: %s. Formatos válidos: pdf, xlsx, csv", format)
-	}
-
-	// Verifica se dashboard existe
-	dashboard, err := uc.repo.GetByID(ctx, dashboardID)
-	if err != nil {
-		return nil, fmt.Errorf("dashboard não encontrado: %v", err)
-	}
-
-	// Verifica se pesquisa tem dados suficientes
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, dashboard.IDPesquisa)
-	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
-	}
-
-	if pesquisa.Status == "Rascunho" {
-		return nil, fmt.Errorf("não é possível gerar relatório de pesquisa em rascunho")
-	}
-
-	// Log de auditoria
-	if userAdminID > 0 {
-		log := &entity.LogAuditoria{
-			IDUserAdmin:   userAdminID,
-			TimeStamp:     time.Now(),
-			AcaoRealizada: "Relatório Gerado",
-			Detalhes:      fmt.Sprintf("Relatório gerado (%s) do dashboard: %s (ID: %d)", format, dashboard.Titulo, dashboard.ID),
-			EnderecoIP:    enderecoIP,
-		}
-		uc.logAuditoriaRepo.Create(ctx, log)
-	}
-
-	// Aqui seria implementada a lógica de geração do relatório
-	// Por enquanto, retorna dados simulados
-	reportContent := fmt.Sprintf("Relatório do Dashboard: %s\nFormato: %s\nPesquisa: %s\nGerado em: %s",
-		dashboard.Titulo, format, pesquisa.Titulo, time.Now().Format("2006-01-02 15:04:05"))
-
-	return []byte(reportContent), nil
-}
-
 // GetDashboardData obtém dados processados do dashboard
 func (uc *DashboardUseCase) GetDashboardData(ctx context.Context, dashboardID int, filters string) (interface{}, error) {
 	// Buscar dashboard
-	dashboard, err := uc.repo.GetByID(ctx, dashboardID)
+	dashboard, pesquisa, err := uc.getInScope(ctx, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
 	if err != nil {
-		return nil, fmt.Errorf("dashboard não encontrado: %v", err)
+		return nil, err
 	}
 
 	// Usar método que existe para buscar dados agregados
@@ -348,15 +329,41 @@ func (uc *DashboardUseCase) GetDashboardData(ctx context.Context, dashboardID in
 		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
 	}
 
+	// Perguntas com menos respondentes que o mínimo da empresa não são exibidas
+	suprimidas := suprimirPerguntas(respostasAgregadas, k)
+
 	// Processar dados usando dados agregados
 	dadosProcessados := make(map[string]interface{})
+	for perguntaID, supressao := range suprimidas {
+		dadosProcessados[fmt.Sprintf("pergunta_%d", perguntaID)] = supressao
+	}
+
+	// Média das perguntas de escala normalizadas para 0-100, comparável entre escalas diferentes
+	var somaNormalizada float64
+	var escalasComMedia int
 
 	for _, pergunta := range perguntas {
 		if respostasPergunta, exists := respostasAgregadas[pergunta.ID]; exists {
-			dadosProcessados[fmt.Sprintf("pergunta_%d", pergunta.ID)] = processarDadosAgregados(pergunta.TipoPergunta, respostasPergunta)
+			dados := processarDadosAgregados(pergunta, respostasPergunta, k)
+			dadosProcessados[fmt.Sprintf("pergunta_%d", pergunta.ID)] = dados
+			if normalizada, ok := dados["media_normalizada"].(float64); ok {
+				somaNormalizada += normalizada
+				escalasComMedia++
+			}
 		}
 	}
 
+	var mediaGeralNormalizada interface{}
+	if escalasComMedia > 0 {
+		mediaGeralNormalizada = somaNormalizada / float64(escalasComMedia)
+	}
+
+	// Pontuação das dimensões da pesquisa e dos setores (nil sem perguntas com dimensão)
+	dimensoes, err := uc.calcularDimensoes(ctx, pesquisa, perguntas)
+	if err != nil {
+		return nil, err
+	}
+
 	// Contar total de respostas usando método que existe
 	totalRespostas, err := uc.respostaRepo.CountByPesquisa(ctx, dashboard.IDPesquisa)
 	if err != nil {
@@ -364,48 +371,62 @@ func (uc *DashboardUseCase) GetDashboardData(ctx context.Context, dashboardID in
 	}
 
 	return map[string]interface{}{
-		"dashboard_id":       dashboardID,
-		"total_respostas":    totalRespostas,
-		"dados_processados":  dadosProcessados,
-		"ultima_atualizacao": time.Now(),
+		"dashboard_id":            dashboardID,
+		"total_respostas":         totalRespostas,
+		"dados_processados":       dadosProcessados,
+		"media_geral_normalizada": mediaGeralNormalizada,
+		"dimensoes":               dadosDimensoes(dimensoes, k),
+		"ultima_atualizacao":      time.Now(),
 	}, nil
 }
 
 // Função auxiliar para processar dados agregados
-func processarDadosAgregados(tipoPergunta string, dadosAgregados map[string]int) map[string]interface{} {
-	switch tipoPergunta {
-	case "Multipla Escolha":
+// k é o mínimo de respondentes da empresa, aplicado também aos respondentes válidos do eNPS
+// Escalas comuns trazem a favorabilidade; o eNPS, promotores e detratores
+func processarDadosAgregados(pergunta *entity.Pergunta, dadosAgregados map[string]int, k int) map[string]interface{} {
+	switch pergunta.TipoPergunta {
+	case entity.TipoMultiplaEscolha:
 		return map[string]interface{}{
 			"tipo":         "multipla_escolha",
-			"distribuicao": dadosAgregados,
+			"distribuicao": entity.OpcoesDaPergunta(pergunta).AgregarSelecoes(dadosAgregados),
+		}
+	case entity.TipoEscalaNumerica:
+		escala := entity.EscalaDaPergunta(pergunta)
+		dados := processarEscalaAgregada(escala, dadosAgregados)
+		if escala.Variante == entity.VarianteENPS {
+			enps := entity.CalcularENPS(dadosAgregados)
+			dados["enps"] = segmentoENPS(&enps, k)
+		} else {
+			dados["favorabilidade"] = favorabilidadeVisivel(entity.CalcularFavorabilidade(pergunta, dadosAgregados), k)
 		}
-	case "Escala Numerica":
-		return processarEscalaAgregada(dadosAgregados)
+		return dados
 	default:
 		return map[string]interface{}{
-			"tipo":  tipoPergunta,
+			"tipo":  pergunta.TipoPergunta,
 			"dados": dadosAgregados,
 		}
 	}
 }
 
-func processarEscalaAgregada(dados map[string]int) map[string]interface{} {
-	var soma, total int
-	for valor, count := range dados {
-		if v, err := strconv.Atoi(valor); err == nil {
-			soma += v * count
-			total += count
-		}
-	}
-
-	media := float64(soma) / float64(total)
-
-	return map[string]interface{}{
-		"tipo":            "escala",
-		"distribuicao":    dados,
-		"media":           media,
-		"total_respostas": total,
-	}
+// processarEscalaAgregada calcula a média na escala da pergunta e normalizada para 0-100
+// A média normalizada permite comparar perguntas com escalas diferentes (1-5, 0-10, 1-7)
+func processarEscalaAgregada(escala *entity.EscalaNumerica, dados map[string]int) map[string]interface{} {
+	media, total := escala.Media(dados)
+
+	resultado := map[string]interface{}{
+		"tipo":              "escala",
+		"escala":            escala,
+		"distribuicao":      dados,
+		"media":             nil,
+		"media_normalizada": nil,
+		"total_respostas":   total,
+		"nao_se_aplica":     dados[entity.ValorNaoSeAplica],
+	}
+	if total > 0 {
+		resultado["media"] = media
+		resultado["media_normalizada"] = escala.Normalizar(media)
+	}
+	return resultado
 }
 
 // Funções auxiliares para processamento
@@ -419,11 +440,14 @@ func filtrarRespostasPorPergunta(respostas []*entity.Resposta, perguntaID int) [
 	return filtered
 }
 
-func processarMultiplaEscolha(respostas []*entity.Resposta) map[string]interface{} {
+func processarMultiplaEscolha(pergunta *entity.Pergunta, respostas []*entity.Resposta) map[string]interface{} {
+	opcoes := entity.OpcoesDaPergunta(pergunta)
 	contadores := make(map[string]int)
 
 	for _, resposta := range respostas {
-		contadores[resposta.ValorResposta]++
+		for _, opcao := range opcoes.SelecoesResposta(resposta.ValorResposta) {
+			contadores[opcao.Rotulo]++
+		}
 	}
 
 	return map[string]interface{}{
@@ -462,9 +486,9 @@ func (uc *DashboardUseCase) RefreshDashboard(ctx context.Context, dashboardID, u
 	}
 
 	// Buscar dashboard
-	dashboard, err := uc.repo.GetByID(ctx, dashboardID)
+	dashboard, _, err := uc.getInScope(ctx, dashboardID)
 	if err != nil {
-		return fmt.Errorf("dashboard não encontrado: %v", err)
+		return err
 	}
 
 	// Recalcular métricas usando método correto
@@ -514,9 +538,9 @@ func (uc *DashboardUseCase) GetDashboardMetrics(ctx context.Context, dashboardID
 	}
 
 	// Buscar dashboard
-	dashboard, err := uc.repo.GetByID(ctx, dashboardID)
+	dashboard, pesquisa, err := uc.getInScope(ctx, dashboardID)
 	if err != nil {
-		return nil, fmt.Errorf("dashboard não encontrado: %v", err)
+		return nil, err
 	}
 
 	// Buscar dados reais usando métodos corretos
@@ -539,6 +563,17 @@ func (uc *DashboardUseCase) GetDashboardMetrics(ctx context.Context, dashboardID
 		tiposPergunta[pergunta.TipoPergunta]++
 	}
 
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+
+	// eNPS da pesquisa, por setor e por ciclo (nil quando a pesquisa não tem pergunta de eNPS)
+	enps, err := uc.buildENPSMetrics(ctx, pesquisa, perguntas, k)
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		"total_respostas": totalRespostas,
 		// "data_ultima_resposta": ultimaResposta, // Remover por enquanto
@@ -547,5 +582,6 @@ func (uc *DashboardUseCase) GetDashboardMetrics(ctx context.Context, dashboardID
 			"total_perguntas": len(perguntas),
 			"tipos_pergunta":  tiposPergunta,
 		},
+		"enps": enps,
 	}, nil
 }
@@ -0,0 +1,195 @@
+// Package usecase implementa os casos de uso para Dimensões do clima.
+// Fornece o cadastro das dimensões da empresa usadas para agrupar e pontuar perguntas.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"strings"
+	"time"
+)
+
+// DimensaoUseCase implementa casos de uso para gerenciamento de dimensões
+type DimensaoUseCase struct {
+	repo             repository.DimensaoRepository     // Repositório de dimensões
+	empresaRepo      repository.EmpresaRepository      // Repositório de empresas
+	logAuditoriaRepo repository.LogAuditoriaRepository // Repositório de logs
+}
+
+// NewDimensaoUseCase cria uma nova instância do caso de uso de dimensões
+func NewDimensaoUseCase(repo repository.DimensaoRepository,
+	empresaRepo repository.EmpresaRepository,
+	logRepo repository.LogAuditoriaRepository) *DimensaoUseCase {
+	return &DimensaoUseCase{
+		repo:             repo,
+		empresaRepo:      empresaRepo,
+		logAuditoriaRepo: logRepo,
+	}
+}
+
+// getDimensaoInScope busca uma dimensão e verifica se pertence à empresa do contexto
+func getDimensaoInScope(ctx context.Context, repo repository.DimensaoRepository, dimensaoID int) (*entity.Dimensao, error) {
+	dimensao, err := repo.GetByID(ctx, dimensaoID)
+	if err != nil {
+		return nil, fmt.Errorf("dimensão não encontrada: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, dimensao.IDEmpresa, "dimensão não encontrada"); err != nil {
+		return nil, err
+	}
+	return dimensao, nil
+}
+
+// validarDimensao normaliza e valida nome e descrição da dimensão
+func validarDimensao(dimensao *entity.Dimensao) error {
+	dimensao.Nome = strings.TrimSpace(dimensao.Nome)
+	dimensao.Descricao = strings.TrimSpace(dimensao.Descricao)
+
+	if dimensao.Nome == "" {
+		return fmt.Errorf("nome da dimensão é obrigatório")
+	}
+	if len([]rune(dimensao.Nome)) > entity.MaxNomeDimensao {
+		return fmt.Errorf("nome da dimensão inválido: máximo de %d caracteres", entity.MaxNomeDimensao)
+	}
+	if len([]rune(dimensao.Descricao)) > entity.MaxDescricaoDimensao {
+		return fmt.Errorf("descrição da dimensão inválida: máximo de %d caracteres", entity.MaxDescricaoDimensao)
+	}
+	return nil
+}
+
+// Create cria uma nova dimensão da empresa
+func (uc *DimensaoUseCase) Create(ctx context.Context, dimensao *entity.Dimensao, userAdminID int, enderecoIP string) error {
+	if dimensao.IDEmpresa <= 0 {
+		return fmt.Errorf("ID da empresa é obrigatório")
+	}
+
+	if err := validarDimensao(dimensao); err != nil {
+		return err
+	}
+
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, dimensao.IDEmpresa, "empresa não encontrada"); err != nil {
+		return err
+	}
+	if _, err := uc.empresaRepo.GetByID(ctx, dimensao.IDEmpresa); err != nil {
+		return fmt.Errorf("empresa não encontrada: %v", err)
+	}
+
+	// Verifica se já existe dimensão com mesmo nome na empresa
+	if existente, err := uc.repo.GetByNome(ctx, dimensao.IDEmpresa, dimensao.Nome); err == nil && existente != nil {
+		return fmt.Errorf("dimensão '%s' já existe nesta empresa", dimensao.Nome)
+	}
+
+	if err := uc.repo.Create(ctx, dimensao); err != nil {
+		return fmt.Errorf("erro ao criar dimensão: %v", err)
+	}
+
+	// Log de auditoria
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Dimensão Criada",
+			Detalhes:      fmt.Sprintf("Dimensão criada: %s (ID: %d)", dimensao.Nome, dimensao.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// GetByID busca uma dimensão pelo seu ID
+func (uc *DimensaoUseCase) GetByID(ctx context.Context, id int) (*entity.Dimensao, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID da dimensão deve ser maior que zero")
+	}
+
+	return getDimensaoInScope(ctx, uc.repo, id)
+}
+
+// ListByEmpresa lista as dimensões de uma empresa
+func (uc *DimensaoUseCase) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Dimensao, error) {
+	if empresaID <= 0 {
+		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
+	}
+
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListByEmpresa(ctx, empresaID)
+}
+
+// Update atualiza nome e descrição de uma dimensão
+// As perguntas continuam vinculadas; os resultados passam a exibir o novo nome
+func (uc *DimensaoUseCase) Update(ctx context.Context, dimensao *entity.Dimensao, userAdminID int, enderecoIP string) error {
+	if dimensao.ID <= 0 {
+		return fmt.Errorf("ID da dimensão inválido")
+	}
+
+	if err := validarDimensao(dimensao); err != nil {
+		return err
+	}
+
+	existente, err := getDimensaoInScope(ctx, uc.repo, dimensao.ID)
+	if err != nil {
+		return err
+	}
+
+	// Não permite transferir a dimensão para outra empresa
+	dimensao.IDEmpresa = existente.IDEmpresa
+
+	if comNome, err := uc.repo.GetByNome(ctx, dimensao.IDEmpresa, dimensao.Nome); err == nil && comNome != nil && comNome.ID != dimensao.ID {
+		return fmt.Errorf("dimensão '%s' já existe nesta empresa", dimensao.Nome)
+	}
+
+	if err := uc.repo.Update(ctx, dimensao); err != nil {
+		return fmt.Errorf("erro ao atualizar dimensão: %v", err)
+	}
+
+	// Log de auditoria
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Dimensão Atualizada",
+			Detalhes:      fmt.Sprintf("Dimensão atualizada: %s -> %s (ID: %d)", existente.Nome, dimensao.Nome, dimensao.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// Delete remove uma dimensão; as perguntas vinculadas ficam sem dimensão
+func (uc *DimensaoUseCase) Delete(ctx context.Context, id int, userAdminID int, enderecoIP string) error {
+	if id <= 0 {
+		return fmt.Errorf("ID da dimensão inválido")
+	}
+
+	dimensao, err := getDimensaoInScope(ctx, uc.repo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("erro ao deletar dimensão: %v", err)
+	}
+
+	// Log de auditoria
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Dimensão Deletada",
+			Detalhes:      fmt.Sprintf("Dimensão deletada: %s (ID: %d)", dimensao.Nome, dimensao.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
@@ -78,6 +78,10 @@ func (uc *EmpresaUseCase) GetByID(ctx context.Context, id int) (*entity.Empresa,
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
+	if err := checkEmpresaScope(ctx, id, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
 	return uc.empresaRepo.GetByID(ctx, id)
 }
 
@@ -87,11 +91,34 @@ func (uc *EmpresaUseCase) GetByCNPJ(ctx context.Context, cnpj string) (*entity.E
 		return nil, err
 	}
 
-	return uc.empresaRepo.GetByCNPJ(ctx, cnpj)
+	empresa, err := uc.empresaRepo.GetByCNPJ(ctx, cnpj)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEmpresaScope(ctx, empresa.ID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
+	return empresa, nil
 }
 
 // List lista empresas com paginação
+// Usuários restritos a uma empresa recebem apenas a própria empresa
 func (uc *EmpresaUseCase) List(ctx context.Context, limit, offset int) ([]*entity.Empresa, error) {
+	if empresaID, ok := EmpresaScope(ctx); ok {
+		if offset > 0 {
+			return []*entity.Empresa{}, nil
+		}
+		empresa, err := uc.empresaRepo.GetByID(ctx, empresaID)
+		if err != nil {
+			return nil, fmt.Errorf("empresa não encontrada: %v", err)
+		}
+		return []*entity.Empresa{empresa}, nil
+	}
+	if !hasSystemScope(ctx) {
+		return []*entity.Empresa{}, nil
+	}
+
 	if limit <= 0 || limit > 100 {
 		limit = 20 // Limite padrão
 	}
@@ -121,7 +148,10 @@ func (uc *EmpresaUseCase) Update(ctx context.Context, empresa *entity.Empresa, u
 		return err
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresa.ID, "empresa não encontrada"); err != nil {
+		return err
+	}
 	existing, err := uc.empresaRepo.GetByID(ctx, empresa.ID)
 	if err != nil {
 		return fmt.Errorf("empresa não encontrada: %v", err)
@@ -160,6 +190,9 @@ func (uc *EmpresaUseCase) Delete(ctx context.Context, id int, userAdminID int, e
 	}
 
 	// Busca empresa para log
+	if err := checkEmpresaScope(ctx, id, "empresa não encontrada"); err != nil {
+		return err
+	}
 	empresa, err := uc.empresaRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("empresa não encontrada: %v", err)
@@ -183,4 +216,77 @@ func (uc *EmpresaUseCase) Delete(ctx context.Context, id int, userAdminID int, e
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// UpdatePoliticaMFA define se o segundo fator é obrigatório para os administradores da empresa
+// Com a política ativa, administradores sem segundo fator precisam cadastrá-lo no próximo login
+func (uc *EmpresaUseCase) UpdatePoliticaMFA(ctx context.Context, id int, obrigatorio bool, userAdminID int, enderecoIP string) error {
+	if id <= 0 {
+		return fmt.Errorf("ID da empresa inválido")
+	}
+
+	if err := checkEmpresaScope(ctx, id, "empresa não encontrada"); err != nil {
+		return err
+	}
+	empresa, err := uc.empresaRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("empresa não encontrada: %v", err)
+	}
+
+	if err := uc.empresaRepo.UpdatePoliticaMFA(ctx, id, obrigatorio); err != nil {
+		return fmt.Errorf("erro ao atualizar política MFA: %v", err)
+	}
+
+	if userAdminID > 0 {
+		situacao := "opcional"
+		if obrigatorio {
+			situacao = "obrigatório"
+		}
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Política MFA Atualizada",
+			Detalhes:      fmt.Sprintf("Segundo fator %s para a empresa %s (ID: %d)", situacao, empresa.NomeFantasia, empresa.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// UpdatePoliticaAnonimato define o mínimo de respondentes para exibir resultados agregados da empresa
+// Grupos menores que o mínimo são suprimidos em todos os resultados, inclusive os já publicados
+func (uc *EmpresaUseCase) UpdatePoliticaAnonimato(ctx context.Context, id int, minimo int, userAdminID int, enderecoIP string) error {
+	if id <= 0 {
+		return fmt.Errorf("ID da empresa inválido")
+	}
+	if minimo < entity.MinRespondentesMinimo || minimo > entity.MinRespondentesMaximo {
+		return fmt.Errorf("mínimo de respondentes deve estar entre %d e %d", entity.MinRespondentesMinimo, entity.MinRespondentesMaximo)
+	}
+
+	if err := checkEmpresaScope(ctx, id, "empresa não encontrada"); err != nil {
+		return err
+	}
+	empresa, err := uc.empresaRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("empresa não encontrada: %v", err)
+	}
+
+	if err := uc.empresaRepo.UpdateMinRespondentes(ctx, id, minimo); err != nil {
+		return fmt.Errorf("erro ao atualizar política de anonimato: %v", err)
+	}
+
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Política de Anonimato Atualizada",
+			Detalhes:      fmt.Sprintf("Mínimo de respondentes alterado de %d para %d na empresa %s (ID: %d)", empresa.MinRespondentes, minimo, empresa.NomeFantasia, empresa.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
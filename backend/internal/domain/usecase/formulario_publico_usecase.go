@@ -0,0 +1,143 @@
+// Package usecase implementa o caso de uso do formulário público de pesquisas.
+// Fornece ao respondente anônimo, a partir do link de acesso, apenas os dados necessários para responder.
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// Situações do formulário público
+const (
+	FormularioAberto    = "aberta"    // Recebendo respostas
+	FormularioAgendado  = "agendada"  // Ativa, mas antes da data de abertura
+	FormularioEncerrado = "encerrada" // Concluída, arquivada ou após a data de fechamento
+)
+
+// FormularioPublico é a visão da pesquisa exposta ao respondente
+// As perguntas só são preenchidas quando a pesquisa está aberta
+type FormularioPublico struct {
+	Pesquisa       *entity.Pesquisa     // Pesquisa (campos administrativos não devem ser expostos)
+	Situacao       string               // aberta, agendada ou encerrada
+	Perguntas      []FormularioPergunta // Perguntas em ordem de exibição
+	ProximaMudanca *time.Time           // Próxima abertura ou fechamento, limite para cache
+}
+
+// FormularioPergunta é uma pergunta do formulário com as opções já interpretadas
+type FormularioPergunta struct {
+	Pergunta *entity.Pergunta
+	Opcoes   json.RawMessage // Opções de resposta em JSON (nil quando a pergunta não tem opções)
+	Condicao json.RawMessage // Condição de exibição em JSON (nil quando a pergunta é sempre exibida)
+}
+
+// FormularioPublicoUseCase implementa a consulta anônima do formulário de uma pesquisa
+type FormularioPublicoUseCase struct {
+	pesquisaRepo repository.PesquisaRepository // Repositório de pesquisas
+	perguntaRepo repository.PerguntaRepository // Repositório de perguntas
+}
+
+// NewFormularioPublicoUseCase cria uma nova instância do caso de uso do formulário público
+func NewFormularioPublicoUseCase(pesquisaRepo repository.PesquisaRepository, perguntaRepo repository.PerguntaRepository) *FormularioPublicoUseCase {
+	return &FormularioPublicoUseCase{
+		pesquisaRepo: pesquisaRepo,
+		perguntaRepo: perguntaRepo,
+	}
+}
+
+// GetByLink retorna o formulário da pesquisa identificada pelo link de acesso
+// Rascunhos não são publicados e resultam em "não encontrada", como links inexistentes
+func (uc *FormularioPublicoUseCase) GetByLink(ctx context.Context, link string, now time.Time) (*FormularioPublico, error) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return nil, fmt.Errorf("link de acesso é obrigatório")
+	}
+
+	pesquisa, err := uc.pesquisaRepo.GetByLinkAcesso(ctx, link)
+	if err != nil {
+		return nil, fmt.Errorf("pesquisa não encontrada com este link")
+	}
+
+	formulario := &FormularioPublico{Pesquisa: pesquisa}
+
+	switch pesquisa.Status {
+	case "Ativa":
+		switch {
+		case pesquisa.DataFechamento != nil && !now.Before(*pesquisa.DataFechamento):
+			formulario.Situacao = FormularioEncerrado
+		case pesquisa.DataAbertura != nil && now.Before(*pesquisa.DataAbertura):
+			formulario.Situacao = FormularioAgendado
+			formulario.ProximaMudanca = pesquisa.DataAbertura
+		default:
+			formulario.Situacao = FormularioAberto
+			formulario.ProximaMudanca = pesquisa.DataFechamento
+		}
+	case "Concluída", "Arquivada":
+		formulario.Situacao = FormularioEncerrado
+	default:
+		return nil, fmt.Errorf("pesquisa não encontrada com este link")
+	}
+
+	if formulario.Situacao != FormularioAberto {
+		return formulario, nil
+	}
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, pesquisa.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+	sort.SliceStable(perguntas, func(i, j int) bool {
+		return perguntas[i].OrdemExibicao < perguntas[j].OrdemExibicao
+	})
+
+	formulario.Perguntas = make([]FormularioPergunta, 0, len(perguntas))
+	for _, pergunta := range perguntas {
+		opcoes := parseOpcoesResposta(pergunta.OpcoesResposta)
+		if pergunta.TipoPergunta == entity.TipoEscalaNumerica {
+			// Perguntas sem configuração exibem a escala padrão aplicada na validação
+			opcoes = json.RawMessage(entity.EscalaDaPergunta(pergunta).JSON())
+		}
+		var condicao json.RawMessage
+		if c, err := entity.ParseCondicaoExibicao(pergunta.CondicaoExibicao); err == nil && c != nil {
+			condicao = json.RawMessage(c.JSON())
+		}
+		formulario.Perguntas = append(formulario.Perguntas, FormularioPergunta{
+			Pergunta: pergunta,
+			Opcoes:   opcoes,
+			Condicao: condicao,
+		})
+	}
+
+	return formulario, nil
+}
+
+// parseOpcoesResposta interpreta as opções salvas na pergunta
+// JSON válido é repassado como está; texto simples é tratado como lista separada por vírgulas ou quebras de linha
+func parseOpcoesResposta(raw *string) json.RawMessage {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil
+	}
+
+	value := strings.TrimSpace(*raw)
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+
+	opcoes := []string{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			opcoes = append(opcoes, item)
+		}
+	}
+	encoded, err := json.Marshal(opcoes)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+type fakeFormularioPesquisaRepo struct {
+	repository.PesquisaRepository
+	pesquisa *entity.Pesquisa
+}
+
+func (r *fakeFormularioPesquisaRepo) GetByLinkAcesso(ctx context.Context, link string) (*entity.Pesquisa, error) {
+	if link != r.pesquisa.LinkAcesso {
+		return nil, fmt.Errorf("pesquisa com link %s não encontrada", link)
+	}
+	return r.pesquisa, nil
+}
+
+func TestFormularioPublicoGetByLink(t *testing.T) {
+	agora := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	antes := agora.Add(-24 * time.Hour)
+	depois := agora.Add(2 * time.Hour)
+
+	perguntas := []*entity.Pergunta{
+		{ID: 2, IDPesquisa: 10, OrdemExibicao: 2, TipoPergunta: "SimNao"},
+		{ID: 1, IDPesquisa: 10, OrdemExibicao: 1, TipoPergunta: entity.TipoEscalaNumerica},
+	}
+
+	casos := []struct {
+		nome           string
+		status         string
+		abertura       *time.Time
+		fechamento     *time.Time
+		erro           bool
+		situacao       string
+		proximaMudanca *time.Time
+	}{
+		{nome: "rascunho não é publicado", status: "Rascunho", erro: true},
+		{nome: "ativa sem datas", status: "Ativa", situacao: FormularioAberto},
+		{nome: "ativa com fechamento futuro", status: "Ativa", abertura: &antes, fechamento: &depois, situacao: FormularioAberto, proximaMudanca: &depois},
+		{nome: "abertura futura", status: "Ativa", abertura: &depois, situacao: FormularioAgendado, proximaMudanca: &depois},
+		{nome: "fechamento passado", status: "Ativa", abertura: &antes, fechamento: &antes, situacao: FormularioEncerrado},
+		{nome: "fechamento no instante atual", status: "Ativa", fechamento: &agora, situacao: FormularioEncerrado},
+		{nome: "concluída", status: "Concluída", situacao: FormularioEncerrado},
+		{nome: "arquivada", status: "Arquivada", situacao: FormularioEncerrado},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			pesquisa := &entity.Pesquisa{ID: 10, IDEmpresa: 1, Status: c.status, LinkAcesso: "link-10", DataAbertura: c.abertura, DataFechamento: c.fechamento}
+			uc := NewFormularioPublicoUseCase(&fakeFormularioPesquisaRepo{pesquisa: pesquisa}, &fakeRespostaPerguntaRepo{perguntas: perguntas})
+
+			formulario, err := uc.GetByLink(context.Background(), " link-10 ", agora)
+			if c.erro {
+				if err == nil || err.Error() != "pesquisa não encontrada com este link" {
+					t.Fatalf("erro = %v, esperado pesquisa não encontrada", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if formulario.Situacao != c.situacao {
+				t.Errorf("situação %q, esperado %q", formulario.Situacao, c.situacao)
+			}
+			if (formulario.ProximaMudanca == nil) != (c.proximaMudanca == nil) ||
+				(c.proximaMudanca != nil && !formulario.ProximaMudanca.Equal(*c.proximaMudanca)) {
+				t.Errorf("próxima mudança %v, esperado %v", formulario.ProximaMudanca, c.proximaMudanca)
+			}
+
+			// Perguntas só são expostas com a pesquisa aberta, em ordem de exibição
+			if c.situacao != FormularioAberto {
+				if len(formulario.Perguntas) > 0 {
+					t.Errorf("%d perguntas expostas com a pesquisa %s", len(formulario.Perguntas), c.situacao)
+				}
+				return
+			}
+			if len(formulario.Perguntas) != 2 || formulario.Perguntas[0].Pergunta.ID != 1 || formulario.Perguntas[1].Pergunta.ID != 2 {
+				t.Fatalf("perguntas %v, esperado 1 e 2 em ordem", formulario.Perguntas)
+			}
+			if escala := string(formulario.Perguntas[0].Opcoes); escala != entity.EscalaPadrao().JSON() {
+				t.Errorf("escala sem configuração exibida como %s, esperado a escala padrão", escala)
+			}
+		})
+	}
+
+	uc := NewFormularioPublicoUseCase(&fakeFormularioPesquisaRepo{pesquisa: &entity.Pesquisa{ID: 10, Status: "Ativa", LinkAcesso: "link-10"}}, nil)
+	if _, err := uc.GetByLink(context.Background(), "outro-link", agora); err == nil || err.Error() != "pesquisa não encontrada com este link" {
+		t.Errorf("link inexistente: erro = %v, esperado pesquisa não encontrada", err)
+	}
+	if _, err := uc.GetByLink(context.Background(), "  ", agora); err == nil {
+		t.Error("link vazio aceito")
+	}
+}
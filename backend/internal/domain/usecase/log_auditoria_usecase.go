@@ -66,10 +66,9 @@ func (uc *LogAuditoriaUseCase) Create(ctx context.Context, log *entity.LogAudito
 		return err
 	}
 
-	// Verifica se usuário administrador existe
-	_, err := uc.userRepo.GetByID(ctx, log.IDUserAdmin)
-	if err != nil {
-		return fmt.Errorf("usuário administrador não encontrado: %v", err)
+	// Verifica se usuário administrador existe e pertence à empresa
+	if err := uc.checkUsuarioScope(ctx, log.IDUserAdmin); err != nil {
+		return err
 	}
 
 	// Define timestamp se não informado
@@ -103,7 +102,33 @@ func (uc *LogAuditoriaUseCase) GetByID(ctx context.Context, id int) (*entity.Log
 		return nil, fmt.Errorf("ID do log deve ser maior que zero")
 	}
 
-	return uc.repo.GetByID(ctx, id)
+	log, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logs de sistema não pertencem a nenhuma empresa
+	if _, scoped := EmpresaScope(ctx); scoped {
+		if log.IDUserAdmin <= 0 {
+			return nil, fmt.Errorf("log de auditoria não encontrado")
+		}
+		if err := uc.checkUsuarioScope(ctx, log.IDUserAdmin); err != nil {
+			return nil, fmt.Errorf("log de auditoria não encontrado")
+		}
+	} else if !hasSystemScope(ctx) {
+		return nil, fmt.Errorf("log de auditoria não encontrado")
+	}
+
+	return log, nil
+}
+
+// checkUsuarioScope verifica se o usuário existe e pertence à empresa do contexto
+func (uc *LogAuditoriaUseCase) checkUsuarioScope(ctx context.Context, userAdminID int) error {
+	usuario, err := uc.userRepo.GetByID(ctx, userAdminID)
+	if err != nil {
+		return fmt.Errorf("usuário administrador não encontrado: %v", err)
+	}
+	return checkEmpresaScope(ctx, usuario.IDEmpresa, "usuário administrador não encontrado")
 }
 
 // ListByEmpresa lista logs de uma empresa com paginação
@@ -113,7 +138,10 @@ func (uc *LogAuditoriaUseCase) ListByEmpresa(ctx context.Context, empresaID int,
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -137,10 +165,9 @@ func (uc *LogAuditoriaUseCase) ListByUsuarioAdmin(ctx context.Context, userAdmin
 		return nil, fmt.Errorf("ID do usuário administrador deve ser maior que zero")
 	}
 
-	// Verifica se usuário existe
-	_, err := uc.userRepo.GetByID(ctx, userAdminID)
-	if err != nil {
-		return nil, fmt.Errorf("usuário administrador não encontrado: %v", err)
+	// Verifica se usuário existe e pertence à empresa
+	if err := uc.checkUsuarioScope(ctx, userAdminID); err != nil {
+		return nil, err
 	}
 
 	// Valida paginação
@@ -190,7 +217,10 @@ func (uc *LogAuditoriaUseCase) ListByDateRange(ctx context.Context, empresaID in
 		return nil, fmt.Errorf("período máximo para consulta é de 1 ano")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err = uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -209,7 +239,10 @@ func (uc *LogAuditoriaUseCase) ListByAction(ctx context.Context, empresaID int,
 		return nil, fmt.Errorf("ação é obrigatória")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -389,7 +422,10 @@ func (uc *LogAuditoriaUseCase) GetLogStatistics(ctx context.Context, empresaID i
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -0,0 +1,394 @@
+// Package usecase implementa os casos de uso de autenticação em dois fatores.
+// Gerencia o cadastro TOTP, a verificação no login e os códigos de recuperação.
+package usecase
+
+import (
+	"context"
+	"encoding/base32"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/crypto"
+	"organizational-climate-survey/backend/pkg/totp"
+	"strings"
+	"time"
+)
+
+// Parâmetros dos códigos de recuperação
+const (
+	recoveryCodeCount = 10 // Quantidade de códigos gerados por vez
+	recoveryCodeBytes = 5  // 40 bits aleatórios, exibidos como XXXX-XXXX
+)
+
+// MFAStatus resume a situação do segundo fator de um usuário
+type MFAStatus struct {
+	Ativo            bool `json:"ativo"`             // Segundo fator cadastrado e confirmado
+	Obrigatorio      bool `json:"obrigatorio"`       // Política da empresa exige segundo fator
+	CodigosRestantes int  `json:"codigos_restantes"` // Códigos de recuperação ainda não usados
+}
+
+// Exigido indica se o login deve passar pela etapa de segundo fator
+func (s *MFAStatus) Exigido() bool {
+	return s.Ativo || s.Obrigatorio
+}
+
+// MFAUseCase implementa a autenticação em dois fatores por TOTP
+type MFAUseCase struct {
+	mfaRepo          repository.MFARepository                  // Repositório de segundo fator
+	usuarioRepo      repository.UsuarioAdministradorRepository // Repositório de usuários
+	empresaRepo      repository.EmpresaRepository              // Política MFA da empresa
+	logAuditoriaRepo repository.LogAuditoriaRepository         // Repositório de logs
+	crypto           crypto.CryptoService                      // Hash dos códigos de recuperação
+	issuer           string                                    // Emissor exibido no aplicativo autenticador
+	protecao         *UsuarioAdministradorUseCase              // Contadores de falhas do login (opcional)
+}
+
+// NewMFAUseCase cria uma nova instância do caso de uso de segundo fator
+func NewMFAUseCase(
+	mfaRepo repository.MFARepository,
+	usuarioRepo repository.UsuarioAdministradorRepository,
+	empresaRepo repository.EmpresaRepository,
+	logRepo repository.LogAuditoriaRepository,
+	cryptoSvc crypto.CryptoService,
+	issuer string,
+) *MFAUseCase {
+	if strings.TrimSpace(issuer) == "" {
+		issuer = "Clima Organizacional"
+	}
+	return &MFAUseCase{
+		mfaRepo:          mfaRepo,
+		usuarioRepo:      usuarioRepo,
+		empresaRepo:      empresaRepo,
+		logAuditoriaRepo: logRepo,
+		crypto:           cryptoSvc,
+		issuer:           issuer,
+	}
+}
+
+// SetLoginProtection faz os códigos inválidos na verificação do login contarem na proteção contra força bruta
+// As falhas contam por conta e por IP, e o login pendente é invalidado após mfaMaxFalhasDesafio códigos inválidos
+func (uc *MFAUseCase) SetLoginProtection(protecao *UsuarioAdministradorUseCase) {
+	uc.protecao = protecao
+}
+
+// Status retorna a situação do segundo fator do usuário e a política da empresa
+func (uc *MFAUseCase) Status(ctx context.Context, usuario *entity.UsuarioAdministrador) (*MFAStatus, error) {
+	status := &MFAStatus{}
+
+	mfa, err := uc.mfaRepo.GetByUsuario(ctx, usuario.ID)
+	if err != nil && !strings.Contains(err.Error(), "não encontrada") {
+		return nil, fmt.Errorf("erro ao consultar segundo fator: %v", err)
+	}
+	if err == nil && mfa.Ativo {
+		status.Ativo = true
+
+		codigos, err := uc.mfaRepo.ListUnusedRecoveryCodes(ctx, usuario.ID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao consultar códigos de recuperação: %v", err)
+		}
+		status.CodigosRestantes = len(codigos)
+	}
+
+	empresa, err := uc.empresaRepo.GetByID(ctx, usuario.IDEmpresa)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar política MFA da empresa: %v", err)
+	}
+	status.Obrigatorio = empresa.MFAObrigatorio
+
+	return status, nil
+}
+
+// Challenge decide se o login com senha válida precisa da etapa de segundo fator
+// Quando exigida, a etapa pendente é registrada na auditoria
+func (uc *MFAUseCase) Challenge(ctx context.Context, usuario *entity.UsuarioAdministrador, clientIP string) (*MFAStatus, error) {
+	status, err := uc.Status(ctx, usuario)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Exigido() {
+		detalhes := "Senha validada; aguardando código do segundo fator"
+		if !status.Ativo {
+			detalhes = "Senha validada; política da empresa exige cadastro do segundo fator"
+		}
+		uc.audit(ctx, usuario.ID, "Login Aguardando MFA", detalhes, clientIP)
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment gera um novo segredo pendente e retorna o segredo e a URI otpauth
+// O segundo fator só passa a valer após ConfirmEnrollment com um código válido
+func (uc *MFAUseCase) BeginEnrollment(ctx context.Context, usuario *entity.UsuarioAdministrador, clientIP string) (string, string, error) {
+	existing, err := uc.mfaRepo.GetByUsuario(ctx, usuario.ID)
+	if err != nil && !strings.Contains(err.Error(), "não encontrad") {
+		return "", "", fmt.Errorf("erro ao consultar segundo fator: %v", err)
+	}
+	if err == nil && existing.Ativo {
+		return "", "", fmt.Errorf("segundo fator já está ativo")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	mfa := &entity.MFAUsuario{
+		IDUserAdmin: usuario.ID,
+		Segredo:     secret,
+		DataCriacao: time.Now(),
+	}
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return "", "", fmt.Errorf("erro ao iniciar cadastro do segundo fator: %v", err)
+	}
+
+	uc.audit(ctx, usuario.ID, "Cadastro MFA Iniciado", fmt.Sprintf("Usuário %s iniciou o cadastro do segundo fator", usuario.Email), clientIP)
+
+	return secret, totp.URI(uc.issuer, usuario.Email, secret), nil
+}
+
+// ConfirmEnrollment ativa o segundo fator com o primeiro código válido
+// Retorna os códigos de recuperação em texto claro, exibidos uma única vez
+func (uc *MFAUseCase) ConfirmEnrollment(ctx context.Context, userAdminID int, codigo, clientIP string) ([]string, error) {
+	mfa, err := uc.mfaRepo.GetByUsuario(ctx, userAdminID)
+	if err != nil {
+		return nil, fmt.Errorf("cadastro do segundo fator não iniciado")
+	}
+	if mfa.Ativo {
+		return nil, fmt.Errorf("segundo fator já está ativo")
+	}
+
+	now := time.Now()
+	step, ok := totp.Validate(mfa.Segredo, codigo, now, mfa.UltimoPasso)
+	if !ok {
+		uc.audit(ctx, userAdminID, "Falha na Verificação MFA", "Código inválido na confirmação do cadastro do segundo fator", clientIP)
+		return nil, fmt.Errorf("código MFA inválido")
+	}
+
+	codigos, hashes, err := uc.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Activate(ctx, userAdminID, step, now, hashes); err != nil {
+		return nil, fmt.Errorf("erro ao ativar segundo fator: %v", err)
+	}
+
+	uc.audit(ctx, userAdminID, "MFA Ativado", fmt.Sprintf("Segundo fator ativado com %d códigos de recuperação", len(codigos)), clientIP)
+
+	return codigos, nil
+}
+
+// Verify valida o segundo fator no login, por código TOTP ou código de recuperação
+// Cada código TOTP e cada código de recuperação é aceito uma única vez
+// desafio identifica o login pendente; com a proteção configurada, excessos retornam LoginBloqueadoError
+func (uc *MFAUseCase) Verify(ctx context.Context, usuario *entity.UsuarioAdministrador, desafio, codigo, codigoRecuperacao, clientIP string) error {
+	if uc.protecao != nil {
+		if err := uc.protecao.checkMFAThrottle(ctx, usuario, desafio, clientIP); err != nil {
+			return err
+		}
+	}
+
+	mfa, err := uc.mfaRepo.GetByUsuario(ctx, usuario.ID)
+	if err != nil || !mfa.Ativo {
+		return fmt.Errorf("segundo fator não configurado")
+	}
+
+	recuperacao := strings.TrimSpace(codigoRecuperacao) != ""
+	if recuperacao {
+		err = uc.useRecoveryCode(ctx, usuario.ID, codigoRecuperacao, clientIP)
+	} else {
+		err = uc.checkCode(ctx, mfa, codigo, clientIP, "login")
+	}
+	if err != nil {
+		if uc.protecao != nil && strings.Contains(err.Error(), "código MFA inválido") {
+			uc.protecao.registerMFAFailure(ctx, usuario, desafio, clientIP)
+		}
+		return err
+	}
+
+	if uc.protecao != nil {
+		uc.protecao.clearMFAFailures(ctx, usuario, desafio)
+	}
+	if !recuperacao {
+		uc.audit(ctx, usuario.ID, "Login MFA Concluído", "Segundo fator verificado com código TOTP", clientIP)
+	}
+	return nil
+}
+
+// Disable remove o segundo fator do usuário mediante código válido
+// Não é permitido quando a política da empresa exige segundo fator
+// Com a proteção configurada, excessos de códigos inválidos retornam LoginBloqueadoError
+func (uc *MFAUseCase) Disable(ctx context.Context, usuario *entity.UsuarioAdministrador, codigo, clientIP string) error {
+	if uc.protecao != nil {
+		if err := uc.protecao.checkMFAThrottle(ctx, usuario, "", clientIP); err != nil {
+			return err
+		}
+	}
+
+	mfa, err := uc.mfaRepo.GetByUsuario(ctx, usuario.ID)
+	if err != nil || !mfa.Ativo {
+		return fmt.Errorf("segundo fator não configurado")
+	}
+
+	empresa, err := uc.empresaRepo.GetByID(ctx, usuario.IDEmpresa)
+	if err != nil {
+		return fmt.Errorf("erro ao consultar política MFA da empresa: %v", err)
+	}
+	if empresa.MFAObrigatorio {
+		return fmt.Errorf("política da empresa exige segundo fator")
+	}
+
+	if err := uc.checkThrottledCode(ctx, usuario, mfa, codigo, clientIP, "desativação"); err != nil {
+		return err
+	}
+
+	if err := uc.mfaRepo.Delete(ctx, usuario.ID); err != nil {
+		return fmt.Errorf("erro ao desativar segundo fator: %v", err)
+	}
+
+	uc.audit(ctx, usuario.ID, "MFA Desativado", fmt.Sprintf("Usuário %s desativou o segundo fator", usuario.Email), clientIP)
+	return nil
+}
+
+// RegenerateRecoveryCodes invalida os códigos de recuperação atuais e gera novos mediante código válido
+// Com a proteção configurada, excessos de códigos inválidos retornam LoginBloqueadoError
+func (uc *MFAUseCase) RegenerateRecoveryCodes(ctx context.Context, usuario *entity.UsuarioAdministrador, codigo, clientIP string) ([]string, error) {
+	if uc.protecao != nil {
+		if err := uc.protecao.checkMFAThrottle(ctx, usuario, "", clientIP); err != nil {
+			return nil, err
+		}
+	}
+
+	userAdminID := usuario.ID
+	mfa, err := uc.mfaRepo.GetByUsuario(ctx, userAdminID)
+	if err != nil || !mfa.Ativo {
+		return nil, fmt.Errorf("segundo fator não configurado")
+	}
+
+	if err := uc.checkThrottledCode(ctx, usuario, mfa, codigo, clientIP, "geração de códigos de recuperação"); err != nil {
+		return nil, err
+	}
+
+	codigos, hashes, err := uc.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(ctx, userAdminID, hashes); err != nil {
+		return nil, fmt.Errorf("erro ao gerar códigos de recuperação: %v", err)
+	}
+
+	uc.audit(ctx, userAdminID, "Códigos de Recuperação MFA Gerados", fmt.Sprintf("%d novos códigos de recuperação; os anteriores foram invalidados", len(codigos)), clientIP)
+
+	return codigos, nil
+}
+
+// checkThrottledCode valida o código TOTP contabilizando falhas na proteção contra força bruta
+func (uc *MFAUseCase) checkThrottledCode(ctx context.Context, usuario *entity.UsuarioAdministrador, mfa *entity.MFAUsuario, codigo, clientIP, etapa string) error {
+	if err := uc.checkCode(ctx, mfa, codigo, clientIP, etapa); err != nil {
+		if uc.protecao != nil && strings.Contains(err.Error(), "código MFA inválido") {
+			uc.protecao.registerMFAFailure(ctx, usuario, "", clientIP)
+		}
+		return err
+	}
+
+	if uc.protecao != nil {
+		uc.protecao.clearMFAFailures(ctx, usuario, "")
+	}
+	return nil
+}
+
+// checkCode valida o código TOTP e registra o passo usado, impedindo reuso
+func (uc *MFAUseCase) checkCode(ctx context.Context, mfa *entity.MFAUsuario, codigo, clientIP, etapa string) error {
+	step, ok := totp.Validate(mfa.Segredo, codigo, time.Now(), mfa.UltimoPasso)
+	if !ok {
+		uc.audit(ctx, mfa.IDUserAdmin, "Falha na Verificação MFA", fmt.Sprintf("Código inválido na etapa de %s", etapa), clientIP)
+		return fmt.Errorf("código MFA inválido")
+	}
+
+	if err := uc.mfaRepo.UseStep(ctx, mfa.IDUserAdmin, step); err != nil {
+		if strings.Contains(err.Error(), "já utilizado") {
+			uc.audit(ctx, mfa.IDUserAdmin, "Falha na Verificação MFA", fmt.Sprintf("Código já utilizado reapresentado na etapa de %s", etapa), clientIP)
+			return fmt.Errorf("código MFA inválido")
+		}
+		return fmt.Errorf("erro ao verificar código MFA: %v", err)
+	}
+
+	return nil
+}
+
+// useRecoveryCode consome um código de recuperação válido
+func (uc *MFAUseCase) useRecoveryCode(ctx context.Context, userAdminID int, codigoRecuperacao, clientIP string) error {
+	codigos, err := uc.mfaRepo.ListUnusedRecoveryCodes(ctx, userAdminID)
+	if err != nil {
+		return fmt.Errorf("erro ao consultar códigos de recuperação: %v", err)
+	}
+
+	normalized := normalizeRecoveryCode(codigoRecuperacao)
+	for _, codigo := range codigos {
+		if !uc.crypto.CheckPasswordHash(normalized, codigo.CodigoHash) {
+			continue
+		}
+
+		if err := uc.mfaRepo.MarkRecoveryCodeUsed(ctx, codigo.ID, time.Now()); err != nil {
+			if strings.Contains(err.Error(), "já utilizado") {
+				break
+			}
+			return fmt.Errorf("erro ao usar código de recuperação: %v", err)
+		}
+
+		uc.audit(ctx, userAdminID, "Código de Recuperação MFA Utilizado",
+			fmt.Sprintf("Login concluído com código de recuperação; restam %d", len(codigos)-1), clientIP)
+		return nil
+	}
+
+	uc.audit(ctx, userAdminID, "Falha na Verificação MFA", "Código de recuperação inválido no login", clientIP)
+	return fmt.Errorf("código MFA inválido")
+}
+
+// generateRecoveryCodes gera os códigos de recuperação e seus hashes
+func (uc *MFAUseCase) generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codigos := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b, err := uc.crypto.GenerateRandomBytes(recoveryCodeBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao gerar código de recuperação: %v", err)
+		}
+		raw := encoding.EncodeToString(b)
+
+		hash, err := uc.crypto.HashPassword(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao proteger código de recuperação: %v", err)
+		}
+
+		codigos = append(codigos, raw[:4]+"-"+raw[4:])
+		hashes = append(hashes, hash)
+	}
+
+	return codigos, hashes, nil
+}
+
+// normalizeRecoveryCode aceita o código com ou sem hífen, espaços e minúsculas
+func normalizeRecoveryCode(codigo string) string {
+	codigo = strings.ToUpper(strings.TrimSpace(codigo))
+	return strings.NewReplacer("-", "", " ", "").Replace(codigo)
+}
+
+// audit registra uma etapa do segundo fator no log de auditoria
+func (uc *MFAUseCase) audit(ctx context.Context, userAdminID int, acao, detalhes, clientIP string) {
+	if uc.logAuditoriaRepo == nil {
+		return
+	}
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: acao,
+		Detalhes:      detalhes,
+		EnderecoIP:    clientIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+}
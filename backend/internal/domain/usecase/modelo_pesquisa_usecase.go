@@ -0,0 +1,260 @@
+// Package usecase implementa os casos de uso para Modelos de Pesquisa.
+// Fornece a biblioteca de modelos do sistema e das empresas, com versionamento das perguntas.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ModeloPesquisaUseCase implementa casos de uso para gerenciamento de modelos de pesquisa
+type ModeloPesquisaUseCase struct {
+	repo             repository.ModeloPesquisaRepository // Repositório de modelos
+	empresaRepo      repository.EmpresaRepository        // Repositório de empresas
+	logAuditoriaRepo repository.LogAuditoriaRepository   // Repositório de logs
+}
+
+// NewModeloPesquisaUseCase cria uma nova instância do caso de uso de modelos de pesquisa
+func NewModeloPesquisaUseCase(repo repository.ModeloPesquisaRepository,
+	empresaRepo repository.EmpresaRepository,
+	logRepo repository.LogAuditoriaRepository) *ModeloPesquisaUseCase {
+	return &ModeloPesquisaUseCase{
+		repo:             repo,
+		empresaRepo:      empresaRepo,
+		logAuditoriaRepo: logRepo,
+	}
+}
+
+// tiposPerguntaValidos são os tipos de pergunta aceitos nos modelos
+var tiposPerguntaValidos = map[string]bool{
+	"MultiplaEscolha": true,
+	"RespostaAberta":  true,
+	"EscalaNumerica":  true,
+	"SimNao":          true,
+}
+
+// getModeloInScope busca um modelo ativo visível no contexto
+// Modelos do sistema são visíveis a todas as empresas; modelos de outra empresa são tratados como inexistentes
+func getModeloInScope(ctx context.Context, repo repository.ModeloPesquisaRepository, modeloID int) (*entity.ModeloPesquisa, error) {
+	modelo, err := repo.GetByID(ctx, modeloID)
+	if err != nil {
+		return nil, fmt.Errorf("modelo de pesquisa não encontrado: %v", err)
+	}
+	if !modelo.Ativo {
+		return nil, fmt.Errorf("modelo de pesquisa não encontrado")
+	}
+	if !modelo.DoSistema() {
+		if err := checkEmpresaScope(ctx, *modelo.IDEmpresa, "modelo de pesquisa não encontrado"); err != nil {
+			return nil, err
+		}
+	}
+	return modelo, nil
+}
+
+// validarPerguntasModelo valida as perguntas de um modelo e grava a forma normalizada
+// As opções passam pelas mesmas regras das perguntas de pesquisa, para que a cópia não falhe na criação
+func validarPerguntasModelo(perguntas []entity.PerguntaModelo) error {
+	if len(perguntas) == 0 || len(perguntas) > entity.MaxPerguntasModelo {
+		return fmt.Errorf("perguntas do modelo inválidas: o modelo deve ter entre 1 e %d perguntas", entity.MaxPerguntasModelo)
+	}
+
+	for i := range perguntas {
+		p := &perguntas[i]
+		p.TextoPergunta = strings.TrimSpace(p.TextoPergunta)
+		p.Dimensao = strings.ToLower(strings.TrimSpace(p.Dimensao))
+
+		if p.TextoPergunta == "" {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: texto da pergunta é obrigatório", i+1)
+		}
+		if len(p.TextoPergunta) > entity.MaxTextoPergunta {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: texto da pergunta não pode exceder %d caracteres", i+1, entity.MaxTextoPergunta)
+		}
+		if !tiposPerguntaValidos[p.TipoPergunta] {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: tipo de pergunta inválido: %s", i+1, p.TipoPergunta)
+		}
+		if len(p.Dimensao) > entity.MaxDimensaoPergunta {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: dimensão não pode exceder %d caracteres", i+1, entity.MaxDimensaoPergunta)
+		}
+
+		pergunta := p.ToPergunta(0, i+1)
+		if err := normalizeOpcoesResposta(pergunta); err != nil {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: %v", i+1, err)
+		}
+		if p.PontuacaoInvertida && !entity.PerguntaPontuavel(pergunta) {
+			return fmt.Errorf("perguntas do modelo inválidas: pergunta %d: pontuação invertida só se aplica a perguntas de escala (exceto eNPS) e Sim/Não", i+1)
+		}
+		p.OpcoesResposta = pergunta.OpcoesResposta
+	}
+
+	return nil
+}
+
+// Create cria um modelo privado da empresa com a versão 1 das perguntas
+func (uc *ModeloPesquisaUseCase) Create(ctx context.Context, modelo *entity.ModeloPesquisa, userAdminID int, enderecoIP string) error {
+	// Modelos do sistema só são criados por migration
+	if modelo.IDEmpresa == nil || *modelo.IDEmpresa <= 0 {
+		return fmt.Errorf("ID da empresa é obrigatório")
+	}
+
+	modelo.Nome = strings.TrimSpace(modelo.Nome)
+	modelo.Descricao = strings.TrimSpace(modelo.Descricao)
+	if modelo.Nome == "" {
+		return fmt.Errorf("nome do modelo é obrigatório")
+	}
+
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, *modelo.IDEmpresa, "empresa não encontrada"); err != nil {
+		return err
+	}
+	if _, err := uc.empresaRepo.GetByID(ctx, *modelo.IDEmpresa); err != nil {
+		return fmt.Errorf("empresa não encontrada: %v", err)
+	}
+
+	if err := validarPerguntasModelo(modelo.Perguntas); err != nil {
+		return err
+	}
+
+	modelo.DataCriacao = time.Now()
+	modelo.DataAtualizacao = modelo.DataCriacao
+
+	if err := uc.repo.Create(ctx, modelo, userAdminID); err != nil {
+		return fmt.Errorf("erro ao criar modelo: %v", err)
+	}
+
+	// Log de auditoria
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Modelo de Pesquisa Criado",
+		Detalhes:      fmt.Sprintf("Modelo de pesquisa criado: %s (ID: %d, %d perguntas)", modelo.Nome, modelo.ID, len(modelo.Perguntas)),
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nil
+}
+
+// GetByID busca um modelo com as perguntas da versão informada (0: versão atual)
+func (uc *ModeloPesquisaUseCase) GetByID(ctx context.Context, id, versao int) (*entity.ModeloPesquisa, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID do modelo deve ser maior que zero")
+	}
+	if versao < 0 {
+		return nil, fmt.Errorf("versão do modelo não pode ser negativa")
+	}
+
+	modelo, err := getModeloInScope(ctx, uc.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if versao > 0 && versao != modelo.Versao {
+		v, err := uc.repo.GetVersao(ctx, id, versao)
+		if err != nil {
+			return nil, fmt.Errorf("versão do modelo não encontrada: %v", err)
+		}
+		modelo.Versao = v.Versao
+		modelo.Perguntas = v.Perguntas
+	}
+
+	return modelo, nil
+}
+
+// ListByEmpresa lista os modelos disponíveis para a empresa: os do sistema e os privados
+func (uc *ModeloPesquisaUseCase) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.ModeloPesquisa, error) {
+	if empresaID <= 0 {
+		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
+	}
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListDisponiveis(ctx, empresaID)
+}
+
+// ListVersoes lista o histórico de versões de um modelo
+func (uc *ModeloPesquisaUseCase) ListVersoes(ctx context.Context, id int) ([]*entity.VersaoModelo, error) {
+	if _, err := getModeloInScope(ctx, uc.repo, id); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListVersoes(ctx, id)
+}
+
+// Update altera um modelo da empresa
+// Quando as perguntas mudam é gerada uma nova versão; pesquisas já criadas continuam ligadas à versão usada
+func (uc *ModeloPesquisaUseCase) Update(ctx context.Context, modelo *entity.ModeloPesquisa, userAdminID int, enderecoIP string) error {
+	atual, err := getModeloInScope(ctx, uc.repo, modelo.ID)
+	if err != nil {
+		return err
+	}
+	if atual.DoSistema() {
+		return fmt.Errorf("modelos do sistema são somente leitura")
+	}
+
+	modelo.IDEmpresa = atual.IDEmpresa
+	modelo.Nome = strings.TrimSpace(modelo.Nome)
+	modelo.Descricao = strings.TrimSpace(modelo.Descricao)
+	if modelo.Nome == "" {
+		return fmt.Errorf("nome do modelo é obrigatório")
+	}
+
+	if err := validarPerguntasModelo(modelo.Perguntas); err != nil {
+		return err
+	}
+	novaVersao := !reflect.DeepEqual(atual.Perguntas, modelo.Perguntas)
+
+	modelo.DataAtualizacao = time.Now()
+	if err := uc.repo.Update(ctx, modelo, novaVersao, userAdminID); err != nil {
+		return fmt.Errorf("erro ao atualizar modelo: %v", err)
+	}
+
+	// Log de auditoria
+	detalhes := fmt.Sprintf("Modelo de pesquisa atualizado: %s (ID: %d)", modelo.Nome, modelo.ID)
+	if novaVersao {
+		detalhes = fmt.Sprintf("Modelo de pesquisa atualizado: %s (ID: %d, nova versão %d)", modelo.Nome, modelo.ID, modelo.Versao)
+	}
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Modelo de Pesquisa Atualizado",
+		Detalhes:      detalhes,
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nil
+}
+
+// Delete remove um modelo da empresa da biblioteca
+// As versões são preservadas para manter a origem das pesquisas criadas a partir do modelo
+func (uc *ModeloPesquisaUseCase) Delete(ctx context.Context, id int, userAdminID int, enderecoIP string) error {
+	modelo, err := getModeloInScope(ctx, uc.repo, id)
+	if err != nil {
+		return err
+	}
+	if modelo.DoSistema() {
+		return fmt.Errorf("modelos do sistema são somente leitura")
+	}
+
+	if err := uc.repo.Deactivate(ctx, id); err != nil {
+		return fmt.Errorf("erro ao remover modelo: %v", err)
+	}
+
+	// Log de auditoria
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Modelo de Pesquisa Removido",
+		Detalhes:      fmt.Sprintf("Modelo de pesquisa removido: %s (ID: %d)", modelo.Nome, modelo.ID),
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nil
+}
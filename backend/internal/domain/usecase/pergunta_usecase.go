@@ -17,6 +17,8 @@ type PerguntaUseCase struct {
 	respostaRepo     repository.RespostaRepository     // Repositório de respostas
 	pesquisaRepo     repository.PesquisaRepository     // Repositório de pesquisas
 	logAuditoriaRepo repository.LogAuditoriaRepository // Repositório de logs
+	anonimato        *PoliticaAnonimato                // Mínimo de respondentes das estatísticas
+	dimensaoRepo     repository.DimensaoRepository     // Repositório de dimensões (opcional, vínculo das perguntas)
 }
 
 // NewPerguntaUseCase cria uma nova instância do caso de uso de perguntas
@@ -32,6 +34,18 @@ func NewPerguntaUseCase(repo repository.PerguntaRepository,
 	}
 }
 
+// SetAnonimato define a política de anonimato aplicada às estatísticas
+// Sem esta configuração vale o mínimo padrão de respondentes
+func (uc *PerguntaUseCase) SetAnonimato(anonimato *PoliticaAnonimato) {
+	uc.anonimato = anonimato
+}
+
+// SetDimensoes habilita o vínculo das perguntas às dimensões da empresa
+// Sem esta configuração perguntas com dimensão são rejeitadas
+func (uc *PerguntaUseCase) SetDimensoes(dimensaoRepo repository.DimensaoRepository) {
+	uc.dimensaoRepo = dimensaoRepo
+}
+
 // Create cria uma nova pergunta com validações
 func (uc *PerguntaUseCase) Create(ctx context.Context, pergunta *entity.Pergunta, userAdminID int, enderecoIP string) error {
 	// Validações básicas
@@ -43,10 +57,10 @@ func (uc *PerguntaUseCase) Create(ctx context.Context, pergunta *entity.Pergunta
 		return fmt.Errorf("texto da pergunta é obrigatório")
 	}
 
-	// Verifica se pesquisa existe
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pergunta.IDPesquisa)
+	// Verifica se pesquisa existe e pertence à empresa
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pergunta.IDPesquisa)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Não permite adicionar perguntas em pesquisas ativas
@@ -66,6 +80,14 @@ func (uc *PerguntaUseCase) Create(ctx context.Context, pergunta *entity.Pergunta
 		return fmt.Errorf("tipo de pergunta inválido: %s", pergunta.TipoPergunta)
 	}
 
+	if err := normalizeOpcoesResposta(pergunta); err != nil {
+		return err
+	}
+
+	if err := uc.validarDimensaoPergunta(ctx, pergunta, pesquisa); err != nil {
+		return err
+	}
+
 	// Define ordem se não informada
 	if pergunta.OrdemExibicao <= 0 {
 		// Busca próxima ordem disponível
@@ -76,6 +98,10 @@ func (uc *PerguntaUseCase) Create(ctx context.Context, pergunta *entity.Pergunta
 		pergunta.OrdemExibicao = len(perguntas) + 1
 	}
 
+	if err := uc.validarCondicoes(ctx, pergunta.IDPesquisa, []*entity.Pergunta{pergunta}, nil, 0); err != nil {
+		return err
+	}
+
 	if err := uc.repo.Create(ctx, pergunta); err != nil {
 		return fmt.Errorf("erro ao criar pergunta: %v", err)
 	}
@@ -95,6 +121,108 @@ func (uc *PerguntaUseCase) Create(ctx context.Context, pergunta *entity.Pergunta
 	return nil
 }
 
+// normalizeOpcoesResposta valida as opções das perguntas de múltipla escolha e de escala numérica
+// e grava a forma normalizada (IDs gerados para listas de rótulos, limites e passo preenchidos)
+func normalizeOpcoesResposta(pergunta *entity.Pergunta) error {
+	var normalizadas string
+
+	switch pergunta.TipoPergunta {
+	case entity.TipoMultiplaEscolha:
+		opcoes, err := entity.ParseOpcoesMultiplaEscolha(pergunta.OpcoesResposta)
+		if err != nil {
+			return fmt.Errorf("opções de resposta inválidas: %v", err)
+		}
+		normalizadas = opcoes.JSON()
+
+	case entity.TipoEscalaNumerica:
+		// Sem configuração, a pergunta usa a escala padrão de 1 a 10
+		if pergunta.OpcoesResposta == nil || strings.TrimSpace(*pergunta.OpcoesResposta) == "" {
+			pergunta.OpcoesResposta = nil
+			return nil
+		}
+		escala, err := entity.ParseEscalaNumerica(pergunta.OpcoesResposta)
+		if err != nil {
+			return fmt.Errorf("opções de resposta inválidas: %v", err)
+		}
+		normalizadas = escala.JSON()
+
+	default:
+		return nil
+	}
+
+	pergunta.OpcoesResposta = &normalizadas
+	return nil
+}
+
+// validarDimensaoPergunta verifica se a dimensão da pergunta é da empresa da pesquisa
+// e se a pontuação invertida se aplica ao tipo da pergunta (deve ser chamada após normalizeOpcoesResposta)
+func (uc *PerguntaUseCase) validarDimensaoPergunta(ctx context.Context, pergunta *entity.Pergunta, pesquisa *entity.Pesquisa) error {
+	if pergunta.PontuacaoInvertida && !entity.PerguntaPontuavel(pergunta) {
+		return fmt.Errorf("dimensão da pergunta inválida: pontuação invertida só se aplica a perguntas de escala (exceto eNPS) e Sim/Não")
+	}
+	if pergunta.IDDimensao == nil {
+		return nil
+	}
+	if uc.dimensaoRepo == nil {
+		return fmt.Errorf("dimensões não configuradas")
+	}
+
+	dimensao, err := uc.dimensaoRepo.GetByID(ctx, *pergunta.IDDimensao)
+	if err != nil || dimensao.IDEmpresa != pesquisa.IDEmpresa {
+		return fmt.Errorf("dimensão da pergunta inválida: a dimensão ID %d não pertence à empresa da pesquisa", *pergunta.IDDimensao)
+	}
+	return nil
+}
+
+// validarCondicoes valida as condições de exibição da pesquisa como ficarão após a alteração
+// alteradas substituem as perguntas de mesmo ID (perguntas novas, com ID 0, são acrescentadas), ordens redefine
+// a ordem de exibição e removida exclui uma pergunta. As condições das perguntas alteradas são gravadas na forma canônica
+func (uc *PerguntaUseCase) validarCondicoes(ctx context.Context, pesquisaID int, alteradas []*entity.Pergunta, ordens map[int]int, removida int) error {
+	atuais, err := uc.repo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	substituidas := make(map[int]*entity.Pergunta, len(alteradas))
+	perguntas := make([]*entity.Pergunta, 0, len(atuais)+len(alteradas))
+	for _, pergunta := range alteradas {
+		if pergunta.ID > 0 {
+			substituidas[pergunta.ID] = pergunta
+		}
+		perguntas = append(perguntas, pergunta)
+	}
+	for _, atual := range atuais {
+		if atual.ID == removida || substituidas[atual.ID] != nil {
+			continue
+		}
+		if ordem, ok := ordens[atual.ID]; ok {
+			copia := *atual
+			copia.OrdemExibicao = ordem
+			atual = &copia
+		}
+		perguntas = append(perguntas, atual)
+	}
+
+	if removida > 0 {
+		for _, pergunta := range perguntas {
+			condicao, err := entity.ParseCondicaoExibicao(pergunta.CondicaoExibicao)
+			if err != nil || condicao == nil {
+				continue
+			}
+			for _, ref := range condicao.Referencias() {
+				if ref == removida {
+					return fmt.Errorf("não é possível deletar: a pergunta é usada na condição de exibição da pergunta %d", pergunta.ID)
+				}
+			}
+		}
+	}
+
+	if err := entity.ValidarCondicoesExibicao(perguntas); err != nil {
+		return fmt.Errorf("condição de exibição inválida: %v", err)
+	}
+	return nil
+}
+
 // CreateBatch cria múltiplas perguntas em lote
 func (uc *PerguntaUseCase) CreateBatch(ctx context.Context, perguntas []*entity.Pergunta, userAdminID int, enderecoIP string) error {
 	if len(perguntas) == 0 {
@@ -111,18 +239,33 @@ func (uc *PerguntaUseCase) CreateBatch(ctx context.Context, perguntas []*entity.
 		if strings.TrimSpace(pergunta.TextoPergunta) == "" {
 			return fmt.Errorf("pergunta %d: texto é obrigatório", i+1)
 		}
+
+		if err := normalizeOpcoesResposta(pergunta); err != nil {
+			return fmt.Errorf("pergunta %d: %v", i+1, err)
+		}
 	}
 
-	// Verifica se pesquisa existe e se permite edição
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe, pertence à empresa e se permite edição
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	if pesquisa.Status == "Ativa" || pesquisa.Status == "Concluída" {
 		return fmt.Errorf("não é possível adicionar perguntas em pesquisas ativas ou concluídas")
 	}
 
+	for i, pergunta := range perguntas {
+		if err := uc.validarDimensaoPergunta(ctx, pergunta, pesquisa); err != nil {
+			return fmt.Errorf("pergunta %d: %v", i+1, err)
+		}
+	}
+
+	// Perguntas do lote só podem depender de perguntas já cadastradas, pois ainda não têm ID
+	if err := uc.validarCondicoes(ctx, pesquisaID, perguntas, nil, 0); err != nil {
+		return err
+	}
+
 	if err := uc.repo.CreateBatch(ctx, perguntas); err != nil {
 		return fmt.Errorf("erro ao criar perguntas: %v", err)
 	}
@@ -148,7 +291,8 @@ func (uc *PerguntaUseCase) GetByID(ctx context.Context, id int) (*entity.Pergunt
 		return nil, fmt.Errorf("ID da pergunta deve ser maior que zero")
 	}
 
-	return uc.repo.GetByID(ctx, id)
+	pergunta, _, err := getPerguntaInScope(ctx, uc.repo, uc.pesquisaRepo, id)
+	return pergunta, err
 }
 
 // ListByPesquisa lista todas as perguntas de uma pesquisa
@@ -157,6 +301,10 @@ func (uc *PerguntaUseCase) ListByPesquisa(ctx context.Context, pesquisaID int) (
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
+	if _, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID); err != nil {
+		return nil, err
+	}
+
 	return uc.repo.ListByPesquisa(ctx, pesquisaID)
 }
 
@@ -171,22 +319,32 @@ func (uc *PerguntaUseCase) Update(ctx context.Context, pergunta *entity.Pergunta
 		return fmt.Errorf("texto da pergunta é obrigatório")
 	}
 
-	// Verifica se pergunta existe
-	existing, err := uc.repo.GetByID(ctx, pergunta.ID)
+	// Verifica se pergunta existe e pertence à empresa
+	existing, pesquisa, err := getPerguntaInScope(ctx, uc.repo, uc.pesquisaRepo, pergunta.ID)
 	if err != nil {
-		return fmt.Errorf("pergunta não encontrada: %v", err)
+		return err
 	}
 
-	// Verifica se pesquisa permite edição
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, existing.IDPesquisa)
-	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
-	}
+	// Não permite mover a pergunta para outra pesquisa
+	pergunta.IDPesquisa = existing.IDPesquisa
 
 	if pesquisa.Status == "Ativa" || pesquisa.Status == "Concluída" {
 		return fmt.Errorf("não é possível editar perguntas de pesquisas ativas ou concluídas")
 	}
 
+	if err := normalizeOpcoesResposta(pergunta); err != nil {
+		return err
+	}
+
+	if err := uc.validarDimensaoPergunta(ctx, pergunta, pesquisa); err != nil {
+		return err
+	}
+
+	// Revalida todas as condições: a mudança de tipo, opções ou ordem pode invalidar as perguntas dependentes
+	if err := uc.validarCondicoes(ctx, pergunta.IDPesquisa, []*entity.Pergunta{pergunta}, nil, 0); err != nil {
+		return err
+	}
+
 	if err := uc.repo.Update(ctx, pergunta); err != nil {
 		return fmt.Errorf("erro ao atualizar pergunta: %v", err)
 	}
@@ -213,21 +371,19 @@ func (uc *PerguntaUseCase) Delete(ctx context.Context, id int, userAdminID int,
 	}
 
 	// Busca pergunta para validações e log
-	pergunta, err := uc.repo.GetByID(ctx, id)
-	if err != nil {
-		return fmt.Errorf("pergunta não encontrada: %v", err)
-	}
-
-	// Verifica se pesquisa permite edição
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pergunta.IDPesquisa)
+	pergunta, pesquisa, err := getPerguntaInScope(ctx, uc.repo, uc.pesquisaRepo, id)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	if pesquisa.Status == "Ativa" || pesquisa.Status == "Concluída" {
 		return fmt.Errorf("não é possível deletar perguntas de pesquisas ativas ou concluídas")
 	}
 
+	if err := uc.validarCondicoes(ctx, pergunta.IDPesquisa, nil, nil, id); err != nil {
+		return err
+	}
+
 	if err := uc.repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("erro ao deletar pergunta: %v", err)
 	}
@@ -257,22 +413,20 @@ func (uc *PerguntaUseCase) UpdateOrdem(ctx context.Context, perguntaID int, nova
 		return fmt.Errorf("ordem deve ser maior que zero")
 	}
 
-	// Verifica se pergunta existe
-	pergunta, err := uc.repo.GetByID(ctx, perguntaID)
-	if err != nil {
-		return fmt.Errorf("pergunta não encontrada: %v", err)
-	}
-
-	// Verifica se pesquisa permite edição
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pergunta.IDPesquisa)
+	// Verifica se pergunta existe e pertence à empresa
+	pergunta, pesquisa, err := getPerguntaInScope(ctx, uc.repo, uc.pesquisaRepo, perguntaID)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	if pesquisa.Status == "Ativa" || pesquisa.Status == "Concluída" {
 		return fmt.Errorf("não é possível reordenar perguntas de pesquisas ativas ou concluídas")
 	}
 
+	if err := uc.validarCondicoes(ctx, pergunta.IDPesquisa, nil, map[int]int{perguntaID: novaOrdem}, 0); err != nil {
+		return err
+	}
+
 	if err := uc.repo.UpdateOrdem(ctx, perguntaID, novaOrdem); err != nil {
 		return fmt.Errorf("erro ao atualizar ordem: %v", err)
 	}
@@ -302,10 +456,10 @@ func (uc *PerguntaUseCase) ReorderPerguntas(ctx context.Context, pesquisaID int,
 		return fmt.Errorf("lista de IDs não pode estar vazia")
 	}
 
-	// Verifica se pesquisa existe e permite edição
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe, pertence à empresa e permite edição
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	if pesquisa.Status == "Ativa" || pesquisa.Status == "Concluída" {
@@ -335,6 +489,15 @@ func (uc *PerguntaUseCase) ReorderPerguntas(ctx context.Context, pesquisaID int,
 		return fmt.Errorf("todos os IDs das perguntas devem estar incluídos na reordenação")
 	}
 
+	// Nenhuma pergunta pode passar a ser exibida antes das perguntas de que depende
+	ordens := make(map[int]int, len(perguntaIDs))
+	for i, perguntaID := range perguntaIDs {
+		ordens[perguntaID] = i + 1
+	}
+	if err := uc.validarCondicoes(ctx, pesquisaID, nil, ordens, 0); err != nil {
+		return err
+	}
+
 	// Atualiza a ordem de cada pergunta
 	for i, perguntaID := range perguntaIDs {
 		novaOrdem := i + 1
@@ -365,8 +528,13 @@ type PerguntaComEstatisticas struct {
 	TipoPergunta   string                 `json:"tipo_pergunta"`
 	OrdemExibicao  int                    `json:"ordem_exibicao"`
 	OpcoesResposta *string                `json:"opcoes_resposta"`
+	Obrigatoria    bool                   `json:"obrigatoria"`
+	IDDimensao     *int                   `json:"id_dimensao"`
 	TotalRespostas int                    `json:"total_respostas"`
+	Exibicoes      *int                   `json:"exibicoes"`    // Submissões em que a pergunta foi exibida (nulo com menos de k)
+	TaxaOmissao    *float64               `json:"taxa_omissao"` // Percentual das exibições sem resposta (nulo com menos de k exibições)
 	Estatisticas   map[string]interface{} `json:"estatisticas"`
+	Supressao      *Supressao             `json:"supressao,omitempty"` // Preenchida quando as estatísticas são omitidas por anonimato
 }
 
 // GetPerguntasWithStats retorna perguntas com suas estatísticas
@@ -375,12 +543,28 @@ func (uc *PerguntaUseCase) GetPerguntasWithStats(ctx context.Context, pesquisaID
 		return nil, fmt.Errorf("ID da pesquisa inválido")
 	}
 
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+
 	// Busca perguntas da pesquisa
 	perguntas, err := uc.repo.ListByPesquisa(ctx, pesquisaID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
 	}
 
+	// Exibições e omissões por pergunta, considerando as condições de exibição de cada submissão
+	completude, err := carregarCompletude(ctx, uc.repo, uc.respostaRepo, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*PerguntaComEstatisticas, len(perguntas))
 
 	for i, pergunta := range perguntas {
@@ -393,7 +577,11 @@ func (uc *PerguntaUseCase) GetPerguntasWithStats(ctx context.Context, pesquisaID
 		// Busca estatísticas agregadas
 		stats := make(map[string]interface{})
 
-		if totalRespostas > 0 {
+		// Perguntas com menos respondentes que o mínimo da empresa não têm estatísticas
+		var supressao *Supressao
+		if totalRespostas > 0 && totalRespostas < k {
+			supressao = novaSupressao(k)
+		} else if totalRespostas > 0 {
 			aggregated, err := uc.respostaRepo.GetAggregatedByPergunta(ctx, pergunta.ID)
 			if err != nil {
 				return nil, fmt.Errorf("erro ao buscar estatísticas da pergunta %d: %v", pergunta.ID, err)
@@ -401,14 +589,28 @@ func (uc *PerguntaUseCase) GetPerguntasWithStats(ctx context.Context, pesquisaID
 
 			// Processa estatísticas baseadas no tipo de pergunta
 			switch pergunta.TipoPergunta {
-			case "MultiplaEscolha":
+			case entity.TipoMultiplaEscolha:
+				aggregated = entity.OpcoesDaPergunta(pergunta).AgregarSelecoes(aggregated)
 				stats["distribuicao_opcoes"] = aggregated
 				stats["opcao_mais_escolhida"] = getMostFrequentOption(aggregated)
 
-			case "EscalaNumerica":
+			case entity.TipoEscalaNumerica:
+				escala := entity.EscalaDaPergunta(pergunta)
+				media, validas := escala.Media(aggregated)
 				stats["distribuicao_valores"] = aggregated
-				stats["media"] = calculateAverage(aggregated)
+				stats["escala"] = escala
+				stats["respostas_validas"] = validas
+				stats["media"] = nil
+				stats["media_normalizada"] = nil
+				if validas > 0 {
+					stats["media"] = media
+					stats["media_normalizada"] = escala.Normalizar(media)
+				}
+				stats["nao_se_aplica"] = aggregated[entity.ValorNaoSeAplica]
 				stats["valor_mais_comum"] = getMostFrequentOption(aggregated)
+				if !entity.PerguntaENPS(pergunta) {
+					stats["favorabilidade"] = favorabilidadeVisivel(entity.CalcularFavorabilidade(pergunta, aggregated), k)
+				}
 
 			case "SimNao":
 				stats["distribuicao"] = aggregated
@@ -424,14 +626,24 @@ func (uc *PerguntaUseCase) GetPerguntasWithStats(ctx context.Context, pesquisaID
 			}
 		}
 
+		var exibicoes *int
+		if n := completude.Exibicoes[pergunta.ID]; n >= k {
+			exibicoes = &n
+		}
+
 		result[i] = &PerguntaComEstatisticas{
 			ID:             pergunta.ID,
 			TextoPergunta:  pergunta.TextoPergunta,
 			TipoPergunta:   pergunta.TipoPergunta,
 			OrdemExibicao:  pergunta.OrdemExibicao,
 			OpcoesResposta: pergunta.OpcoesResposta,
+			Obrigatoria:    pergunta.Obrigatoria,
+			IDDimensao:     pergunta.IDDimensao,
 			TotalRespostas: totalRespostas,
+			Exibicoes:      exibicoes,
+			TaxaOmissao:    completude.TaxaOmissao(pergunta.ID, k),
 			Estatisticas:   stats,
+			Supressao:      supressao,
 		}
 	}
 
@@ -452,23 +664,3 @@ func getMostFrequentOption(aggregated map[string]int) string {
 
 	return mostFrequent
 }
-
-func calculateAverage(aggregated map[string]int) float64 {
-	total := 0.0
-	count := 0.0
-
-	for valueStr, freq := range aggregated {
-		// Tenta converter string para número
-		var value float64
-		if _, err := fmt.Sscanf(valueStr, "%f", &value); err == nil {
-			total += value * float64(freq)
-			count += float64(freq)
-		}
-	}
-
-	if count == 0 {
-		return 0
-	}
-
-	return total / count
-}
@@ -0,0 +1,348 @@
+// Package usecase implementa a geração dos QR codes das pesquisas.
+// Fornece a geração, o armazenamento e o download das imagens do link de acesso, com logo opcional da empresa.
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Logos em JPEG
+	"image/png"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/pkg/qrcode"
+	"organizational-climate-survey/backend/pkg/storage"
+)
+
+// Limites do logo sobreposto ao QR code
+const (
+	maxLogoBytes     = 512 * 1024 // Tamanho máximo do arquivo enviado
+	maxLogoDimension = 1024       // Maior largura/altura aceita, em pixels
+)
+
+// QRCodeConfig agrupa as configurações de geração de QR codes
+type QRCodeConfig struct {
+	PublicURL    string       // URL pública das pesquisas; o link de acesso é acrescentado ao final
+	DefaultSize  int          // Largura padrão da imagem em pixels
+	DefaultLevel qrcode.Level // Nível de correção padrão
+}
+
+// QRCodeOptions são as opções de geração informadas pelo administrador
+// Opções omitidas mantêm as da última geração (ou os padrões)
+type QRCodeOptions struct {
+	Tamanho     int    // Largura da imagem em pixels
+	Nivel       string // Nível de correção: L, M, Q ou H
+	Logo        []byte // Logo da empresa (PNG ou JPEG) a sobrepor ao centro
+	RemoverLogo bool   // Remove o logo salvo anteriormente
+}
+
+// QRCodeImage é uma imagem de QR code pronta para download
+type QRCodeImage struct {
+	Name        string // Nome sugerido do arquivo
+	ContentType string // Tipo MIME
+	Data        []byte // Conteúdo da imagem
+}
+
+// qrCodeSettings são as opções persistidas junto das imagens para reaproveitar na regeneração
+type qrCodeSettings struct {
+	Tamanho int    `json:"tamanho"`
+	Nivel   string `json:"nivel"`
+	Logo    bool   `json:"logo"`
+}
+
+// Formatos de imagem disponíveis para download
+var qrCodeFormats = map[string]string{
+	"png": "image/png",
+	"svg": "image/svg+xml",
+}
+
+// SetQRCode habilita a geração de imagens de QR code
+// Sem esta configuração GenerateQRCode retorna erro e RegenerateLinkAcesso apenas troca o link
+func (uc *PesquisaUseCase) SetQRCode(store storage.Storage, cfg QRCodeConfig) {
+	if cfg.DefaultSize <= 0 {
+		cfg.DefaultSize = qrcode.DefaultSize
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	uc.qrStorage = store
+	uc.qrConfig = cfg
+}
+
+// PublicURL retorna a URL pública de resposta da pesquisa, codificada no QR code
+func (uc *PesquisaUseCase) PublicURL(pesquisa *entity.Pesquisa) string {
+	return uc.qrConfig.PublicURL + "/" + pesquisa.LinkAcesso
+}
+
+// GenerateQRCode gera as imagens PNG e SVG do QR code da pesquisa e atualiza o caminho salvo
+func (uc *PesquisaUseCase) GenerateQRCode(ctx context.Context, pesquisaID int, opts QRCodeOptions, userAdminID int, enderecoIP string) (*entity.Pesquisa, error) {
+	if uc.qrStorage == nil {
+		return nil, fmt.Errorf("geração de QR code não configurada")
+	}
+	if pesquisaID <= 0 {
+		return nil, fmt.Errorf("ID da pesquisa inválido")
+	}
+
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+	if pesquisa.LinkAcesso == "" {
+		return nil, fmt.Errorf("pesquisa sem link de acesso")
+	}
+
+	settings := uc.loadQRCodeSettings(ctx, pesquisa.ID)
+	if opts.Tamanho != 0 {
+		if opts.Tamanho < qrcode.MinSize || opts.Tamanho > qrcode.MaxSize {
+			return nil, fmt.Errorf("tamanho do QR code deve estar entre %d e %d pixels", qrcode.MinSize, qrcode.MaxSize)
+		}
+		settings.Tamanho = opts.Tamanho
+	}
+	if opts.Nivel != "" {
+		level, err := qrcode.ParseLevel(opts.Nivel)
+		if err != nil {
+			return nil, err
+		}
+		settings.Nivel = level.String()
+	}
+
+	// Atualiza o logo antes de gerar: um logo inválido não deve produzir imagens parciais
+	logoKey := qrCodeLogoKey(pesquisa.ID)
+	switch {
+	case len(opts.Logo) > 0:
+		logo, err := decodeLogo(opts.Logo)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, logo); err != nil {
+			return nil, fmt.Errorf("erro ao converter logo: %v", err)
+		}
+		if err := uc.qrStorage.Save(ctx, logoKey, buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("erro ao salvar logo: %v", err)
+		}
+		settings.Logo = true
+	case opts.RemoverLogo:
+		if err := uc.qrStorage.Delete(ctx, logoKey); err != nil {
+			return nil, fmt.Errorf("erro ao remover logo: %v", err)
+		}
+		settings.Logo = false
+	}
+
+	qrPath, err := uc.renderQRCode(ctx, pesquisa, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPath := pesquisa.QRCodePath
+	if err := uc.pesquisaRepo.UpdateQRCodePath(ctx, pesquisa.ID, qrPath); err != nil {
+		return nil, fmt.Errorf("erro ao salvar QR code: %v", err)
+	}
+	pesquisa.QRCodePath = qrPath
+
+	if oldPath != "" && oldPath != qrPath {
+		uc.deleteQRCodeFiles(ctx, oldPath)
+	}
+
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "QR Code Gerado",
+		Detalhes:      fmt.Sprintf("QR code gerado para pesquisa: %s (ID: %d), %dpx, nível %s, logo: %t", pesquisa.Titulo, pesquisa.ID, settings.Tamanho, settings.Nivel, settings.Logo),
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return pesquisa, nil
+}
+
+// GetQRCode retorna a imagem do QR code da pesquisa no formato informado (png ou svg)
+func (uc *PesquisaUseCase) GetQRCode(ctx context.Context, pesquisaID int, formato string) (*QRCodeImage, error) {
+	if uc.qrStorage == nil {
+		return nil, fmt.Errorf("geração de QR code não configurada")
+	}
+
+	formato = strings.ToLower(strings.TrimSpace(formato))
+	if formato == "" {
+		formato = "png"
+	}
+	contentType, ok := qrCodeFormats[formato]
+	if !ok {
+		return nil, fmt.Errorf("formato inválido: use png ou svg")
+	}
+
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := qrCodeKey(pesquisa.QRCodePath, formato)
+	if !ok {
+		return nil, fmt.Errorf("QR code não encontrado: gere o QR code da pesquisa")
+	}
+
+	file, err := uc.qrStorage.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("QR code não encontrado: gere o QR code da pesquisa")
+		}
+		return nil, fmt.Errorf("erro ao abrir QR code: %v", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler QR code: %v", err)
+	}
+
+	return &QRCodeImage{
+		Name:        fmt.Sprintf("pesquisa-%d-qrcode.%s", pesquisa.ID, formato),
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// renderQRCode gera e grava as imagens PNG e SVG da pesquisa, retornando o caminho do PNG
+func (uc *PesquisaUseCase) renderQRCode(ctx context.Context, pesquisa *entity.Pesquisa, settings qrCodeSettings) (string, error) {
+	level, err := qrcode.ParseLevel(settings.Nivel)
+	if err != nil {
+		return "", err
+	}
+	opts := qrcode.Options{Size: settings.Tamanho, Level: level}
+
+	if settings.Logo {
+		logo, err := uc.loadLogo(ctx, pesquisa.ID)
+		if err != nil {
+			return "", err
+		}
+		opts.Logo = logo
+	}
+
+	content := uc.PublicURL(pesquisa)
+	pngData, err := qrcode.PNG(content, opts)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar QR code: %v", err)
+	}
+	svgData, err := qrcode.SVG(content, opts)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar QR code: %v", err)
+	}
+
+	base := fmt.Sprintf("qrcodes/%d/%s", pesquisa.ID, pesquisa.LinkAcesso)
+	if err := uc.qrStorage.Save(ctx, base+".png", pngData); err != nil {
+		return "", fmt.Errorf("erro ao salvar QR code: %v", err)
+	}
+	if err := uc.qrStorage.Save(ctx, base+".svg", svgData); err != nil {
+		return "", fmt.Errorf("erro ao salvar QR code: %v", err)
+	}
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("erro ao salvar opções do QR code: %v", err)
+	}
+	if err := uc.qrStorage.Save(ctx, qrCodeSettingsKey(pesquisa.ID), raw); err != nil {
+		return "", fmt.Errorf("erro ao salvar opções do QR code: %v", err)
+	}
+
+	return base + ".png", nil
+}
+
+// regenerateQRCode gera as imagens para o novo link com as opções da última geração
+// Retorna o caminho vazio quando a pesquisa ainda não tinha QR code
+func (uc *PesquisaUseCase) regenerateQRCode(ctx context.Context, pesquisa *entity.Pesquisa) (string, error) {
+	if uc.qrStorage == nil || pesquisa.QRCodePath == "" {
+		return "", nil
+	}
+	return uc.renderQRCode(ctx, pesquisa, uc.loadQRCodeSettings(ctx, pesquisa.ID))
+}
+
+// loadQRCodeSettings lê as opções da última geração, aplicando os padrões quando não existem
+func (uc *PesquisaUseCase) loadQRCodeSettings(ctx context.Context, pesquisaID int) qrCodeSettings {
+	settings := qrCodeSettings{Tamanho: uc.qrConfig.DefaultSize, Nivel: uc.qrConfig.DefaultLevel.String()}
+
+	file, err := uc.qrStorage.Open(ctx, qrCodeSettingsKey(pesquisaID))
+	if err != nil {
+		return settings
+	}
+	defer file.Close()
+
+	var saved qrCodeSettings
+	if err := json.NewDecoder(file).Decode(&saved); err != nil {
+		return settings
+	}
+	if saved.Tamanho > 0 {
+		settings.Tamanho = saved.Tamanho
+	}
+	if saved.Nivel != "" {
+		settings.Nivel = saved.Nivel
+	}
+	settings.Logo = saved.Logo
+	return settings
+}
+
+// loadLogo lê o logo salvo da pesquisa
+func (uc *PesquisaUseCase) loadLogo(ctx context.Context, pesquisaID int) (image.Image, error) {
+	file, err := uc.qrStorage.Open(ctx, qrCodeLogoKey(pesquisaID))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir logo: %v", err)
+	}
+	defer file.Close()
+
+	logo, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler logo: %v", err)
+	}
+	return logo, nil
+}
+
+// deleteQRCodeFiles remove as imagens de uma geração anterior; falhas não interrompem a operação
+func (uc *PesquisaUseCase) deleteQRCodeFiles(ctx context.Context, qrPath string) {
+	for formato := range qrCodeFormats {
+		if key, ok := qrCodeKey(qrPath, formato); ok {
+			uc.qrStorage.Delete(ctx, key)
+		}
+	}
+}
+
+// decodeLogo valida e decodifica o logo enviado (PNG ou JPEG)
+func decodeLogo(data []byte) (image.Image, error) {
+	if len(data) > maxLogoBytes {
+		return nil, fmt.Errorf("logo deve ter no máximo %d KB", maxLogoBytes/1024)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return nil, fmt.Errorf("logo inválido: envie uma imagem PNG ou JPEG")
+	}
+	if cfg.Width > maxLogoDimension || cfg.Height > maxLogoDimension {
+		return nil, fmt.Errorf("logo deve ter no máximo %dx%d pixels", maxLogoDimension, maxLogoDimension)
+	}
+
+	logo, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("logo inválido: %v", err)
+	}
+	return logo, nil
+}
+
+// qrCodeKey deriva a chave de armazenamento do formato a partir do caminho salvo na pesquisa
+// Caminhos antigos, que não apontam para o armazenamento, são ignorados
+func qrCodeKey(qrPath, formato string) (string, bool) {
+	if !strings.HasPrefix(qrPath, "qrcodes/") || path.Ext(qrPath) != ".png" {
+		return "", false
+	}
+	return strings.TrimSuffix(qrPath, ".png") + "." + formato, true
+}
+
+func qrCodeSettingsKey(pesquisaID int) string {
+	return fmt.Sprintf("qrcodes/%d/opcoes.json", pesquisaID)
+}
+
+func qrCodeLogoKey(pesquisaID int) string {
+	return fmt.Sprintf("qrcodes/%d/logo.png", pesquisaID)
+}
@@ -9,17 +9,24 @@ import (
 	"fmt"
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/storage"
 	"strings"
 	"time"
 )
 
 // PesquisaUseCase implementa casos de uso para gerenciamento de pesquisas
 type PesquisaUseCase struct {
-	pesquisaRepo     repository.PesquisaRepository     // Repositório de pesquisas
-	empresaRepo      repository.EmpresaRepository      // Repositório de empresas
-	setorRepo        repository.SetorRepository        // Repositório de setores
-	dashboardRepo    repository.DashboardRepository    // Repositório de dashboards
-	logAuditoriaRepo repository.LogAuditoriaRepository // Repositório de logs
+	pesquisaRepo     repository.PesquisaRepository       // Repositório de pesquisas
+	empresaRepo      repository.EmpresaRepository        // Repositório de empresas
+	setorRepo        repository.SetorRepository          // Repositório de setores
+	dashboardRepo    repository.DashboardRepository      // Repositório de dashboards
+	logAuditoriaRepo repository.LogAuditoriaRepository   // Repositório de logs
+	qrStorage        storage.Storage                     // Armazenamento das imagens de QR code (opcional)
+	qrConfig         QRCodeConfig                        // Configuração de geração de QR codes
+	modeloRepo       repository.ModeloPesquisaRepository // Repositório de modelos de pesquisa (opcional)
+	perguntaRepo     repository.PerguntaRepository       // Repositório de perguntas (clonagem, opcional)
+	cloneRepo        repository.PesquisaCloneRepository  // Repositório de pesquisas clonadas (opcional)
+	dimensaoRepo     repository.DimensaoRepository       // Repositório de dimensões (vínculo das perguntas de modelos, opcional)
 }
 
 // NewPesquisaUseCase cria uma nova instância do caso de uso de pesquisas
@@ -39,8 +46,29 @@ func NewPesquisaUseCase(
 	}
 }
 
+// SetModelos habilita a criação de pesquisas a partir de modelos
+func (uc *PesquisaUseCase) SetModelos(modeloRepo repository.ModeloPesquisaRepository) {
+	uc.modeloRepo = modeloRepo
+}
+
+// SetDimensoes habilita o vínculo das perguntas criadas a partir de modelos às dimensões da empresa
+func (uc *PesquisaUseCase) SetDimensoes(dimensaoRepo repository.DimensaoRepository) {
+	uc.dimensaoRepo = dimensaoRepo
+}
+
+// SetClonagem habilita a clonagem de pesquisas
+func (uc *PesquisaUseCase) SetClonagem(perguntaRepo repository.PerguntaRepository, cloneRepo repository.PesquisaCloneRepository) {
+	uc.perguntaRepo = perguntaRepo
+	uc.cloneRepo = cloneRepo
+}
+
 // GenerateUniqueLink gera um link único para a pesquisa
 func (uc *PesquisaUseCase) GenerateUniqueLink() (string, error) {
+	return generateLinkAcesso()
+}
+
+// generateLinkAcesso gera 16 bytes aleatórios codificados em hexadecimal
+func generateLinkAcesso() (string, error) {
 	bytes := make([]byte, 16)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", fmt.Errorf("erro ao gerar link: %v", err)
@@ -66,6 +94,260 @@ func (uc *PesquisaUseCase) ValidatePesquisaDates(dataAbertura, dataFechamento *t
 
 // Create cria uma nova pesquisa com validações
 func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa, userAdminID int, enderecoIP string) error {
+	if err := uc.prepararPesquisa(ctx, pesquisa, userAdminID); err != nil {
+		return err
+	}
+
+	// Cria a pesquisa
+	if err := uc.pesquisaRepo.Create(ctx, pesquisa); err != nil {
+		return fmt.Errorf("erro ao criar pesquisa: %v", err)
+	}
+
+	uc.criarDashboard(ctx, pesquisa)
+
+	// Log de auditoria
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Pesquisa Criada",
+		Detalhes:      fmt.Sprintf("Pesquisa criada: %s (ID: %d)", pesquisa.Titulo, pesquisa.ID),
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nil
+}
+
+// CreateFromModelo cria uma pesquisa com as perguntas de um modelo em uma única transação
+// versao escolhe a versão do modelo (0: versão atual); a pesquisa guarda o modelo e a versão de origem
+func (uc *PesquisaUseCase) CreateFromModelo(ctx context.Context, pesquisa *entity.Pesquisa, modeloID, versao int, userAdminID int, enderecoIP string) error {
+	if uc.modeloRepo == nil {
+		return fmt.Errorf("modelos de pesquisa não configurados")
+	}
+	if versao < 0 {
+		return fmt.Errorf("versão do modelo não pode ser negativa")
+	}
+
+	if err := uc.prepararPesquisa(ctx, pesquisa, userAdminID); err != nil {
+		return err
+	}
+
+	// Modelos privados só podem ser usados pela empresa dona
+	modelo, err := getModeloInScope(ctx, uc.modeloRepo, modeloID)
+	if err != nil {
+		return err
+	}
+	if !modelo.DoSistema() && *modelo.IDEmpresa != pesquisa.IDEmpresa {
+		return fmt.Errorf("modelo de pesquisa não encontrado")
+	}
+
+	perguntasModelo := modelo.Perguntas
+	versaoUsada := modelo.Versao
+	if versao > 0 && versao != modelo.Versao {
+		v, err := uc.modeloRepo.GetVersao(ctx, modeloID, versao)
+		if err != nil {
+			return fmt.Errorf("versão do modelo não encontrada: %v", err)
+		}
+		perguntasModelo = v.Perguntas
+		versaoUsada = v.Versao
+	}
+
+	perguntas := make([]*entity.Pergunta, len(perguntasModelo))
+	for i, p := range perguntasModelo {
+		perguntas[i] = p.ToPergunta(0, i+1)
+		if err := normalizeOpcoesResposta(perguntas[i]); err != nil {
+			return fmt.Errorf("pergunta %d do modelo: %v", i+1, err)
+		}
+	}
+
+	novas, err := uc.vincularDimensoesModelo(ctx, pesquisa.IDEmpresa, modelo, perguntasModelo, perguntas)
+	if err != nil {
+		return err
+	}
+
+	criadas, err := uc.modeloRepo.CreatePesquisa(ctx, pesquisa, perguntas, novas, modelo.ID, versaoUsada)
+	if err != nil {
+		return fmt.Errorf("erro ao criar pesquisa: %v", err)
+	}
+
+	pesquisa.Perguntas = make([]entity.Pergunta, len(perguntas))
+	for i, pergunta := range perguntas {
+		pesquisa.Perguntas[i] = *pergunta
+	}
+
+	uc.criarDashboard(ctx, pesquisa)
+
+	// Log de auditoria
+	detalhes := fmt.Sprintf("Pesquisa criada a partir do modelo '%s' (ID: %d, versão %d): %s (ID: %d, %d perguntas)",
+		modelo.Nome, modelo.ID, versaoUsada, pesquisa.Titulo, pesquisa.ID, len(perguntas))
+	if len(criadas) > 0 {
+		nomes := make([]string, len(criadas))
+		for i, dimensao := range criadas {
+			nomes[i] = dimensao.Nome
+		}
+		detalhes += fmt.Sprintf("; dimensões criadas: %s", strings.Join(nomes, ", "))
+	}
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Pesquisa Criada",
+		Detalhes:      detalhes,
+		EnderecoIP:    enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nil
+}
+
+// vincularDimensoesModelo liga as perguntas pontuáveis à dimensão da empresa com o nome informado no modelo
+// Dimensões ainda inexistentes são apenas montadas e retornadas: o repositório as cria na transação da pesquisa,
+// e as perguntas vinculadas a elas apontam para o ID que será preenchido na inserção
+// Perguntas de eNPS e abertas não entram na pontuação de dimensão e ficam sem vínculo
+func (uc *PesquisaUseCase) vincularDimensoesModelo(ctx context.Context, empresaID int, modelo *entity.ModeloPesquisa, perguntasModelo []entity.PerguntaModelo, perguntas []*entity.Pergunta) ([]*entity.Dimensao, error) {
+	if uc.dimensaoRepo == nil {
+		return nil, nil
+	}
+
+	dimensoes := make(map[string]*entity.Dimensao)
+	var novas []*entity.Dimensao
+	for i, p := range perguntasModelo {
+		if p.Dimensao == "" || !entity.PerguntaPontuavel(perguntas[i]) {
+			continue
+		}
+
+		dimensao, ok := dimensoes[p.Dimensao]
+		if !ok {
+			var nova bool
+			var err error
+			dimensao, nova, err = uc.dimensaoDoModelo(ctx, empresaID, p.Dimensao, modelo.Nome)
+			if err != nil {
+				return nil, fmt.Errorf("pergunta %d do modelo: %v", i+1, err)
+			}
+			if nova {
+				novas = append(novas, dimensao)
+			}
+			dimensoes[p.Dimensao] = dimensao
+		}
+		perguntas[i].IDDimensao = &dimensao.ID
+	}
+
+	return novas, nil
+}
+
+// dimensaoDoModelo busca a dimensão da empresa pelo nome; quando não existe, monta a dimensão a ser criada
+func (uc *PesquisaUseCase) dimensaoDoModelo(ctx context.Context, empresaID int, nome, nomeModelo string) (*entity.Dimensao, bool, error) {
+	dimensao, err := uc.dimensaoRepo.GetByNome(ctx, empresaID, nome)
+	if err == nil {
+		return dimensao, false, nil
+	}
+	if !strings.Contains(err.Error(), "não encontrad") {
+		return nil, false, fmt.Errorf("erro ao buscar dimensão '%s': %v", nome, err)
+	}
+
+	dimensao = &entity.Dimensao{
+		IDEmpresa: empresaID,
+		Nome:      nome,
+		Descricao: fmt.Sprintf("Criada a partir do modelo de pesquisa '%s'", nomeModelo),
+	}
+	if err := validarDimensao(dimensao); err != nil {
+		return nil, false, err
+	}
+
+	return dimensao, true, nil
+}
+
+// OpcoesClonagem são os campos que podem ser substituídos na pesquisa clonada
+type OpcoesClonagem struct {
+	Titulo         string     // Novo título (vazio: título da origem com o sufixo "(cópia)")
+	IDSetor        int        // Novo setor alvo (0: setor da origem)
+	DataAbertura   *time.Time // Abertura do clone; o período da origem não é copiado
+	DataFechamento *time.Time // Fechamento do clone
+}
+
+// Clone cria um rascunho com os dados, as perguntas e os filtros do dashboard de uma pesquisa existente
+// O clone recebe um novo link de acesso e fica ligado à origem para comparação entre aplicações
+func (uc *PesquisaUseCase) Clone(ctx context.Context, origemID int, opcoes OpcoesClonagem, userAdminID int, enderecoIP string) (*entity.Pesquisa, error) {
+	if uc.perguntaRepo == nil || uc.cloneRepo == nil {
+		return nil, fmt.Errorf("clonagem de pesquisas não configurada")
+	}
+
+	origem, err := getPesquisaInScope(ctx, uc.pesquisaRepo, origemID)
+	if err != nil {
+		return nil, err
+	}
+
+	titulo := strings.TrimSpace(opcoes.Titulo)
+	if titulo == "" {
+		titulo = origem.Titulo + " (cópia)"
+		if len([]rune(titulo)) > 255 {
+			titulo = origem.Titulo
+		}
+	}
+
+	pesquisa := &entity.Pesquisa{
+		IDEmpresa:         origem.IDEmpresa,
+		IDSetor:           origem.IDSetor,
+		Titulo:            titulo,
+		Descricao:         origem.Descricao,
+		DataAbertura:      opcoes.DataAbertura,
+		DataFechamento:    opcoes.DataFechamento,
+		ConfigRecorrencia: origem.ConfigRecorrencia,
+	}
+	if opcoes.IDSetor > 0 {
+		pesquisa.IDSetor = opcoes.IDSetor
+	}
+
+	if err := uc.prepararPesquisa(ctx, pesquisa, userAdminID); err != nil {
+		return nil, err
+	}
+	pesquisa.Anonimato = origem.Anonimato
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, origem.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas da pesquisa: %v", err)
+	}
+
+	// Filtros do dashboard da origem; sem dashboard, vale a configuração padrão
+	configFiltros := `{"filtros_padrao": true}`
+	if dashboardOrigem, err := uc.dashboardRepo.GetByPesquisaID(ctx, origem.ID); err == nil && dashboardOrigem.ConfigFiltros != nil {
+		configFiltros = *dashboardOrigem.ConfigFiltros
+	}
+	dashboard := &entity.Dashboard{
+		Titulo:        fmt.Sprintf("Dashboard - %s", pesquisa.Titulo),
+		DataCriacao:   time.Now(),
+		ConfigFiltros: &configFiltros,
+	}
+
+	clone := &entity.ClonePesquisa{
+		IDPesquisaOrigem: origem.ID,
+		DataCriacao:      pesquisa.DataCriacao,
+	}
+	if err := uc.cloneRepo.Create(ctx, pesquisa, perguntas, dashboard, clone); err != nil {
+		return nil, fmt.Errorf("erro ao clonar pesquisa: %v", err)
+	}
+
+	pesquisa.Perguntas = make([]entity.Pergunta, 0, len(perguntas))
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		pesquisa.Perguntas = append(pesquisa.Perguntas, *pergunta)
+	}
+	pesquisa.Dashboard = dashboard
+
+	// Log de auditoria
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   userAdminID,
+		TimeStamp:     time.Now(),
+		AcaoRealizada: "Pesquisa Clonada",
+		Detalhes: fmt.Sprintf("Pesquisa '%s' (ID: %d) clonada como '%s' (ID: %d, %d perguntas)",
+			origem.Titulo, origem.ID, pesquisa.Titulo, pesquisa.ID, len(perguntas)),
+		EnderecoIP: enderecoIP,
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return pesquisa, nil
+}
+
+// prepararPesquisa valida uma nova pesquisa e define os valores padrão e o link de acesso
+func (uc *PesquisaUseCase) prepararPesquisa(ctx context.Context, pesquisa *entity.Pesquisa, userAdminID int) error {
 	// Validações básicas
 	if pesquisa.IDEmpresa <= 0 {
 		return fmt.Errorf("ID da empresa é obrigatório")
@@ -75,7 +357,10 @@ func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa
 		return fmt.Errorf("título da pesquisa é obrigatório")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, "empresa não encontrada"); err != nil {
+		return err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, pesquisa.IDEmpresa)
 	if err != nil {
 		return fmt.Errorf("empresa não encontrada: %v", err)
@@ -87,9 +372,9 @@ func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa
 		if err != nil {
 			return fmt.Errorf("setor não encontrado: %v", err)
 		}
-		// Verifica se setor pertence à empresa
+		// Setor de outra empresa é tratado como inexistente
 		if setor.IDEmpresa != pesquisa.IDEmpresa {
-			return fmt.Errorf("setor não pertence à empresa informada")
+			return fmt.Errorf("setor não encontrado")
 		}
 	}
 
@@ -98,6 +383,13 @@ func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa
 		return err
 	}
 
+	// Valida configuração de recorrência (se fornecida)
+	if pesquisa.ConfigRecorrencia != nil && strings.TrimSpace(*pesquisa.ConfigRecorrencia) != "" {
+		if _, err := ParseConfigRecorrencia(*pesquisa.ConfigRecorrencia); err != nil {
+			return err
+		}
+	}
+
 	// Define valores padrão
 	pesquisa.IDUserAdmin = userAdminID
 	pesquisa.DataCriacao = time.Now()
@@ -111,12 +403,12 @@ func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa
 	}
 	pesquisa.LinkAcesso = linkUnico
 
-	// Cria a pesquisa
-	if err := uc.pesquisaRepo.Create(ctx, pesquisa); err != nil {
-		return fmt.Errorf("erro ao criar pesquisa: %v", err)
-	}
+	return nil
+}
 
-	// Cria dashboard automático (requisito RF02.3)
+// criarDashboard cria o dashboard automático da pesquisa (requisito RF02.3)
+// Falhas não desfazem a criação da pesquisa
+func (uc *PesquisaUseCase) criarDashboard(ctx context.Context, pesquisa *entity.Pesquisa) {
 	dashboard := &entity.Dashboard{
 		IDPesquisa:    pesquisa.ID,
 		Titulo:        fmt.Sprintf("Dashboard - %s", pesquisa.Titulo),
@@ -131,18 +423,6 @@ func (uc *PesquisaUseCase) Create(ctx context.Context, pesquisa *entity.Pesquisa
 		// Log do erro, mas não falha a criação da pesquisa
 		fmt.Printf("Aviso: erro ao criar dashboard para pesquisa %d: %v\n", pesquisa.ID, err)
 	}
-
-	// Log de auditoria
-	log := &entity.LogAuditoria{
-		IDUserAdmin:   userAdminID,
-		TimeStamp:     time.Now(),
-		AcaoRealizada: "Pesquisa Criada",
-		Detalhes:      fmt.Sprintf("Pesquisa criada: %s (ID: %d)", pesquisa.Titulo, pesquisa.ID),
-		EnderecoIP:    enderecoIP,
-	}
-	uc.logAuditoriaRepo.Create(ctx, log)
-
-	return nil
 }
 
 // GetByID busca uma pesquisa pelo seu ID
@@ -151,7 +431,24 @@ func (uc *PesquisaUseCase) GetByID(ctx context.Context, id int) (*entity.Pesquis
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	return uc.pesquisaRepo.GetByID(ctx, id)
+	return getPesquisaInScope(ctx, uc.pesquisaRepo, id)
+}
+
+// GetOrigem retorna o ID da pesquisa copiada quando a pesquisa foi criada por clonagem
+// Retorna 0 para pesquisas não clonadas, com a origem removida ou sem a clonagem configurada
+func (uc *PesquisaUseCase) GetOrigem(ctx context.Context, pesquisaID int) (int, error) {
+	if uc.cloneRepo == nil {
+		return 0, nil
+	}
+
+	clone, err := uc.cloneRepo.GetByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return clone.IDPesquisaOrigem, nil
 }
 
 // GetByLinkAcesso busca uma pesquisa pelo seu link de acesso
@@ -164,6 +461,9 @@ func (uc *PesquisaUseCase) GetByLinkAcesso(ctx context.Context, link string) (*e
 	if err != nil {
 		return nil, fmt.Errorf("pesquisa não encontrada com este link: %v", err)
 	}
+	if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, "pesquisa não encontrada com este link"); err != nil {
+		return nil, err
+	}
 
 	// Verifica se pesquisa está ativa e dentro do período
 	if err := uc.ValidateAccess(pesquisa); err != nil {
@@ -198,7 +498,10 @@ func (uc *PesquisaUseCase) ListByEmpresa(ctx context.Context, empresaID int) ([]
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -213,11 +516,14 @@ func (uc *PesquisaUseCase) ListBySetor(ctx context.Context, setorID int) ([]*ent
 		return nil, fmt.Errorf("ID do setor deve ser maior que zero")
 	}
 
-	// Verifica se setor existe
-	_, err := uc.setorRepo.GetByID(ctx, setorID)
+	// Verifica se setor existe e pertence à empresa
+	setor, err := uc.setorRepo.GetByID(ctx, setorID)
 	if err != nil {
 		return nil, fmt.Errorf("setor não encontrado: %v", err)
 	}
+	if err := checkEmpresaScope(ctx, setor.IDEmpresa, "setor não encontrado"); err != nil {
+		return nil, err
+	}
 
 	return uc.pesquisaRepo.ListBySetor(ctx, setorID)
 }
@@ -228,7 +534,10 @@ func (uc *PesquisaUseCase) ListByStatus(ctx context.Context, empresaID int, stat
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -254,7 +563,10 @@ func (uc *PesquisaUseCase) ListActive(ctx context.Context, empresaID int) ([]*en
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, empresaID)
 	if err != nil {
 		return nil, fmt.Errorf("empresa não encontrada: %v", err)
@@ -275,9 +587,9 @@ func (uc *PesquisaUseCase) Update(ctx context.Context, pesquisa *entity.Pesquisa
 	}
 
 	// Busca pesquisa atual
-	existing, err := uc.pesquisaRepo.GetByID(ctx, pesquisa.ID)
+	existing, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisa.ID)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Verifica permissão (usuário deve ser da mesma empresa)
@@ -292,7 +604,7 @@ func (uc *PesquisaUseCase) Update(ctx context.Context, pesquisa *entity.Pesquisa
 			return fmt.Errorf("setor não encontrado: %v", err)
 		}
 		if setor.IDEmpresa != pesquisa.IDEmpresa {
-			return fmt.Errorf("setor não pertence à empresa informada")
+			return fmt.Errorf("setor não encontrado")
 		}
 	}
 
@@ -301,6 +613,13 @@ func (uc *PesquisaUseCase) Update(ctx context.Context, pesquisa *entity.Pesquisa
 		return err
 	}
 
+	// Valida configuração de recorrência (se fornecida)
+	if pesquisa.ConfigRecorrencia != nil && strings.TrimSpace(*pesquisa.ConfigRecorrencia) != "" {
+		if _, err := ParseConfigRecorrencia(*pesquisa.ConfigRecorrencia); err != nil {
+			return err
+		}
+	}
+
 	// Não permite alterar alguns campos se pesquisa já está ativa
 	if existing.Status == "Ativa" {
 		pesquisa.LinkAcesso = existing.LinkAcesso
@@ -343,9 +662,9 @@ func (uc *PesquisaUseCase) UpdateStatus(ctx context.Context, id int, status stri
 	}
 
 	// Busca pesquisa para validações
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, id)
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, id)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Regras de transição de status
@@ -424,9 +743,9 @@ func (uc *PesquisaUseCase) Delete(ctx context.Context, id int, userAdminID int,
 	}
 
 	// Busca pesquisa para log e validações
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, id)
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, id)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Não permite deletar pesquisa ativa
@@ -463,9 +782,9 @@ func (uc *PesquisaUseCase) RegenerateLinkAcesso(ctx context.Context, pesquisaID
 	}
 
 	// Busca pesquisa
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return "", fmt.Errorf("pesquisa não encontrada: %v", err)
+		return "", err
 	}
 
 	// Não permite regenerar link de pesquisa ativa
@@ -479,21 +798,75 @@ func (uc *PesquisaUseCase) RegenerateLinkAcesso(ctx context.Context, pesquisaID
 		return "", fmt.Errorf("erro ao gerar novo link: %v", err)
 	}
 
-	// Atualiza apenas o link
+	// Gera o QR code do novo link antes de trocá-lo, para que o caminho salvo sempre aponte para imagens existentes
+	linkAnterior, qrAnterior := pesquisa.LinkAcesso, pesquisa.QRCodePath
 	pesquisa.LinkAcesso = novoLink
-	if err := uc.pesquisaRepo.Update(ctx, pesquisa); err != nil {
+	novoQR, err := uc.regenerateQRCode(ctx, pesquisa)
+	if err != nil {
+		return "", fmt.Errorf("erro ao regenerar QR code: %v", err)
+	}
+
+	// Atualiza apenas o link e o QR code
+	if err := uc.pesquisaRepo.UpdateLinkAcesso(ctx, pesquisa.ID, novoLink, novoQR); err != nil {
 		return "", fmt.Errorf("erro ao atualizar link: %v", err)
 	}
+	pesquisa.QRCodePath = novoQR
+
+	// O QR code do link anterior deixa de ser válido
+	if qrAnterior != "" && uc.qrStorage != nil {
+		uc.deleteQRCodeFiles(ctx, qrAnterior)
+	}
 
 	// Log de auditoria
 	log := &entity.LogAuditoria{
 		IDUserAdmin:   userAdminID,
 		TimeStamp:     time.Now(),
 		AcaoRealizada: "Link de Acesso Regenerado",
-		Detalhes:      fmt.Sprintf("Novo link gerado para pesquisa: %s (ID: %d)", pesquisa.Titulo, pesquisaID),
+		Detalhes:      fmt.Sprintf("Novo link gerado para pesquisa: %s (ID: %d), link anterior: %s", pesquisa.Titulo, pesquisaID, linkAnterior),
 		EnderecoIP:    enderecoIP,
 	}
 	uc.logAuditoriaRepo.Create(ctx, log)
 
 	return novoLink, nil
 }
+
+// ScheduleResult resume as transições de status aplicadas pelo agendamento
+type ScheduleResult struct {
+	Abertas    []int          // IDs das pesquisas ativadas
+	Encerradas []int          // IDs das pesquisas concluídas
+	Falhas     map[int]string // Erro de transição por ID de pesquisa
+}
+
+// ApplySchedule ativa pesquisas cuja data de abertura chegou e conclui as que passaram da data de fechamento
+// As transições passam por UpdateStatus e são auditadas como ações do sistema
+func (uc *PesquisaUseCase) ApplySchedule(ctx context.Context, now time.Time) (*ScheduleResult, error) {
+	result := &ScheduleResult{Falhas: make(map[int]string)}
+
+	paraEncerrar, err := uc.pesquisaRepo.ListScheduledToClose(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pesquisas a encerrar: %v", err)
+	}
+
+	for _, pesquisa := range paraEncerrar {
+		if err := uc.UpdateStatus(ctx, pesquisa.ID, "Concluída", 0, "sistema"); err != nil {
+			result.Falhas[pesquisa.ID] = err.Error()
+			continue
+		}
+		result.Encerradas = append(result.Encerradas, pesquisa.ID)
+	}
+
+	paraAbrir, err := uc.pesquisaRepo.ListScheduledToOpen(ctx, now)
+	if err != nil {
+		return result, fmt.Errorf("erro ao buscar pesquisas a abrir: %v", err)
+	}
+
+	for _, pesquisa := range paraAbrir {
+		if err := uc.UpdateStatus(ctx, pesquisa.ID, "Ativa", 0, "sistema"); err != nil {
+			result.Falhas[pesquisa.ID] = err.Error()
+			continue
+		}
+		result.Abertas = append(result.Abertas, pesquisa.ID)
+	}
+
+	return result, nil
+}
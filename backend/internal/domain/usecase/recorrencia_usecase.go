@@ -0,0 +1,287 @@
+// Package usecase implementa os casos de uso para recorrência de pesquisas.
+// Gera novos ciclos de pesquisas recorrentes a partir de Pesquisa.ConfigRecorrencia.
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/cron"
+	"strings"
+	"time"
+)
+
+// RecorrenciaUseCase implementa a geração automática de ciclos de pesquisas recorrentes
+type RecorrenciaUseCase struct {
+	pesquisaRepo     repository.PesquisaRepository      // Repositório de pesquisas
+	perguntaRepo     repository.PerguntaRepository      // Repositório de perguntas
+	cicloRepo        repository.PesquisaCicloRepository // Repositório de ciclos
+	dashboardRepo    repository.DashboardRepository     // Repositório de dashboards
+	logAuditoriaRepo repository.LogAuditoriaRepository  // Repositório de logs
+}
+
+// NewRecorrenciaUseCase cria uma nova instância do caso de uso de recorrência
+func NewRecorrenciaUseCase(
+	pesquisaRepo repository.PesquisaRepository,
+	perguntaRepo repository.PerguntaRepository,
+	cicloRepo repository.PesquisaCicloRepository,
+	dashboardRepo repository.DashboardRepository,
+	logRepo repository.LogAuditoriaRepository,
+) *RecorrenciaUseCase {
+	return &RecorrenciaUseCase{
+		pesquisaRepo:     pesquisaRepo,
+		perguntaRepo:     perguntaRepo,
+		cicloRepo:        cicloRepo,
+		dashboardRepo:    dashboardRepo,
+		logAuditoriaRepo: logRepo,
+	}
+}
+
+// RecorrenciaResult resume os ciclos gerados em uma execução
+type RecorrenciaResult struct {
+	Gerados map[int]int    // ID da pesquisa anterior -> ID da pesquisa gerada
+	Falhas  map[int]string // Erro por ID da pesquisa anterior
+}
+
+// ParseConfigRecorrencia interpreta e valida o JSON de configuração de recorrência
+// Ativação automática é habilitada por padrão e a ausência de término equivale a "nunca"
+func ParseConfigRecorrencia(raw string) (*entity.ConfigRecorrencia, error) {
+	cfg := &entity.ConfigRecorrencia{AtivacaoAutomatica: true}
+	if err := json.Unmarshal([]byte(raw), cfg); err != nil {
+		return nil, fmt.Errorf("configuração de recorrência inválida: %v", err)
+	}
+
+	cfg.Intervalo = strings.ToLower(strings.TrimSpace(cfg.Intervalo))
+	switch cfg.Intervalo {
+	case entity.IntervaloMensal, entity.IntervaloTrimestral, entity.IntervaloSemestral:
+	case entity.IntervaloCron:
+		if strings.TrimSpace(cfg.ExpressaoCron) == "" {
+			return nil, fmt.Errorf("expressao_cron é obrigatória para intervalo cron")
+		}
+		if _, err := cron.Parse(cfg.ExpressaoCron); err != nil {
+			return nil, fmt.Errorf("expressao_cron inválida: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("intervalo de recorrência inválido: %q (use mensal, trimestral, semestral ou cron)", cfg.Intervalo)
+	}
+
+	if cfg.DuracaoDias <= 0 {
+		return nil, fmt.Errorf("duracao_dias deve ser maior que zero")
+	}
+	if cfg.DuracaoDias > 365 {
+		return nil, fmt.Errorf("período máximo da pesquisa é de 1 ano")
+	}
+	if cfg.AntecedenciaDias < 0 {
+		return nil, fmt.Errorf("antecedencia_dias não pode ser negativo")
+	}
+
+	if cfg.Termino.Tipo == "" {
+		cfg.Termino.Tipo = entity.TerminoNunca
+	}
+	switch cfg.Termino.Tipo {
+	case entity.TerminoNunca:
+	case entity.TerminoData:
+		if cfg.Termino.Data == nil {
+			return nil, fmt.Errorf("termino.data é obrigatória para término por data")
+		}
+	case entity.TerminoOcorrencias:
+		if cfg.Termino.Ocorrencias < 1 {
+			return nil, fmt.Errorf("termino.ocorrencias deve ser maior que zero")
+		}
+	default:
+		return nil, fmt.Errorf("tipo de término inválido: %q (use nunca, data ou ocorrencias)", cfg.Termino.Tipo)
+	}
+
+	return cfg, nil
+}
+
+// proximoInicio calcula o início do ciclo seguinte a partir do início do ciclo atual
+// Retorna o valor zero quando a expressão cron não possui próxima ocorrência
+func proximoInicio(cfg *entity.ConfigRecorrencia, inicio time.Time) time.Time {
+	switch cfg.Intervalo {
+	case entity.IntervaloMensal:
+		return inicio.AddDate(0, 1, 0)
+	case entity.IntervaloTrimestral:
+		return inicio.AddDate(0, 3, 0)
+	case entity.IntervaloSemestral:
+		return inicio.AddDate(0, 6, 0)
+	case entity.IntervaloCron:
+		schedule, err := cron.Parse(cfg.ExpressaoCron)
+		if err != nil {
+			return time.Time{}
+		}
+		return schedule.Next(inicio)
+	}
+	return time.Time{}
+}
+
+// ProcessRecurrences gera o próximo ciclo de cada série recorrente cujo início está dentro da antecedência configurada
+// Cada série avança no máximo um ciclo por execução; janelas totalmente perdidas são puladas
+func (uc *RecorrenciaUseCase) ProcessRecurrences(ctx context.Context, now time.Time) (*RecorrenciaResult, error) {
+	result := &RecorrenciaResult{
+		Gerados: make(map[int]int),
+		Falhas:  make(map[int]string),
+	}
+
+	heads, err := uc.cicloRepo.ListRecurringHeads(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pesquisas recorrentes: %v", err)
+	}
+
+	for _, head := range heads {
+		novaID, err := uc.processHead(ctx, head, now)
+		if err != nil {
+			result.Falhas[head.ID] = err.Error()
+			continue
+		}
+		if novaID > 0 {
+			result.Gerados[head.ID] = novaID
+		}
+	}
+
+	return result, nil
+}
+
+// processHead gera o ciclo seguinte de uma pesquisa, retornando 0 se ainda não for o momento
+func (uc *RecorrenciaUseCase) processHead(ctx context.Context, head *entity.Pesquisa, now time.Time) (int, error) {
+	cfg, err := ParseConfigRecorrencia(*head.ConfigRecorrencia)
+	if err != nil {
+		return 0, err
+	}
+
+	// Posição da pesquisa na série
+	inicio := head.DataCriacao
+	if head.DataAbertura != nil {
+		inicio = *head.DataAbertura
+	}
+	numero, origem := 1, head
+
+	ciclo, err := uc.cicloRepo.GetByPesquisa(ctx, head.ID)
+	if err != nil && !strings.Contains(err.Error(), "não encontrado") {
+		return 0, err
+	}
+	if ciclo != nil {
+		inicio = ciclo.DataInicioPrevista
+		numero = ciclo.NumeroCiclo
+		if ciclo.IDPesquisaOrigem > 0 && ciclo.IDPesquisaOrigem != head.ID {
+			if o, err := uc.pesquisaRepo.GetByID(ctx, ciclo.IDPesquisaOrigem); err == nil {
+				origem = o
+			}
+		}
+	}
+
+	duracao := time.Duration(cfg.DuracaoDias) * 24 * time.Hour
+	proximo := proximoInicio(cfg, inicio)
+	for !proximo.IsZero() && !proximo.Add(duracao).After(now) {
+		proximo = proximoInicio(cfg, proximo)
+	}
+	if proximo.IsZero() {
+		return 0, nil
+	}
+
+	antecedencia := time.Duration(cfg.AntecedenciaDias) * 24 * time.Hour
+	if now.Before(proximo.Add(-antecedencia)) {
+		return 0, nil
+	}
+
+	// Condições de término
+	switch cfg.Termino.Tipo {
+	case entity.TerminoData:
+		if proximo.After(*cfg.Termino.Data) {
+			return 0, nil
+		}
+	case entity.TerminoOcorrencias:
+		if numero+1 > cfg.Termino.Ocorrencias {
+			return 0, nil
+		}
+	}
+
+	link, err := generateLinkAcesso()
+	if err != nil {
+		return 0, err
+	}
+
+	nova := &entity.Pesquisa{
+		IDEmpresa:         head.IDEmpresa,
+		IDUserAdmin:       head.IDUserAdmin,
+		IDSetor:           head.IDSetor,
+		Titulo:            fmt.Sprintf("%s (Ciclo %d)", origem.Titulo, numero+1),
+		Descricao:         head.Descricao,
+		DataCriacao:       now,
+		Status:            "Rascunho",
+		LinkAcesso:        link,
+		ConfigRecorrencia: head.ConfigRecorrencia,
+		Anonimato:         head.Anonimato,
+	}
+
+	// Com ativação automática o agendador abre a pesquisa quando DataAbertura chegar
+	if cfg.AtivacaoAutomatica {
+		abertura := proximo
+		if abertura.Before(now) {
+			abertura = now
+		}
+		fechamento := abertura.Add(duracao)
+		nova.DataAbertura = &abertura
+		nova.DataFechamento = &fechamento
+	}
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, head.ID)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	// As cópias mantêm o ID original até a gravação, para que as condições de exibição sejam remapeadas
+	copias := make([]*entity.Pergunta, 0, len(perguntas))
+	for _, p := range perguntas {
+		copias = append(copias, &entity.Pergunta{
+			ID:                 p.ID,
+			TextoPergunta:      p.TextoPergunta,
+			TipoPergunta:       p.TipoPergunta,
+			OrdemExibicao:      p.OrdemExibicao,
+			OpcoesResposta:     p.OpcoesResposta,
+			CondicaoExibicao:   p.CondicaoExibicao,
+			Obrigatoria:        p.Obrigatoria,
+			IDDimensao:         p.IDDimensao,
+			PontuacaoInvertida: p.PontuacaoInvertida,
+		})
+	}
+
+	novoCiclo := &entity.CicloPesquisa{
+		IDPesquisaAnterior: head.ID,
+		IDPesquisaOrigem:   origem.ID,
+		NumeroCiclo:        numero + 1,
+		DataInicioPrevista: proximo,
+		DataCriacao:        now,
+	}
+
+	if err := uc.cicloRepo.CreateCycle(ctx, nova, copias, novoCiclo); err != nil {
+		return 0, err
+	}
+
+	// Cria dashboard automático (requisito RF02.3)
+	defaultConfig := `{"filtros_padrao": true}`
+	dashboard := &entity.Dashboard{
+		IDPesquisa:    nova.ID,
+		Titulo:        fmt.Sprintf("Dashboard - %s", nova.Titulo),
+		DataCriacao:   now,
+		ConfigFiltros: &defaultConfig,
+	}
+	if err := uc.dashboardRepo.Create(ctx, dashboard); err != nil {
+		// Log do erro, mas não falha a geração do ciclo
+		fmt.Printf("Aviso: erro ao criar dashboard para pesquisa %d: %v\n", nova.ID, err)
+	}
+
+	// Log de auditoria (ação do sistema)
+	log := &entity.LogAuditoria{
+		IDUserAdmin:   0,
+		TimeStamp:     now,
+		AcaoRealizada: "Ciclo de Pesquisa Gerado",
+		Detalhes:      fmt.Sprintf("Ciclo %d gerado: %s (ID: %d) a partir da pesquisa ID %d", novoCiclo.NumeroCiclo, nova.Titulo, nova.ID, head.ID),
+		EnderecoIP:    "sistema",
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+
+	return nova.ID, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+type fakeCicloRepo struct {
+	repository.PesquisaCicloRepository
+	heads   []*entity.Pesquisa
+	ciclos  map[int]*entity.CicloPesquisa
+	criados []*entity.CicloPesquisa
+	novas   []*entity.Pesquisa
+}
+
+func (r *fakeCicloRepo) ListRecurringHeads(ctx context.Context) ([]*entity.Pesquisa, error) {
+	return r.heads, nil
+}
+
+func (r *fakeCicloRepo) GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.CicloPesquisa, error) {
+	if ciclo, ok := r.ciclos[pesquisaID]; ok {
+		return ciclo, nil
+	}
+	return nil, fmt.Errorf("ciclo da pesquisa ID %d não encontrado", pesquisaID)
+}
+
+func (r *fakeCicloRepo) CreateCycle(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, ciclo *entity.CicloPesquisa) error {
+	pesquisa.ID = 100 + len(r.novas)
+	ciclo.IDPesquisa = pesquisa.ID
+	r.novas = append(r.novas, pesquisa)
+	r.criados = append(r.criados, ciclo)
+	return nil
+}
+
+type fakeRecorrenciaPerguntaRepo struct {
+	repository.PerguntaRepository
+}
+
+func (r *fakeRecorrenciaPerguntaRepo) ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error) {
+	return []*entity.Pergunta{{ID: 1, IDPesquisa: pesquisaID, TextoPergunta: "Como está o clima?", TipoPergunta: "escala", OrdemExibicao: 1}}, nil
+}
+
+type fakeRecorrenciaDashboardRepo struct {
+	repository.DashboardRepository
+}
+
+func (r *fakeRecorrenciaDashboardRepo) Create(ctx context.Context, dashboard *entity.Dashboard) error {
+	return nil
+}
+
+type fakeRecorrenciaLogRepo struct {
+	repository.LogAuditoriaRepository
+}
+
+func (r *fakeRecorrenciaLogRepo) Create(ctx context.Context, log *entity.LogAuditoria) error {
+	return nil
+}
+
+func TestProcessRecurrencesGeraCiclo(t *testing.T) {
+	// Toda segunda-feira às 9h, 5 dias de duração, gerado com 2 dias de antecedência
+	const semanal = `{"intervalo": "cron", "expressao_cron": "0 9 * * 1", "duracao_dias": 5, "antecedencia_dias": 2}`
+	const ocorrencias = `{"intervalo": "cron", "expressao_cron": "0 9 * * 1", "duracao_dias": 5, "antecedencia_dias": 2,
+		"termino": {"tipo": "ocorrencias", "ocorrencias": 2}}`
+	const impossivel = `{"intervalo": "cron", "expressao_cron": "0 9 30 2 *", "duracao_dias": 5}`
+
+	abertura := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Segunda-feira
+
+	casos := []struct {
+		nome     string
+		config   string
+		ciclo    *entity.CicloPesquisa
+		agora    time.Time
+		inicio   time.Time // Início previsto do ciclo gerado; zero quando nenhum ciclo é gerado
+		abertura time.Time
+		numero   int
+	}{
+		{
+			nome:   "antes da antecedência",
+			config: semanal,
+			agora:  time.Date(2026, 3, 7, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			nome:     "dentro da antecedência",
+			config:   semanal,
+			agora:    time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC),
+			inicio:   time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+			abertura: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+			numero:   2,
+		},
+		{
+			nome:     "janelas perdidas são puladas",
+			config:   semanal,
+			agora:    time.Date(2026, 3, 25, 12, 0, 0, 0, time.UTC),
+			inicio:   time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC),
+			abertura: time.Date(2026, 3, 25, 12, 0, 0, 0, time.UTC),
+			numero:   2,
+		},
+		{
+			nome:     "continua a partir do ciclo anterior",
+			config:   semanal,
+			ciclo:    &entity.CicloPesquisa{IDPesquisaOrigem: 1, NumeroCiclo: 3, DataInicioPrevista: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)},
+			agora:    time.Date(2026, 3, 21, 10, 0, 0, 0, time.UTC),
+			inicio:   time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC),
+			abertura: time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC),
+			numero:   4,
+		},
+		{
+			nome:   "limite de ocorrências atingido",
+			config: ocorrencias,
+			ciclo:  &entity.CicloPesquisa{IDPesquisaOrigem: 1, NumeroCiclo: 2, DataInicioPrevista: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+			agora:  time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			nome:   "expressão sem ocorrência",
+			config: impossivel,
+			agora:  time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			config := c.config
+			head := &entity.Pesquisa{
+				ID:                1,
+				IDEmpresa:         1,
+				Titulo:            "Clima semanal",
+				DataCriacao:       abertura.AddDate(0, 0, -7),
+				DataAbertura:      &abertura,
+				Status:            "Ativa",
+				ConfigRecorrencia: &config,
+			}
+			cicloRepo := &fakeCicloRepo{heads: []*entity.Pesquisa{head}, ciclos: map[int]*entity.CicloPesquisa{}}
+			if c.ciclo != nil {
+				cicloRepo.ciclos[head.ID] = c.ciclo
+			}
+			uc := NewRecorrenciaUseCase(nil, &fakeRecorrenciaPerguntaRepo{}, cicloRepo, &fakeRecorrenciaDashboardRepo{}, &fakeRecorrenciaLogRepo{})
+
+			result, err := uc.ProcessRecurrences(context.Background(), c.agora)
+			if err != nil {
+				t.Fatalf("ProcessRecurrences: %v", err)
+			}
+			if len(result.Falhas) > 0 {
+				t.Fatalf("falhas: %v", result.Falhas)
+			}
+
+			if c.inicio.IsZero() {
+				if len(cicloRepo.criados) > 0 {
+					t.Fatalf("ciclo gerado com início %v, esperado nenhum", cicloRepo.criados[0].DataInicioPrevista)
+				}
+				return
+			}
+			if len(cicloRepo.criados) != 1 {
+				t.Fatalf("%d ciclos gerados, esperado 1", len(cicloRepo.criados))
+			}
+
+			ciclo, nova := cicloRepo.criados[0], cicloRepo.novas[0]
+			if !ciclo.DataInicioPrevista.Equal(c.inicio) {
+				t.Errorf("início previsto %v, esperado %v", ciclo.DataInicioPrevista, c.inicio)
+			}
+			if ciclo.NumeroCiclo != c.numero || ciclo.IDPesquisaAnterior != head.ID {
+				t.Errorf("ciclo %d após pesquisa %d, esperado ciclo %d após %d", ciclo.NumeroCiclo, ciclo.IDPesquisaAnterior, c.numero, head.ID)
+			}
+			if titulo := fmt.Sprintf("Clima semanal (Ciclo %d)", c.numero); nova.Titulo != titulo {
+				t.Errorf("título %q, esperado %q", nova.Titulo, titulo)
+			}
+			if nova.DataAbertura == nil || !nova.DataAbertura.Equal(c.abertura) {
+				t.Errorf("abertura %v, esperado %v", nova.DataAbertura, c.abertura)
+			}
+			if fechamento := c.abertura.AddDate(0, 0, 5); nova.DataFechamento == nil || !nova.DataFechamento.Equal(fechamento) {
+				t.Errorf("fechamento %v, esperado %v", nova.DataFechamento, fechamento)
+			}
+			if result.Gerados[head.ID] != nova.ID {
+				t.Errorf("resultado aponta pesquisa %d, esperado %d", result.Gerados[head.ID], nova.ID)
+			}
+		})
+	}
+}
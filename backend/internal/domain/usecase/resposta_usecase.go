@@ -8,7 +8,6 @@ import (
 	"log"
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -19,6 +18,7 @@ type RespostaUseCase struct {
 	perguntaRepo      repository.PerguntaRepository              // Repositório de perguntas
 	pesquisaRepo      repository.PesquisaRepository              // Repositório de pesquisas
 	submissaoUseCase  *SubmissaoPesquisaUseCase                  // NOVO: UseCase de submissões
+	anonimato         *PoliticaAnonimato                         // Mínimo de respondentes dos resultados
 }
 
 // NewRespostaUseCase cria uma nova instância do caso de uso de respostas
@@ -36,6 +36,12 @@ func NewRespostaUseCase(
 	}
 }
 
+// SetAnonimato define a política de anonimato aplicada aos resultados
+// Sem esta configuração vale o mínimo padrão de respondentes
+func (uc *RespostaUseCase) SetAnonimato(anonimato *PoliticaAnonimato) {
+	uc.anonimato = anonimato
+}
+
 // ValidateResposta valida uma resposta individual
 func (uc *RespostaUseCase) ValidateResposta(resposta *entity.Resposta) error {
 	if resposta.IDPergunta <= 0 {
@@ -82,36 +88,52 @@ func (uc *RespostaUseCase) CreateBatch(ctx context.Context, respostas []*entity.
 	}
 
 	// Criar mapa de perguntas válidas
-	perguntasValidas := make(map[int]bool)
+	perguntasValidas := make(map[int]*entity.Pergunta)
 	for _, p := range perguntas {
-		perguntasValidas[p.ID] = true
+		perguntasValidas[p.ID] = p
 	}
 
 	// Validar todas as respostas e setar IDSubmissao
+	// Os problemas são reunidos por pergunta para que o respondente corrija todos de uma vez
 	now := time.Now()
+	var erros []ErroPergunta
+	validas := make([]*entity.Resposta, 0, len(respostas))
 	for i, resposta := range respostas {
+		// CRÍTICO: Setar IDSubmissao (vincula ao respondente anônimo)
+		resposta.IDSubmissao = submissao.ID
+
 		// Validação básica
 		if err := uc.ValidateResposta(resposta); err != nil {
-			return fmt.Errorf("resposta %d inválida: %v", i+1, err)
+			erros = append(erros, ErroPergunta{IDPergunta: resposta.IDPergunta, Mensagem: fmt.Sprintf("resposta %d inválida: %v", i+1, err)})
+			continue
 		}
 
 		// CRÍTICO: Validar que pergunta pertence à pesquisa do token
-		if !perguntasValidas[resposta.IDPergunta] {
-			return fmt.Errorf("resposta %d: pergunta ID %d não pertence à pesquisa", i+1, resposta.IDPergunta)
+		pergunta, ok := perguntasValidas[resposta.IDPergunta]
+		if !ok {
+			erros = append(erros, ErroPergunta{IDPergunta: resposta.IDPergunta, Mensagem: "pergunta não pertence à pesquisa"})
+			continue
 		}
 
-		// CRÍTICO: Setar IDSubmissao (vincula ao respondente anônimo)
-		resposta.IDSubmissao = submissao.ID
-
 		// Define timestamp se não fornecido
 		if resposta.DataSubmissao.IsZero() {
 			resposta.DataSubmissao = now
 		}
 
-		// Valida valor da resposta baseado no tipo da pergunta
-		if err := uc.ValidateResponseValue(ctx, resposta.IDPergunta, resposta.ValorResposta); err != nil {
-			return fmt.Errorf("resposta %d: %v", i+1, err)
+		// Valida valor da resposta baseado no tipo da pergunta e grava a forma canônica
+		valor, err := normalizeResponseValue(pergunta, resposta.ValorResposta)
+		if err != nil {
+			erros = append(erros, ErroPergunta{IDPergunta: resposta.IDPergunta, Mensagem: err.Error()})
+			continue
 		}
+		resposta.ValorResposta = valor
+		validas = append(validas, resposta)
+	}
+
+	// Aplica as condições de exibição: perguntas ocultas não recebem respostas e as obrigatórias exibidas precisam de resposta
+	erros = append(erros, verificarPerguntasExibidas(perguntas, validas, erros)...)
+	if len(erros) > 0 {
+		return &SubmissaoInvalidaError{Erros: erros}
 	}
 
 	// Cria as respostas no banco (transação única)
@@ -139,10 +161,9 @@ func (uc *RespostaUseCase) CountByPesquisa(ctx context.Context, pesquisaID int)
 		return 0, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	// Verifica se pesquisa existe
-	_, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
-	if err != nil {
-		return 0, fmt.Errorf("pesquisa não encontrada: %v", err)
+	// Verifica se pesquisa existe e pertence à empresa
+	if _, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID); err != nil {
+		return 0, err
 	}
 
 	return uc.repo.CountByPesquisa(ctx, pesquisaID)
@@ -153,10 +174,9 @@ func (uc *RespostaUseCase) CountByPergunta(ctx context.Context, perguntaID int)
 		return 0, fmt.Errorf("ID da pergunta deve ser maior que zero")
 	}
 
-	// Verifica se pergunta existe
-	_, err := uc.perguntaRepo.GetByID(ctx, perguntaID)
-	if err != nil {
-		return 0, fmt.Errorf("pergunta não encontrada: %v", err)
+	// Verifica se pergunta existe e pertence à empresa
+	if _, _, err := getPerguntaInScope(ctx, uc.perguntaRepo, uc.pesquisaRepo, perguntaID); err != nil {
+		return 0, err
 	}
 
 	return uc.repo.CountByPergunta(ctx, perguntaID)
@@ -167,16 +187,10 @@ func (uc *RespostaUseCase) GetAggregatedByPergunta(ctx context.Context, pergunta
 		return nil, fmt.Errorf("ID da pergunta deve ser maior que zero")
 	}
 
-	// Verifica se pergunta existe
-	pergunta, err := uc.perguntaRepo.GetByID(ctx, perguntaID)
+	// Verifica se pergunta existe, pertence à empresa e se a pesquisa permite agregação
+	pergunta, pesquisa, err := getPerguntaInScope(ctx, uc.perguntaRepo, uc.pesquisaRepo, perguntaID)
 	if err != nil {
-		return nil, fmt.Errorf("pergunta não encontrada: %v", err)
-	}
-
-	// Verifica se pesquisa tem dados suficientes para agregação
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pergunta.IDPesquisa)
-	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+		return nil, err
 	}
 
 	// Só permite agregação de pesquisas ativas ou concluídas
@@ -184,25 +198,80 @@ func (uc *RespostaUseCase) GetAggregatedByPergunta(ctx context.Context, pergunta
 		return nil, fmt.Errorf("não é possível agregar dados de pesquisa em rascunho")
 	}
 
-	return uc.repo.GetAggregatedByPergunta(ctx, perguntaID)
+	agregados, err := uc.repo.GetAggregatedByPergunta(ctx, perguntaID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perguntas com menos respondentes que o mínimo da empresa não são exibidas
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	if sumCounts(agregados) < k {
+		return nil, erroSupressao(novaSupressao(k))
+	}
+
+	if pergunta.TipoPergunta == entity.TipoMultiplaEscolha {
+		agregados = entity.OpcoesDaPergunta(pergunta).AgregarSelecoes(agregados)
+	}
+	return agregados, nil
 }
 
-func (uc *RespostaUseCase) GetAggregatedByPesquisa(ctx context.Context, pesquisaID int) (map[int]map[string]int, error) {
+// GetAggregatedByPesquisa retorna a distribuição das respostas de cada pergunta da pesquisa
+// Perguntas com menos respondentes que o mínimo da empresa trazem a supressão no lugar da distribuição
+func (uc *RespostaUseCase) GetAggregatedByPesquisa(ctx context.Context, pesquisaID int) (map[int]interface{}, error) {
 	if pesquisaID <= 0 {
 		return nil, fmt.Errorf("ID da pesquisa deve ser maior que zero")
 	}
 
-	// Verifica se pesquisa existe e permite agregação
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe, pertence à empresa e permite agregação
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+		return nil, err
 	}
 
 	if pesquisa.Status == "Rascunho" {
 		return nil, fmt.Errorf("não é possível agregar dados de pesquisa em rascunho")
 	}
 
-	return uc.repo.GetAggregatedByPesquisa(ctx, pesquisaID)
+	agregados, err := uc.repo.GetAggregatedByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	perguntas, err := uc.perguntaRepo.ListByPesquisa(ctx, pesquisaID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perguntas: %v", err)
+	}
+
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	suprimidas := suprimirPerguntas(agregados, k)
+	if len(agregados) == 0 && len(suprimidas) > 0 {
+		return nil, erroSupressao(novaSupressao(k))
+	}
+	agregarSelecoesPorPergunta(perguntas, agregados)
+
+	resultado := make(map[int]interface{}, len(agregados)+len(suprimidas))
+	for perguntaID, distribuicao := range agregados {
+		resultado[perguntaID] = distribuicao
+	}
+	for perguntaID, supressao := range suprimidas {
+		resultado[perguntaID] = supressao
+	}
+	return resultado, nil
+}
+
+// agregarSelecoesPorPergunta converte, nas perguntas de múltipla escolha, a contagem por valor gravado em contagem pelo rótulo da opção
+func agregarSelecoesPorPergunta(perguntas []*entity.Pergunta, agregados map[int]map[string]int) {
+	for _, pergunta := range perguntas {
+		if distribuicao, ok := agregados[pergunta.ID]; ok && pergunta.TipoPergunta == entity.TipoMultiplaEscolha {
+			agregados[pergunta.ID] = entity.OpcoesDaPergunta(pergunta).AgregarSelecoes(distribuicao)
+		}
+	}
 }
 
 func (uc *RespostaUseCase) GetResponsesByDateRange(ctx context.Context, pesquisaID int, startDate, endDate string) ([]*entity.Resposta, error) {
@@ -235,10 +304,23 @@ func (uc *RespostaUseCase) GetResponsesByDateRange(ctx context.Context, pesquisa
 		return nil, fmt.Errorf("data final deve ser posterior à data inicial")
 	}
 
-	// Verifica se pesquisa existe
-	_, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe e pertence à empresa
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	// O período não pode isolar grupos menores que o mínimo da empresa, nem sozinho nem comparado a outros períodos
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	porDia, err := uc.repo.CountRespondentesByDia(ctx, pesquisaID)
 	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+		return nil, err
+	}
+	if err := verificarPeriodo(porDia, startDate, endDate, k); err != nil {
+		return nil, err
 	}
 
 	return uc.repo.GetResponsesByDateRange(ctx, pesquisaID, startDate, endDate)
@@ -253,10 +335,10 @@ func (uc *RespostaUseCase) DeleteByPesquisa(ctx context.Context, pesquisaID int,
 		return fmt.Errorf("motivo da exclusão é obrigatório")
 	}
 
-	// Verifica se pesquisa existe
-	pesquisa, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
+	// Verifica se pesquisa existe e pertence à empresa
+	pesquisa, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID)
 	if err != nil {
-		return fmt.Errorf("pesquisa não encontrada: %v", err)
+		return err
 	}
 
 	// Verifica quantidade de respostas antes da exclusão (para log)
@@ -287,10 +369,10 @@ func (uc *RespostaUseCase) GetStatisticsByPergunta(ctx context.Context, pergunta
 		return nil, fmt.Errorf("ID da pergunta deve ser maior que zero")
 	}
 
-	// Verifica se pergunta existe
-	pergunta, err := uc.perguntaRepo.GetByID(ctx, perguntaID)
+	// Verifica se pergunta existe e pertence à empresa
+	pergunta, pesquisa, err := getPerguntaInScope(ctx, uc.perguntaRepo, uc.pesquisaRepo, perguntaID)
 	if err != nil {
-		return nil, fmt.Errorf("pergunta não encontrada: %v", err)
+		return nil, err
 	}
 
 	// Busca contagem total
@@ -299,11 +381,22 @@ func (uc *RespostaUseCase) GetStatisticsByPergunta(ctx context.Context, pergunta
 		return nil, fmt.Errorf("erro ao contar respostas: %v", err)
 	}
 
+	k, err := uc.anonimato.MinRespondentes(ctx, pesquisa.IDEmpresa)
+	if err != nil {
+		return nil, err
+	}
+	if totalRespostas < k {
+		return nil, erroSupressao(novaSupressao(k))
+	}
+
 	// Busca dados agregados
 	agregados, err := uc.repo.GetAggregatedByPergunta(ctx, perguntaID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar dados agregados: %v", err)
 	}
+	if pergunta.TipoPergunta == entity.TipoMultiplaEscolha {
+		agregados = entity.OpcoesDaPergunta(pergunta).AgregarSelecoes(agregados)
+	}
 
 	stats := map[string]interface{}{
 		"pergunta_id":     perguntaID,
@@ -314,6 +407,20 @@ func (uc *RespostaUseCase) GetStatisticsByPergunta(ctx context.Context, pergunta
 		"opcoes_resposta": pergunta.OpcoesResposta,
 	}
 
+	if pergunta.TipoPergunta == entity.TipoEscalaNumerica {
+		escala := entity.EscalaDaPergunta(pergunta)
+		media, validas := escala.Media(agregados)
+		stats["escala"] = escala
+		stats["respostas_validas"] = validas
+		stats["nao_se_aplica"] = agregados[entity.ValorNaoSeAplica]
+		stats["media"] = nil
+		stats["media_normalizada"] = nil
+		if validas > 0 {
+			stats["media"] = media
+			stats["media_normalizada"] = escala.Normalizar(media)
+		}
+	}
+
 	return stats, nil
 }
 
@@ -324,39 +431,100 @@ func (uc *RespostaUseCase) ValidateResponseValue(ctx context.Context, perguntaID
 		return fmt.Errorf("pergunta não encontrada: %v", err)
 	}
 
+	_, err = normalizeResponseValue(pergunta, valorResposta)
+	return err
+}
+
+// ErroPergunta descreve o problema de uma pergunta em uma submissão rejeitada
+type ErroPergunta struct {
+	IDPergunta int    `json:"id_pergunta"` // Pergunta com problema
+	Mensagem   string `json:"mensagem"`    // Motivo da rejeição
+}
+
+// SubmissaoInvalidaError indica que a submissão foi rejeitada, com a lista de problemas por pergunta
+// Nenhuma resposta é gravada e o token continua válido para uma nova tentativa
+type SubmissaoInvalidaError struct {
+	Erros []ErroPergunta
+}
+
+// Error implementa a interface error para SubmissaoInvalidaError
+func (e *SubmissaoInvalidaError) Error() string {
+	mensagens := make([]string, len(e.Erros))
+	for i, erro := range e.Erros {
+		mensagens[i] = fmt.Sprintf("pergunta %d: %s", erro.IDPergunta, erro.Mensagem)
+	}
+	return "submissão inválida: " + strings.Join(mensagens, "; ")
+}
+
+// verificarPerguntasExibidas confere as respostas válidas (já normalizadas) contra as condições de exibição
+// Aponta respostas repetidas, respostas a perguntas ocultas e perguntas obrigatórias exibidas sem resposta;
+// perguntas que já têm erro não são apontadas como sem resposta
+func verificarPerguntasExibidas(perguntas []*entity.Pergunta, respostas []*entity.Resposta, anteriores []ErroPergunta) []ErroPergunta {
+	var erros []ErroPergunta
+	comErro := make(map[int]bool, len(anteriores))
+	for _, erro := range anteriores {
+		comErro[erro.IDPergunta] = true
+	}
+
+	valores := make(map[int]string, len(respostas))
+	for _, resposta := range respostas {
+		if _, repetida := valores[resposta.IDPergunta]; repetida {
+			erros = append(erros, ErroPergunta{IDPergunta: resposta.IDPergunta, Mensagem: "pergunta respondida mais de uma vez"})
+			comErro[resposta.IDPergunta] = true
+			continue
+		}
+		valores[resposta.IDPergunta] = resposta.ValorResposta
+	}
+
+	visiveis := entity.PerguntasVisiveis(perguntas, valores)
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		_, respondida := valores[pergunta.ID]
+		switch {
+		case respondida && !visiveis[pergunta.ID]:
+			erros = append(erros, ErroPergunta{IDPergunta: pergunta.ID, Mensagem: "pergunta não é exibida para as respostas enviadas"})
+		case !respondida && visiveis[pergunta.ID] && pergunta.Obrigatoria && !comErro[pergunta.ID]:
+			erros = append(erros, ErroPergunta{IDPergunta: pergunta.ID, Mensagem: "resposta obrigatória"})
+		}
+	}
+	return erros
+}
+
+// normalizeResponseValue valida o valor da resposta conforme a pergunta e retorna o valor a gravar
+// Respostas de múltipla escolha são convertidas para a forma canônica do esquema de opções
+func normalizeResponseValue(pergunta *entity.Pergunta, valorResposta string) (string, error) {
 	valorResposta = strings.TrimSpace(valorResposta)
 
 	switch pergunta.TipoPergunta {
 	case "SimNao":
 		if valorResposta != "Sim" && valorResposta != "Não" {
-			return fmt.Errorf("resposta deve ser 'Sim' ou 'Não'")
-		}
-
-	case "EscalaNumerica":
-		// Valida se é um número de 1 a 10 (ou outro range)
-		if num, err := strconv.Atoi(valorResposta); err != nil || num < 1 || num > 10 {
-			return fmt.Errorf("resposta deve ser um valor numérico entre 1 e 10")
+			return "", fmt.Errorf("resposta deve ser 'Sim' ou 'Não'")
 		}
 
-	case "MultiplaEscolha":
-		// Para múltipla escolha, seria necessário validar contra as opções disponíveis
-		// Seria necessário parsear o JSON de OpcoesResposta
-		if valorResposta == "" {
-			return fmt.Errorf("uma opção deve ser selecionada")
+	case entity.TipoEscalaNumerica:
+		return entity.EscalaDaPergunta(pergunta).NormalizarResposta(valorResposta)
+
+	case entity.TipoMultiplaEscolha:
+		opcoes, err := entity.ParseOpcoesMultiplaEscolha(pergunta.OpcoesResposta)
+		if err != nil {
+			// Perguntas antigas, com opções fora do esquema, só exigem uma seleção
+			if valorResposta == "" {
+				return "", fmt.Errorf("uma opção deve ser selecionada")
+			}
+			return valorResposta, nil
 		}
-		// TODO: Validar contra opções específicas no JSON
+		return opcoes.NormalizarResposta(valorResposta)
 
 	case "RespostaAberta":
 		if len(valorResposta) > 1000 {
-			return fmt.Errorf("resposta de texto livre não pode exceder 1000 caracteres")
+			return "", fmt.Errorf("resposta de texto livre não pode exceder 1000 caracteres")
 		}
 		if len(valorResposta) < 1 {
-			return fmt.Errorf("resposta de texto livre não pode estar vazia")
+			return "", fmt.Errorf("resposta de texto livre não pode estar vazia")
 		}
 
 	default:
-		return fmt.Errorf("tipo de pergunta não reconhecido: %s", pergunta.TipoPergunta)
+		return "", fmt.Errorf("tipo de pergunta não reconhecido: %s", pergunta.TipoPergunta)
 	}
 
-	return nil
+	return valorResposta, nil
 }
\ No newline at end of file
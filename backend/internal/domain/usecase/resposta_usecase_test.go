@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+type fakeSubmissaoRepo struct {
+	repository.SubmissaoPesquisaRepository
+	submissao  *entity.SubmissaoPesquisa
+	concluidas []int
+}
+
+func (r *fakeSubmissaoRepo) GetByToken(ctx context.Context, token string) (*entity.SubmissaoPesquisa, error) {
+	if token != r.submissao.TokenAcesso {
+		return nil, fmt.Errorf("token não encontrado")
+	}
+	return r.submissao, nil
+}
+
+func (r *fakeSubmissaoRepo) MarkAsCompleted(ctx context.Context, id int) error {
+	r.concluidas = append(r.concluidas, id)
+	return nil
+}
+
+type fakeRespostaPesquisaRepo struct {
+	repository.PesquisaRepository
+	pesquisa *entity.Pesquisa
+}
+
+func (r *fakeRespostaPesquisaRepo) GetByID(ctx context.Context, id int) (*entity.Pesquisa, error) {
+	if id != r.pesquisa.ID {
+		return nil, fmt.Errorf("pesquisa não encontrada")
+	}
+	return r.pesquisa, nil
+}
+
+type fakeRespostaPerguntaRepo struct {
+	repository.PerguntaRepository
+	perguntas []*entity.Pergunta
+}
+
+func (r *fakeRespostaPerguntaRepo) ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error) {
+	return r.perguntas, nil
+}
+
+type fakeRespostaRepo struct {
+	repository.RespostaRepository
+	gravadas []*entity.Resposta
+}
+
+func (r *fakeRespostaRepo) CreateBatch(ctx context.Context, respostas []*entity.Resposta) error {
+	r.gravadas = append(r.gravadas, respostas...)
+	return nil
+}
+
+func TestCreateBatchAplicaCondicoesExibicao(t *testing.T) {
+	const token = "token-valido"
+	condicao := `{"pergunta":1,"operador":">=","valor":"4"}`
+	perguntas := []*entity.Pergunta{
+		{ID: 1, IDPesquisa: 10, OrdemExibicao: 1, TipoPergunta: entity.TipoEscalaNumerica, Obrigatoria: true},
+		{ID: 2, IDPesquisa: 10, OrdemExibicao: 2, TipoPergunta: "SimNao", Obrigatoria: true, CondicaoExibicao: &condicao},
+	}
+
+	casos := []struct {
+		nome      string
+		respostas map[int]string
+		erros     []ErroPergunta // Vazio quando a submissão é aceita
+	}{
+		{
+			nome:      "pergunta condicional exibida e respondida",
+			respostas: map[int]string{1: "5", 2: "Sim"},
+		},
+		{
+			nome:      "pergunta condicional oculta e sem resposta",
+			respostas: map[int]string{1: "2"},
+		},
+		{
+			nome:      "pergunta oculta respondida",
+			respostas: map[int]string{1: "2", 2: "Sim"},
+			erros:     []ErroPergunta{{IDPergunta: 2, Mensagem: "pergunta não é exibida para as respostas enviadas"}},
+		},
+		{
+			nome:      "pergunta obrigatória exibida sem resposta",
+			respostas: map[int]string{1: "4"},
+			erros:     []ErroPergunta{{IDPergunta: 2, Mensagem: "resposta obrigatória"}},
+		},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			submissaoRepo := &fakeSubmissaoRepo{submissao: &entity.SubmissaoPesquisa{ID: 7, IDPesquisa: 10, TokenAcesso: token, Status: "pendente"}}
+			pesquisaRepo := &fakeRespostaPesquisaRepo{pesquisa: &entity.Pesquisa{ID: 10, IDEmpresa: 1, Status: "Ativa"}}
+			respostaRepo := &fakeRespostaRepo{}
+			submissaoUC := NewSubmissaoPesquisaUseCase(submissaoRepo, pesquisaRepo, nil, "")
+			uc := NewRespostaUseCase(respostaRepo, &fakeRespostaPerguntaRepo{perguntas: perguntas}, pesquisaRepo, submissaoUC)
+
+			var respostas []*entity.Resposta
+			for _, pergunta := range perguntas {
+				if valor, ok := c.respostas[pergunta.ID]; ok {
+					respostas = append(respostas, &entity.Resposta{IDPergunta: pergunta.ID, ValorResposta: valor})
+				}
+			}
+
+			err := uc.CreateBatch(context.Background(), respostas, token)
+			if len(c.erros) == 0 {
+				if err != nil {
+					t.Fatalf("submissão rejeitada: %v", err)
+				}
+				if len(respostaRepo.gravadas) != len(respostas) || len(submissaoRepo.concluidas) != 1 {
+					t.Errorf("%d respostas gravadas e %d submissões concluídas, esperado %d e 1",
+						len(respostaRepo.gravadas), len(submissaoRepo.concluidas), len(respostas))
+				}
+				return
+			}
+
+			var invalida *SubmissaoInvalidaError
+			if !errors.As(err, &invalida) {
+				t.Fatalf("erro = %v, esperado SubmissaoInvalidaError", err)
+			}
+			if fmt.Sprint(invalida.Erros) != fmt.Sprint(c.erros) {
+				t.Errorf("erros %v, esperado %v", invalida.Erros, c.erros)
+			}
+			if len(respostaRepo.gravadas) > 0 || len(submissaoRepo.concluidas) > 0 {
+				t.Errorf("submissão rejeitada gravou %d respostas e concluiu %d submissões", len(respostaRepo.gravadas), len(submissaoRepo.concluidas))
+			}
+		})
+	}
+}
+
+// perguntasCompletude monta escala obrigatória (1), sim/não opcional (2) e sim/não obrigatória exibida com escala >= 4 (3)
+func perguntasCompletude() []*entity.Pergunta {
+	condicao := `{"pergunta":1,"operador":">=","valor":"4"}`
+	return []*entity.Pergunta{
+		{ID: 1, OrdemExibicao: 1, TipoPergunta: entity.TipoEscalaNumerica, Obrigatoria: true},
+		{ID: 2, OrdemExibicao: 2, TipoPergunta: "SimNao"},
+		{ID: 3, OrdemExibicao: 3, TipoPergunta: "SimNao", Obrigatoria: true, CondicaoExibicao: &condicao},
+	}
+}
+
+func TestVerificarPerguntasExibidas(t *testing.T) {
+	resposta := func(perguntaID int, valor string) *entity.Resposta {
+		return &entity.Resposta{IDPergunta: perguntaID, ValorResposta: valor}
+	}
+
+	casos := []struct {
+		nome       string
+		respostas  []*entity.Resposta
+		anteriores []ErroPergunta
+		erros      []ErroPergunta
+	}{
+		{
+			nome:      "obrigatória sem resposta",
+			respostas: []*entity.Resposta{resposta(2, "Sim")},
+			erros:     []ErroPergunta{{IDPergunta: 1, Mensagem: "resposta obrigatória"}},
+		},
+		{
+			nome:      "opcional em branco",
+			respostas: []*entity.Resposta{resposta(1, "5"), resposta(3, "Sim")},
+		},
+		{
+			nome:      "obrigatória oculta sem resposta",
+			respostas: []*entity.Resposta{resposta(1, "2"), resposta(2, "Não")},
+		},
+		{
+			nome:      "obrigatória exibida sem resposta",
+			respostas: []*entity.Resposta{resposta(1, "4")},
+			erros:     []ErroPergunta{{IDPergunta: 3, Mensagem: "resposta obrigatória"}},
+		},
+		{
+			nome:      "resposta repetida",
+			respostas: []*entity.Resposta{resposta(1, "5"), resposta(1, "2"), resposta(3, "Sim")},
+			erros:     []ErroPergunta{{IDPergunta: 1, Mensagem: "pergunta respondida mais de uma vez"}},
+		},
+		{
+			nome:       "pergunta com erro anterior não é apontada sem resposta",
+			respostas:  []*entity.Resposta{resposta(2, "Sim")},
+			anteriores: []ErroPergunta{{IDPergunta: 1, Mensagem: "valor fora da escala"}},
+		},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			erros := verificarPerguntasExibidas(perguntasCompletude(), c.respostas, c.anteriores)
+			if fmt.Sprint(erros) != fmt.Sprint(c.erros) {
+				t.Errorf("erros %v, esperado %v", erros, c.erros)
+			}
+		})
+	}
+}
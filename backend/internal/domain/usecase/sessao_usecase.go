@@ -0,0 +1,291 @@
+// Package usecase implementa os casos de uso para sessões de login.
+// Emite e rotaciona refresh tokens, detecta reuso e revoga access tokens pelo jti.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/crypto"
+	"strings"
+	"time"
+)
+
+// Tamanho em bytes do refresh token opaco
+const refreshTokenBytes = 32
+
+// SessaoUseCase implementa o ciclo de vida das sessões de login
+type SessaoUseCase struct {
+	sessaoRepo        repository.SessaoRepository               // Repositório de sessões e refresh tokens
+	tokenRevogadoRepo repository.TokenRevogadoRepository        // Denylist de access tokens
+	usuarioRepo       repository.UsuarioAdministradorRepository // Repositório de usuários
+	logAuditoriaRepo  repository.LogAuditoriaRepository         // Repositório de logs
+	crypto            crypto.CryptoService                      // Geração de tokens
+	refreshTTL        time.Duration                             // Validade máxima de uma sessão
+}
+
+// NewSessaoUseCase cria uma nova instância do caso de uso de sessões
+func NewSessaoUseCase(
+	sessaoRepo repository.SessaoRepository,
+	tokenRevogadoRepo repository.TokenRevogadoRepository,
+	usuarioRepo repository.UsuarioAdministradorRepository,
+	logRepo repository.LogAuditoriaRepository,
+	cryptoSvc crypto.CryptoService,
+	refreshTTL time.Duration,
+) *SessaoUseCase {
+	if refreshTTL <= 0 {
+		refreshTTL = 30 * 24 * time.Hour
+	}
+	return &SessaoUseCase{
+		sessaoRepo:        sessaoRepo,
+		tokenRevogadoRepo: tokenRevogadoRepo,
+		usuarioRepo:       usuarioRepo,
+		logAuditoriaRepo:  logRepo,
+		crypto:            cryptoSvc,
+		refreshTTL:        refreshTTL,
+	}
+}
+
+// Create abre uma sessão para o usuário autenticado e retorna o refresh token em texto claro
+// jti e accessExp identificam o access token emitido junto com a sessão
+func (uc *SessaoUseCase) Create(ctx context.Context, usuario *entity.UsuarioAdministrador, jti string, accessExp time.Time, clientIP, userAgent string) (*entity.SessaoUsuario, string, error) {
+	refreshToken, err := uc.crypto.GenerateToken(refreshTokenBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao gerar refresh token: %v", err)
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	now := time.Now()
+	sessao := &entity.SessaoUsuario{
+		IDUserAdmin:         usuario.ID,
+		JTIAtual:            jti,
+		DataExpiracaoAcesso: accessExp,
+		DataCriacao:         now,
+		DataUltimoUso:       now,
+		DataExpiracao:       now.Add(uc.refreshTTL),
+		EnderecoIP:          clientIP,
+		UserAgent:           userAgent,
+	}
+
+	if err := uc.sessaoRepo.Create(ctx, sessao, crypto.HashToken(refreshToken)); err != nil {
+		return nil, "", fmt.Errorf("erro ao criar sessão: %v", err)
+	}
+
+	return sessao, refreshToken, nil
+}
+
+// Refresh troca um refresh token por um novo, vinculando o novo access token (jti) à sessão
+// A apresentação de um token já trocado indica vazamento: a sessão inteira é revogada
+func (uc *SessaoUseCase) Refresh(ctx context.Context, refreshToken, jti string, accessExp time.Time, clientIP string) (*entity.SessaoUsuario, *entity.UsuarioAdministrador, string, error) {
+	if strings.TrimSpace(refreshToken) == "" {
+		return nil, nil, "", fmt.Errorf("refresh token inválido")
+	}
+
+	sessao, token, err := uc.sessaoRepo.GetByRefreshHash(ctx, crypto.HashToken(refreshToken))
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("refresh token inválido")
+	}
+
+	now := time.Now()
+	if token.DataUso != nil {
+		uc.handleReuse(ctx, sessao, now, clientIP)
+		return nil, nil, "", fmt.Errorf("refresh token inválido")
+	}
+
+	if !sessao.Ativa(now) {
+		return nil, nil, "", fmt.Errorf("refresh token inválido")
+	}
+
+	usuario, err := uc.usuarioRepo.GetByID(ctx, sessao.IDUserAdmin)
+	if err != nil {
+		uc.revoke(ctx, sessao, now)
+		return nil, nil, "", fmt.Errorf("refresh token inválido")
+	}
+	if usuario.Status != "Ativo" {
+		uc.revoke(ctx, sessao, now)
+		return nil, nil, "", fmt.Errorf("usuário inativo")
+	}
+
+	novoRefresh, err := uc.crypto.GenerateToken(refreshTokenBytes)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao gerar refresh token: %v", err)
+	}
+
+	anterior := *sessao
+	sessao.JTIAtual = jti
+	sessao.DataExpiracaoAcesso = accessExp
+	sessao.DataUltimoUso = now
+
+	if err := uc.sessaoRepo.Rotate(ctx, token.ID, sessao, crypto.HashToken(novoRefresh)); err != nil {
+		if strings.Contains(err.Error(), "já utilizado") {
+			// Outra requisição trocou o mesmo token primeiro
+			uc.handleReuse(ctx, &anterior, now, clientIP)
+			return nil, nil, "", fmt.Errorf("refresh token inválido")
+		}
+		return nil, nil, "", fmt.Errorf("erro ao renovar sessão: %v", err)
+	}
+
+	// O access token anterior da sessão deixa de valer
+	uc.revokeJTI(ctx, anterior.JTIAtual, anterior.DataExpiracaoAcesso, now)
+
+	return sessao, usuario, novoRefresh, nil
+}
+
+// handleReuse revoga a sessão cujo refresh token foi reapresentado e registra o incidente
+func (uc *SessaoUseCase) handleReuse(ctx context.Context, sessao *entity.SessaoUsuario, now time.Time, clientIP string) {
+	uc.revoke(ctx, sessao, now)
+
+	if uc.logAuditoriaRepo != nil {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   sessao.IDUserAdmin,
+			TimeStamp:     now,
+			AcaoRealizada: "Reuso de Refresh Token Detectado",
+			Detalhes:      fmt.Sprintf("Refresh token já utilizado reapresentado; sessão ID %d revogada", sessao.ID),
+			EnderecoIP:    clientIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+}
+
+// Logout encerra a sessão do token atual e revoga o próprio access token
+// sessaoID pode ser 0 para tokens emitidos sem sessão
+func (uc *SessaoUseCase) Logout(ctx context.Context, userAdminID, sessaoID int, jti string, accessExp time.Time, clientIP string) error {
+	now := time.Now()
+
+	if sessaoID > 0 {
+		sessao, err := uc.sessaoRepo.GetByID(ctx, sessaoID)
+		if err == nil && sessao.IDUserAdmin == userAdminID {
+			uc.revoke(ctx, sessao, now)
+		}
+	}
+
+	if err := uc.revokeJTI(ctx, jti, accessExp, now); err != nil {
+		return err
+	}
+
+	if uc.logAuditoriaRepo != nil {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     now,
+			AcaoRealizada: "Logout",
+			Detalhes:      fmt.Sprintf("Usuário ID %d realizou logout (sessão ID %d)", userAdminID, sessaoID),
+			EnderecoIP:    clientIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// ListActive lista as sessões ativas do usuário
+func (uc *SessaoUseCase) ListActive(ctx context.Context, userAdminID int) ([]*entity.SessaoUsuario, error) {
+	if userAdminID <= 0 {
+		return nil, fmt.Errorf("ID do usuário inválido")
+	}
+
+	sessoes, err := uc.sessaoRepo.ListActiveByUsuario(ctx, userAdminID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar sessões: %v", err)
+	}
+
+	return sessoes, nil
+}
+
+// Revoke encerra uma sessão do próprio usuário
+// Sessões de outros usuários são tratadas como inexistentes
+func (uc *SessaoUseCase) Revoke(ctx context.Context, userAdminID, sessaoID int, clientIP string) error {
+	if sessaoID <= 0 {
+		return fmt.Errorf("ID da sessão inválido")
+	}
+
+	sessao, err := uc.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil || sessao.IDUserAdmin != userAdminID {
+		return fmt.Errorf("sessão não encontrada")
+	}
+
+	now := time.Now()
+	if !sessao.Ativa(now) {
+		return fmt.Errorf("sessão não encontrada")
+	}
+
+	if err := uc.revoke(ctx, sessao, now); err != nil {
+		return err
+	}
+
+	if uc.logAuditoriaRepo != nil {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     now,
+			AcaoRealizada: "Sessão Revogada",
+			Detalhes:      fmt.Sprintf("Sessão ID %d revogada (login em %s, IP %s)", sessao.ID, sessao.DataCriacao.Format(time.RFC3339), sessao.EnderecoIP),
+			EnderecoIP:    clientIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// RevokeAll encerra todas as sessões ativas do usuário e revoga seus access tokens vigentes
+// Os refresh tokens das sessões encerradas deixam de ser aceitos; retorna a quantidade de sessões encerradas
+func (uc *SessaoUseCase) RevokeAll(ctx context.Context, userAdminID int) (int, error) {
+	now := time.Now()
+	sessoes, err := uc.sessaoRepo.ListActiveByUsuario(ctx, userAdminID, now)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao listar sessões: %v", err)
+	}
+
+	for i, sessao := range sessoes {
+		if err := uc.revoke(ctx, sessao, now); err != nil {
+			return i, err
+		}
+	}
+
+	return len(sessoes), nil
+}
+
+// IsRevoked verifica se o access token (jti) foi revogado
+func (uc *SessaoUseCase) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return uc.tokenRevogadoRepo.IsRevoked(ctx, jti)
+}
+
+// CleanupExpired remove sessões e registros da denylist que já expiraram
+// Retorna a quantidade total de registros removidos
+func (uc *SessaoUseCase) CleanupExpired(ctx context.Context) (int64, error) {
+	now := time.Now()
+
+	sessoes, err := uc.sessaoRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover sessões expiradas: %v", err)
+	}
+
+	tokens, err := uc.tokenRevogadoRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return sessoes, fmt.Errorf("erro ao remover tokens revogados expirados: %v", err)
+	}
+
+	return sessoes + tokens, nil
+}
+
+// revoke marca a sessão como revogada e adiciona seu access token vigente à denylist
+func (uc *SessaoUseCase) revoke(ctx context.Context, sessao *entity.SessaoUsuario, now time.Time) error {
+	if err := uc.sessaoRepo.Revoke(ctx, sessao.ID, now); err != nil {
+		return fmt.Errorf("erro ao revogar sessão: %v", err)
+	}
+	return uc.revokeJTI(ctx, sessao.JTIAtual, sessao.DataExpiracaoAcesso, now)
+}
+
+// revokeJTI adiciona o jti à denylist enquanto o token ainda não expirou
+func (uc *SessaoUseCase) revokeJTI(ctx context.Context, jti string, expiresAt, now time.Time) error {
+	if jti == "" || !expiresAt.After(now) {
+		return nil
+	}
+	if err := uc.tokenRevogadoRepo.Revoke(ctx, jti, expiresAt); err != nil {
+		return fmt.Errorf("erro ao revogar access token: %v", err)
+	}
+	return nil
+}
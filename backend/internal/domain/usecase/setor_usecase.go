@@ -46,7 +46,10 @@ func (uc *SetorUseCase) Create(ctx context.Context, setor *entity.Setor, userAdm
 	
 	fmt.Printf("DEBUG: Validações OK - Empresa=%d, Nome=%s\n", setor.IDEmpresa, setor.NomeSetor)
 	
-	// Verifica se empresa existe
+	// Verifica se empresa existe e está no escopo do usuário
+	if err := checkEmpresaScope(ctx, setor.IDEmpresa, "empresa não encontrada"); err != nil {
+		return err
+	}
 	_, err := uc.empresaRepo.GetByID(ctx, setor.IDEmpresa)
 	if err != nil {
 		fmt.Printf("DEBUG: Empresa não encontrada: %v\n", err)
@@ -94,7 +97,19 @@ func (uc *SetorUseCase) GetByID(ctx context.Context, id int) (*entity.Setor, err
 		return nil, fmt.Errorf("ID do setor deve ser maior que zero")
 	}
 	
-	return uc.repo.GetByID(ctx, id)
+	return uc.getInScope(ctx, id)
+}
+
+// getInScope busca um setor e verifica se pertence à empresa do contexto
+func (uc *SetorUseCase) getInScope(ctx context.Context, id int) (*entity.Setor, error) {
+	setor, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("setor não encontrado: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, setor.IDEmpresa, "setor não encontrado"); err != nil {
+		return nil, err
+	}
+	return setor, nil
 }
 
 // GetByNome busca um setor pelo nome dentro de uma empresa
@@ -107,6 +122,10 @@ func (uc *SetorUseCase) GetByNome(ctx context.Context, empresaID int, nome strin
 		return nil, fmt.Errorf("nome do setor é obrigatório")
 	}
 	
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+	
 	return uc.repo.GetByNome(ctx, empresaID, nome)
 }
 
@@ -116,6 +135,10 @@ func (uc *SetorUseCase) ListByEmpresa(ctx context.Context, empresaID int) ([]*en
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 	
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+	
 	return uc.repo.ListByEmpresa(ctx, empresaID)
 }
 
@@ -130,12 +153,15 @@ func (uc *SetorUseCase) Update(ctx context.Context, setor *entity.Setor, userAdm
 		return fmt.Errorf("nome do setor é obrigatório")
 	}
 	
-	// Verifica se setor existe
-	existing, err := uc.repo.GetByID(ctx, setor.ID)
+	// Verifica se setor existe e pertence à empresa
+	existing, err := uc.getInScope(ctx, setor.ID)
 	if err != nil {
-		return fmt.Errorf("setor não encontrado: %v", err)
+		return err
 	}
 	
+	// Não permite transferir o setor para outra empresa
+	setor.IDEmpresa = existing.IDEmpresa
+	
 	// Verifica se nome não está sendo usado por outro setor da mesma empresa
 	setorComNome, err := uc.repo.GetByNome(ctx, setor.IDEmpresa, setor.NomeSetor)
 	if err == nil && setorComNome != nil && setorComNome.ID != setor.ID {
@@ -168,9 +194,9 @@ func (uc *SetorUseCase) Delete(ctx context.Context, id int, userAdminID int, end
 	}
 	
 	// Busca setor para log
-	setor, err := uc.repo.GetByID(ctx, id)
+	setor, err := uc.getInScope(ctx, id)
 	if err != nil {
-		return fmt.Errorf("setor não encontrado: %v", err)
+		return err
 	}
 	
 	if err := uc.repo.Delete(ctx, id); err != nil {
@@ -24,6 +24,8 @@ type SubmissaoPesquisaUseCase struct {
 	hashSalt     string                                 // Salt para hashes de IP/fingerprint
 	tokenTTL     time.Duration                          // Tempo de vida do token (padrão: 1h)
 	rateLimitMax int                                    // Máximo de tokens por IP/hora (padrão: 3)
+	perguntaRepo repository.PerguntaRepository          // Repositório de perguntas (completude)
+	respostaRepo repository.RespostaRepository          // Repositório de respostas (completude)
 }
 
 // NewSubmissaoPesquisaUseCase cria nova instância do caso de uso
@@ -43,6 +45,13 @@ func NewSubmissaoPesquisaUseCase(
 	}
 }
 
+// SetCompletude habilita a distinção entre submissões integrais e parciais nas estatísticas
+// Sem esta configuração as estatísticas trazem apenas as contagens por status
+func (uc *SubmissaoPesquisaUseCase) SetCompletude(perguntaRepo repository.PerguntaRepository, respostaRepo repository.RespostaRepository) {
+	uc.perguntaRepo = perguntaRepo
+	uc.respostaRepo = respostaRepo
+}
+
 // GenerateAccessToken gera token único para submissão anônima de pesquisa
 func (uc *SubmissaoPesquisaUseCase) GenerateAccessToken(
 	ctx context.Context,
@@ -187,10 +196,9 @@ func (uc *SubmissaoPesquisaUseCase) GetSubmissionStats(ctx context.Context, pesq
 		return nil, fmt.Errorf("ID da pesquisa inválido")
 	}
 
-	// Verificar se pesquisa existe
-	_, err := uc.pesquisaRepo.GetByID(ctx, pesquisaID)
-	if err != nil {
-		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+	// Verificar se pesquisa existe e pertence à empresa
+	if _, err := getPesquisaInScope(ctx, uc.pesquisaRepo, pesquisaID); err != nil {
+		return nil, err
 	}
 
 	// Contar submissões completas
@@ -227,6 +235,18 @@ func (uc *SubmissaoPesquisaUseCase) GetSubmissionStats(ctx context.Context, pesq
 		"participantes_unicos": completas, // Cada submissão completa = 1 respondente
 	}
 
+	// Submissões completas divididas entre integrais (todas as perguntas exibidas respondidas)
+	// e parciais (alguma pergunta opcional exibida ficou sem resposta)
+	if uc.perguntaRepo != nil && uc.respostaRepo != nil {
+		completude, err := carregarCompletude(ctx, uc.perguntaRepo, uc.respostaRepo, pesquisaID)
+		if err != nil {
+			return nil, err
+		}
+		stats["completas_integrais"] = completude.Integrais
+		stats["completas_parciais"] = completude.Parciais
+		stats["taxa_integral"] = calculateCompletionRate(completude.Integrais, completude.Integrais+completude.Parciais)
+	}
+
 	return stats, nil
 }
 
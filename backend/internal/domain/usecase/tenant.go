@@ -0,0 +1,90 @@
+// Package usecase implementa o isolamento de dados entre empresas (tenants).
+// Fornece o escopo de empresa propagado pelo contexto e as verificações de propriedade usadas pelos casos de uso.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// empresaScopeKey é a chave privada do escopo de empresa no contexto
+type empresaScopeKey struct{}
+
+// systemScopeKey é a chave privada do escopo de sistema no contexto
+type systemScopeKey struct{}
+
+// WithEmpresaScope restringe as operações executadas com o contexto retornado à empresa informada
+func WithEmpresaScope(ctx context.Context, empresaID int) context.Context {
+	return context.WithValue(ctx, empresaScopeKey{}, empresaID)
+}
+
+// WithSystemScope libera o acesso a todas as empresas no contexto retornado
+// Usado pelo agendador e pelas rotas públicas; contextos sem escopo algum não acessam nenhuma empresa
+func WithSystemScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemScopeKey{}, true)
+}
+
+// EmpresaScope retorna a empresa à qual o contexto está restrito
+func EmpresaScope(ctx context.Context) (int, bool) {
+	empresaID, ok := ctx.Value(empresaScopeKey{}).(int)
+	return empresaID, ok
+}
+
+// hasSystemScope verifica se o contexto tem escopo de sistema e nenhum escopo de empresa
+func hasSystemScope(ctx context.Context) bool {
+	if _, ok := EmpresaScope(ctx); ok {
+		return false
+	}
+	system, _ := ctx.Value(systemScopeKey{}).(bool)
+	return system
+}
+
+// inEmpresaScope verifica se a empresa informada pode ser acessada no contexto
+// O acesso é negado por padrão: exige o escopo da própria empresa ou o escopo de sistema
+func inEmpresaScope(ctx context.Context, empresaID int) bool {
+	if scope, ok := EmpresaScope(ctx); ok {
+		return scope == empresaID
+	}
+	return hasSystemScope(ctx)
+}
+
+// checkEmpresaScope retorna erro de recurso inexistente quando a empresa está fora do escopo
+// Acesso entre empresas é indistinguível de um ID inexistente (resulta em 404)
+func checkEmpresaScope(ctx context.Context, empresaID int, naoEncontrado string) error {
+	if !inEmpresaScope(ctx, empresaID) {
+		return fmt.Errorf("%s", naoEncontrado)
+	}
+	return nil
+}
+
+// getPesquisaInScope busca uma pesquisa e verifica se pertence à empresa do contexto
+func getPesquisaInScope(ctx context.Context, repo repository.PesquisaRepository, pesquisaID int) (*entity.Pesquisa, error) {
+	pesquisa, err := repo.GetByID(ctx, pesquisaID)
+	if err != nil {
+		return nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, "pesquisa não encontrada"); err != nil {
+		return nil, err
+	}
+	return pesquisa, nil
+}
+
+// getPerguntaInScope busca uma pergunta e verifica se sua pesquisa pertence à empresa do contexto
+func getPerguntaInScope(ctx context.Context, perguntaRepo repository.PerguntaRepository, pesquisaRepo repository.PesquisaRepository, perguntaID int) (*entity.Pergunta, *entity.Pesquisa, error) {
+	pergunta, err := perguntaRepo.GetByID(ctx, perguntaID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pergunta não encontrada: %v", err)
+	}
+
+	pesquisa, err := pesquisaRepo.GetByID(ctx, pergunta.IDPesquisa)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pesquisa não encontrada: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, pesquisa.IDEmpresa, "pergunta não encontrada"); err != nil {
+		return nil, nil, err
+	}
+
+	return pergunta, pesquisa, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInEmpresaScopeNegaPorPadrao(t *testing.T) {
+	casos := []struct {
+		nome      string
+		ctx       context.Context
+		empresaID int
+		permitido bool
+	}{
+		{"sem escopo", context.Background(), 1, false},
+		{"própria empresa", WithEmpresaScope(context.Background(), 1), 1, true},
+		{"outra empresa", WithEmpresaScope(context.Background(), 1), 2, false},
+		{"escopo de sistema", WithSystemScope(context.Background()), 2, true},
+		{"empresa prevalece sobre sistema", WithEmpresaScope(WithSystemScope(context.Background()), 1), 2, false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if permitido := inEmpresaScope(c.ctx, c.empresaID); permitido != c.permitido {
+				t.Errorf("permitido = %v, esperado %v", permitido, c.permitido)
+			}
+		})
+	}
+}
@@ -5,22 +5,64 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
 	"organizational-climate-survey/backend/pkg/crypto"
+	"organizational-climate-survey/backend/pkg/mailer"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
 // UsuarioAdministradorUseCase implementa casos de uso para gerenciamento de usuários admin
 type UsuarioAdministradorUseCase struct {
-	repo             repository.UsuarioAdministradorRepository // Repositório de usuários
-	empresaRepo      repository.EmpresaRepository              // Repositório de empresas
-	logAuditoriaRepo repository.LogAuditoriaRepository         // Repositório de logs
-	crypto           crypto.CryptoService                      // Serviço de criptografia
+	repo             repository.UsuarioAdministradorRepository  // Repositório de usuários
+	empresaRepo      repository.EmpresaRepository               // Repositório de empresas
+	logAuditoriaRepo repository.LogAuditoriaRepository          // Repositório de logs
+	crypto           crypto.CryptoService                       // Serviço de criptografia
+	resetRepo        repository.TokenRedefinicaoSenhaRepository // Repositório de tokens de redefinição
+	mailer           mailer.Mailer                              // Envio do link de redefinição
+	resetTTL         time.Duration                              // Validade do token de redefinição
+	resetURL         string                                     // Página do frontend que recebe o token
+	sessoes          *SessaoUseCase                             // Encerramento das sessões após a redefinição (opcional)
+	tentativaRepo    repository.TentativaLoginRepository        // Contadores de falhas de login
+	loginPolicy      LoginProtectionPolicy                      // Limites de atraso e bloqueio
+	dummyHash        string                                     // Hash comparado quando o email não existe
+	dummyOnce        sync.Once                                  // Gera dummyHash uma única vez
 }
 
+// LoginProtectionPolicy define os limites da proteção contra força bruta no login
+type LoginProtectionPolicy struct {
+	MaxFailures   int           // Falhas por conta até o bloqueio temporário
+	IPMaxFailures int           // Falhas por IP até o bloqueio temporário
+	LockDuration  time.Duration // Duração do bloqueio temporário
+	BackoffBase   time.Duration // Atraso após a primeira falha, dobrado a cada nova falha
+	BackoffMax    time.Duration // Limite do atraso exponencial
+	Window        time.Duration // Falhas anteriores a esta janela deixam de contar
+}
+
+// LoginBloqueadoError indica que a conta ou o IP precisa aguardar antes de uma nova tentativa
+// A mensagem é a mesma para emails existentes e inexistentes
+type LoginBloqueadoError struct {
+	RetryAfter time.Duration // Tempo até a próxima tentativa aceita
+}
+
+// Error implementa a interface error para LoginBloqueadoError
+func (e LoginBloqueadoError) Error() string {
+	return "muitas tentativas de login; tente novamente mais tarde"
+}
+
+// Tamanho em bytes do token de redefinição de senha
+const resetTokenBytes = 32
+
+// Códigos MFA inválidos aceitos em um mesmo login pendente; depois disso é preciso informar a senha novamente
+const mfaMaxFalhasDesafio = 3
+
+// Tempo mínimo de RequestPasswordReset, igual para emails existentes e inexistentes
+const resetTempoResposta = 500 * time.Millisecond
+
 // NewUsuarioAdministradorUseCase cria uma nova instância do caso de uso
 func NewUsuarioAdministradorUseCase(
 	repo repository.UsuarioAdministradorRepository,
@@ -37,43 +79,56 @@ func NewUsuarioAdministradorUseCase(
 }
 
 // Authenticate realiza autenticação do usuário
+// Falhas contam por conta e por IP; com a proteção configurada, excessos retornam LoginBloqueadoError
 func (uc *UsuarioAdministradorUseCase) Authenticate(ctx context.Context, email, senha, clientIP string) (*entity.UsuarioAdministrador, error) {
+	chaveConta := chaveLogin(email)
+	if err := uc.checkLoginThrottle(ctx, chaveConta, clientIP); err != nil {
+		return nil, err
+	}
+
 	usuario, err := uc.repo.GetByEmail(ctx, email)
 	if err != nil {
 		// Não criar log para email inexistente (FK inválida)
+		// O hash fictício iguala o tempo de resposta ao de uma senha incorreta
+		uc.crypto.CheckPasswordHash(senha, uc.dummyPasswordHash())
+		uc.registerLoginFailure(ctx, chaveConta, nil, clientIP)
 		return nil, fmt.Errorf("credenciais inválidas")
 	}
 
-	if usuario.Status != "Ativo" {
-		// Usar ID do usuário encontrado para o log
+	if !uc.crypto.CheckPasswordHash(senha, usuario.SenhaHash) {
+		// Usar ID do usuário para o log
 		if uc.logAuditoriaRepo != nil {
 			log := &entity.LogAuditoria{
 				IDUserAdmin:   usuario.ID,
 				TimeStamp:     time.Now(),
-				AcaoRealizada: "Tentativa de Login - Usuário Inativo",
-				Detalhes:      fmt.Sprintf("Tentativa de login com usuário inativo: %s (ID: %d)", email, usuario.ID),
+				AcaoRealizada: "Tentativa de Login - Senha Incorreta",
+				Detalhes:      fmt.Sprintf("Senha incorreta para usuário: %s (ID: %d)", email, usuario.ID),
 				EnderecoIP:    clientIP,
 			}
 			uc.logAuditoriaRepo.Create(ctx, log)
 		}
-		return nil, fmt.Errorf("usuário inativo")
+		uc.registerLoginFailure(ctx, chaveConta, usuario, clientIP)
+		return nil, fmt.Errorf("credenciais inválidas")
 	}
 
-	if !uc.crypto.CheckPasswordHash(senha, usuario.SenhaHash) {
-		// Usar ID do usuário para o log
+	// Status verificado só após a senha, para não revelar contas inativas a quem não a conhece
+	if usuario.Status != "Ativo" {
+		// Usar ID do usuário encontrado para o log
 		if uc.logAuditoriaRepo != nil {
 			log := &entity.LogAuditoria{
 				IDUserAdmin:   usuario.ID,
 				TimeStamp:     time.Now(),
-				AcaoRealizada: "Tentativa de Login - Senha Incorreta",
-				Detalhes:      fmt.Sprintf("Senha incorreta para usuário: %s (ID: %d)", email, usuario.ID),
+				AcaoRealizada: "Tentativa de Login - Usuário Inativo",
+				Detalhes:      fmt.Sprintf("Tentativa de login com usuário inativo: %s (ID: %d)", email, usuario.ID),
 				EnderecoIP:    clientIP,
 			}
 			uc.logAuditoriaRepo.Create(ctx, log)
 		}
-		return nil, fmt.Errorf("credenciais inválidas")
+		return nil, fmt.Errorf("usuário inativo")
 	}
 
+	uc.clearLoginFailures(ctx, chaveConta)
+
 	// Login bem-sucedido
 	if uc.logAuditoriaRepo != nil {
 		log := &entity.LogAuditoria{
@@ -103,9 +158,9 @@ func (uc *UsuarioAdministradorUseCase) UpdatePassword(ctx context.Context, userI
 		return fmt.Errorf("nova senha deve ter pelo menos 8 caracteres")
 	}
 
-	usuario, err := uc.repo.GetByID(ctx, userID)
+	usuario, err := uc.getInScope(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("usuário não encontrado: %v", err)
+		return err
 	}
 
 	hashedPassword, err := uc.crypto.HashPassword(newPassword)
@@ -131,8 +186,312 @@ func (uc *UsuarioAdministradorUseCase) UpdatePassword(ctx context.Context, userI
 	return nil
 }
 
+// SetPasswordReset habilita a redefinição de senha por email
+// Sem esta configuração RequestPasswordReset apenas registra a solicitação
+func (uc *UsuarioAdministradorUseCase) SetPasswordReset(resetRepo repository.TokenRedefinicaoSenhaRepository, m mailer.Mailer, ttl time.Duration, resetURL string) {
+	uc.resetRepo = resetRepo
+	uc.mailer = m
+	uc.resetTTL = ttl
+	uc.resetURL = resetURL
+}
+
+// SetSessoes habilita o encerramento de todas as sessões do usuário quando a senha é redefinida por token
+func (uc *UsuarioAdministradorUseCase) SetSessoes(sessoes *SessaoUseCase) {
+	uc.sessoes = sessoes
+}
+
+// SetLoginProtection habilita o atraso exponencial e o bloqueio temporário após falhas de login
+// Valores não positivos da política recebem os padrões
+func (uc *UsuarioAdministradorUseCase) SetLoginProtection(tentativaRepo repository.TentativaLoginRepository, policy LoginProtectionPolicy) {
+	if policy.MaxFailures <= 0 {
+		policy.MaxFailures = 5
+	}
+	if policy.IPMaxFailures <= 0 {
+		policy.IPMaxFailures = 20
+	}
+	if policy.LockDuration <= 0 {
+		policy.LockDuration = 15 * time.Minute
+	}
+	if policy.BackoffBase <= 0 {
+		policy.BackoffBase = time.Second
+	}
+	if policy.BackoffMax <= 0 {
+		policy.BackoffMax = time.Minute
+	}
+	if policy.Window <= 0 {
+		policy.Window = 15 * time.Minute
+	}
+	uc.tentativaRepo = tentativaRepo
+	uc.loginPolicy = policy
+}
+
+// checkLoginThrottle recusa a tentativa enquanto a conta ou o IP estiver em atraso ou bloqueado
+func (uc *UsuarioAdministradorUseCase) checkLoginThrottle(ctx context.Context, chaveConta, clientIP string) error {
+	return uc.checkThrottle(ctx, entity.TentativaLoginConta, chaveConta, clientIP)
+}
+
+// checkThrottle recusa a tentativa enquanto o contador da conta (do tipo informado) ou o do IP estiver em atraso ou bloqueado
+func (uc *UsuarioAdministradorUseCase) checkThrottle(ctx context.Context, tipoConta, chaveConta, clientIP string) error {
+	if uc.tentativaRepo == nil {
+		return nil
+	}
+
+	now := time.Now()
+	var espera time.Duration
+	for _, chave := range []struct{ tipo, valor string }{
+		{tipoConta, chaveConta},
+		{entity.TentativaLoginIP, clientIP},
+	} {
+		tentativa, err := uc.tentativaRepo.Get(ctx, chave.tipo, chave.valor)
+		if err != nil {
+			continue
+		}
+		if restante := tentativa.EsperaRestante(now); restante > espera {
+			espera = restante
+		}
+	}
+
+	if espera > 0 {
+		return LoginBloqueadoError{RetryAfter: espera}
+	}
+	return nil
+}
+
+// registerLoginFailure incrementa os contadores da conta e do IP e aplica atraso e bloqueio
+// usuario é nil quando o email não existe; o contador da conta é mantido mesmo assim
+func (uc *UsuarioAdministradorUseCase) registerLoginFailure(ctx context.Context, chaveConta string, usuario *entity.UsuarioAdministrador, clientIP string) {
+	uc.registerFailure(ctx, entity.TentativaLoginConta, chaveConta, usuario, clientIP)
+}
+
+// registerFailure incrementa o contador da conta (do tipo informado) e o do IP e aplica atraso e bloqueio
+func (uc *UsuarioAdministradorUseCase) registerFailure(ctx context.Context, tipoConta, chaveConta string, usuario *entity.UsuarioAdministrador, clientIP string) {
+	if uc.tentativaRepo == nil {
+		return
+	}
+
+	now := time.Now()
+	windowStart := now.Add(-uc.loginPolicy.Window)
+
+	conta := &entity.TentativaLogin{Tipo: tipoConta, Chave: chaveConta}
+	if usuario != nil {
+		conta.IDUserAdmin = &usuario.ID
+	}
+	if atualizada, err := uc.tentativaRepo.RegisterFailure(ctx, conta, now, windowStart); err == nil {
+		uc.applyLoginBackoff(ctx, atualizada, uc.loginPolicy.MaxFailures, now, clientIP)
+	}
+
+	ip := &entity.TentativaLogin{Tipo: entity.TentativaLoginIP, Chave: clientIP}
+	if atualizada, err := uc.tentativaRepo.RegisterFailure(ctx, ip, now, windowStart); err == nil {
+		uc.applyLoginBackoff(ctx, atualizada, uc.loginPolicy.IPMaxFailures, now, clientIP)
+	}
+}
+
+// applyLoginBackoff grava o atraso exponencial e, ao atingir maxFailures, o bloqueio temporário
+func (uc *UsuarioAdministradorUseCase) applyLoginBackoff(ctx context.Context, tentativa *entity.TentativaLogin, maxFailures int, now time.Time, clientIP string) {
+	atraso := uc.loginPolicy.BackoffMax
+	if shift := tentativa.Falhas - 1; shift < 31 {
+		if d := uc.loginPolicy.BackoffBase << uint(shift); d > 0 && d < atraso {
+			atraso = d
+		}
+	}
+	proxima := now.Add(atraso)
+
+	var bloqueadoAte *time.Time
+	if tentativa.Falhas >= maxFailures {
+		fim := now.Add(uc.loginPolicy.LockDuration)
+		bloqueadoAte = &fim
+	}
+
+	if err := uc.tentativaRepo.UpdateBlock(ctx, tentativa.ID, &proxima, bloqueadoAte); err != nil {
+		return
+	}
+
+	// Registra apenas a transição para bloqueado, não cada falha durante o bloqueio
+	if bloqueadoAte == nil || tentativa.Bloqueada(now) || uc.logAuditoriaRepo == nil {
+		return
+	}
+
+	log := &entity.LogAuditoria{
+		TimeStamp:  now,
+		EnderecoIP: clientIP,
+	}
+	if tentativa.IDUserAdmin != nil {
+		log.IDUserAdmin = *tentativa.IDUserAdmin
+	}
+	switch tentativa.Tipo {
+	case entity.TentativaLoginIP:
+		log.AcaoRealizada = "IP Bloqueado"
+		log.Detalhes = fmt.Sprintf("Logins a partir do IP %s bloqueados até %s após %d falhas consecutivas",
+			tentativa.Chave, bloqueadoAte.Format(time.RFC3339), tentativa.Falhas)
+	case entity.TentativaLoginMFA:
+		log.AcaoRealizada = "Segundo Fator Bloqueado"
+		log.Detalhes = fmt.Sprintf("Segundo fator de %s bloqueado até %s após %d códigos inválidos consecutivos",
+			tentativa.Chave, bloqueadoAte.Format(time.RFC3339), tentativa.Falhas)
+	default:
+		log.AcaoRealizada = "Conta Bloqueada"
+		log.Detalhes = fmt.Sprintf("Login de %s bloqueado até %s após %d falhas consecutivas",
+			tentativa.Chave, bloqueadoAte.Format(time.RFC3339), tentativa.Falhas)
+	}
+	uc.logAuditoriaRepo.Create(ctx, log)
+}
+
+// clearLoginFailures zera o contador da conta após login bem-sucedido
+// O contador do IP é mantido para que uma conta válida não libere tentativas contra outras
+func (uc *UsuarioAdministradorUseCase) clearLoginFailures(ctx context.Context, chaveConta string) {
+	if uc.tentativaRepo == nil {
+		return
+	}
+	uc.tentativaRepo.Delete(ctx, entity.TentativaLoginConta, chaveConta)
+}
+
+// checkMFAThrottle recusa a verificação do segundo fator enquanto a conta ou o IP estiver em atraso ou bloqueado
+// Um login pendente que já acumulou mfaMaxFalhasDesafio códigos inválidos deixa de ser aceito
+func (uc *UsuarioAdministradorUseCase) checkMFAThrottle(ctx context.Context, usuario *entity.UsuarioAdministrador, desafio, clientIP string) error {
+	if uc.tentativaRepo == nil {
+		return nil
+	}
+
+	if desafio != "" {
+		tentativa, err := uc.tentativaRepo.Get(ctx, entity.TentativaLoginDesafio, desafio)
+		if err == nil && tentativa.Falhas >= mfaMaxFalhasDesafio {
+			return fmt.Errorf("login pendente inválido ou expirado")
+		}
+	}
+
+	return uc.checkThrottle(ctx, entity.TentativaLoginMFA, chaveLogin(usuario.Email), clientIP)
+}
+
+// registerMFAFailure conta um código MFA inválido na conta, no IP e no login pendente
+// O contador de segundo fator da conta não é zerado por um login com senha correta
+func (uc *UsuarioAdministradorUseCase) registerMFAFailure(ctx context.Context, usuario *entity.UsuarioAdministrador, desafio, clientIP string) {
+	if uc.tentativaRepo == nil {
+		return
+	}
+
+	uc.registerFailure(ctx, entity.TentativaLoginMFA, chaveLogin(usuario.Email), usuario, clientIP)
+
+	if desafio != "" {
+		now := time.Now()
+		pendente := &entity.TentativaLogin{Tipo: entity.TentativaLoginDesafio, Chave: desafio, IDUserAdmin: &usuario.ID}
+		uc.tentativaRepo.RegisterFailure(ctx, pendente, now, now.Add(-uc.loginPolicy.Window))
+	}
+}
+
+// clearMFAFailures zera os contadores de segundo fator da conta e do login pendente após verificação bem-sucedida
+func (uc *UsuarioAdministradorUseCase) clearMFAFailures(ctx context.Context, usuario *entity.UsuarioAdministrador, desafio string) {
+	if uc.tentativaRepo == nil {
+		return
+	}
+	uc.tentativaRepo.Delete(ctx, entity.TentativaLoginMFA, chaveLogin(usuario.Email))
+	if desafio != "" {
+		uc.tentativaRepo.Delete(ctx, entity.TentativaLoginDesafio, desafio)
+	}
+}
+
+// chaveLogin normaliza o email usado como chave dos contadores da conta
+func chaveLogin(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// dummyPasswordHash retorna um hash bcrypt gerado com o mesmo custo das senhas reais
+func (uc *UsuarioAdministradorUseCase) dummyPasswordHash() string {
+	uc.dummyOnce.Do(func() {
+		if b, err := uc.crypto.GenerateRandomBytes(16); err == nil {
+			uc.dummyHash, _ = uc.crypto.HashPassword(fmt.Sprintf("%x", b))
+		}
+	})
+	return uc.dummyHash
+}
+
+// ListLoginLocks lista as contas da empresa com login temporariamente bloqueado
+func (uc *UsuarioAdministradorUseCase) ListLoginLocks(ctx context.Context, empresaID int) ([]*entity.TentativaLogin, error) {
+	if empresaID <= 0 {
+		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
+	}
+
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
+	if uc.tentativaRepo == nil {
+		return []*entity.TentativaLogin{}, nil
+	}
+
+	tentativas, err := uc.tentativaRepo.ListLockedByEmpresa(ctx, empresaID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar bloqueios de login: %v", err)
+	}
+
+	return tentativas, nil
+}
+
+// UnlockLogin libera o login de um usuário bloqueado, zerando os contadores de falhas de senha e de segundo fator da conta
+func (uc *UsuarioAdministradorUseCase) UnlockLogin(ctx context.Context, id int, userAdminID int, enderecoIP string) error {
+	if id <= 0 {
+		return fmt.Errorf("ID do usuário inválido")
+	}
+
+	usuario, err := uc.getInScope(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if uc.tentativaRepo == nil {
+		return fmt.Errorf("bloqueio de login não encontrado")
+	}
+
+	now := time.Now()
+	chaveConta := chaveLogin(usuario.Email)
+	falhas := 0
+	for _, tipo := range []string{entity.TentativaLoginConta, entity.TentativaLoginMFA} {
+		tentativa, err := uc.tentativaRepo.Get(ctx, tipo, chaveConta)
+		if err != nil || !tentativa.Bloqueada(now) {
+			continue
+		}
+		if err := uc.tentativaRepo.Delete(ctx, tipo, chaveConta); err != nil {
+			return fmt.Errorf("erro ao desbloquear login: %v", err)
+		}
+		falhas += tentativa.Falhas
+	}
+	if falhas == 0 {
+		return fmt.Errorf("bloqueio de login não encontrado")
+	}
+
+	if uc.logAuditoriaRepo != nil {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Conta Desbloqueada",
+			Detalhes:      fmt.Sprintf("Login de %s (ID: %d) desbloqueado após %d falhas", usuario.Email, usuario.ID, falhas),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// CleanupExpiredLoginAttempts remove contadores de falhas fora da janela e sem bloqueio vigente
+func (uc *UsuarioAdministradorUseCase) CleanupExpiredLoginAttempts(ctx context.Context) (int64, error) {
+	if uc.tentativaRepo == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	count, err := uc.tentativaRepo.DeleteExpired(ctx, now.Add(-uc.loginPolicy.Window), now)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover tentativas de login expiradas: %v", err)
+	}
+
+	return count, nil
+}
+
 // RequestPasswordReset inicia processo de redefinição de senha
+// Emite um token de uso único e enfileira o link por email; a resposta não revela se o email existe
+// Todas as saídas levam ao menos resetTempoResposta, para que o tempo de resposta também não revele
 func (uc *UsuarioAdministradorUseCase) RequestPasswordReset(ctx context.Context, email, clientIP string) error {
+	defer aguardarAte(ctx, time.Now().Add(resetTempoResposta))
+
 	usuario, err := uc.repo.GetByEmail(ctx, email)
 	if err != nil {
 		if uc.logAuditoriaRepo != nil {
@@ -162,6 +521,12 @@ func (uc *UsuarioAdministradorUseCase) RequestPasswordReset(ctx context.Context,
 		return nil
 	}
 
+	if uc.resetRepo != nil && uc.mailer != nil {
+		if err := uc.sendResetToken(ctx, usuario, clientIP); err != nil {
+			return err
+		}
+	}
+
 	if uc.logAuditoriaRepo != nil {
 		log := &entity.LogAuditoria{
 			IDUserAdmin:   usuario.ID,
@@ -176,6 +541,145 @@ func (uc *UsuarioAdministradorUseCase) RequestPasswordReset(ctx context.Context,
 	return nil
 }
 
+// aguardarAte bloqueia até o instante informado ou até o cancelamento do contexto
+func aguardarAte(ctx context.Context, limite time.Time) {
+	espera := time.Until(limite)
+	if espera <= 0 {
+		return
+	}
+	timer := time.NewTimer(espera)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// sendResetToken invalida tokens anteriores, persiste o hash de um novo token e envia o link por email
+// O envio é feito pelo mailer configurado; em produção, uma fila que entrega o email fora da requisição
+func (uc *UsuarioAdministradorUseCase) sendResetToken(ctx context.Context, usuario *entity.UsuarioAdministrador, clientIP string) error {
+	if err := uc.resetRepo.DeleteByUsuario(ctx, usuario.ID); err != nil {
+		return fmt.Errorf("erro ao invalidar tokens anteriores: %v", err)
+	}
+
+	token, err := uc.crypto.GenerateToken(resetTokenBytes)
+	if err != nil {
+		return fmt.Errorf("erro ao gerar token de redefinição: %v", err)
+	}
+
+	now := time.Now()
+	ttl := uc.resetTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	registro := &entity.TokenRedefinicaoSenha{
+		IDUserAdmin:   usuario.ID,
+		TokenHash:     crypto.HashToken(token),
+		DataCriacao:   now,
+		DataExpiracao: now.Add(ttl),
+		IPSolicitacao: clientIP,
+	}
+	if err := uc.resetRepo.Create(ctx, registro); err != nil {
+		return fmt.Errorf("erro ao registrar token de redefinição: %v", err)
+	}
+
+	link := uc.resetURL + "?token=" + url.QueryEscape(token)
+	msg := mailer.Message{
+		To:      usuario.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf("Olá, %s.\n\n"+
+			"Recebemos uma solicitação para redefinir a sua senha. Para criar uma nova senha, acesse:\n\n%s\n\n"+
+			"O link é válido por %s e pode ser usado uma única vez. "+
+			"Se você não solicitou a redefinição, ignore este email.\n",
+			usuario.NomeAdmin, link, ttl),
+	}
+	if err := uc.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("erro ao enviar email de redefinição: %v", err)
+	}
+
+	return nil
+}
+
+// ResetPassword redefine a senha a partir de um token emitido por RequestPasswordReset
+// O token é consumido antes da troca de senha, garantindo uso único mesmo com requisições concorrentes
+// Com sessões configuradas, todas as sessões do usuário são encerradas e seus refresh e access tokens deixam de valer
+func (uc *UsuarioAdministradorUseCase) ResetPassword(ctx context.Context, token, novaSenha, clientIP string) error {
+	if uc.resetRepo == nil {
+		return fmt.Errorf("redefinição de senha não configurada")
+	}
+
+	if strings.TrimSpace(token) == "" {
+		return fmt.Errorf("token de redefinição inválido ou expirado")
+	}
+
+	if len(novaSenha) < 8 {
+		return fmt.Errorf("nova senha deve ter pelo menos 8 caracteres")
+	}
+
+	registro, err := uc.resetRepo.GetByHash(ctx, crypto.HashToken(token))
+	if err != nil || !registro.Disponivel(time.Now()) {
+		return fmt.Errorf("token de redefinição inválido ou expirado")
+	}
+
+	usuario, err := uc.repo.GetByID(ctx, registro.IDUserAdmin)
+	if err != nil || usuario.Status != "Ativo" {
+		return fmt.Errorf("token de redefinição inválido ou expirado")
+	}
+
+	if err := uc.resetRepo.MarkUsed(ctx, registro.ID, time.Now()); err != nil {
+		return fmt.Errorf("token de redefinição inválido ou expirado")
+	}
+
+	hashedPassword, err := uc.crypto.HashPassword(novaSenha)
+	if err != nil {
+		return fmt.Errorf("erro ao gerar hash da senha: %v", err)
+	}
+
+	if err := uc.repo.UpdatePassword(ctx, usuario.ID, hashedPassword); err != nil {
+		return fmt.Errorf("erro ao atualizar senha: %v", err)
+	}
+
+	// Invalida demais links pendentes do usuário
+	uc.resetRepo.DeleteByUsuario(ctx, usuario.ID)
+
+	// Quem tinha a senha anterior não deve continuar conectado
+	encerradas := 0
+	if uc.sessoes != nil {
+		encerradas, err = uc.sessoes.RevokeAll(ctx, usuario.ID)
+		if err != nil {
+			return fmt.Errorf("senha redefinida, mas houve erro ao encerrar as sessões: %v", err)
+		}
+	}
+
+	if uc.logAuditoriaRepo != nil {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   usuario.ID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Senha Redefinida",
+			Detalhes:      fmt.Sprintf("Senha redefinida via token para: %s (ID: %d); %d sessões encerradas", usuario.Email, usuario.ID, encerradas),
+			EnderecoIP:    clientIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// CleanupExpiredResetTokens remove tokens de redefinição expirados
+func (uc *UsuarioAdministradorUseCase) CleanupExpiredResetTokens(ctx context.Context) (int64, error) {
+	if uc.resetRepo == nil {
+		return 0, nil
+	}
+
+	count, err := uc.resetRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover tokens de redefinição expirados: %v", err)
+	}
+
+	return count, nil
+}
+
 // ValidateEmail valida formato e disponibilidade do email
 func (uc *UsuarioAdministradorUseCase) ValidateEmail(email string) error {
 	email = strings.TrimSpace(email)
@@ -214,6 +718,10 @@ func (uc *UsuarioAdministradorUseCase) Create(ctx context.Context, usuario *enti
 		return fmt.Errorf("senha deve ter pelo menos 8 caracteres")
 	}
 
+	if err := checkEmpresaScope(ctx, usuario.IDEmpresa, "empresa não encontrada"); err != nil {
+		return err
+	}
+
 	_, err := uc.empresaRepo.GetByID(ctx, usuario.IDEmpresa)
 	if err != nil {
 		return fmt.Errorf("empresa não encontrada: %v", err)
@@ -245,6 +753,14 @@ func (uc *UsuarioAdministradorUseCase) Create(ctx context.Context, usuario *enti
 		return fmt.Errorf("status inválido: %s", usuario.Status)
 	}
 
+	// Sem papel informado, o usuário recebe o papel de menor privilégio
+	if usuario.Papel == "" {
+		usuario.Papel = entity.PapelAnalista
+	}
+	if !entity.PapelValido(usuario.Papel) {
+		return fmt.Errorf("papel inválido: %s", usuario.Papel)
+	}
+
 	if err := uc.repo.Create(ctx, usuario); err != nil {
 		return fmt.Errorf("erro ao criar usuário: %v", err)
 	}
@@ -254,7 +770,7 @@ func (uc *UsuarioAdministradorUseCase) Create(ctx context.Context, usuario *enti
 			IDUserAdmin:   userAdminID,
 			TimeStamp:     time.Now(),
 			AcaoRealizada: "Usuário Administrador Criado",
-			Detalhes:      fmt.Sprintf("Usuário criado: %s (%s) (ID: %d)", usuario.NomeAdmin, usuario.Email, usuario.ID),
+			Detalhes:      fmt.Sprintf("Usuário criado: %s (%s) (ID: %d) com papel '%s'", usuario.NomeAdmin, usuario.Email, usuario.ID, usuario.Papel),
 			EnderecoIP:    enderecoIP,
 		}
 		uc.logAuditoriaRepo.Create(ctx, log)
@@ -269,7 +785,19 @@ func (uc *UsuarioAdministradorUseCase) GetByID(ctx context.Context, id int) (*en
 		return nil, fmt.Errorf("ID do usuário deve ser maior que zero")
 	}
 
-	return uc.repo.GetByID(ctx, id)
+	return uc.getInScope(ctx, id)
+}
+
+// getInScope busca um usuário e verifica se pertence à empresa do contexto
+func (uc *UsuarioAdministradorUseCase) getInScope(ctx context.Context, id int) (*entity.UsuarioAdministrador, error) {
+	usuario, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("usuário não encontrado: %v", err)
+	}
+	if err := checkEmpresaScope(ctx, usuario.IDEmpresa, "usuário não encontrado"); err != nil {
+		return nil, err
+	}
+	return usuario, nil
 }
 
 // GetByEmail busca um usuário pelo email
@@ -278,7 +806,15 @@ func (uc *UsuarioAdministradorUseCase) GetByEmail(ctx context.Context, email str
 		return nil, err
 	}
 
-	return uc.repo.GetByEmail(ctx, email)
+	usuario, err := uc.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEmpresaScope(ctx, usuario.IDEmpresa, "usuário não encontrado"); err != nil {
+		return nil, err
+	}
+
+	return usuario, nil
 }
 
 // ListByEmpresa lista usuários de uma empresa
@@ -287,6 +823,10 @@ func (uc *UsuarioAdministradorUseCase) ListByEmpresa(ctx context.Context, empres
 		return nil, fmt.Errorf("ID da empresa deve ser maior que zero")
 	}
 
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
 	return uc.repo.ListByEmpresa(ctx, empresaID)
 }
 
@@ -306,6 +846,10 @@ func (uc *UsuarioAdministradorUseCase) ListByStatus(ctx context.Context, empresa
 		return nil, fmt.Errorf("status inválido: %s", status)
 	}
 
+	if err := checkEmpresaScope(ctx, empresaID, "empresa não encontrada"); err != nil {
+		return nil, err
+	}
+
 	return uc.repo.ListByStatus(ctx, empresaID, status)
 }
 
@@ -323,9 +867,9 @@ func (uc *UsuarioAdministradorUseCase) Update(ctx context.Context, usuario *enti
 		return err
 	}
 
-	existing, err := uc.repo.GetByID(ctx, usuario.ID)
+	existing, err := uc.getInScope(ctx, usuario.ID)
 	if err != nil {
-		return fmt.Errorf("usuário não encontrado: %v", err)
+		return err
 	}
 
 	userComEmail, err := uc.repo.GetByEmail(ctx, usuario.Email)
@@ -379,9 +923,15 @@ func (uc *UsuarioAdministradorUseCase) UpdateStatus(ctx context.Context, id int,
 		return fmt.Errorf("status inválido: %s", status)
 	}
 
-	usuario, err := uc.repo.GetByID(ctx, id)
+	usuario, err := uc.getInScope(ctx, id)
 	if err != nil {
-		return fmt.Errorf("usuário não encontrado: %v", err)
+		return err
+	}
+
+	if status != "Ativo" {
+		if err := uc.checkOutroProprietario(ctx, usuario); err != nil {
+			return err
+		}
 	}
 
 	if err := uc.repo.UpdateStatus(ctx, id, status); err != nil {
@@ -402,15 +952,77 @@ func (uc *UsuarioAdministradorUseCase) UpdateStatus(ctx context.Context, id int,
 	return nil
 }
 
+// UpdatePapel altera o papel do usuário e, consequentemente, suas permissões
+// A empresa deve manter ao menos um proprietário ativo; o novo papel vale a partir do próximo token
+func (uc *UsuarioAdministradorUseCase) UpdatePapel(ctx context.Context, id int, papel string, userAdminID int, enderecoIP string) error {
+	if id <= 0 {
+		return fmt.Errorf("ID do usuário inválido")
+	}
+
+	if !entity.PapelValido(papel) {
+		return fmt.Errorf("papel inválido: %s", papel)
+	}
+
+	usuario, err := uc.getInScope(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if usuario.Papel == papel {
+		return nil
+	}
+
+	if err := uc.checkOutroProprietario(ctx, usuario); err != nil {
+		return err
+	}
+
+	if err := uc.repo.UpdatePapel(ctx, id, papel); err != nil {
+		return fmt.Errorf("erro ao atualizar papel: %v", err)
+	}
+
+	if userAdminID > 0 {
+		log := &entity.LogAuditoria{
+			IDUserAdmin:   userAdminID,
+			TimeStamp:     time.Now(),
+			AcaoRealizada: "Papel Usuário Alterado",
+			Detalhes:      fmt.Sprintf("Papel alterado de '%s' para '%s' - Usuário: %s (ID: %d)", usuario.Papel, papel, usuario.NomeAdmin, usuario.ID),
+			EnderecoIP:    enderecoIP,
+		}
+		uc.logAuditoriaRepo.Create(ctx, log)
+	}
+
+	return nil
+}
+
+// checkOutroProprietario impede que o último proprietário ativo da empresa perca o papel ou seja inativado
+func (uc *UsuarioAdministradorUseCase) checkOutroProprietario(ctx context.Context, usuario *entity.UsuarioAdministrador) error {
+	if usuario.Papel != entity.PapelProprietario || usuario.Status != "Ativo" {
+		return nil
+	}
+
+	usuarios, err := uc.repo.ListByStatus(ctx, usuario.IDEmpresa, "Ativo")
+	if err != nil {
+		return fmt.Errorf("erro ao verificar proprietários: %v", err)
+	}
+
+	for _, u := range usuarios {
+		if u.ID != usuario.ID && u.Papel == entity.PapelProprietario {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("a empresa deve manter ao menos um proprietário ativo")
+}
+
 // Delete inativa um usuário (soft delete) em vez de deletar fisicamente
 func (uc *UsuarioAdministradorUseCase) Delete(ctx context.Context, id int, userAdminID int, enderecoIP string) error {
 	if id <= 0 {
 		return fmt.Errorf("ID do usuário inválido")
 	}
 
-	usuario, err := uc.repo.GetByID(ctx, id)
+	usuario, err := uc.getInScope(ctx, id)
 	if err != nil {
-		return fmt.Errorf("usuário não encontrado: %v", err)
+		return err
 	}
 
 	// Verificar se já está inativo
@@ -418,6 +1030,10 @@ func (uc *UsuarioAdministradorUseCase) Delete(ctx context.Context, id int, userA
 		return fmt.Errorf("usuário já está inativo")
 	}
 
+	if err := uc.checkOutroProprietario(ctx, usuario); err != nil {
+		return err
+	}
+
 	// SOFT DELETE: Mudar status para "Inativo" em vez de deletar fisicamente
 	if err := uc.repo.UpdateStatus(ctx, id, "Inativo"); err != nil {
 		return fmt.Errorf("erro ao inativar usuário: %v", err)
@@ -488,6 +1104,7 @@ func (uc *UsuarioAdministradorUseCase) CreateBootstrap(ctx context.Context, usua
 	// Setar valores padrão
 	usuario.DataCadastro = time.Now()
 	usuario.Status = "Ativo" // Bootstrap sempre cria admin ativo
+	usuario.Papel = entity.PapelProprietario
 
 	// Criar usuário SEM validar userAdminID (bootstrap não tem admin autenticado)
 	if err := uc.repo.Create(ctx, usuario); err != nil {
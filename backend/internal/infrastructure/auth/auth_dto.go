@@ -10,7 +10,7 @@ type LoginRequest struct {
 
 // RefreshTokenRequest representa pedido de renovação de token
 type RefreshTokenRequest struct {
-	Token string `json:"token" binding:"required"` // Token JWT atual
+	RefreshToken string `json:"refresh_token" binding:"required"` // Refresh token opaco recebido no login ou na última renovação
 }
 
 // ValidateTokenRequest representa pedido de validação de token
@@ -28,3 +28,27 @@ type ChangePasswordRequest struct {
 type ForgotPasswordRequest struct {
 	Email string `json:"email" binding:"required,email"` // Email para recuperação
 }
+
+// ResetPasswordRequest representa redefinição de senha com token recebido por email
+type ResetPasswordRequest struct {
+	Token     string `json:"token" binding:"required"`                    // Token de redefinição
+	NovaSenha string `json:"nova_senha" binding:"required,min=8,max=128"` // Nova senha
+}
+
+// MFAEnrollRequest inicia o cadastro do segundo fator durante o login
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"` // Token de login pendente
+}
+
+// MFAVerifyRequest conclui o login com o segundo fator
+// Informe codigo (TOTP) ou codigo_recuperacao
+type MFAVerifyRequest struct {
+	MFAToken          string `json:"mfa_token" binding:"required"` // Token de login pendente
+	Codigo            string `json:"codigo,omitempty"`             // Código de 6 dígitos do aplicativo autenticador
+	CodigoRecuperacao string `json:"codigo_recuperacao,omitempty"` // Código de recuperação de uso único
+}
+
+// MFACodeRequest confirma uma operação do segundo fator com um código TOTP
+type MFACodeRequest struct {
+	Codigo string `json:"codigo" binding:"required,len=6"` // Código de 6 dígitos do aplicativo autenticador
+}
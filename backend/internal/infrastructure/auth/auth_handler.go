@@ -3,15 +3,22 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"organizational-climate-survey/backend/internal/application/dto/response"
 	"organizational-climate-survey/backend/internal/application/middleware"
+	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/validator"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
@@ -21,23 +28,53 @@ import (
 type AuthHandler struct {
 	usuarioUseCase      *usecase.UsuarioAdministradorUseCase // Use case de usuário admin
 	logAuditoriaUseCase *usecase.LogAuditoriaUseCase         // Use case para logs
+	sessaoUseCase       *usecase.SessaoUseCase               // Use case de sessões e refresh tokens
+	mfaUseCase          *usecase.MFAUseCase                  // Use case de segundo fator
 	jwtSecret           []byte                               // Chave secreta para JWT
+	mfaSecret           []byte                               // Chave dos tokens de login pendente (distinta da JWT)
+	accessTTL           time.Duration                        // Validade do access token
+	validator           *validator.Validator                 // Validação de força de senha
+	trustedProxies      []*net.IPNet                         // Proxies cujos X-Forwarded-For/X-Real-IP identificam o cliente
 }
 
 // NewAuthHandler cria uma nova instância do handler de autenticação
 func NewAuthHandler(
 	usuarioUseCase *usecase.UsuarioAdministradorUseCase,
 	logAuditoriaUseCase *usecase.LogAuditoriaUseCase,
+	sessaoUseCase *usecase.SessaoUseCase,
+	mfaUseCase *usecase.MFAUseCase,
 	jwtSecret string,
+	accessTTL time.Duration,
+	trustedProxies []*net.IPNet,
 ) *AuthHandler {
+	if accessTTL <= 0 {
+		accessTTL = 15 * time.Minute
+	}
 	return &AuthHandler{
 		usuarioUseCase:      usuarioUseCase,
 		logAuditoriaUseCase: logAuditoriaUseCase,
+		sessaoUseCase:       sessaoUseCase,
+		mfaUseCase:          mfaUseCase,
 		jwtSecret:           []byte(jwtSecret),
+		mfaSecret:           []byte(jwtSecret + ":mfa-pendente"),
+		accessTTL:           accessTTL,
+		validator:           validator.New(),
+		trustedProxies:      trustedProxies,
 	}
 }
 
+// Validade do token de login pendente, entre a senha e o segundo fator
+const mfaPendingTTL = 5 * time.Minute
+
+// mfaPendingClaims identifica um login com senha válida aguardando o segundo fator
+// É assinado com mfaSecret, portanto não é aceito como access token
+type mfaPendingClaims struct {
+	UserID int `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
 // Login realiza autenticação do usuário e retorna token JWT
+// Quando o segundo fator é exigido, retorna um token pendente em vez do access token
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 
@@ -52,11 +89,16 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clientIP := h.getClientIP(r)
+	clientIP := h.clientIP(r)
 
 	// Tentar autenticar
 	usuario, err := h.usuarioUseCase.Authenticate(r.Context(), req.Email, req.Senha, clientIP)
 	if err != nil {
+		var bloqueado usecase.LoginBloqueadoError
+		if errors.As(err, &bloqueado) {
+			h.writeLoginThrottled(w, bloqueado)
+			return
+		}
 		if strings.Contains(err.Error(), "credenciais inválidas") || strings.Contains(err.Error(), "não encontrado") {
 			response.WriteError(w, http.StatusUnauthorized, "Credenciais inválidas", "Email ou senha incorretos")
 			return
@@ -69,8 +111,51 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Gerar token JWT
-	token, err := h.generateJWT(usuario.ID, usuario.IDEmpresa, usuario.Email)
+	if h.mfaUseCase != nil {
+		status, err := h.mfaUseCase.Challenge(r.Context(), usuario, clientIP)
+		if err != nil {
+			response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+			return
+		}
+		if status.Exigido() {
+			mfaToken, err := h.generateMFAPendingToken(usuario.ID)
+			if err != nil {
+				response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar token", err.Error())
+				return
+			}
+			response.WriteSuccess(w, http.StatusOK, "Segundo fator necessário", response.MFAChallengeResponse{
+				MFARequired:        true,
+				MFAToken:           mfaToken,
+				EnrollmentRequired: !status.Ativo,
+				ExpiresIn:          int64(mfaPendingTTL.Seconds()),
+			})
+			return
+		}
+	}
+
+	h.completeLogin(w, r, usuario, nil)
+}
+
+// completeLogin gera o access token, abre a sessão com refresh token e responde o login
+func (h *AuthHandler) completeLogin(w http.ResponseWriter, r *http.Request, usuario *entity.UsuarioAdministrador, codigosRecuperacao []string) {
+	jti, err := newJTI()
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar token", err.Error())
+		return
+	}
+	expiresAt := time.Now().Add(h.accessTTL)
+
+	sessaoID, refreshToken := 0, ""
+	if h.sessaoUseCase != nil {
+		sessao, refresh, err := h.sessaoUseCase.Create(r.Context(), usuario, jti, expiresAt, h.clientIP(r), r.UserAgent())
+		if err != nil {
+			response.WriteError(w, http.StatusInternalServerError, "Erro ao criar sessão", err.Error())
+			return
+		}
+		sessaoID, refreshToken = sessao.ID, refresh
+	}
+
+	token, err := h.generateJWT(usuario, jti, sessaoID, expiresAt)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar token", err.Error())
 		return
@@ -78,21 +163,200 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	// Preparar resposta
 	loginResponse := response.LoginResponse{
-		Token:     token,
-		ExpiresIn: 24 * 60 * 60, // 24 horas em segundos
+		Token:        token,
+		ExpiresIn:    int64(h.accessTTL.Seconds()),
+		RefreshToken: refreshToken,
 		User: response.UserInfo{
 			ID:        usuario.ID,
 			Nome:      usuario.NomeAdmin,
 			Email:     usuario.Email,
 			EmpresaID: usuario.IDEmpresa,
 			Status:    usuario.Status,
+			Papel:     usuario.Papel,
 		},
+		CodigosRecuperacao: codigosRecuperacao,
 	}
 
 	response.WriteSuccess(w, http.StatusOK, "Login realizado com sucesso", loginResponse)
 }
 
-// RefreshToken renova um token JWT existente
+// MFAEnroll inicia o cadastro do segundo fator durante o login, quando a política da empresa o exige
+func (h *AuthHandler) MFAEnroll(w http.ResponseWriter, r *http.Request) {
+	var req MFAEnrollRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	usuario, _, ok := h.pendingLoginUser(w, r, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	secret, uri, err := h.mfaUseCase.BeginEnrollment(r.Context(), usuario, h.clientIP(r))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Cadastro do segundo fator iniciado", response.MFAEnrollmentResponse{
+		Segredo: secret,
+		URI:     uri,
+	})
+}
+
+// MFAVerify conclui o login com o segundo fator e emite os tokens de acesso
+// Se o cadastro estava pendente, o código o confirma e os códigos de recuperação acompanham a resposta
+func (h *AuthHandler) MFAVerify(w http.ResponseWriter, r *http.Request) {
+	var req MFAVerifyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	if strings.TrimSpace(req.Codigo) == "" && strings.TrimSpace(req.CodigoRecuperacao) == "" {
+		response.WriteError(w, http.StatusBadRequest, "Código obrigatório", "Informe codigo ou codigo_recuperacao")
+		return
+	}
+
+	usuario, desafio, ok := h.pendingLoginUser(w, r, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	clientIP := h.clientIP(r)
+
+	status, err := h.mfaUseCase.Status(r.Context(), usuario)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	var codigosRecuperacao []string
+	if status.Ativo {
+		err = h.mfaUseCase.Verify(r.Context(), usuario, desafio, req.Codigo, req.CodigoRecuperacao, clientIP)
+	} else {
+		codigosRecuperacao, err = h.mfaUseCase.ConfirmEnrollment(r.Context(), usuario.ID, req.Codigo, clientIP)
+	}
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	h.completeLogin(w, r, usuario, codigosRecuperacao)
+}
+
+// GetMFAStatus retorna a situação do segundo fator do usuário autenticado
+func (h *AuthHandler) GetMFAStatus(w http.ResponseWriter, r *http.Request) {
+	usuario, ok := h.authenticatedMFAUser(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.mfaUseCase.Status(r.Context(), usuario)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Situação do segundo fator", status)
+}
+
+// SetupMFA inicia o cadastro do segundo fator para o usuário autenticado
+func (h *AuthHandler) SetupMFA(w http.ResponseWriter, r *http.Request) {
+	usuario, ok := h.authenticatedMFAUser(w, r)
+	if !ok {
+		return
+	}
+
+	secret, uri, err := h.mfaUseCase.BeginEnrollment(r.Context(), usuario, h.clientIP(r))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Cadastro do segundo fator iniciado", response.MFAEnrollmentResponse{
+		Segredo: secret,
+		URI:     uri,
+	})
+}
+
+// ActivateMFA confirma o cadastro do segundo fator e retorna os códigos de recuperação
+func (h *AuthHandler) ActivateMFA(w http.ResponseWriter, r *http.Request) {
+	var req MFACodeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	usuario, ok := h.authenticatedMFAUser(w, r)
+	if !ok {
+		return
+	}
+
+	codigos, err := h.mfaUseCase.ConfirmEnrollment(r.Context(), usuario.ID, req.Codigo, h.clientIP(r))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Segundo fator ativado com sucesso", response.MFARecoveryCodesResponse{
+		CodigosRecuperacao: codigos,
+	})
+}
+
+// DisableMFA remove o segundo fator do usuário autenticado
+func (h *AuthHandler) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	var req MFACodeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	usuario, ok := h.authenticatedMFAUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.mfaUseCase.Disable(r.Context(), usuario, req.Codigo, h.clientIP(r)); err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Segundo fator desativado com sucesso", nil)
+}
+
+// RegenerateRecoveryCodes invalida os códigos de recuperação atuais e retorna novos
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	var req MFACodeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	usuario, ok := h.authenticatedMFAUser(w, r)
+	if !ok {
+		return
+	}
+
+	codigos, err := h.mfaUseCase.RegenerateRecoveryCodes(r.Context(), usuario, req.Codigo, h.clientIP(r))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Códigos de recuperação gerados com sucesso", response.MFARecoveryCodesResponse{
+		CodigosRecuperacao: codigos,
+	})
+}
+
+// RefreshToken troca um refresh token por um novo par de tokens
+// O refresh token apresentado é invalidado; reapresentá-lo revoga a sessão
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req RefreshTokenRequest
 
@@ -101,61 +365,148 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if strings.TrimSpace(req.Token) == "" {
-		response.WriteError(w, http.StatusBadRequest, "Token obrigatório", "Token é obrigatório")
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.WriteError(w, http.StatusBadRequest, "Token obrigatório", "refresh_token é obrigatório")
 		return
 	}
 
-	// Validar token atual
-	claims, err := h.validateJWT(req.Token)
-	if err != nil {
-		response.WriteError(w, http.StatusUnauthorized, "Token inválido", err.Error())
+	if h.sessaoUseCase == nil {
+		response.WriteError(w, http.StatusServiceUnavailable, "Renovação indisponível", "Sessões não configuradas")
 		return
 	}
 
-	// Verificar se usuário ainda está ativo
-	usuario, err := h.usuarioUseCase.GetByID(r.Context(), claims.UserID)
+	jti, err := newJTI()
 	if err != nil {
-		response.WriteError(w, http.StatusUnauthorized, "Usuário não encontrado", "Usuário não existe ou foi removido")
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar token", err.Error())
 		return
 	}
+	expiresAt := time.Now().Add(h.accessTTL)
 
-	if usuario.Status != "Ativo" {
-		response.WriteError(w, http.StatusUnauthorized, "Usuário inativo", "Conta desativada")
+	sessao, usuario, refreshToken, err := h.sessaoUseCase.Refresh(r.Context(), req.RefreshToken, jti, expiresAt, h.clientIP(r))
+	if err != nil {
+		if strings.Contains(err.Error(), "inativo") {
+			response.WriteError(w, http.StatusUnauthorized, "Usuário inativo", "Conta desativada")
+			return
+		}
+		if strings.Contains(err.Error(), "refresh token inválido") {
+			response.WriteError(w, http.StatusUnauthorized, "Token inválido", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
 		return
 	}
 
-	// Gerar novo token
-	newToken, err := h.generateJWT(usuario.ID, usuario.IDEmpresa, usuario.Email)
+	// Papel e permissões são relidos do banco, aplicando alterações de papel
+	newToken, err := h.generateJWT(usuario, jti, sessao.ID, expiresAt)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "Erro ao gerar token", err.Error())
 		return
 	}
 
 	refreshResponse := response.RefreshTokenResponse{
-		Token:     newToken,
-		ExpiresIn: 24 * 60 * 60, // 24 horas em segundos
+		Token:        newToken,
+		ExpiresIn:    int64(h.accessTTL.Seconds()),
+		RefreshToken: refreshToken,
 	}
 
 	response.WriteSuccess(w, http.StatusOK, "Token renovado com sucesso", refreshResponse)
 }
 
-// Logout invalida o token atual do usuário
+// Logout encerra a sessão atual, revogando o access token e os refresh tokens da sessão
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Em uma implementação completa, aqui seria adicionado o token a uma blacklist
-	// Por enquanto, apenas retorna sucesso
-
 	userAdminID := h.getUserAdminIDFromContext(r)
-	clientIP := h.getClientIP(r)
+	if userAdminID == 0 {
+		response.WriteError(w, http.StatusUnauthorized, "Não autorizado", "Token inválido ou expirado")
+		return
+	}
+
+	clientIP := h.clientIP(r)
 
-	// Log de auditoria para logout
-	if userAdminID > 0 {
+	if h.sessaoUseCase == nil {
 		h.logAuditoriaUseCase.CreateSystemLog(r.Context(), "Logout", fmt.Sprintf("Usuário ID %d realizou logout", userAdminID), clientIP)
+		response.WriteSuccess(w, http.StatusOK, "Logout realizado com sucesso", nil)
+		return
+	}
+
+	sessaoID, _ := r.Context().Value("session_id").(int)
+	jti, _ := r.Context().Value("token_jti").(string)
+	expiresAt, _ := r.Context().Value("token_expires_at").(time.Time)
+
+	if err := h.sessaoUseCase.Logout(r.Context(), userAdminID, sessaoID, jti, expiresAt, clientIP); err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao encerrar sessão", err.Error())
+		return
 	}
 
 	response.WriteSuccess(w, http.StatusOK, "Logout realizado com sucesso", nil)
 }
 
+// ListSessions lista as sessões ativas do usuário autenticado
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userAdminID := h.getUserAdminIDFromContext(r)
+	if userAdminID == 0 {
+		response.WriteError(w, http.StatusUnauthorized, "Não autorizado", "Token inválido ou expirado")
+		return
+	}
+
+	if h.sessaoUseCase == nil {
+		response.WriteError(w, http.StatusServiceUnavailable, "Sessões indisponíveis", "Sessões não configuradas")
+		return
+	}
+
+	sessoes, err := h.sessaoUseCase.ListActive(r.Context(), userAdminID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao listar sessões", err.Error())
+		return
+	}
+
+	atual, _ := r.Context().Value("session_id").(int)
+	sessoesResponse := make([]response.SessaoResponse, 0, len(sessoes))
+	for _, sessao := range sessoes {
+		sessoesResponse = append(sessoesResponse, response.SessaoResponse{
+			ID:            sessao.ID,
+			DataCriacao:   sessao.DataCriacao,
+			DataUltimoUso: sessao.DataUltimoUso,
+			DataExpiracao: sessao.DataExpiracao,
+			EnderecoIP:    sessao.EnderecoIP,
+			UserAgent:     sessao.UserAgent,
+			Atual:         sessao.ID == atual,
+		})
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Sessões listadas com sucesso", sessoesResponse)
+}
+
+// RevokeSession encerra uma sessão do usuário autenticado
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userAdminID := h.getUserAdminIDFromContext(r)
+	if userAdminID == 0 {
+		response.WriteError(w, http.StatusUnauthorized, "Não autorizado", "Token inválido ou expirado")
+		return
+	}
+
+	if h.sessaoUseCase == nil {
+		response.WriteError(w, http.StatusServiceUnavailable, "Sessões indisponíveis", "Sessões não configuradas")
+		return
+	}
+
+	sessaoID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "ID inválido", "ID da sessão deve ser um número")
+		return
+	}
+
+	if err := h.sessaoUseCase.Revoke(r.Context(), userAdminID, sessaoID, h.clientIP(r)); err != nil {
+		if strings.Contains(err.Error(), "não encontrad") {
+			response.WriteError(w, http.StatusNotFound, "Sessão não encontrada", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro ao revogar sessão", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Sessão revogada com sucesso", nil)
+}
+
 // ValidateToken verifica se um token JWT é válido
 func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	var req ValidateTokenRequest
@@ -177,6 +528,13 @@ func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.sessaoUseCase != nil {
+		if revoked, err := h.sessaoUseCase.IsRevoked(r.Context(), claims.ID); err != nil || revoked || claims.ID == "" {
+			response.WriteError(w, http.StatusUnauthorized, "Token inválido", "Token revogado")
+			return
+		}
+	}
+
 	// Verificar se usuário ainda existe e está ativo
 	usuario, err := h.usuarioUseCase.GetByID(r.Context(), claims.UserID)
 	if err != nil {
@@ -192,6 +550,7 @@ func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 			Email:     usuario.Email,
 			EmpresaID: usuario.IDEmpresa,
 			Status:    usuario.Status,
+			Papel:     usuario.Papel,
 		},
 		ExpiresAt: claims.ExpiresAt.Time,
 	}
@@ -219,7 +578,7 @@ func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clientIP := h.getClientIP(r)
+	clientIP := h.clientIP(r)
 
 	usuario, err := h.usuarioUseCase.GetByID(r.Context(), userAdminID)
 	if err != nil {
@@ -230,6 +589,11 @@ func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	// MUDAR: authenticate para validar senha atual
 	_, err = h.usuarioUseCase.Authenticate(r.Context(), usuario.Email, req.SenhaAtual, clientIP)
 	if err != nil {
+		var bloqueado usecase.LoginBloqueadoError
+		if errors.As(err, &bloqueado) {
+			h.writeLoginThrottled(w, bloqueado)
+			return
+		}
 		response.WriteError(w, http.StatusUnauthorized, "Senha atual incorreta", "A senha atual fornecida está incorreta")
 		return
 	}
@@ -256,7 +620,7 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clientIP := h.getClientIP(r)
+	clientIP := h.clientIP(r)
 
 	// Processar solicitação de recuperação
 	if err := h.usuarioUseCase.RequestPasswordReset(r.Context(), req.Email, clientIP); err != nil {
@@ -268,15 +632,53 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	response.WriteSuccess(w, http.StatusOK, "Se o email existir, instruções foram enviadas", nil)
 }
 
+// ResetPassword redefine a senha usando o token enviado por email
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Dados inválidos", err.Error())
+		return
+	}
+
+	if strings.TrimSpace(req.Token) == "" {
+		response.WriteError(w, http.StatusBadRequest, "Token obrigatório", "Token de redefinição é obrigatório")
+		return
+	}
+
+	if err := h.validator.IsPasswordStrong(req.NovaSenha); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Senha fraca", err.Error())
+		return
+	}
+
+	clientIP := h.clientIP(r)
+
+	if err := h.usuarioUseCase.ResetPassword(r.Context(), req.Token, req.NovaSenha, clientIP); err != nil {
+		if strings.Contains(err.Error(), "inválido ou expirado") {
+			response.WriteError(w, http.StatusBadRequest, "Token inválido", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Senha redefinida com sucesso", nil)
+}
+
 // Métodos auxiliares
 
-func (h *AuthHandler) generateJWT(userID, empresaID int, email string) (string, error) {
+func (h *AuthHandler) generateJWT(usuario *entity.UsuarioAdministrador, jti string, sessaoID int, expiresAt time.Time) (string, error) {
+	userID := usuario.ID
 	claims := middleware.JWTClaims{
-		UserID:    userID,
-		EmpresaID: empresaID,
-		Email:     email,
+		UserID:     userID,
+		EmpresaID:  usuario.IDEmpresa,
+		Email:      usuario.Email,
+		Papel:      usuario.Papel,
+		Permissoes: entity.PermissoesDoPapel(usuario.Papel),
+		SessaoID:   sessaoID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "organizational-climate-survey",
@@ -288,6 +690,129 @@ func (h *AuthHandler) generateJWT(userID, empresaID int, email string) (string,
 	return token.SignedString(h.jwtSecret)
 }
 
+// generateMFAPendingToken gera o token de login pendente, válido por mfaPendingTTL
+func (h *AuthHandler) generateMFAPendingToken(userID int) (string, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := mfaPendingClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaPendingTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "organizational-climate-survey",
+			Subject:   fmt.Sprintf("user_%d", userID),
+			Audience:  jwt.ClaimStrings{"mfa-pendente"},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(h.mfaSecret)
+}
+
+// pendingLoginUser valida o token de login pendente e carrega o usuário, retornando também o jti do token
+// Escreve a resposta de erro e retorna false quando o token ou o usuário não são válidos
+func (h *AuthHandler) pendingLoginUser(w http.ResponseWriter, r *http.Request, tokenString string) (*entity.UsuarioAdministrador, string, bool) {
+	if h.mfaUseCase == nil {
+		response.WriteError(w, http.StatusServiceUnavailable, "Segundo fator indisponível", "Segundo fator não configurado")
+		return nil, "", false
+	}
+
+	if strings.TrimSpace(tokenString) == "" {
+		response.WriteError(w, http.StatusBadRequest, "Token obrigatório", "mfa_token é obrigatório")
+		return nil, "", false
+	}
+
+	claims := &mfaPendingClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("método de assinatura inesperado: %v", token.Header["alg"])
+		}
+		return h.mfaSecret, nil
+	}, jwt.WithAudience("mfa-pendente"))
+	if err != nil || !token.Valid {
+		response.WriteError(w, http.StatusUnauthorized, "Token inválido", "Login pendente inválido ou expirado; entre novamente com email e senha")
+		return nil, "", false
+	}
+
+	usuario, err := h.usuarioUseCase.GetByID(r.Context(), claims.UserID)
+	if err != nil || usuario.Status != "Ativo" {
+		response.WriteError(w, http.StatusUnauthorized, "Token inválido", "Login pendente inválido ou expirado; entre novamente com email e senha")
+		return nil, "", false
+	}
+
+	return usuario, claims.ID, true
+}
+
+// authenticatedMFAUser carrega o usuário do access token para as rotas de gestão do segundo fator
+func (h *AuthHandler) authenticatedMFAUser(w http.ResponseWriter, r *http.Request) (*entity.UsuarioAdministrador, bool) {
+	if h.mfaUseCase == nil {
+		response.WriteError(w, http.StatusServiceUnavailable, "Segundo fator indisponível", "Segundo fator não configurado")
+		return nil, false
+	}
+
+	userAdminID := h.getUserAdminIDFromContext(r)
+	if userAdminID == 0 {
+		response.WriteError(w, http.StatusUnauthorized, "Não autorizado", "Token inválido ou expirado")
+		return nil, false
+	}
+
+	usuario, err := h.usuarioUseCase.GetByID(r.Context(), userAdminID)
+	if err != nil {
+		response.WriteError(w, http.StatusUnauthorized, "Não autorizado", "Usuário não encontrado")
+		return nil, false
+	}
+
+	return usuario, true
+}
+
+// writeLoginThrottled responde 429 com Retry-After; a mensagem não revela se o email existe
+func (h *AuthHandler) writeLoginThrottled(w http.ResponseWriter, bloqueado usecase.LoginBloqueadoError) {
+	seconds := int(bloqueado.RetryAfter / time.Second)
+	if bloqueado.RetryAfter%time.Second != 0 {
+		seconds++
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	response.WriteError(w, http.StatusTooManyRequests, "Muitas tentativas", "Muitas tentativas de login. Tente novamente mais tarde")
+}
+
+// writeMFAError mapeia os erros do segundo fator para status HTTP
+func (h *AuthHandler) writeMFAError(w http.ResponseWriter, err error) {
+	var bloqueado usecase.LoginBloqueadoError
+	if errors.As(err, &bloqueado) {
+		h.writeLoginThrottled(w, bloqueado)
+		return
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "código MFA inválido"):
+		response.WriteError(w, http.StatusUnauthorized, "Código inválido", err.Error())
+	case strings.Contains(err.Error(), "login pendente inválido"):
+		response.WriteError(w, http.StatusUnauthorized, "Token inválido", "Login pendente inválido ou expirado; entre novamente com email e senha")
+	case strings.Contains(err.Error(), "já está ativo"):
+		response.WriteError(w, http.StatusConflict, "Segundo fator já ativo", err.Error())
+	case strings.Contains(err.Error(), "política da empresa"):
+		response.WriteError(w, http.StatusForbidden, "Segundo fator obrigatório", err.Error())
+	case strings.Contains(err.Error(), "não iniciado"), strings.Contains(err.Error(), "não configurado"):
+		response.WriteError(w, http.StatusBadRequest, "Segundo fator não configurado", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "Erro interno", err.Error())
+	}
+}
+
+// newJTI gera o identificador único (jti) de um access token
+func newJTI() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar identificador do token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func (h *AuthHandler) validateJWT(tokenString string) (*middleware.JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &middleware.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -345,25 +870,32 @@ func (h *AuthHandler) getUserAdminIDFromContext(r *http.Request) int {
 	return 0
 }
 
-func (h *AuthHandler) getClientIP(r *http.Request) string {
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		return strings.Split(ip, ",")[0]
-	}
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return ip
-	}
-	return r.RemoteAddr
+// clientIP identifica o cliente da requisição; headers de proxy só valem vindos de um proxy confiável
+// É a chave do bloqueio por IP no login, portanto não pode depender de valores enviados pelo próprio cliente
+func (h *AuthHandler) clientIP(r *http.Request) string {
+	return middleware.ClientIP(r, h.trustedProxies)
 }
 
-// RegisterRoutes registra as rotas do handler
+// RegisterRoutes registra as rotas públicas do handler (sem autenticação)
 func (h *AuthHandler) RegisterRoutes(router *mux.Router) {
-	// Rotas públicas (sem autenticação)
 	router.HandleFunc("/auth/login", h.Login).Methods("POST")
 	router.HandleFunc("/auth/forgot-password", h.ForgotPassword).Methods("POST")
+	router.HandleFunc("/auth/reset-password", h.ResetPassword).Methods("POST")
 	router.HandleFunc("/auth/validate", h.ValidateToken).Methods("POST")
-
-	// Rotas que requerem autenticação
 	router.HandleFunc("/auth/refresh", h.RefreshToken).Methods("POST")
+	router.HandleFunc("/auth/mfa/enroll", h.MFAEnroll).Methods("POST")
+	router.HandleFunc("/auth/mfa/verify", h.MFAVerify).Methods("POST")
+}
+
+// RegisterProtectedRoutes registra as rotas que requerem access token válido
+func (h *AuthHandler) RegisterProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/auth/logout", h.Logout).Methods("POST")
 	router.HandleFunc("/auth/change-password", h.ChangePassword).Methods("POST")
+	router.HandleFunc("/auth/sessions", h.ListSessions).Methods("GET")
+	router.HandleFunc("/auth/sessions/{id:[0-9]+}", h.RevokeSession).Methods("DELETE")
+	router.HandleFunc("/auth/mfa", h.GetMFAStatus).Methods("GET")
+	router.HandleFunc("/auth/mfa/setup", h.SetupMFA).Methods("POST")
+	router.HandleFunc("/auth/mfa/activate", h.ActivateMFA).Methods("POST")
+	router.HandleFunc("/auth/mfa/disable", h.DisableMFA).Methods("POST")
+	router.HandleFunc("/auth/mfa/recovery-codes", h.RegenerateRecoveryCodes).Methods("POST")
 }
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/crypto"
+	"organizational-climate-survey/backend/pkg/totp"
+)
+
+// fakeUsuarioRepo não conhece nenhum email: toda tentativa de login falha
+type fakeUsuarioRepo struct {
+	repository.UsuarioAdministradorRepository
+}
+
+func (r *fakeUsuarioRepo) GetByEmail(ctx context.Context, email string) (*entity.UsuarioAdministrador, error) {
+	return nil, fmt.Errorf("usuário com email %s não encontrado", email)
+}
+
+type fakeLogRepo struct {
+	repository.LogAuditoriaRepository
+}
+
+func (r *fakeLogRepo) Create(ctx context.Context, log *entity.LogAuditoria) error {
+	return nil
+}
+
+// fakeTentativaRepo guarda os contadores de falhas em memória
+type fakeTentativaRepo struct {
+	repository.TentativaLoginRepository
+	tentativas map[string]*entity.TentativaLogin
+	porID      map[int]*entity.TentativaLogin
+}
+
+func (r *fakeTentativaRepo) Get(ctx context.Context, tipo, chave string) (*entity.TentativaLogin, error) {
+	if tentativa, ok := r.tentativas[tipo+":"+chave]; ok {
+		return tentativa, nil
+	}
+	return nil, fmt.Errorf("tentativa não encontrada")
+}
+
+func (r *fakeTentativaRepo) RegisterFailure(ctx context.Context, tentativa *entity.TentativaLogin, now, windowStart time.Time) (*entity.TentativaLogin, error) {
+	atual, ok := r.tentativas[tentativa.Tipo+":"+tentativa.Chave]
+	if !ok {
+		atual = &entity.TentativaLogin{ID: len(r.porID) + 1, Tipo: tentativa.Tipo, Chave: tentativa.Chave}
+		r.tentativas[tentativa.Tipo+":"+tentativa.Chave] = atual
+		r.porID[atual.ID] = atual
+	}
+	atual.Falhas++
+	atual.UltimaFalha = now
+	copia := *atual
+	return &copia, nil
+}
+
+func (r *fakeTentativaRepo) UpdateBlock(ctx context.Context, id int, proximaTentativa, bloqueadoAte *time.Time) error {
+	r.porID[id].ProximaTentativa = proximaTentativa
+	r.porID[id].BloqueadoAte = bloqueadoAte
+	return nil
+}
+
+// fakeMFAUsuarioRepo conhece apenas o usuário autenticado nos testes de segundo fator
+type fakeMFAUsuarioRepo struct {
+	repository.UsuarioAdministradorRepository
+	usuario *entity.UsuarioAdministrador
+}
+
+func (r *fakeMFAUsuarioRepo) GetByID(ctx context.Context, id int) (*entity.UsuarioAdministrador, error) {
+	if id != r.usuario.ID {
+		return nil, fmt.Errorf("usuário ID %d não encontrado", id)
+	}
+	return r.usuario, nil
+}
+
+type fakeMFARepo struct {
+	repository.MFARepository
+	mfa *entity.MFAUsuario
+}
+
+func (r *fakeMFARepo) GetByUsuario(ctx context.Context, userAdminID int) (*entity.MFAUsuario, error) {
+	if userAdminID != r.mfa.IDUserAdmin {
+		return nil, fmt.Errorf("segundo fator não encontrado")
+	}
+	return r.mfa, nil
+}
+
+type fakeEmpresaRepo struct {
+	repository.EmpresaRepository
+}
+
+func (r *fakeEmpresaRepo) GetByID(ctx context.Context, id int) (*entity.Empresa, error) {
+	return &entity.Empresa{ID: id}, nil
+}
+
+func TestCodigosMFAInvalidosBloqueiamDesativacaoERegeneracao(t *testing.T) {
+	casos := []struct {
+		nome    string
+		handler func(h *AuthHandler) http.HandlerFunc
+	}{
+		{"desativação", func(h *AuthHandler) http.HandlerFunc { return h.DisableMFA }},
+		{"códigos de recuperação", func(h *AuthHandler) http.HandlerFunc { return h.RegenerateRecoveryCodes }},
+	}
+
+	const limite = 3
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			segredo, err := totp.GenerateSecret()
+			if err != nil {
+				t.Fatal(err)
+			}
+			usuario := &entity.UsuarioAdministrador{ID: 1, IDEmpresa: 1, Email: "admin@empresa.com"}
+			// Passo já utilizado à frente do atual: nenhum código da janela é aceito
+			mfa := &entity.MFAUsuario{IDUserAdmin: usuario.ID, Segredo: segredo, Ativo: true, UltimoPasso: totp.Step(time.Now()) + 10}
+
+			usuarioRepo := &fakeMFAUsuarioRepo{usuario: usuario}
+			cryptoSvc := crypto.NewCryptoService(4)
+			usuarioUseCase := usecase.NewUsuarioAdministradorUseCase(usuarioRepo, nil, &fakeLogRepo{}, cryptoSvc)
+			usuarioUseCase.SetLoginProtection(&fakeTentativaRepo{
+				tentativas: make(map[string]*entity.TentativaLogin),
+				porID:      make(map[int]*entity.TentativaLogin),
+			}, usecase.LoginProtectionPolicy{
+				MaxFailures:   limite,
+				IPMaxFailures: 100,
+				LockDuration:  time.Hour,
+				BackoffBase:   time.Nanosecond,
+				BackoffMax:    time.Nanosecond,
+				Window:        time.Hour,
+			})
+			mfaUseCase := usecase.NewMFAUseCase(&fakeMFARepo{mfa: mfa}, usuarioRepo, &fakeEmpresaRepo{}, &fakeLogRepo{}, cryptoSvc, "")
+			mfaUseCase.SetLoginProtection(usuarioUseCase)
+			h := NewAuthHandler(usuarioUseCase, nil, nil, mfaUseCase, "segredo-de-teste", time.Minute, nil)
+
+			for i := 0; i <= limite; i++ {
+				r := httptest.NewRequest("POST", "/auth/mfa", strings.NewReader(`{"codigo":"123456"}`))
+				ctx := usecase.WithEmpresaScope(r.Context(), usuario.IDEmpresa)
+				r = r.WithContext(context.WithValue(ctx, "user_admin_id", usuario.ID))
+				w := httptest.NewRecorder()
+				c.handler(h)(w, r)
+
+				esperado := http.StatusUnauthorized
+				if i == limite {
+					esperado = http.StatusTooManyRequests
+				}
+				if w.Code != esperado {
+					t.Fatalf("tentativa %d: status %d, esperado %d", i+1, w.Code, esperado)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginBloqueiaIPSemConfiarEmHeadersDoCliente(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	confiaveis := []*net.IPNet{proxies}
+
+	casos := []struct {
+		nome      string
+		remote    func(i int) string
+		xff       func(i int) string
+		proxies   []*net.IPNet
+		bloqueado bool
+	}{
+		{
+			"X-Forwarded-For trocado a cada tentativa por cliente não confiável",
+			func(i int) string { return fmt.Sprintf("203.0.113.7:%d", 40000+i) },
+			func(i int) string { return fmt.Sprintf("198.51.100.%d", i+1) },
+			nil, true,
+		},
+		{
+			"X-Forwarded-For de cliente fora dos proxies confiáveis",
+			func(i int) string { return fmt.Sprintf("203.0.113.7:%d", 40000+i) },
+			func(i int) string { return fmt.Sprintf("198.51.100.%d", i+1) },
+			confiaveis, true,
+		},
+		{
+			"mesmo cliente atrás de proxy confiável",
+			func(i int) string { return fmt.Sprintf("10.0.0.1:%d", 40000+i) },
+			func(i int) string { return "198.51.100.1" },
+			confiaveis, true,
+		},
+		{
+			"clientes distintos atrás de proxy confiável",
+			func(i int) string { return fmt.Sprintf("10.0.0.1:%d", 40000+i) },
+			func(i int) string { return fmt.Sprintf("198.51.100.%d", i+1) },
+			confiaveis, false,
+		},
+	}
+
+	const limiteIP = 3
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			usuarioUseCase := usecase.NewUsuarioAdministradorUseCase(&fakeUsuarioRepo{}, nil, &fakeLogRepo{}, crypto.NewCryptoService(4))
+			usuarioUseCase.SetLoginProtection(&fakeTentativaRepo{
+				tentativas: make(map[string]*entity.TentativaLogin),
+				porID:      make(map[int]*entity.TentativaLogin),
+			}, usecase.LoginProtectionPolicy{
+				MaxFailures:   100,
+				IPMaxFailures: limiteIP,
+				LockDuration:  time.Hour,
+				BackoffBase:   time.Nanosecond,
+				BackoffMax:    time.Nanosecond,
+				Window:        time.Hour,
+			})
+			h := NewAuthHandler(usuarioUseCase, nil, nil, nil, "segredo-de-teste", time.Minute, c.proxies)
+
+			// Emails distintos: só o contador do IP pode bloquear
+			var codigo int
+			for i := 0; i <= limiteIP; i++ {
+				corpo := fmt.Sprintf(`{"email":"conta%d@empresa.com","senha":"errada"}`, i)
+				r := httptest.NewRequest("POST", "/auth/login", strings.NewReader(corpo))
+				r.RemoteAddr = c.remote(i)
+				r.Header.Set("X-Forwarded-For", c.xff(i))
+				w := httptest.NewRecorder()
+				h.Login(w, r)
+				codigo = w.Code
+			}
+
+			if c.bloqueado && codigo != http.StatusTooManyRequests {
+				t.Errorf("status %d após %d falhas, esperado 429", codigo, limiteIP)
+			}
+			if !c.bloqueado && codigo != http.StatusUnauthorized {
+				t.Errorf("status %d, esperado 401", codigo)
+			}
+		})
+	}
+}
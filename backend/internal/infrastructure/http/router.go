@@ -3,11 +3,15 @@
 package http
 
 import (
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"organizational-climate-survey/backend/internal/application/dto/response"
 	"organizational-climate-survey/backend/internal/application/handler"
 	"organizational-climate-survey/backend/internal/application/middleware"
+	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
 	"organizational-climate-survey/backend/internal/domain/usecase"
 	"organizational-climate-survey/backend/internal/infrastructure/auth"
@@ -24,13 +28,22 @@ type RouterConfig struct {
 	SetorUseCase                *usecase.SetorUseCase                // Use case de setor
 	PesquisaUseCase             *usecase.PesquisaUseCase             // Use case de pesquisa
 	PerguntaUseCase             *usecase.PerguntaUseCase             // Use case de pergunta
+	ModeloPesquisaUseCase       *usecase.ModeloPesquisaUseCase       // Use case da biblioteca de modelos de pesquisa
+	DimensaoUseCase             *usecase.DimensaoUseCase             // Use case das dimensões do clima
 	RespostaUseCase             *usecase.RespostaUseCase             // Use case de resposta
 	SubmissaoUseCase            *usecase.SubmissaoPesquisaUseCase    // Use case de submissão (NOVO)
 	DashboardUseCase            *usecase.DashboardUseCase            // Use case de dashboard
+	AnalyticsUseCase            *usecase.AnalyticsUseCase            // Use case de analytics
 	LogAuditoriaUseCase         *usecase.LogAuditoriaUseCase         // Use case de log
+	SessaoUseCase               *usecase.SessaoUseCase               // Use case de sessões (nil desativa refresh tokens e revogação)
+	MFAUseCase                  *usecase.MFAUseCase                  // Use case de segundo fator (nil desativa MFA)
+	FormularioPublicoUseCase    *usecase.FormularioPublicoUseCase    // Use case do formulário público de pesquisas
 	PesquisaRepo                repository.PesquisaRepository        // Repositório de pesquisa (NOVO - para middleware)
 	JWTSecret                   string                               // Chave secreta para JWT
-	BootstrapUseCase            *usecase.BootstrapUseCase    	// Use case de bootstrap
+	AccessTokenTTL              time.Duration                        // Validade do access token
+	BootstrapUseCase            *usecase.BootstrapUseCase            // Use case de bootstrap
+	RateLimiter                 *middleware.RateLimiter              // Limitador de taxa (nil desativa)
+	TrustedProxies              []*net.IPNet                         // Proxies confiáveis para identificar o IP do cliente no login
 }
 
 // SetupRouter configura todas as rotas da API com seus respectivos handlers
@@ -43,9 +56,19 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 	authHandler := auth.NewAuthHandler(
 		config.UsuarioAdministradorUseCase,
 		config.LogAuditoriaUseCase,
+		config.SessaoUseCase,
+		config.MFAUseCase,
 		config.JWTSecret,
+		config.AccessTokenTTL,
+		config.TrustedProxies,
 	)
 
+	// Denylist de access tokens consultada pelo JWTAuthMiddleware
+	var revocation middleware.TokenRevocationChecker
+	if config.SessaoUseCase != nil {
+		revocation = config.SessaoUseCase
+	}
+
 	var empresaHandler *handler.EmpresaHandler
 	if config.EmpresaUseCase != nil {
 		empresaHandler = handler.NewEmpresaHandler(config.EmpresaUseCase, log, val)
@@ -66,6 +89,16 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 		pesquisaHandler = handler.NewPesquisaHandler(config.PesquisaUseCase, log)
 	}
 
+	var modeloHandler *handler.ModeloPesquisaHandler
+	if config.ModeloPesquisaUseCase != nil {
+		modeloHandler = handler.NewModeloPesquisaHandler(config.ModeloPesquisaUseCase, log)
+	}
+
+	var dimensaoHandler *handler.DimensaoHandler
+	if config.DimensaoUseCase != nil {
+		dimensaoHandler = handler.NewDimensaoHandler(config.DimensaoUseCase, log)
+	}
+
 	var perguntaHandler *handler.PerguntaHandler
 	if config.PerguntaUseCase != nil {
 		perguntaHandler = handler.NewPerguntaHandler(config.PerguntaUseCase, log)
@@ -76,6 +109,11 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 		respostaHandler = handler.NewRespostaHandler(config.RespostaUseCase, log)
 	}
 
+	var formularioHandler *handler.FormularioPublicoHandler
+	if config.FormularioPublicoUseCase != nil {
+		formularioHandler = handler.NewFormularioPublicoHandler(config.FormularioPublicoUseCase, log)
+	}
+
 	var submissaoHandler *handler.SubmissaoHandler
 	if config.SubmissaoUseCase != nil {
 		submissaoHandler = handler.NewSubmissaoHandler(config.SubmissaoUseCase, log)
@@ -83,7 +121,7 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 
 	var bootstrapHandler *handler.BootstrapHandler
 	if config.BootstrapUseCase != nil {
-    bootstrapHandler = handler.NewBootstrapHandler(config.BootstrapUseCase, log, val)
+		bootstrapHandler = handler.NewBootstrapHandler(config.BootstrapUseCase, log, val)
 	}
 
 	var dashboardHandler *handler.DashboardHandler
@@ -91,6 +129,11 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 		dashboardHandler = handler.NewDashboardHandler(config.DashboardUseCase, log)
 	}
 
+	var analyticsHandler *handler.AnalyticsHandler
+	if config.AnalyticsUseCase != nil {
+		analyticsHandler = handler.NewAnalyticsHandler(config.AnalyticsUseCase, log)
+	}
+
 	var logHandler *handler.LogAuditoriaHandler
 	if config.LogAuditoriaUseCase != nil {
 		logHandler = handler.NewLogAuditoriaHandler(config.LogAuditoriaUseCase, log)
@@ -100,10 +143,17 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 
 	// === ROTAS PÚBLICAS (sem autenticação) ===
 	publicRoutes := api.PathPrefix("").Subrouter()
-	publicRoutes.Use(middleware.PublicMiddlewares())
+	publicRoutes.Use(middleware.PublicMiddlewares(config.RateLimiter))
+
+	// Auth (login) com limite de taxa próprio
+	authPublicRoutes := api.PathPrefix("").Subrouter()
+	authPublicRoutes.Use(middleware.AuthRouteMiddlewares(config.RateLimiter))
+	authHandler.RegisterRoutes(authPublicRoutes)
 
-	// Auth (login)
-	authHandler.RegisterRoutes(publicRoutes)
+	// Auth do próprio usuário (logout, troca de senha, sessões): JWT sem permissão específica
+	authSessionRoutes := api.PathPrefix("").Subrouter()
+	authSessionRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret), revocation, config.RateLimiter))
+	authHandler.RegisterProtectedRoutes(authSessionRoutes)
 
 	// NOVO: Bootstrap (criar primeiro admin)
 	if bootstrapHandler != nil {
@@ -115,16 +165,21 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 		submissaoHandler.RegisterRoutes(publicRoutes)
 	}
 
+	// Formulário da pesquisa pelo link de acesso (respondentes anônimos)
+	if formularioHandler != nil {
+		formularioHandler.RegisterRoutes(publicRoutes)
+	}
+
 	// === ROTAS DE SUBMISSÃO DE RESPOSTAS (anônimas com token) ===
 	if respostaHandler != nil && config.PesquisaRepo != nil {
 		surveyRoutes := api.PathPrefix("").Subrouter()
-		surveyRoutes.Use(middleware.SurveySubmissionMiddlewares(config.PesquisaRepo)) // Passa repo
+		surveyRoutes.Use(middleware.SurveySubmissionMiddlewares(config.PesquisaRepo, config.RateLimiter)) // Passa repo
 		surveyRoutes.HandleFunc("/respostas/submit", respostaHandler.SubmitRespostas).Methods("POST")
 	}
 
 	// === ROTAS AUTENTICADAS (requerem JWT) ===
 	authRoutes := api.PathPrefix("").Subrouter()
-	authRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret)))
+	authRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret), revocation, config.RateLimiter))
 
 	if empresaHandler != nil {
 		empresaHandler.RegisterRoutes(authRoutes)
@@ -138,25 +193,36 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 	if pesquisaHandler != nil {
 		pesquisaHandler.RegisterRoutes(authRoutes)
 	}
+	if modeloHandler != nil {
+		modeloHandler.RegisterRoutes(authRoutes)
+	}
+	if dimensaoHandler != nil {
+		dimensaoHandler.RegisterRoutes(authRoutes)
+	}
 	if perguntaHandler != nil {
 		perguntaHandler.RegisterRoutes(authRoutes)
 	}
 	if dashboardHandler != nil {
 		dashboardHandler.RegisterRoutes(authRoutes)
 	}
+	if analyticsHandler != nil {
+		analyticsHandler.RegisterRoutes(authRoutes)
+	}
+	applyRoutePermissions(authRoutes)
 
 	// === ROTAS ADMINISTRATIVAS (requerem JWT + permissões admin) ===
 	adminRoutes := api.PathPrefix("").Subrouter()
-	adminRoutes.Use(middleware.AdminMiddlewares([]byte(config.JWTSecret)))
+	adminRoutes.Use(middleware.AdminMiddlewares([]byte(config.JWTSecret), revocation, config.RateLimiter))
 
 	if logHandler != nil {
 		logHandler.RegisterRoutes(adminRoutes)
 	}
+	applyRoutePermissions(adminRoutes)
 
 	// Rotas administrativas de resposta (estatísticas, análises)
 	if respostaHandler != nil {
 		respostaAdminRoutes := api.PathPrefix("").Subrouter()
-		respostaAdminRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret)))
+		respostaAdminRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret), revocation, config.RateLimiter))
 
 		respostaAdminRoutes.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/respostas/stats", respostaHandler.GetRespostaStats).Methods("GET")
 		respostaAdminRoutes.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/respostas/aggregated", respostaHandler.GetRespostasByPesquisa).Methods("GET")
@@ -166,13 +232,15 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 		respostaAdminRoutes.HandleFunc("/perguntas/{pergunta_id:[0-9]+}/respostas/aggregated", respostaHandler.GetRespostasByPergunta).Methods("GET")
 		respostaAdminRoutes.HandleFunc("/perguntas/{pergunta_id:[0-9]+}/respostas/count", respostaHandler.CountRespostasByPergunta).Methods("GET")
 		respostaAdminRoutes.HandleFunc("/perguntas/{pergunta_id:[0-9]+}/respostas/stats", respostaHandler.GetStatsByPergunta).Methods("GET")
+		applyRoutePermissions(respostaAdminRoutes)
 	}
 
 	// NOVO: Estatísticas de submissão (admin)
 	if submissaoHandler != nil {
 		submissaoAdminRoutes := api.PathPrefix("").Subrouter()
-		submissaoAdminRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret)))
+		submissaoAdminRoutes.Use(middleware.AuthenticatedMiddlewares([]byte(config.JWTSecret), revocation, config.RateLimiter))
 		submissaoAdminRoutes.HandleFunc("/pesquisas/{pesquisa_id:[0-9]+}/submissions/stats", submissaoHandler.GetSubmissionStats).Methods("GET")
+		applyRoutePermissions(submissaoAdminRoutes)
 	}
 
 	// Health check e documentação
@@ -182,6 +250,155 @@ func SetupRouter(config *RouterConfig) *mux.Router {
 	return router
 }
 
+// routePermissions define a permissão exigida por cada rota autenticada, no formato "MÉTODO caminho"
+var routePermissions = map[string]string{
+	// Empresas
+	"POST /empresas":                               entity.PermEmpresaGerenciar,
+	"GET /empresas":                                entity.PermEmpresaLer,
+	"GET /empresas/{id:[0-9]+}":                    entity.PermEmpresaLer,
+	"PUT /empresas/{id:[0-9]+}":                    entity.PermEmpresaGerenciar,
+	"DELETE /empresas/{id:[0-9]+}":                 entity.PermEmpresaGerenciar,
+	"PUT /empresas/{id:[0-9]+}/politica-mfa":       entity.PermEmpresaGerenciar,
+	"PUT /empresas/{id:[0-9]+}/politica-anonimato": entity.PermEmpresaGerenciar,
+	"GET /empresas/cnpj/{cnpj:.+}":                 entity.PermEmpresaLer,
+
+	// Usuários administradores
+	"POST /usuarios-administradores":                                       entity.PermUsuariosGerenciar,
+	"GET /usuarios-administradores/{id:[0-9]+}":                            entity.PermUsuariosLer,
+	"PUT /usuarios-administradores/{id:[0-9]+}":                            entity.PermUsuariosGerenciar,
+	"DELETE /usuarios-administradores/{id:[0-9]+}":                         entity.PermUsuariosGerenciar,
+	"PUT /usuarios-administradores/{id:[0-9]+}/password":                   entity.PermUsuariosGerenciar,
+	"PUT /usuarios-administradores/{id:[0-9]+}/status":                     entity.PermUsuariosGerenciar,
+	"PUT /usuarios-administradores/{id:[0-9]+}/papel":                      entity.PermUsuariosGerenciar,
+	"DELETE /usuarios-administradores/{id:[0-9]+}/bloqueio":                entity.PermUsuariosGerenciar,
+	"GET /usuarios-administradores/email/{email}":                          entity.PermUsuariosLer,
+	"GET /empresas/{empresa_id:[0-9]+}/usuarios-administradores":           entity.PermUsuariosLer,
+	"GET /empresas/{empresa_id:[0-9]+}/usuarios-administradores/bloqueios": entity.PermUsuariosGerenciar,
+
+	// Setores
+	"POST /setores":                                         entity.PermSetoresGerenciar,
+	"GET /setores/{id:[0-9]+}":                              entity.PermSetoresLer,
+	"PUT /setores/{id:[0-9]+}":                              entity.PermSetoresGerenciar,
+	"DELETE /setores/{id:[0-9]+}":                           entity.PermSetoresGerenciar,
+	"GET /empresas/{empresa_id:[0-9]+}/setores":             entity.PermSetoresLer,
+	"GET /empresas/{empresa_id:[0-9]+}/setores/nome/{nome}": entity.PermSetoresLer,
+
+	// Pesquisas
+	"POST /pesquisas":                                    entity.PermPesquisasGerenciar,
+	"GET /pesquisas/{id:[0-9]+}":                         entity.PermPesquisasLer,
+	"PUT /pesquisas/{id:[0-9]+}":                         entity.PermPesquisasGerenciar,
+	"DELETE /pesquisas/{id:[0-9]+}":                      entity.PermPesquisasGerenciar,
+	"PUT /pesquisas/{id:[0-9]+}/status":                  entity.PermPesquisasGerenciar,
+	"POST /pesquisas/{id:[0-9]+}/qrcode":                 entity.PermPesquisasGerenciar,
+	"GET /pesquisas/{id:[0-9]+}/qrcode":                  entity.PermPesquisasLer,
+	"POST /pesquisas/{id:[0-9]+}/link-acesso":            entity.PermPesquisasGerenciar,
+	"POST /pesquisas/{id:[0-9]+}/clone":                  entity.PermPesquisasGerenciar,
+	"GET /pesquisas/link/{link}":                         entity.PermPesquisasLer,
+	"GET /empresas/{empresa_id:[0-9]+}/pesquisas":        entity.PermPesquisasLer,
+	"GET /empresas/{empresa_id:[0-9]+}/pesquisas/active": entity.PermPesquisasLer,
+	"GET /setores/{setor_id:[0-9]+}/pesquisas":           entity.PermPesquisasLer,
+
+	// Modelos de pesquisa
+	"POST /modelos-pesquisa":                             entity.PermPesquisasGerenciar,
+	"GET /modelos-pesquisa/{id:[0-9]+}":                  entity.PermPesquisasLer,
+	"PUT /modelos-pesquisa/{id:[0-9]+}":                  entity.PermPesquisasGerenciar,
+	"DELETE /modelos-pesquisa/{id:[0-9]+}":               entity.PermPesquisasGerenciar,
+	"GET /modelos-pesquisa/{id:[0-9]+}/versoes":          entity.PermPesquisasLer,
+	"POST /modelos-pesquisa/{id:[0-9]+}/pesquisas":       entity.PermPesquisasGerenciar,
+	"GET /empresas/{empresa_id:[0-9]+}/modelos-pesquisa": entity.PermPesquisasLer,
+
+	// Dimensões do clima
+	"POST /dimensoes":                             entity.PermPesquisasGerenciar,
+	"GET /dimensoes/{id:[0-9]+}":                  entity.PermPesquisasLer,
+	"PUT /dimensoes/{id:[0-9]+}":                  entity.PermPesquisasGerenciar,
+	"DELETE /dimensoes/{id:[0-9]+}":               entity.PermPesquisasGerenciar,
+	"GET /empresas/{empresa_id:[0-9]+}/dimensoes": entity.PermPesquisasLer,
+
+	// Perguntas
+	"POST /perguntas":                                          entity.PermPesquisasGerenciar,
+	"POST /perguntas/batch":                                    entity.PermPesquisasGerenciar,
+	"GET /perguntas/{id:[0-9]+}":                               entity.PermPesquisasLer,
+	"PUT /perguntas/{id:[0-9]+}":                               entity.PermPesquisasGerenciar,
+	"DELETE /perguntas/{id:[0-9]+}":                            entity.PermPesquisasGerenciar,
+	"PUT /perguntas/{id:[0-9]+}/ordem":                         entity.PermPesquisasGerenciar,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/perguntas":            entity.PermPesquisasLer,
+	"PUT /pesquisas/{pesquisa_id:[0-9]+}/perguntas/reorder":    entity.PermPesquisasGerenciar,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/perguntas/with-stats": entity.PermResultadosLer,
+
+	// Dashboards
+	"POST /dashboards":                              entity.PermDashboardsGerenciar,
+	"GET /dashboards/{id:[0-9]+}":                   entity.PermResultadosLer,
+	"PUT /dashboards/{id:[0-9]+}":                   entity.PermDashboardsGerenciar,
+	"DELETE /dashboards/{id:[0-9]+}":                entity.PermDashboardsGerenciar,
+	"GET /dashboards/{id:[0-9]+}/data":              entity.PermResultadosLer,
+	"POST /dashboards/{id:[0-9]+}/refresh":          entity.PermResultadosLer,
+	"GET /dashboards/{id:[0-9]+}/export":            entity.PermResultadosLer,
+	"GET /dashboards/{id:[0-9]+}/metrics":           entity.PermResultadosLer,
+	"GET /dashboards/{id:[0-9]+}/analise-texto":     entity.PermResultadosLer,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/dashboard": entity.PermResultadosLer,
+	"GET /empresas/{empresa_id:[0-9]+}/dashboards":  entity.PermResultadosLer,
+
+	// Analytics
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/analytics":                                      entity.PermResultadosLer,
+	"GET /analytics/comparison":                                                          entity.PermResultadosLer,
+	"GET /empresas/{empresa_id:[0-9]+}/pesquisas/{pesquisa_id:[0-9]+}/analytics/setores": entity.PermResultadosLer,
+	"GET /empresas/{empresa_id:[0-9]+}/analytics/trends":                                 entity.PermResultadosLer,
+
+	// Respostas e submissões
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/respostas/stats":      entity.PermResultadosLer,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/respostas/aggregated": entity.PermResultadosLer,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/respostas/by-date":    entity.PermResultadosLer,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/respostas/count":      entity.PermResultadosLer,
+	"DELETE /pesquisas/{pesquisa_id:[0-9]+}/respostas":         entity.PermRespostasExcluir,
+	"GET /perguntas/{pergunta_id:[0-9]+}/respostas/aggregated": entity.PermResultadosLer,
+	"GET /perguntas/{pergunta_id:[0-9]+}/respostas/count":      entity.PermResultadosLer,
+	"GET /perguntas/{pergunta_id:[0-9]+}/respostas/stats":      entity.PermResultadosLer,
+	"GET /pesquisas/{pesquisa_id:[0-9]+}/submissions/stats":    entity.PermResultadosLer,
+
+	// Logs de auditoria
+	"POST /logs-auditoria":                                                entity.PermLogsGerenciar,
+	"GET /logs-auditoria/{id:[0-9]+}":                                     entity.PermLogsLer,
+	"POST /logs-auditoria/clean":                                          entity.PermLogsGerenciar,
+	"GET /empresas/{empresa_id:[0-9]+}/logs-auditoria":                    entity.PermLogsLer,
+	"GET /empresas/{empresa_id:[0-9]+}/logs-auditoria/by-date":            entity.PermLogsLer,
+	"GET /empresas/{empresa_id:[0-9]+}/logs-auditoria/by-action":          entity.PermLogsLer,
+	"GET /empresas/{empresa_id:[0-9]+}/logs-auditoria/summary":            entity.PermLogsLer,
+	"GET /empresas/{empresa_id:[0-9]+}/logs-auditoria/export":             entity.PermLogsLer,
+	"GET /usuarios-administradores/{user_admin_id:[0-9]+}/logs-auditoria": entity.PermLogsLer,
+}
+
+// applyRoutePermissions envolve cada rota do subrouter com a verificação da permissão configurada
+// Rotas sem permissão em routePermissions são negadas, evitando exposição acidental de novas rotas
+func applyRoutePermissions(sub *mux.Router) {
+	sub.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		h := route.GetHandler()
+		if h == nil {
+			return nil
+		}
+
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		tpl = strings.TrimPrefix(tpl, "/api/v1")
+
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			permissao, ok := routePermissions[method+" "+tpl]
+			if !ok {
+				h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					response.WriteError(w, http.StatusForbidden, "Acesso negado", "Rota sem permissão configurada")
+				})
+				break
+			}
+			h = middleware.RequirePermission(permissao)(h)
+		}
+
+		route.Handler(h)
+		return nil
+	})
+}
+
 // HealthCheckHandler responde às requisições de verificação de saúde da API
 func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -221,12 +438,17 @@ func SetupMinimalRouter(config *RouterConfig) *mux.Router {
 		authHandler := auth.NewAuthHandler(
 			config.UsuarioAdministradorUseCase,
 			config.LogAuditoriaUseCase,
+			config.SessaoUseCase,
+			config.MFAUseCase,
 			config.JWTSecret,
+			config.AccessTokenTTL,
+			config.TrustedProxies,
 		)
 
 		api := router.PathPrefix("/api/v1").Subrouter()
+		api.Use(middleware.SystemScopeMiddleware)
 		authHandler.RegisterRoutes(api)
 	}
 
 	return router
-}
\ No newline at end of file
+}
@@ -0,0 +1,358 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+)
+
+// Repositórios em memória usados nos testes do router
+// Cada fake embute a interface: métodos não implementados causam panic (500 pelo RecoveryMiddleware),
+// de modo que uma rota que chegue a eles sem verificar o escopo falha o teste em vez de passar despercebida
+
+// fakeDados contém os registros das empresas usadas nos testes
+type fakeDados struct {
+	empresas   map[int]*entity.Empresa
+	usuarios   map[int]*entity.UsuarioAdministrador
+	setores    map[int]*entity.Setor
+	pesquisas  map[int]*entity.Pesquisa
+	perguntas  map[int]*entity.Pergunta
+	dashboards map[int]*entity.Dashboard
+	dimensoes  map[int]*entity.Dimensao
+	modelos    map[int]*entity.ModeloPesquisa
+	logs       map[int]*entity.LogAuditoria
+}
+
+type fakeEmpresaRepo struct {
+	repository.EmpresaRepository
+	dados *fakeDados
+}
+
+func (r *fakeEmpresaRepo) GetByID(ctx context.Context, id int) (*entity.Empresa, error) {
+	if empresa, ok := r.dados.empresas[id]; ok {
+		return empresa, nil
+	}
+	return nil, fmt.Errorf("empresa com ID %d não encontrada", id)
+}
+
+func (r *fakeEmpresaRepo) GetByCNPJ(ctx context.Context, cnpj string) (*entity.Empresa, error) {
+	for _, empresa := range r.dados.empresas {
+		if empresa.CNPJ == cnpj {
+			return empresa, nil
+		}
+	}
+	return nil, fmt.Errorf("empresa com CNPJ %s não encontrada", cnpj)
+}
+
+type fakeUsuarioRepo struct {
+	repository.UsuarioAdministradorRepository
+	dados *fakeDados
+}
+
+func (r *fakeUsuarioRepo) GetByID(ctx context.Context, id int) (*entity.UsuarioAdministrador, error) {
+	if usuario, ok := r.dados.usuarios[id]; ok {
+		return usuario, nil
+	}
+	return nil, fmt.Errorf("usuário com ID %d não encontrado", id)
+}
+
+func (r *fakeUsuarioRepo) GetByEmail(ctx context.Context, email string) (*entity.UsuarioAdministrador, error) {
+	for _, usuario := range r.dados.usuarios {
+		if usuario.Email == email {
+			return usuario, nil
+		}
+	}
+	return nil, fmt.Errorf("usuário com email %s não encontrado", email)
+}
+
+func (r *fakeUsuarioRepo) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.UsuarioAdministrador, error) {
+	var usuarios []*entity.UsuarioAdministrador
+	for _, usuario := range r.dados.usuarios {
+		if usuario.IDEmpresa == empresaID {
+			usuarios = append(usuarios, usuario)
+		}
+	}
+	return usuarios, nil
+}
+
+type fakeSetorRepo struct {
+	repository.SetorRepository
+	dados *fakeDados
+}
+
+func (r *fakeSetorRepo) GetByID(ctx context.Context, id int) (*entity.Setor, error) {
+	if setor, ok := r.dados.setores[id]; ok {
+		return setor, nil
+	}
+	return nil, fmt.Errorf("setor com ID %d não encontrado", id)
+}
+
+func (r *fakeSetorRepo) GetByNome(ctx context.Context, empresaID int, nome string) (*entity.Setor, error) {
+	for _, setor := range r.dados.setores {
+		if setor.IDEmpresa == empresaID && setor.NomeSetor == nome {
+			return setor, nil
+		}
+	}
+	return nil, fmt.Errorf("setor %s não encontrado na empresa ID %d", nome, empresaID)
+}
+
+func (r *fakeSetorRepo) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Setor, error) {
+	var setores []*entity.Setor
+	for _, setor := range r.dados.setores {
+		if setor.IDEmpresa == empresaID {
+			setores = append(setores, setor)
+		}
+	}
+	return setores, nil
+}
+
+type fakePesquisaRepo struct {
+	repository.PesquisaRepository
+	dados *fakeDados
+}
+
+func (r *fakePesquisaRepo) GetByID(ctx context.Context, id int) (*entity.Pesquisa, error) {
+	if pesquisa, ok := r.dados.pesquisas[id]; ok {
+		return pesquisa, nil
+	}
+	return nil, fmt.Errorf("pesquisa com ID %d não encontrada", id)
+}
+
+func (r *fakePesquisaRepo) GetByLinkAcesso(ctx context.Context, link string) (*entity.Pesquisa, error) {
+	for _, pesquisa := range r.dados.pesquisas {
+		if pesquisa.LinkAcesso == link {
+			return pesquisa, nil
+		}
+	}
+	return nil, fmt.Errorf("pesquisa com link %s não encontrada", link)
+}
+
+func (r *fakePesquisaRepo) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Pesquisa, error) {
+	var pesquisas []*entity.Pesquisa
+	for _, pesquisa := range r.dados.pesquisas {
+		if pesquisa.IDEmpresa == empresaID {
+			pesquisas = append(pesquisas, pesquisa)
+		}
+	}
+	return pesquisas, nil
+}
+
+func (r *fakePesquisaRepo) ListBySetor(ctx context.Context, setorID int) ([]*entity.Pesquisa, error) {
+	var pesquisas []*entity.Pesquisa
+	for _, pesquisa := range r.dados.pesquisas {
+		if pesquisa.IDSetor == setorID {
+			pesquisas = append(pesquisas, pesquisa)
+		}
+	}
+	return pesquisas, nil
+}
+
+func (r *fakePesquisaRepo) ListActive(ctx context.Context, empresaID int) ([]*entity.Pesquisa, error) {
+	var pesquisas []*entity.Pesquisa
+	for _, pesquisa := range r.dados.pesquisas {
+		if pesquisa.IDEmpresa == empresaID && pesquisa.Status == "Ativa" {
+			pesquisas = append(pesquisas, pesquisa)
+		}
+	}
+	return pesquisas, nil
+}
+
+type fakePerguntaRepo struct {
+	repository.PerguntaRepository
+	dados *fakeDados
+}
+
+func (r *fakePerguntaRepo) GetByID(ctx context.Context, id int) (*entity.Pergunta, error) {
+	if pergunta, ok := r.dados.perguntas[id]; ok {
+		return pergunta, nil
+	}
+	return nil, fmt.Errorf("pergunta com ID %d não encontrada", id)
+}
+
+func (r *fakePerguntaRepo) ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error) {
+	return r.ListByPesquisas(ctx, []int{pesquisaID})
+}
+
+func (r *fakePerguntaRepo) ListByPesquisas(ctx context.Context, pesquisaIDs []int) ([]*entity.Pergunta, error) {
+	var perguntas []*entity.Pergunta
+	for _, pergunta := range r.dados.perguntas {
+		for _, id := range pesquisaIDs {
+			if pergunta.IDPesquisa == id {
+				perguntas = append(perguntas, pergunta)
+			}
+		}
+	}
+	return perguntas, nil
+}
+
+type fakeDashboardRepo struct {
+	repository.DashboardRepository
+	dados *fakeDados
+}
+
+func (r *fakeDashboardRepo) GetByID(ctx context.Context, id int) (*entity.Dashboard, error) {
+	if dashboard, ok := r.dados.dashboards[id]; ok {
+		return dashboard, nil
+	}
+	return nil, fmt.Errorf("dashboard com ID %d não encontrado", id)
+}
+
+func (r *fakeDashboardRepo) GetByPesquisaID(ctx context.Context, pesquisaID int) (*entity.Dashboard, error) {
+	for _, dashboard := range r.dados.dashboards {
+		if dashboard.IDPesquisa == pesquisaID {
+			return dashboard, nil
+		}
+	}
+	return nil, fmt.Errorf("dashboard para pesquisa ID %d não encontrado", pesquisaID)
+}
+
+func (r *fakeDashboardRepo) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Dashboard, error) {
+	var dashboards []*entity.Dashboard
+	for _, dashboard := range r.dados.dashboards {
+		if pesquisa, ok := r.dados.pesquisas[dashboard.IDPesquisa]; ok && pesquisa.IDEmpresa == empresaID {
+			dashboards = append(dashboards, dashboard)
+		}
+	}
+	return dashboards, nil
+}
+
+type fakeDimensaoRepo struct {
+	repository.DimensaoRepository
+	dados *fakeDados
+}
+
+func (r *fakeDimensaoRepo) GetByID(ctx context.Context, id int) (*entity.Dimensao, error) {
+	if dimensao, ok := r.dados.dimensoes[id]; ok {
+		return dimensao, nil
+	}
+	return nil, fmt.Errorf("dimensão com ID %d não encontrada", id)
+}
+
+func (r *fakeDimensaoRepo) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Dimensao, error) {
+	var dimensoes []*entity.Dimensao
+	for _, dimensao := range r.dados.dimensoes {
+		if dimensao.IDEmpresa == empresaID {
+			dimensoes = append(dimensoes, dimensao)
+		}
+	}
+	return dimensoes, nil
+}
+
+type fakeModeloRepo struct {
+	repository.ModeloPesquisaRepository
+	dados *fakeDados
+}
+
+func (r *fakeModeloRepo) GetByID(ctx context.Context, id int) (*entity.ModeloPesquisa, error) {
+	if modelo, ok := r.dados.modelos[id]; ok {
+		return modelo, nil
+	}
+	return nil, fmt.Errorf("modelo de pesquisa com ID %d não encontrado", id)
+}
+
+func (r *fakeModeloRepo) ListDisponiveis(ctx context.Context, empresaID int) ([]*entity.ModeloPesquisa, error) {
+	var modelos []*entity.ModeloPesquisa
+	for _, modelo := range r.dados.modelos {
+		if modelo.IDEmpresa == nil || *modelo.IDEmpresa == empresaID {
+			modelos = append(modelos, modelo)
+		}
+	}
+	return modelos, nil
+}
+
+type fakeLogRepo struct {
+	repository.LogAuditoriaRepository
+	dados *fakeDados
+}
+
+// Create aceita os registros de auditoria das operações permitidas
+func (r *fakeLogRepo) Create(ctx context.Context, log *entity.LogAuditoria) error {
+	return nil
+}
+
+func (r *fakeLogRepo) GetByID(ctx context.Context, id int) (*entity.LogAuditoria, error) {
+	if log, ok := r.dados.logs[id]; ok {
+		return log, nil
+	}
+	return nil, fmt.Errorf("log de auditoria com ID %d não encontrado", id)
+}
+
+func (r *fakeLogRepo) ListByEmpresa(ctx context.Context, empresaID int, limit, offset int) ([]*entity.LogAuditoria, error) {
+	var logs []*entity.LogAuditoria
+	for _, log := range r.dados.logs {
+		if usuario, ok := r.dados.usuarios[log.IDUserAdmin]; ok && usuario.IDEmpresa == empresaID {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+type fakeRespostaRepo struct {
+	repository.RespostaRepository
+}
+
+type fakeSubmissaoRepo struct {
+	repository.SubmissaoPesquisaRepository
+}
+
+type fakeAnalyticsRepo struct {
+	repository.AnalyticsRepository
+}
+
+type fakeAnaliseTextoRepo struct {
+	repository.AnaliseTextoRepository
+}
+
+type fakeCicloRepo struct {
+	repository.PesquisaCicloRepository
+}
+
+// GetByPesquisa trata todas as pesquisas como originais
+func (r *fakeCicloRepo) GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.CicloPesquisa, error) {
+	return nil, fmt.Errorf("ciclo da pesquisa ID %d não encontrado", pesquisaID)
+}
+
+type fakeCloneRepo struct {
+	repository.PesquisaCloneRepository
+}
+
+// GetByPesquisa trata todas as pesquisas como não clonadas
+func (r *fakeCloneRepo) GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.ClonePesquisa, error) {
+	return nil, fmt.Errorf("origem da pesquisa ID %d não encontrada", pesquisaID)
+}
+
+// novosFakeDados cria as empresas A (ID 1) e B (ID 2), cada uma com um registro de cada tipo
+// Os IDs da empresa B são os da empresa A multiplicados por 2
+func novosFakeDados() *fakeDados {
+	dados := &fakeDados{
+		empresas:   make(map[int]*entity.Empresa),
+		usuarios:   make(map[int]*entity.UsuarioAdministrador),
+		setores:    make(map[int]*entity.Setor),
+		pesquisas:  make(map[int]*entity.Pesquisa),
+		perguntas:  make(map[int]*entity.Pergunta),
+		dashboards: make(map[int]*entity.Dashboard),
+		dimensoes:  make(map[int]*entity.Dimensao),
+		modelos:    make(map[int]*entity.ModeloPesquisa),
+		logs:       make(map[int]*entity.LogAuditoria),
+	}
+
+	agora := time.Now()
+	cnpjs := map[int]string{1: "11444777000161", 2: "11222333000181"}
+	for _, empresaID := range []int{1, 2} {
+		id := empresaID
+		dados.empresas[id] = &entity.Empresa{ID: id, NomeFantasia: fmt.Sprintf("Empresa %d", id), CNPJ: cnpjs[id], DataCadastro: agora, MinRespondentes: 3}
+		dados.usuarios[id] = &entity.UsuarioAdministrador{ID: id, IDEmpresa: id, NomeAdmin: fmt.Sprintf("Admin %d", id), Email: fmt.Sprintf("admin%d@empresa.com", id), Status: "Ativo", Papel: entity.PapelProprietario, DataCadastro: agora}
+		dados.setores[10*id] = &entity.Setor{ID: 10 * id, IDEmpresa: id, NomeSetor: fmt.Sprintf("Setor %d", id)}
+		dados.pesquisas[100*id] = &entity.Pesquisa{ID: 100 * id, IDEmpresa: id, IDUserAdmin: id, IDSetor: 10 * id, Titulo: fmt.Sprintf("Pesquisa %d", id), Status: "Rascunho", LinkAcesso: fmt.Sprintf("link-%d", id), DataCriacao: agora}
+		dados.perguntas[1000*id] = &entity.Pergunta{ID: 1000 * id, IDPesquisa: 100 * id, TextoPergunta: "Como você avalia o clima?", TipoPergunta: "EscalaNumerica", OrdemExibicao: 1}
+		dados.dashboards[500*id] = &entity.Dashboard{ID: 500 * id, IDPesquisa: 100 * id, Titulo: fmt.Sprintf("Dashboard %d", id), DataCriacao: agora}
+		dados.dimensoes[30*id] = &entity.Dimensao{ID: 30 * id, IDEmpresa: id, Nome: "Liderança", DataCriacao: agora}
+		dados.modelos[70*id] = &entity.ModeloPesquisa{ID: 70 * id, IDEmpresa: &id, Nome: fmt.Sprintf("Modelo %d", id), Versao: 1, Ativo: true, DataCriacao: agora, DataAtualizacao: agora}
+		dados.logs[80*id] = &entity.LogAuditoria{ID: 80 * id, IDUserAdmin: id, TimeStamp: agora, AcaoRealizada: "Login"}
+	}
+
+	return dados
+}
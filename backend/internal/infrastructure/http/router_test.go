@@ -0,0 +1,316 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/internal/application/middleware"
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/crypto"
+	"organizational-climate-survey/backend/pkg/qrcode"
+	"organizational-climate-survey/backend/pkg/storage"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+const testJWTSecret = "segredo-de-teste"
+
+// novoRouterTeste monta o router com os casos de uso ligados aos repositórios em memória
+func novoRouterTeste(t *testing.T, dados *fakeDados) *mux.Router {
+	empresaRepo := &fakeEmpresaRepo{dados: dados}
+	usuarioRepo := &fakeUsuarioRepo{dados: dados}
+	setorRepo := &fakeSetorRepo{dados: dados}
+	pesquisaRepo := &fakePesquisaRepo{dados: dados}
+	perguntaRepo := &fakePerguntaRepo{dados: dados}
+	dashboardRepo := &fakeDashboardRepo{dados: dados}
+	dimensaoRepo := &fakeDimensaoRepo{dados: dados}
+	modeloRepo := &fakeModeloRepo{dados: dados}
+	logRepo := &fakeLogRepo{dados: dados}
+	respostaRepo := &fakeRespostaRepo{}
+	submissaoRepo := &fakeSubmissaoRepo{}
+	cloneRepo := &fakeCloneRepo{}
+	cryptoSvc := crypto.NewDefaultCryptoService()
+	anonimato := usecase.NewPoliticaAnonimato(empresaRepo)
+
+	pesquisaUseCase := usecase.NewPesquisaUseCase(pesquisaRepo, empresaRepo, setorRepo, dashboardRepo, logRepo)
+	pesquisaUseCase.SetQRCode(storage.NewLocalStorage(t.TempDir()), usecase.QRCodeConfig{
+		PublicURL:    "https://pesquisas.exemplo.com/",
+		DefaultSize:  256,
+		DefaultLevel: qrcode.LevelM,
+	})
+	pesquisaUseCase.SetModelos(modeloRepo)
+	pesquisaUseCase.SetDimensoes(dimensaoRepo)
+	pesquisaUseCase.SetClonagem(perguntaRepo, cloneRepo)
+
+	perguntaUseCase := usecase.NewPerguntaUseCase(perguntaRepo, respostaRepo, pesquisaRepo, logRepo)
+	perguntaUseCase.SetAnonimato(anonimato)
+	perguntaUseCase.SetDimensoes(dimensaoRepo)
+
+	submissaoUseCase := usecase.NewSubmissaoPesquisaUseCase(submissaoRepo, pesquisaRepo, cryptoSvc, "salt")
+	submissaoUseCase.SetCompletude(perguntaRepo, respostaRepo)
+
+	respostaUseCase := usecase.NewRespostaUseCase(respostaRepo, perguntaRepo, pesquisaRepo, submissaoUseCase)
+	respostaUseCase.SetAnonimato(anonimato)
+
+	dashboardUseCase := usecase.NewDashboardUseCase(dashboardRepo, pesquisaRepo, perguntaRepo, respostaRepo, submissaoRepo, empresaRepo, logRepo)
+	dashboardUseCase.SetSegmentacao(setorRepo, &fakeCicloRepo{})
+	dashboardUseCase.SetDimensoes(dimensaoRepo)
+	dashboardUseCase.SetAnaliseTexto(&fakeAnaliseTextoRepo{})
+	dashboardUseCase.SetClonagem(cloneRepo)
+	dashboardUseCase.SetAnonimato(anonimato)
+
+	analyticsUseCase := usecase.NewAnalyticsUseCase(&fakeAnalyticsRepo{}, pesquisaRepo, logRepo)
+	analyticsUseCase.SetAnonimato(anonimato)
+	analyticsUseCase.SetClonagem(cloneRepo)
+
+	return SetupRouter(&RouterConfig{
+		EmpresaUseCase:              usecase.NewEmpresaUseCase(empresaRepo, logRepo),
+		UsuarioAdministradorUseCase: usecase.NewUsuarioAdministradorUseCase(usuarioRepo, empresaRepo, logRepo, cryptoSvc),
+		SetorUseCase:                usecase.NewSetorUseCase(setorRepo, empresaRepo, logRepo),
+		PesquisaUseCase:             pesquisaUseCase,
+		PerguntaUseCase:             perguntaUseCase,
+		ModeloPesquisaUseCase:       usecase.NewModeloPesquisaUseCase(modeloRepo, empresaRepo, logRepo),
+		DimensaoUseCase:             usecase.NewDimensaoUseCase(dimensaoRepo, empresaRepo, logRepo),
+		RespostaUseCase:             respostaUseCase,
+		SubmissaoUseCase:            submissaoUseCase,
+		DashboardUseCase:            dashboardUseCase,
+		AnalyticsUseCase:            analyticsUseCase,
+		LogAuditoriaUseCase:         usecase.NewLogAuditoriaUseCase(logRepo, usuarioRepo, empresaRepo),
+		FormularioPublicoUseCase:    usecase.NewFormularioPublicoUseCase(pesquisaRepo, perguntaRepo),
+		PesquisaRepo:                pesquisaRepo,
+		JWTSecret:                   testJWTSecret,
+		AccessTokenTTL:              time.Hour,
+	})
+}
+
+// tokenTeste emite um access token da empresa com todas as permissões configuradas nas rotas
+// Assim um 403 só pode vir da verificação de escopo, e não da falta de permissão
+func tokenTeste(t *testing.T, usuarioID, empresaID int) string {
+	t.Helper()
+
+	vistas := make(map[string]bool)
+	var permissoes []string
+	for _, permissao := range routePermissions {
+		if !vistas[permissao] {
+			vistas[permissao] = true
+			permissoes = append(permissoes, permissao)
+		}
+	}
+
+	claims := middleware.JWTClaims{
+		UserID:     usuarioID,
+		EmpresaID:  empresaID,
+		Email:      "admin@empresa.com",
+		Papel:      "proprietario",
+		Permissoes: permissoes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-teste",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("erro ao assinar token: %v", err)
+	}
+	return token
+}
+
+// rotaTeste é uma requisição de um administrador da empresa A a recursos da empresa B
+type rotaTeste struct {
+	metodo  string
+	caminho string
+	corpo   string
+}
+
+// rotasEntreEmpresas cobre as rotas autenticadas que recebem IDs, no caminho, na query ou no corpo
+var rotasEntreEmpresas = []rotaTeste{
+	// Empresas
+	{"GET", "/empresas/2", ""},
+	{"PUT", "/empresas/2", `{"nome_fantasia":"Outra"}`},
+	{"DELETE", "/empresas/2", ""},
+	{"PUT", "/empresas/2/politica-mfa", `{"mfa_obrigatorio":true}`},
+	{"PUT", "/empresas/2/politica-anonimato", `{"min_respondentes":5}`},
+	{"GET", "/empresas/cnpj/11222333000181", ""},
+
+	// Usuários administradores
+	{"POST", "/usuarios-administradores", `{"id_empresa":2,"nome_admin":"Intruso","email":"intruso@empresa.com","senha":"SenhaForte123!","papel":"analista","status":"Ativo"}`},
+	{"GET", "/usuarios-administradores/2", ""},
+	{"PUT", "/usuarios-administradores/2", `{"nome_admin":"Outro"}`},
+	{"DELETE", "/usuarios-administradores/2", ""},
+	{"PUT", "/usuarios-administradores/2/password", `{"senha_atual":"SenhaForte123!","nova_senha":"NovaSenha123!"}`},
+	{"PUT", "/usuarios-administradores/2/status", `{"status":"Inativo"}`},
+	{"PUT", "/usuarios-administradores/2/papel", `{"papel":"analista"}`},
+	{"DELETE", "/usuarios-administradores/2/bloqueio", ""},
+	{"GET", "/usuarios-administradores/email/admin2@empresa.com", ""},
+	{"GET", "/empresas/2/usuarios-administradores", ""},
+	{"GET", "/empresas/2/usuarios-administradores/bloqueios", ""},
+
+	// Setores
+	{"POST", "/setores", `{"id_empresa":2,"nome_setor":"Intruso"}`},
+	{"GET", "/setores/20", ""},
+	{"PUT", "/setores/20", `{"nome_setor":"Outro"}`},
+	{"DELETE", "/setores/20", ""},
+	{"GET", "/empresas/2/setores", ""},
+	{"GET", "/empresas/2/setores/nome/Setor%202", ""},
+
+	// Pesquisas
+	{"POST", "/pesquisas", `{"id_empresa":2,"id_user_admin":1,"id_setor":20,"titulo":"Intrusa","status":"Rascunho"}`},
+	{"POST", "/pesquisas", `{"id_empresa":1,"id_user_admin":1,"id_setor":20,"titulo":"Setor de outra empresa","status":"Rascunho"}`},
+	{"GET", "/pesquisas/200", ""},
+	{"PUT", "/pesquisas/200", `{"titulo":"Outra"}`},
+	{"DELETE", "/pesquisas/200", ""},
+	{"PUT", "/pesquisas/200/status", `{"status":"Ativa"}`},
+	{"POST", "/pesquisas/200/qrcode", `{}`},
+	{"GET", "/pesquisas/200/qrcode", ""},
+	{"POST", "/pesquisas/200/link-acesso", ""},
+	{"POST", "/pesquisas/200/clone", `{}`},
+	{"POST", "/pesquisas/100/clone", `{"id_setor":20}`},
+	{"GET", "/pesquisas/link/link-2", ""},
+	{"GET", "/empresas/2/pesquisas", ""},
+	{"GET", "/empresas/2/pesquisas/active", ""},
+	{"GET", "/setores/20/pesquisas", ""},
+
+	// Modelos de pesquisa
+	{"POST", "/modelos-pesquisa", `{"id_empresa":2,"nome":"Intruso","perguntas":[{"texto_pergunta":"Como você avalia?","tipo_pergunta":"RespostaAberta"}]}`},
+	{"GET", "/modelos-pesquisa/140", ""},
+	{"PUT", "/modelos-pesquisa/140", `{"nome":"Outro"}`},
+	{"DELETE", "/modelos-pesquisa/140", ""},
+	{"GET", "/modelos-pesquisa/140/versoes", ""},
+	{"POST", "/modelos-pesquisa/140/pesquisas", `{"id_empresa":1,"id_setor":10,"titulo":"A partir de outro"}`},
+	{"POST", "/modelos-pesquisa/70/pesquisas", `{"id_empresa":1,"id_setor":20,"titulo":"Setor de outra empresa"}`},
+	{"GET", "/empresas/2/modelos-pesquisa", ""},
+
+	// Dimensões do clima
+	{"POST", "/dimensoes", `{"id_empresa":2,"nome":"Intrusa"}`},
+	{"GET", "/dimensoes/60", ""},
+	{"PUT", "/dimensoes/60", `{"nome":"Outra"}`},
+	{"DELETE", "/dimensoes/60", ""},
+	{"GET", "/empresas/2/dimensoes", ""},
+
+	// Perguntas
+	{"POST", "/perguntas", `{"id_pesquisa":200,"texto_pergunta":"Pergunta intrusa","tipo_pergunta":"RespostaAberta","ordem_exibicao":2}`},
+	{"POST", "/perguntas/batch", `[{"id_pesquisa":200,"texto_pergunta":"Pergunta intrusa","tipo_pergunta":"RespostaAberta","ordem_exibicao":2}]`},
+	{"GET", "/perguntas/2000", ""},
+	{"PUT", "/perguntas/2000", `{"texto_pergunta":"Texto alterado"}`},
+	{"DELETE", "/perguntas/2000", ""},
+	{"PUT", "/perguntas/2000/ordem", `{"nova_ordem":2}`},
+	{"GET", "/pesquisas/200/perguntas", ""},
+	{"PUT", "/pesquisas/200/perguntas/reorder", `{"pergunta_ids":[2000]}`},
+	{"GET", "/pesquisas/200/perguntas/with-stats", ""},
+
+	// Dashboards
+	{"POST", "/dashboards", `{"id_pesquisa":200,"titulo":"Intruso"}`},
+	{"GET", "/dashboards/1000", ""},
+	{"PUT", "/dashboards/1000", `{"titulo":"Outro"}`},
+	{"DELETE", "/dashboards/1000", ""},
+	{"GET", "/dashboards/1000/data", ""},
+	{"POST", "/dashboards/1000/refresh", ""},
+	{"GET", "/dashboards/1000/export", ""},
+	{"GET", "/dashboards/1000/metrics", ""},
+	{"GET", "/dashboards/1000/analise-texto", ""},
+	{"GET", "/pesquisas/200/dashboard", ""},
+	{"GET", "/empresas/2/dashboards", ""},
+
+	// Analytics
+	{"GET", "/pesquisas/200/analytics", ""},
+	{"GET", "/analytics/comparison?pesquisas=200,100", ""},
+	{"GET", "/empresas/2/pesquisas/200/analytics/setores", ""},
+	{"GET", "/empresas/1/pesquisas/200/analytics/setores", ""},
+	{"GET", "/empresas/2/analytics/trends", ""},
+
+	// Respostas e submissões
+	{"GET", "/pesquisas/200/respostas/stats", ""},
+	{"GET", "/pesquisas/200/respostas/aggregated", ""},
+	{"GET", "/pesquisas/200/respostas/by-date?start_date=2026-01-01&end_date=2026-12-31", ""},
+	{"GET", "/pesquisas/200/respostas/count", ""},
+	{"DELETE", "/pesquisas/200/respostas", ""},
+	{"GET", "/perguntas/2000/respostas/aggregated", ""},
+	{"GET", "/perguntas/2000/respostas/count", ""},
+	{"GET", "/perguntas/2000/respostas/stats", ""},
+	{"GET", "/pesquisas/200/submissions/stats", ""},
+
+	// Logs de auditoria
+	{"GET", "/logs-auditoria/160", ""},
+	{"GET", "/empresas/2/logs-auditoria", ""},
+	{"GET", "/empresas/2/logs-auditoria/by-date?start_date=2026-01-01&end_date=2026-12-31", ""},
+	{"GET", "/empresas/2/logs-auditoria/by-action?acao=Login", ""},
+	{"GET", "/empresas/2/logs-auditoria/summary?start_date=2026-01-01&end_date=2026-12-31", ""},
+	{"GET", "/empresas/2/logs-auditoria/export?start_date=2026-01-01&end_date=2026-12-31", ""},
+	{"GET", "/usuarios-administradores/2/logs-auditoria", ""},
+}
+
+// novaRequisicao cria a requisição autenticada para a rota da API
+func novaRequisicao(rota rotaTeste, token string) *http.Request {
+	r := httptest.NewRequest(rota.metodo, "/api/v1"+rota.caminho, strings.NewReader(rota.corpo))
+	r.Header.Set("Authorization", "Bearer "+token)
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestRotasIsolamEmpresas(t *testing.T) {
+	router := novoRouterTeste(t, novosFakeDados())
+	token := tokenTeste(t, 1, 1)
+
+	cobertas := make(map[string]bool)
+	for _, rota := range rotasEntreEmpresas {
+		t.Run(rota.metodo+" "+rota.caminho, func(t *testing.T) {
+			r := novaRequisicao(rota, token)
+
+			var match mux.RouteMatch
+			if !router.Match(r, &match) || match.Route == nil {
+				t.Fatalf("rota não registrada")
+			}
+			tpl, _ := match.Route.GetPathTemplate()
+			cobertas[rota.metodo+" "+strings.TrimPrefix(tpl, "/api/v1")] = true
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("status %d, esperado 404: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// Toda rota com ID no caminho precisa de um caso acima
+	for rota := range routePermissions {
+		if strings.Contains(rota, "{") && !cobertas[rota] {
+			t.Errorf("rota %s sem caso de acesso entre empresas", rota)
+		}
+	}
+}
+
+func TestRotasPermitemPropriaEmpresa(t *testing.T) {
+	router := novoRouterTeste(t, novosFakeDados())
+	token := tokenTeste(t, 1, 1)
+
+	// Os mesmos recursos da empresa A são encontrados, mostrando que o 404 acima vem do escopo e não dos fakes
+	rotas := []rotaTeste{
+		{"GET", "/empresas/1", ""},
+		{"GET", "/usuarios-administradores/1", ""},
+		{"GET", "/setores/10", ""},
+		{"GET", "/empresas/1/setores", ""},
+		{"GET", "/pesquisas/100", ""},
+		{"GET", "/empresas/1/pesquisas", ""},
+		{"GET", "/perguntas/1000", ""},
+		{"GET", "/pesquisas/100/perguntas", ""},
+		{"GET", "/dashboards/500", ""},
+		{"GET", "/dimensoes/30", ""},
+		{"GET", "/modelos-pesquisa/70", ""},
+	}
+
+	for _, rota := range rotas {
+		t.Run(rota.metodo+" "+rota.caminho, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, novaRequisicao(rota, token))
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status %d, esperado 200: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Package postgres implementa o repositório de AnaliseTexto usando PostgreSQL.
+// Armazena a análise das respostas abertas (sentimento, palavras-chave e tópicos) de cada pergunta.
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// AnaliseTextoRepository implementa a interface repository.AnaliseTextoRepository
+type AnaliseTextoRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewAnaliseTextoRepository cria uma nova instância do repositório
+func NewAnaliseTextoRepository(db *DB) *AnaliseTextoRepository {
+	return &AnaliseTextoRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que AnaliseTextoRepository implementa a interface correta
+var _ repository.AnaliseTextoRepository = (*AnaliseTextoRepository)(nil)
+
+// ListPendingPesquisas lista pesquisas encerradas com alguma pergunta aberta sem análise
+// As mais antigas primeiro, para que pesquisas arquivadas antes da análise existir também sejam processadas;
+// pesquisas com falhas aguardam a próxima tentativa e vêm depois das demais, para não ocupar a fila
+func (r *AnaliseTextoRepository) ListPendingPesquisas(ctx context.Context, limit int, now time.Time) ([]int, error) {
+	query := `
+        SELECT p.id_pesquisa
+        FROM pesquisa p
+        LEFT JOIN analise_texto_falha f ON f.id_pesquisa = p.id_pesquisa
+        WHERE p.status IN ('Concluída', 'Arquivada')
+        AND (f.proxima_tentativa IS NULL OR f.proxima_tentativa <= $2)
+        AND EXISTS (
+            SELECT 1 FROM pergunta q
+            WHERE q.id_pesquisa = p.id_pesquisa
+            AND q.tipo_pergunta = 'RespostaAberta'
+            AND NOT EXISTS (SELECT 1 FROM analise_texto a WHERE a.id_pergunta = q.id_pergunta)
+        )
+        ORDER BY COALESCE(f.tentativas, 0), p.data_fechamento NULLS LAST, p.id_pesquisa
+        LIMIT $1
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, limit, now)
+	if err != nil {
+		r.logger.Error("erro ao listar pesquisas com análise de texto pendente: %v", err)
+		return nil, fmt.Errorf("erro ao listar pesquisas pendentes: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.Error("erro ao escanear pesquisa pendente: %v", err)
+			return nil, fmt.Errorf("erro ao escanear pesquisa pendente: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar pesquisas pendentes: %v", err)
+		return nil, fmt.Errorf("erro ao iterar pesquisas pendentes: %v", err)
+	}
+
+	return ids, nil
+}
+
+// ReplaceByPesquisa remove as análises anteriores da pesquisa e grava as novas em uma única transação
+// Preenche ID e DataAnalise de cada análise
+func (r *AnaliseTextoRepository) ReplaceByPesquisa(ctx context.Context, pesquisaID int, analises []*entity.AnaliseTexto) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de análise de texto: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM analise_texto WHERE id_pesquisa = $1`, pesquisaID); err != nil {
+		r.logger.Error("erro ao remover análises de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return fmt.Errorf("erro ao remover análises anteriores: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM analise_texto_falha WHERE id_pesquisa = $1`, pesquisaID); err != nil {
+		r.logger.Error("erro ao remover falhas de análise de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return fmt.Errorf("erro ao remover falhas anteriores: %v", err)
+	}
+
+	for _, analise := range analises {
+		// Listas vazias são gravadas como "[]" em vez de "null"
+		if analise.PalavrasChave == nil {
+			analise.PalavrasChave = []entity.PalavraChave{}
+		}
+		if analise.Topicos == nil {
+			analise.Topicos = []entity.TopicoTexto{}
+		}
+
+		sentimento, err := json.Marshal(analise.Sentimento)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar sentimento: %v", err)
+		}
+		palavras, err := json.Marshal(analise.PalavrasChave)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar palavras-chave: %v", err)
+		}
+		topicos, err := json.Marshal(analise.Topicos)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar tópicos: %v", err)
+		}
+
+		analise.IDPesquisa = pesquisaID
+		err = tx.QueryRowContext(ctx, `
+            INSERT INTO analise_texto (id_pergunta, id_pesquisa, respostas, min_respondentes,
+                                       sentimento, palavras_chave, topicos, sem_topico)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            RETURNING id_analise, data_analise
+        `,
+			analise.IDPergunta,
+			analise.IDPesquisa,
+			analise.Respostas,
+			analise.MinRespondentes,
+			string(sentimento),
+			string(palavras),
+			string(topicos),
+			analise.SemTopico,
+		).Scan(&analise.ID, &analise.DataAnalise)
+		if err != nil {
+			r.logger.Error("erro ao gravar análise de texto pergunta ID=%d: %v", analise.IDPergunta, err)
+			return fmt.Errorf("erro ao gravar análise de texto: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao confirmar análises de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return fmt.Errorf("erro ao confirmar transação: %v", err)
+	}
+
+	return nil
+}
+
+// RegisterFailure incrementa de forma atômica as falhas consecutivas da análise da pesquisa
+func (r *AnaliseTextoRepository) RegisterFailure(ctx context.Context, pesquisaID int, erro string, now time.Time) (int, error) {
+	query := `
+        INSERT INTO analise_texto_falha AS f (id_pesquisa, tentativas, ultimo_erro, data_falha, proxima_tentativa)
+        VALUES ($1, 1, $2, $3, $3)
+        ON CONFLICT (id_pesquisa) DO UPDATE
+        SET tentativas = f.tentativas + 1,
+            ultimo_erro = EXCLUDED.ultimo_erro,
+            data_falha = EXCLUDED.data_falha
+        RETURNING tentativas
+    `
+
+	var tentativas int
+	if err := r.db.QueryRowContext(ctx, query, pesquisaID, erro, now).Scan(&tentativas); err != nil {
+		r.logger.Error("erro ao registrar falha de análise de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return 0, fmt.Errorf("erro ao registrar falha da análise: %v", err)
+	}
+
+	return tentativas, nil
+}
+
+// ScheduleRetry define quando a pesquisa volta à fila de análise
+func (r *AnaliseTextoRepository) ScheduleRetry(ctx context.Context, pesquisaID int, proximaTentativa time.Time) error {
+	query := `UPDATE analise_texto_falha SET proxima_tentativa = $2 WHERE id_pesquisa = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, pesquisaID, proximaTentativa); err != nil {
+		r.logger.Error("erro ao agendar nova análise de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return fmt.Errorf("erro ao agendar nova tentativa da análise: %v", err)
+	}
+
+	return nil
+}
+
+// ListByPesquisa lista as análises das perguntas abertas de uma pesquisa na ordem de exibição das perguntas
+func (r *AnaliseTextoRepository) ListByPesquisa(ctx context.Context, pesquisaID int) ([]*entity.AnaliseTexto, error) {
+	query := `
+        SELECT a.id_analise, a.id_pergunta, a.id_pesquisa, a.respostas, a.min_respondentes,
+               a.sentimento, a.palavras_chave, a.topicos, a.sem_topico, a.data_analise
+        FROM analise_texto a
+        JOIN pergunta q ON q.id_pergunta = a.id_pergunta
+        WHERE a.id_pesquisa = $1
+        ORDER BY q.ordem_exibicao, q.id_pergunta
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pesquisaID)
+	if err != nil {
+		r.logger.Error("erro ao listar análises de texto pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao listar análises de texto: %v", err)
+	}
+	defer rows.Close()
+
+	var analises []*entity.AnaliseTexto
+	for rows.Next() {
+		analise := &entity.AnaliseTexto{}
+		var sentimento, palavras, topicos []byte
+		err := rows.Scan(
+			&analise.ID,
+			&analise.IDPergunta,
+			&analise.IDPesquisa,
+			&analise.Respostas,
+			&analise.MinRespondentes,
+			&sentimento,
+			&palavras,
+			&topicos,
+			&analise.SemTopico,
+			&analise.DataAnalise,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear análise de texto: %v", err)
+			return nil, fmt.Errorf("erro ao escanear análise de texto: %v", err)
+		}
+
+		if err := json.Unmarshal(sentimento, &analise.Sentimento); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar sentimento da análise ID=%d: %v", analise.ID, err)
+		}
+		if err := json.Unmarshal(palavras, &analise.PalavrasChave); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar palavras-chave da análise ID=%d: %v", analise.ID, err)
+		}
+		if err := json.Unmarshal(topicos, &analise.Topicos); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar tópicos da análise ID=%d: %v", analise.ID, err)
+		}
+		analises = append(analises, analise)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar análises de texto: %v", err)
+		return nil, fmt.Errorf("erro ao iterar análises de texto: %v", err)
+	}
+
+	return analises, nil
+}
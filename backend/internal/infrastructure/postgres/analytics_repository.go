@@ -0,0 +1,465 @@
+// Package postgres implementa o repositório de Analytics usando PostgreSQL.
+// Fornece métricas agregadas e comparativas a partir das views de relatório.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"sort"
+	"time"
+)
+
+// AnalyticsRepository implementa a interface repository.AnalyticsRepository
+// As consultas se apoiam nas views vw_pesquisa_resumo, vw_respostas_por_pergunta e vw_satisfacao_media
+type AnalyticsRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewAnalyticsRepository cria uma nova instância do repositório
+func NewAnalyticsRepository(db *DB) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que AnalyticsRepository implementa a interface correta
+var _ repository.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// GetPesquisaMetrics retorna resumo de participação, média geral e métricas por pergunta de uma pesquisa
+func (r *AnalyticsRepository) GetPesquisaMetrics(ctx context.Context, pesquisaID int) (map[string]interface{}, error) {
+	metrics, err := r.getResumo(ctx, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	perguntas, err := r.getPerguntas(ctx, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics["perguntas"] = perguntas
+	return metrics, nil
+}
+
+// GetComparisonData compara resumo e médias por pergunta entre pesquisas
+// Pesquisas são ordenadas cronologicamente e cada uma traz a variação da média geral em relação à anterior
+// Perguntas com o mesmo texto em mais de uma pesquisa são agrupadas em "perguntas_comuns"
+func (r *AnalyticsRepository) GetComparisonData(ctx context.Context, pesquisaIDs []int) (map[string]interface{}, error) {
+	pesquisas := make([]map[string]interface{}, 0, len(pesquisaIDs))
+	mediasPorTexto := make(map[string]map[string]interface{})
+	ordemTextos := []string{}
+
+	for _, pesquisaID := range pesquisaIDs {
+		resumo, err := r.getResumo(ctx, pesquisaID)
+		if err != nil {
+			return nil, err
+		}
+
+		perguntas, err := r.getPerguntas(ctx, pesquisaID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pergunta := range perguntas {
+			media, ok := pergunta["media"].(float64)
+			if !ok {
+				continue
+			}
+			texto := pergunta["texto_pergunta"].(string)
+			if mediasPorTexto[texto] == nil {
+				mediasPorTexto[texto] = make(map[string]interface{})
+				ordemTextos = append(ordemTextos, texto)
+			}
+			mediasPorTexto[texto][fmt.Sprintf("%d", pesquisaID)] = media
+		}
+
+		pesquisas = append(pesquisas, resumo)
+	}
+
+	sort.SliceStable(pesquisas, func(i, j int) bool {
+		return pesquisas[i]["data_referencia"].(time.Time).Before(pesquisas[j]["data_referencia"].(time.Time))
+	})
+
+	// Variação da média geral em relação ao ciclo anterior
+	for i := range pesquisas {
+		pesquisas[i]["variacao_media_geral"] = nil
+		if i == 0 {
+			continue
+		}
+		atual, okAtual := pesquisas[i]["media_geral"].(float64)
+		anterior, okAnterior := pesquisas[i-1]["media_geral"].(float64)
+		if okAtual && okAnterior {
+			pesquisas[i]["variacao_media_geral"] = round2(atual - anterior)
+		}
+	}
+
+	perguntasComuns := make([]map[string]interface{}, 0)
+	for _, texto := range ordemTextos {
+		if len(mediasPorTexto[texto]) < 2 {
+			continue
+		}
+		perguntasComuns = append(perguntasComuns, map[string]interface{}{
+			"texto_pergunta": texto,
+			"medias":         mediasPorTexto[texto],
+		})
+	}
+
+	return map[string]interface{}{
+		"pesquisas":        pesquisas,
+		"perguntas_comuns": perguntasComuns,
+	}, nil
+}
+
+// GetSetorComparison compara a pesquisa de referência com a pesquisa concluída mais recente de cada setor da empresa
+// Setores sem pesquisa concluída são listados sem métricas
+func (r *AnalyticsRepository) GetSetorComparison(ctx context.Context, empresaID int, pesquisaID int) (map[string]interface{}, error) {
+	query := `
+        SELECT s.id_setor, s.nome_setor, p.id_pesquisa
+        FROM setor s
+        LEFT JOIN LATERAL (
+            SELECT pq.id_pesquisa
+            FROM pesquisa pq
+            WHERE pq.id_setor = s.id_setor
+              AND (pq.id_pesquisa = $2 OR pq.status = 'Concluída')
+            ORDER BY (pq.id_pesquisa = $2) DESC, COALESCE(pq.data_fechamento, pq.data_criacao) DESC
+            LIMIT 1
+        ) p ON true
+        WHERE s.id_empresa = $1
+        ORDER BY s.nome_setor
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, empresaID, pesquisaID)
+	if err != nil {
+		r.logger.Error("erro ao buscar setores para comparação empresa ID=%d: %v", empresaID, err)
+		return nil, fmt.Errorf("erro ao buscar setores para comparação: %v", err)
+	}
+
+	type setorPesquisa struct {
+		setorID    int
+		nomeSetor  string
+		pesquisaID sql.NullInt64
+	}
+
+	var setores []setorPesquisa
+	for rows.Next() {
+		var sp setorPesquisa
+		if err := rows.Scan(&sp.setorID, &sp.nomeSetor, &sp.pesquisaID); err != nil {
+			rows.Close()
+			r.logger.Error("erro ao escanear setor para comparação: %v", err)
+			return nil, fmt.Errorf("erro ao escanear setor: %v", err)
+		}
+		setores = append(setores, sp)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		r.logger.Error("erro ao iterar setores para comparação: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+	rows.Close()
+
+	referencia, err := r.getResumo(ctx, pesquisaID)
+	if err != nil {
+		return nil, err
+	}
+	mediaReferencia, temMediaReferencia := referencia["media_geral"].(float64)
+
+	resultado := make([]map[string]interface{}, 0, len(setores))
+	for _, sp := range setores {
+		item := map[string]interface{}{
+			"id_setor":        sp.setorID,
+			"nome_setor":      sp.nomeSetor,
+			"referencia":      sp.pesquisaID.Valid && int(sp.pesquisaID.Int64) == pesquisaID,
+			"pesquisa":        nil,
+			"diferenca_media": nil,
+		}
+
+		if sp.pesquisaID.Valid {
+			resumo, err := r.getResumo(ctx, int(sp.pesquisaID.Int64))
+			if err != nil {
+				return nil, err
+			}
+			item["pesquisa"] = resumo
+
+			if media, ok := resumo["media_geral"].(float64); ok && temMediaReferencia {
+				item["diferenca_media"] = round2(media - mediaReferencia)
+			}
+		}
+
+		resultado = append(resultado, item)
+	}
+
+	return map[string]interface{}{
+		"empresa_id":          empresaID,
+		"pesquisa_referencia": referencia,
+		"setores":             resultado,
+	}, nil
+}
+
+// getResumo consolida vw_pesquisa_resumo, submissões e vw_satisfacao_media de uma pesquisa
+func (r *AnalyticsRepository) getResumo(ctx context.Context, pesquisaID int) (map[string]interface{}, error) {
+	query := `
+        SELECT vr.id_pesquisa, vr.titulo, vr.status, p.id_setor,
+               COALESCE(p.data_abertura, p.data_criacao) AS data_referencia,
+               vr.total_respostas, vr.primeira_resposta, vr.ultima_resposta,
+               (SELECT COUNT(*) FROM submissao_pesquisa sp WHERE sp.id_pesquisa = vr.id_pesquisa) AS tokens_emitidos,
+               (SELECT COUNT(*) FROM submissao_pesquisa sp WHERE sp.id_pesquisa = vr.id_pesquisa AND sp.status = 'completa') AS submissoes_completas,
+               (SELECT ROUND(AVG(vs.media_resposta), 2) FROM vw_satisfacao_media vs WHERE vs.id_pesquisa = vr.id_pesquisa) AS media_geral,
+               (SELECT ROUND(AVG(vs.media_normalizada), 2) FROM vw_satisfacao_media vs WHERE vs.id_pesquisa = vr.id_pesquisa) AS media_geral_normalizada
+        FROM vw_pesquisa_resumo vr
+        INNER JOIN pesquisa p ON p.id_pesquisa = vr.id_pesquisa
+        WHERE vr.id_pesquisa = $1
+    `
+
+	var (
+		id, setorID, totalRespostas, tokens, completas int
+		titulo, status                                 string
+		dataReferencia                                 time.Time
+		primeira, ultima                               sql.NullTime
+		mediaGeral, mediaGeralNormalizada              sql.NullFloat64
+	)
+
+	err := r.db.QueryRowContext(ctx, query, pesquisaID).Scan(
+		&id, &titulo, &status, &setorID,
+		&dataReferencia,
+		&totalRespostas, &primeira, &ultima,
+		&tokens, &completas, &mediaGeral, &mediaGeralNormalizada,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("pesquisa com ID %d não encontrada", pesquisaID)
+		}
+		r.logger.Error("erro ao buscar resumo analítico pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao buscar resumo da pesquisa: %v", err)
+	}
+
+	taxaConclusao := 0.0
+	if tokens > 0 {
+		taxaConclusao = round2(float64(completas) / float64(tokens) * 100)
+	}
+
+	resumo := map[string]interface{}{
+		"pesquisa_id":          id,
+		"titulo":               titulo,
+		"status":               status,
+		"id_setor":             setorID,
+		"data_referencia":      dataReferencia,
+		"total_respostas":      totalRespostas,
+		"primeira_resposta":    nil,
+		"ultima_resposta":      nil,
+		"tokens_emitidos":      tokens,
+		"submissoes_completas": completas,
+		"taxa_conclusao":       taxaConclusao,
+		"media_geral":          nil,
+		// Média das perguntas de escala convertidas para 0-100, comparável entre escalas diferentes
+		"media_geral_normalizada": nil,
+	}
+	if primeira.Valid {
+		resumo["primeira_resposta"] = primeira.Time
+	}
+	if ultima.Valid {
+		resumo["ultima_resposta"] = ultima.Time
+	}
+	if mediaGeral.Valid {
+		resumo["media_geral"] = mediaGeral.Float64
+	}
+	if mediaGeralNormalizada.Valid {
+		resumo["media_geral_normalizada"] = mediaGeralNormalizada.Float64
+	}
+
+	return resumo, nil
+}
+
+// getPerguntas lista total de respostas por pergunta e média das perguntas de escala numérica
+func (r *AnalyticsRepository) getPerguntas(ctx context.Context, pesquisaID int) ([]map[string]interface{}, error) {
+	query := `
+        SELECT vp.id_pergunta, vp.texto_pergunta, vp.tipo_pergunta, vp.total_respostas,
+               vs.media_resposta, vs.media_normalizada, vs.escala_min, vs.escala_max
+        FROM vw_respostas_por_pergunta vp
+        INNER JOIN pergunta pe ON pe.id_pergunta = vp.id_pergunta
+        LEFT JOIN vw_satisfacao_media vs ON vs.id_pergunta = vp.id_pergunta
+        WHERE vp.id_pesquisa = $1
+        ORDER BY pe.ordem_exibicao
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pesquisaID)
+	if err != nil {
+		r.logger.Error("erro ao buscar métricas por pergunta pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao buscar métricas por pergunta: %v", err)
+	}
+	defer rows.Close()
+
+	perguntas := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		var (
+			id, total   int
+			texto, tipo string
+			media       sql.NullFloat64
+			normalizada sql.NullFloat64
+			escalaMin   sql.NullFloat64
+			escalaMax   sql.NullFloat64
+		)
+		if err := rows.Scan(&id, &texto, &tipo, &total, &media, &normalizada, &escalaMin, &escalaMax); err != nil {
+			r.logger.Error("erro ao escanear métricas por pergunta: %v", err)
+			return nil, fmt.Errorf("erro ao escanear pergunta: %v", err)
+		}
+
+		pergunta := map[string]interface{}{
+			"id_pergunta":       id,
+			"texto_pergunta":    texto,
+			"tipo_pergunta":     tipo,
+			"total_respostas":   total,
+			"media":             nil,
+			"media_normalizada": nil,
+			"escala":            nil,
+		}
+		if media.Valid {
+			pergunta["media"] = media.Float64
+			pergunta["media_normalizada"] = normalizada.Float64
+			pergunta["escala"] = map[string]float64{"min": escalaMin.Float64, "max": escalaMax.Float64}
+		}
+		perguntas = append(perguntas, pergunta)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar métricas por pergunta: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+
+	return perguntas, nil
+}
+
+// round2 arredonda para duas casas decimais
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// GetTrendData agrega tokens emitidos, submissões concluídas e média das escalas numéricas por período
+// A taxa de conclusão considera os tokens emitidos no período que já foram concluídos
+// A média traz os respondentes do próprio período de resposta (respondentes_escala), base da supressão por anonimato
+func (r *AnalyticsRepository) GetTrendData(ctx context.Context, empresaID int, since time.Time, granularidade string) ([]map[string]interface{}, error) {
+	switch granularidade {
+	case "day", "week", "month":
+	default:
+		return nil, fmt.Errorf("granularidade inválida: %s", granularidade)
+	}
+
+	query := `
+        WITH periodos AS (
+            SELECT generate_series(
+                date_trunc($2::text, $3::timestamp),
+                date_trunc($2::text, CURRENT_TIMESTAMP::timestamp),
+                ('1 ' || $2::text)::interval
+            ) AS inicio
+        ),
+        emitidos AS (
+            SELECT date_trunc($2::text, sp.data_criacao) AS inicio,
+                   COUNT(*) AS tokens_emitidos,
+                   COUNT(*) FILTER (WHERE sp.status = 'completa') AS tokens_concluidos
+            FROM submissao_pesquisa sp
+            INNER JOIN pesquisa p ON p.id_pesquisa = sp.id_pesquisa
+            WHERE p.id_empresa = $1 AND sp.data_criacao >= $3::timestamp
+            GROUP BY 1
+        ),
+        concluidos AS (
+            SELECT date_trunc($2::text, sp.data_conclusao) AS inicio,
+                   COUNT(*) AS submissoes
+            FROM submissao_pesquisa sp
+            INNER JOIN pesquisa p ON p.id_pesquisa = sp.id_pesquisa
+            WHERE p.id_empresa = $1 AND sp.status = 'completa' AND sp.data_conclusao >= $3::timestamp
+            GROUP BY 1
+        ),
+        escalas AS (
+            SELECT date_trunc($2::text, r.data_submissao) AS inicio,
+                   COUNT(*) AS respostas_escala,
+                   COUNT(DISTINCT v.id_submissao) AS respondentes_escala,
+                   ROUND(AVG(v.valor), 2) AS media_escala,
+                   ROUND(AVG((v.valor - v.escala_min) / (v.escala_max - v.escala_min) * 100), 2) AS media_normalizada
+            FROM (
+                SELECT r.data_submissao,
+                       r.id_submissao,
+                       CASE WHEN r.valor_resposta ~ '^-?[0-9]+(\.[0-9]+)?$' THEN CAST(r.valor_resposta AS NUMERIC) END AS valor,
+                       escala_limite(pe.opcoes_resposta, 'min', 1) AS escala_min,
+                       escala_limite(pe.opcoes_resposta, 'max', 10) AS escala_max
+                FROM resposta r
+                INNER JOIN pergunta pe ON pe.id_pergunta = r.id_pergunta
+                INNER JOIN pesquisa p ON p.id_pesquisa = pe.id_pesquisa
+                WHERE p.id_empresa = $1
+                  AND pe.tipo_pergunta = 'EscalaNumerica'
+                  AND r.data_submissao >= $3::timestamp
+            ) v
+            WHERE v.escala_max > v.escala_min
+              AND v.valor BETWEEN v.escala_min AND v.escala_max
+            GROUP BY 1
+        )
+        SELECT pr.inicio,
+               COALESCE(e.tokens_emitidos, 0),
+               COALESCE(e.tokens_concluidos, 0),
+               COALESCE(c.submissoes, 0),
+               COALESCE(es.respostas_escala, 0),
+               COALESCE(es.respondentes_escala, 0),
+               es.media_escala,
+               es.media_normalizada
+        FROM periodos pr
+        LEFT JOIN emitidos e ON e.inicio = pr.inicio
+        LEFT JOIN concluidos c ON c.inicio = pr.inicio
+        LEFT JOIN escalas es ON es.inicio = pr.inicio
+        ORDER BY pr.inicio
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, empresaID, granularidade, since)
+	if err != nil {
+		r.logger.Error("erro ao buscar tendências empresa ID=%d: %v", empresaID, err)
+		return nil, fmt.Errorf("erro ao buscar tendências: %v", err)
+	}
+	defer rows.Close()
+
+	series := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		var (
+			inicio                                       time.Time
+			tokensEmitidos, tokensConcluidos, submissoes int
+			respostasEscala, respondentesEscala          int
+			mediaEscala, mediaNormalizada                sql.NullFloat64
+		)
+		if err := rows.Scan(&inicio, &tokensEmitidos, &tokensConcluidos, &submissoes, &respostasEscala, &respondentesEscala, &mediaEscala, &mediaNormalizada); err != nil {
+			r.logger.Error("erro ao escanear tendência: %v", err)
+			return nil, fmt.Errorf("erro ao escanear tendência: %v", err)
+		}
+
+		taxaConclusao := 0.0
+		if tokensEmitidos > 0 {
+			taxaConclusao = round2(float64(tokensConcluidos) / float64(tokensEmitidos) * 100)
+		}
+
+		ponto := map[string]interface{}{
+			"periodo":             inicio,
+			"tokens_emitidos":     tokensEmitidos,
+			"submissoes":          submissoes,
+			"taxa_conclusao":      taxaConclusao,
+			"respostas_escala":    respostasEscala,
+			"respondentes_escala": respondentesEscala,
+			"media_escala":        nil,
+			"media_normalizada":   nil,
+		}
+		if mediaEscala.Valid {
+			ponto["media_escala"] = mediaEscala.Float64
+		}
+		if mediaNormalizada.Valid {
+			ponto["media_normalizada"] = mediaNormalizada.Float64
+		}
+		series = append(series, ponto)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar tendências: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+
+	return series, nil
+}
@@ -55,29 +55,51 @@ func (db *DB) Close() error {
 // Repositories agrupa todos os repositórios da aplicação
 // Facilita o acesso centralizado aos repositórios
 type Repositories struct {
-	Empresa              *EmpresaRepository
-	UsuarioAdministrador *UsuarioAdministradorRepository
-	Setor                *SetorRepository
-	Pesquisa             *PesquisaRepository
-	Pergunta             *PerguntaRepository
-	Resposta             *RespostaRepository
-	SubmissaoPesquisa    *SubmissaoPesquisaRepository // NOVO
-	Dashboard            *DashboardRepository
-	LogAuditoria         *LogAuditoriaRepository
+	Empresa               *EmpresaRepository
+	UsuarioAdministrador  *UsuarioAdministradorRepository
+	Setor                 *SetorRepository
+	Pesquisa              *PesquisaRepository
+	Pergunta              *PerguntaRepository
+	Resposta              *RespostaRepository
+	SubmissaoPesquisa     *SubmissaoPesquisaRepository // NOVO
+	Dashboard             *DashboardRepository
+	LogAuditoria          *LogAuditoriaRepository
+	Analytics             *AnalyticsRepository
+	PesquisaCiclo         *PesquisaCicloRepository
+	TokenRedefinicaoSenha *TokenRedefinicaoSenhaRepository
+	Sessao                *SessaoRepository
+	TokenRevogado         *TokenRevogadoRepository
+	MFA                   *MFARepository
+	TentativaLogin        *TentativaLoginRepository
+	ModeloPesquisa        *ModeloPesquisaRepository
+	PesquisaClone         *PesquisaCloneRepository
+	Dimensao              *DimensaoRepository
+	AnaliseTexto          *AnaliseTextoRepository
 }
 
 // NewRepositories inicializa todos os repositórios com a conexão fornecida
 // Retorna uma estrutura com todos os repositórios prontos para uso
 func NewRepositories(db *DB) *Repositories {
 	return &Repositories{
-		Empresa:              NewEmpresaRepository(db),
-		UsuarioAdministrador: NewUsuarioAdministradorRepository(db),
-		Setor:                NewSetorRepository(db),
-		Pesquisa:             NewPesquisaRepository(db),
-		Pergunta:             NewPerguntaRepository(db),
-		Resposta:             NewRespostaRepository(db),
-		SubmissaoPesquisa:    NewSubmissaoPesquisaRepository(db), // NOVO
-		Dashboard:            NewDashboardRepository(db),
-		LogAuditoria:         NewLogAuditoriaRepository(db),
+		Empresa:               NewEmpresaRepository(db),
+		UsuarioAdministrador:  NewUsuarioAdministradorRepository(db),
+		Setor:                 NewSetorRepository(db),
+		Pesquisa:              NewPesquisaRepository(db),
+		Pergunta:              NewPerguntaRepository(db),
+		Resposta:              NewRespostaRepository(db),
+		SubmissaoPesquisa:     NewSubmissaoPesquisaRepository(db), // NOVO
+		Dashboard:             NewDashboardRepository(db),
+		LogAuditoria:          NewLogAuditoriaRepository(db),
+		Analytics:             NewAnalyticsRepository(db),
+		PesquisaCiclo:         NewPesquisaCicloRepository(db),
+		TokenRedefinicaoSenha: NewTokenRedefinicaoSenhaRepository(db),
+		Sessao:                NewSessaoRepository(db),
+		TokenRevogado:         NewTokenRevogadoRepository(db),
+		MFA:                   NewMFARepository(db),
+		TentativaLogin:        NewTentativaLoginRepository(db),
+		ModeloPesquisa:        NewModeloPesquisaRepository(db),
+		PesquisaClone:         NewPesquisaCloneRepository(db),
+		Dimensao:              NewDimensaoRepository(db),
+		AnaliseTexto:          NewAnaliseTextoRepository(db),
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,207 @@
+// Package postgres implementa o repositório de Dimensao usando PostgreSQL.
+// Fornece operações CRUD para as dimensões do clima definidas pelas empresas.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// DimensaoRepository implementa a interface repository.DimensaoRepository
+type DimensaoRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewDimensaoRepository cria uma nova instância do repositório
+func NewDimensaoRepository(db *DB) *DimensaoRepository {
+	return &DimensaoRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que DimensaoRepository implementa a interface correta
+var _ repository.DimensaoRepository = (*DimensaoRepository)(nil)
+
+// Create insere uma nova dimensão no banco de dados
+// Retorna o ID e a data de criação gerados através do RETURNING
+func (r *DimensaoRepository) Create(ctx context.Context, dimensao *entity.Dimensao) error {
+	query := `
+        INSERT INTO dimensao (id_empresa, nome, descricao)
+        VALUES ($1, $2, $3)
+        RETURNING id_dimensao, data_criacao
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		dimensao.IDEmpresa,
+		dimensao.Nome,
+		dimensao.Descricao,
+	).Scan(&dimensao.ID, &dimensao.DataCriacao)
+
+	if err != nil {
+		r.logger.Error("erro ao criar dimensão nome=%s: %v", dimensao.Nome, err)
+		return fmt.Errorf("erro ao criar dimensão: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID busca uma dimensão pelo seu ID
+// Retorna erro específico quando não encontrada
+func (r *DimensaoRepository) GetByID(ctx context.Context, id int) (*entity.Dimensao, error) {
+	dimensao := &entity.Dimensao{}
+	query := `
+        SELECT id_dimensao, id_empresa, nome, descricao, data_criacao
+        FROM dimensao
+        WHERE id_dimensao = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&dimensao.ID,
+		&dimensao.IDEmpresa,
+		&dimensao.Nome,
+		&dimensao.Descricao,
+		&dimensao.DataCriacao,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("dimensão com ID %d não encontrada", id)
+		}
+		r.logger.Error("erro ao buscar dimensão ID=%d: %v", id, err)
+		return nil, fmt.Errorf("erro ao buscar dimensão: %v", err)
+	}
+
+	return dimensao, nil
+}
+
+// GetByNome busca uma dimensão pelo nome dentro de uma empresa específica
+// Retorna erro específico quando não encontrada
+func (r *DimensaoRepository) GetByNome(ctx context.Context, empresaID int, nome string) (*entity.Dimensao, error) {
+	dimensao := &entity.Dimensao{}
+	query := `
+        SELECT id_dimensao, id_empresa, nome, descricao, data_criacao
+        FROM dimensao
+        WHERE id_empresa = $1 AND nome = $2
+    `
+
+	err := r.db.QueryRowContext(ctx, query, empresaID, nome).Scan(
+		&dimensao.ID,
+		&dimensao.IDEmpresa,
+		&dimensao.Nome,
+		&dimensao.Descricao,
+		&dimensao.DataCriacao,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("dimensão %s não encontrada na empresa ID %d", nome, empresaID)
+		}
+		r.logger.Error("erro ao buscar dimensão nome=%s empresa ID=%d: %v", nome, empresaID, err)
+		return nil, fmt.Errorf("erro ao buscar dimensão: %v", err)
+	}
+
+	return dimensao, nil
+}
+
+// ListByEmpresa lista todas as dimensões de uma empresa
+// Ordenadas alfabeticamente pelo nome
+func (r *DimensaoRepository) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.Dimensao, error) {
+	query := `
+        SELECT id_dimensao, id_empresa, nome, descricao, data_criacao
+        FROM dimensao
+        WHERE id_empresa = $1
+        ORDER BY nome
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, empresaID)
+	if err != nil {
+		r.logger.Error("erro ao listar dimensões empresa ID=%d: %v", empresaID, err)
+		return nil, fmt.Errorf("erro ao listar dimensões: %v", err)
+	}
+	defer rows.Close()
+
+	var dimensoes []*entity.Dimensao
+
+	for rows.Next() {
+		dimensao := &entity.Dimensao{}
+		err := rows.Scan(
+			&dimensao.ID,
+			&dimensao.IDEmpresa,
+			&dimensao.Nome,
+			&dimensao.Descricao,
+			&dimensao.DataCriacao,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear dimensão: %v", err)
+			return nil, fmt.Errorf("erro ao escanear dimensão: %v", err)
+		}
+		dimensoes = append(dimensoes, dimensao)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar dimensões: %v", err)
+		return nil, fmt.Errorf("erro ao iterar dimensões: %v", err)
+	}
+
+	return dimensoes, nil
+}
+
+// Update atualiza o nome e a descrição de uma dimensão existente
+// Retorna erro se a dimensão não for encontrada
+func (r *DimensaoRepository) Update(ctx context.Context, dimensao *entity.Dimensao) error {
+	query := `
+        UPDATE dimensao
+        SET nome = $2, descricao = $3
+        WHERE id_dimensao = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query,
+		dimensao.ID,
+		dimensao.Nome,
+		dimensao.Descricao,
+	)
+
+	if err != nil {
+		r.logger.Error("erro ao atualizar dimensão ID=%d: %v", dimensao.ID, err)
+		return fmt.Errorf("erro ao atualizar dimensão: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("dimensão com ID %d não encontrada", dimensao.ID)
+	}
+
+	return nil
+}
+
+// Delete remove uma dimensão do banco de dados
+// As perguntas vinculadas são desvinculadas pela chave estrangeira (ON DELETE SET NULL)
+func (r *DimensaoRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM dimensao WHERE id_dimensao = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("erro ao deletar dimensão ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao deletar dimensão: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("dimensão com ID %d não encontrada", id)
+	}
+
+	return nil
+}
@@ -57,7 +57,7 @@ func (r *EmpresaRepository) Create(ctx context.Context, empresa *entity.Empresa)
 func (r *EmpresaRepository) GetByID(ctx context.Context, id int) (*entity.Empresa, error) {
 	empresa := &entity.Empresa{}
 	query := `
-        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro
+        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro, mfa_obrigatorio, min_respondentes
         FROM empresa
         WHERE id_empresa = $1
     `
@@ -68,6 +68,8 @@ func (r *EmpresaRepository) GetByID(ctx context.Context, id int) (*entity.Empres
 		&empresa.RazaoSocial,
 		&empresa.CNPJ,
 		&empresa.DataCadastro,
+		&empresa.MFAObrigatorio,
+		&empresa.MinRespondentes,
 	)
 
 	if err != nil {
@@ -86,7 +88,7 @@ func (r *EmpresaRepository) GetByID(ctx context.Context, id int) (*entity.Empres
 func (r *EmpresaRepository) GetByCNPJ(ctx context.Context, cnpj string) (*entity.Empresa, error) {
 	empresa := &entity.Empresa{}
 	query := `
-        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro
+        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro, mfa_obrigatorio, min_respondentes
         FROM empresa
         WHERE cnpj = $1
     `
@@ -97,6 +99,8 @@ func (r *EmpresaRepository) GetByCNPJ(ctx context.Context, cnpj string) (*entity
 		&empresa.RazaoSocial,
 		&empresa.CNPJ,
 		&empresa.DataCadastro,
+		&empresa.MFAObrigatorio,
+		&empresa.MinRespondentes,
 	)
 
 	if err != nil {
@@ -114,7 +118,7 @@ func (r *EmpresaRepository) GetByCNPJ(ctx context.Context, cnpj string) (*entity
 // Ordena por data de cadastro decrescente
 func (r *EmpresaRepository) List(ctx context.Context, limit, offset int) ([]*entity.Empresa, error) {
 	query := `
-        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro
+        SELECT id_empresa, nome_fantasia, razao_social, cnpj, data_cadastro, mfa_obrigatorio, min_respondentes
         FROM empresa
         ORDER BY data_cadastro DESC
         LIMIT $1 OFFSET $2
@@ -137,6 +141,8 @@ func (r *EmpresaRepository) List(ctx context.Context, limit, offset int) ([]*ent
 			&empresa.RazaoSocial,
 			&empresa.CNPJ,
 			&empresa.DataCadastro,
+			&empresa.MFAObrigatorio,
+			&empresa.MinRespondentes,
 		)
 		if err != nil {
 			r.logger.Error("erro ao escanear empresa: %v", err)
@@ -186,6 +192,52 @@ func (r *EmpresaRepository) Update(ctx context.Context, empresa *entity.Empresa)
 	return nil
 }
 
+// UpdatePoliticaMFA define se o segundo fator é obrigatório para os administradores da empresa
+// Retorna erro se a empresa não for encontrada
+func (r *EmpresaRepository) UpdatePoliticaMFA(ctx context.Context, id int, obrigatorio bool) error {
+	query := `UPDATE empresa SET mfa_obrigatorio = $2 WHERE id_empresa = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, obrigatorio)
+	if err != nil {
+		r.logger.Error("erro ao atualizar política MFA empresa ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar política MFA: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("empresa com ID %d não encontrada para atualização", id)
+	}
+
+	return nil
+}
+
+// UpdateMinRespondentes define o menor grupo de respondentes exibido nos resultados da empresa
+// Retorna erro se a empresa não for encontrada
+func (r *EmpresaRepository) UpdateMinRespondentes(ctx context.Context, id int, minimo int) error {
+	query := `UPDATE empresa SET min_respondentes = $2 WHERE id_empresa = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, minimo)
+	if err != nil {
+		r.logger.Error("erro ao atualizar mínimo de respondentes empresa ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar mínimo de respondentes: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("empresa com ID %d não encontrada para atualização", id)
+	}
+
+	return nil
+}
+
 // Delete remove uma empresa do banco de dados
 // Verifica dependências antes da deleção
 func (r *EmpresaRepository) Delete(ctx context.Context, id int) error {
@@ -29,10 +29,11 @@ func NewLogAuditoriaRepository(db *DB) *LogAuditoriaRepository {
 var _ repository.LogAuditoriaRepository = (*LogAuditoriaRepository)(nil)
 
 // Create registra um novo log de auditoria no banco de dados
+// IDUserAdmin igual a 0 indica log de sistema e é gravado como NULL
 func (r *LogAuditoriaRepository) Create(ctx context.Context, log *entity.LogAuditoria) error {
 	query := `
         INSERT INTO log_auditoria (id_user_admin, timestamp, acao_realizada, detalhes, endereco_ip)
-        VALUES ($1, $2, $3, $4, $5)
+        VALUES (NULLIF($1, 0), $2, $3, $4, $5)
         RETURNING id_log
     `
 
@@ -56,7 +57,7 @@ func (r *LogAuditoriaRepository) Create(ctx context.Context, log *entity.LogAudi
 func (r *LogAuditoriaRepository) GetByID(ctx context.Context, id int) (*entity.LogAuditoria, error) {
 	log := &entity.LogAuditoria{}
 	query := `
-        SELECT id_log, id_user_admin, timestamp, acao_realizada, detalhes, endereco_ip
+        SELECT id_log, COALESCE(id_user_admin, 0), timestamp, acao_realizada, detalhes, endereco_ip
         FROM log_auditoria
         WHERE id_log = $1
     `
@@ -84,7 +85,7 @@ func (r *LogAuditoriaRepository) GetByID(ctx context.Context, id int) (*entity.L
 // ListByEmpresa lista logs de auditoria de uma empresa com paginação
 func (r *LogAuditoriaRepository) ListByEmpresa(ctx context.Context, empresaID int, limit, offset int) ([]*entity.LogAuditoria, error) {
 	query := `
-        SELECT l.id_log, l.id_user_admin, l.timestamp, l.acao_realizada, l.detalhes, l.endereco_ip
+        SELECT l.id_log, COALESCE(l.id_user_admin, 0), l.timestamp, l.acao_realizada, l.detalhes, l.endereco_ip
         FROM log_auditoria l
         INNER JOIN usuario_administrador ua ON l.id_user_admin = ua.id_user_admin
         WHERE ua.id_empresa = $1
@@ -124,7 +125,7 @@ func (r *LogAuditoriaRepository) ListByEmpresa(ctx context.Context, empresaID in
 // ListByUsuarioAdmin lista logs de auditoria de um usuário específico
 func (r *LogAuditoriaRepository) ListByUsuarioAdmin(ctx context.Context, userAdminID int, limit, offset int) ([]*entity.LogAuditoria, error) {
 	query := `
-        SELECT id_log, id_user_admin, timestamp, acao_realizada, detalhes, endereco_ip
+        SELECT id_log, COALESCE(id_user_admin, 0), timestamp, acao_realizada, detalhes, endereco_ip
         FROM log_auditoria
         WHERE id_user_admin = $1
         ORDER BY timestamp DESC
@@ -163,7 +164,7 @@ func (r *LogAuditoriaRepository) ListByUsuarioAdmin(ctx context.Context, userAdm
 // ListByDateRange lista logs de auditoria dentro de um intervalo de datas
 func (r *LogAuditoriaRepository) ListByDateRange(ctx context.Context, empresaID int, startDate, endDate string) ([]*entity.LogAuditoria, error) {
 	query := `
-        SELECT l.id_log, l.id_user_admin, l.timestamp, l.acao_realizada, l.detalhes, l.endereco_ip
+        SELECT l.id_log, COALESCE(l.id_user_admin, 0), l.timestamp, l.acao_realizada, l.detalhes, l.endereco_ip
         FROM log_auditoria l
         INNER JOIN usuario_administrador ua ON l.id_user_admin = ua.id_user_admin
         WHERE ua.id_empresa = $1 
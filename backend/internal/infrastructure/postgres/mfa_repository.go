@@ -0,0 +1,298 @@
+// Package postgres implementa o repositório de segundo fator (TOTP) usando PostgreSQL.
+// Fornece cadastro, ativação, controle de reuso de códigos e códigos de recuperação.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// MFARepository implementa a interface repository.MFARepository
+type MFARepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewMFARepository cria uma nova instância do repositório de segundo fator
+func NewMFARepository(db *DB) *MFARepository {
+	return &MFARepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que MFARepository implementa a interface correta
+var _ repository.MFARepository = (*MFARepository)(nil)
+
+// GetByUsuario busca a configuração de segundo fator do usuário
+// Retorna erro específico quando o usuário não iniciou o cadastro
+func (r *MFARepository) GetByUsuario(ctx context.Context, userAdminID int) (*entity.MFAUsuario, error) {
+	mfa := &entity.MFAUsuario{}
+	query := `
+        SELECT id_user_admin, segredo, ativo, data_criacao, data_ativacao, ultimo_passo
+        FROM mfa_usuario
+        WHERE id_user_admin = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, userAdminID).Scan(
+		&mfa.IDUserAdmin,
+		&mfa.Segredo,
+		&mfa.Ativo,
+		&mfa.DataCriacao,
+		&mfa.DataAtivacao,
+		&mfa.UltimoPasso,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("configuração MFA não encontrada")
+		}
+		r.logger.Error("erro ao buscar MFA usuário ID=%d: %v", userAdminID, err)
+		return nil, fmt.Errorf("erro ao buscar configuração MFA: %v", err)
+	}
+
+	return mfa, nil
+}
+
+// Save grava um cadastro pendente, substituindo o anterior e removendo seus códigos de recuperação
+func (r *MFARepository) Save(ctx context.Context, mfa *entity.MFAUsuario) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação MFA: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Um segundo fator já ativo nunca é substituído por um cadastro pendente
+	result, err := tx.ExecContext(ctx, `
+        INSERT INTO mfa_usuario (id_user_admin, segredo, ativo, data_criacao, data_ativacao, ultimo_passo)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (id_user_admin) DO UPDATE
+        SET segredo = EXCLUDED.segredo, ativo = EXCLUDED.ativo, data_criacao = EXCLUDED.data_criacao,
+            data_ativacao = EXCLUDED.data_ativacao, ultimo_passo = EXCLUDED.ultimo_passo
+        WHERE mfa_usuario.ativo = false
+    `,
+		mfa.IDUserAdmin,
+		mfa.Segredo,
+		mfa.Ativo,
+		mfa.DataCriacao,
+		mfa.DataAtivacao,
+		mfa.UltimoPasso,
+	)
+	if err != nil {
+		r.logger.Error("erro ao salvar MFA usuário ID=%d: %v", mfa.IDUserAdmin, err)
+		return fmt.Errorf("erro ao salvar configuração MFA: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("segundo fator já está ativo")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM codigo_recuperacao_mfa WHERE id_user_admin = $1`, mfa.IDUserAdmin); err != nil {
+		r.logger.Error("erro ao remover códigos de recuperação usuário ID=%d: %v", mfa.IDUserAdmin, err)
+		return fmt.Errorf("erro ao remover códigos de recuperação: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit MFA: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// Activate confirma o cadastro pendente, registra o passo usado e grava os códigos de recuperação
+// Retorna erro se não houver cadastro pendente
+func (r *MFARepository) Activate(ctx context.Context, userAdminID int, step int64, activatedAt time.Time, codigoHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de ativação MFA: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE mfa_usuario SET ativo = TRUE, data_ativacao = $2, ultimo_passo = $3
+        WHERE id_user_admin = $1 AND ativo = FALSE
+    `, userAdminID, activatedAt, step)
+	if err != nil {
+		r.logger.Error("erro ao ativar MFA usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao ativar MFA: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("cadastro MFA pendente não encontrado")
+	}
+
+	if err := insertRecoveryCodes(ctx, tx, userAdminID, codigoHashes, activatedAt); err != nil {
+		r.logger.Error("erro ao gravar códigos de recuperação usuário ID=%d: %v", userAdminID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit ativação MFA: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// UseStep registra o passo TOTP aceito
+// A condição ultimo_passo < $2 garante que cada código seja aceito uma única vez, mesmo em requisições concorrentes
+func (r *MFARepository) UseStep(ctx context.Context, userAdminID int, step int64) error {
+	query := `UPDATE mfa_usuario SET ultimo_passo = $2 WHERE id_user_admin = $1 AND ultimo_passo < $2`
+
+	result, err := r.db.ExecContext(ctx, query, userAdminID, step)
+	if err != nil {
+		r.logger.Error("erro ao registrar passo TOTP usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao registrar código MFA: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("código MFA já utilizado")
+	}
+
+	return nil
+}
+
+// Delete remove a configuração de segundo fator e os códigos de recuperação do usuário
+func (r *MFARepository) Delete(ctx context.Context, userAdminID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de remoção MFA: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM codigo_recuperacao_mfa WHERE id_user_admin = $1`, userAdminID); err != nil {
+		r.logger.Error("erro ao remover códigos de recuperação usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao remover códigos de recuperação: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_usuario WHERE id_user_admin = $1`, userAdminID); err != nil {
+		r.logger.Error("erro ao remover MFA usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao remover configuração MFA: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit remoção MFA: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes substitui todos os códigos de recuperação do usuário em uma transação
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userAdminID int, codigoHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de códigos de recuperação: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM codigo_recuperacao_mfa WHERE id_user_admin = $1`, userAdminID); err != nil {
+		r.logger.Error("erro ao remover códigos de recuperação usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao remover códigos de recuperação: %v", err)
+	}
+
+	if err := insertRecoveryCodes(ctx, tx, userAdminID, codigoHashes, time.Now()); err != nil {
+		r.logger.Error("erro ao gravar códigos de recuperação usuário ID=%d: %v", userAdminID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit códigos de recuperação: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// ListUnusedRecoveryCodes lista os códigos de recuperação ainda não utilizados
+func (r *MFARepository) ListUnusedRecoveryCodes(ctx context.Context, userAdminID int) ([]*entity.CodigoRecuperacao, error) {
+	query := `
+        SELECT id_codigo, id_user_admin, codigo_hash, data_criacao, data_uso
+        FROM codigo_recuperacao_mfa
+        WHERE id_user_admin = $1 AND data_uso IS NULL
+        ORDER BY id_codigo
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userAdminID)
+	if err != nil {
+		r.logger.Error("erro ao listar códigos de recuperação usuário ID=%d: %v", userAdminID, err)
+		return nil, fmt.Errorf("erro ao listar códigos de recuperação: %v", err)
+	}
+	defer rows.Close()
+
+	var codigos []*entity.CodigoRecuperacao
+	for rows.Next() {
+		codigo := &entity.CodigoRecuperacao{}
+		if err := rows.Scan(
+			&codigo.ID,
+			&codigo.IDUserAdmin,
+			&codigo.CodigoHash,
+			&codigo.DataCriacao,
+			&codigo.DataUso,
+		); err != nil {
+			r.logger.Error("erro ao escanear código de recuperação: %v", err)
+			return nil, fmt.Errorf("erro ao escanear código de recuperação: %v", err)
+		}
+		codigos = append(codigos, codigo)
+	}
+
+	return codigos, rows.Err()
+}
+
+// MarkRecoveryCodeUsed marca o código de recuperação como utilizado
+// A condição data_uso IS NULL impede o uso concorrente do mesmo código
+func (r *MFARepository) MarkRecoveryCodeUsed(ctx context.Context, id int, usedAt time.Time) error {
+	query := `UPDATE codigo_recuperacao_mfa SET data_uso = $2 WHERE id_codigo = $1 AND data_uso IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, usedAt)
+	if err != nil {
+		r.logger.Error("erro ao marcar código de recuperação ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao marcar código de recuperação: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("código de recuperação já utilizado")
+	}
+
+	return nil
+}
+
+// insertRecoveryCodes grava os hashes dos códigos de recuperação dentro da transação informada
+func insertRecoveryCodes(ctx context.Context, tx *sql.Tx, userAdminID int, codigoHashes []string, createdAt time.Time) error {
+	for _, hash := range codigoHashes {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO codigo_recuperacao_mfa (id_user_admin, codigo_hash, data_criacao)
+            VALUES ($1, $2, $3)
+        `, userAdminID, hash, createdAt)
+		if err != nil {
+			return fmt.Errorf("erro ao gravar código de recuperação: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+// Package postgres implementa o repositório de modelos de pesquisa usando PostgreSQL.
+// Fornece o versionamento das perguntas dos modelos e a criação transacional de pesquisas a partir deles.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// ModeloPesquisaRepository implementa a interface repository.ModeloPesquisaRepository
+type ModeloPesquisaRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewModeloPesquisaRepository cria uma nova instância do repositório
+func NewModeloPesquisaRepository(db *DB) *ModeloPesquisaRepository {
+	return &ModeloPesquisaRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que ModeloPesquisaRepository implementa a interface correta
+var _ repository.ModeloPesquisaRepository = (*ModeloPesquisaRepository)(nil)
+
+// nullUserAdmin converte o ID do administrador para gravação (0 vira NULL)
+func nullUserAdmin(userAdminID int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(userAdminID), Valid: userAdminID > 0}
+}
+
+// Create insere o modelo e a sua versão 1 em uma única transação
+func (r *ModeloPesquisaRepository) Create(ctx context.Context, modelo *entity.ModeloPesquisa, userAdminID int) error {
+	perguntas, err := json.Marshal(modelo.Perguntas)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar perguntas do modelo: %v", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de modelo: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	modelo.Versao = 1
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO modelo_pesquisa (id_empresa, nome, descricao, versao_atual, ativo, data_criacao, data_atualizacao)
+        VALUES ($1, $2, $3, $4, TRUE, $5, $6)
+        RETURNING id_modelo
+    `,
+		modelo.IDEmpresa,
+		modelo.Nome,
+		modelo.Descricao,
+		modelo.Versao,
+		modelo.DataCriacao,
+		modelo.DataAtualizacao,
+	).Scan(&modelo.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar modelo nome=%s: %v", modelo.Nome, err)
+		return fmt.Errorf("erro ao criar modelo: %v", err)
+	}
+	modelo.Ativo = true
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO modelo_pesquisa_versao (id_modelo, versao, perguntas, id_user_admin, data_criacao)
+        VALUES ($1, $2, $3, $4, $5)
+    `, modelo.ID, modelo.Versao, string(perguntas), nullUserAdmin(userAdminID), modelo.DataCriacao)
+	if err != nil {
+		r.logger.Error("erro ao criar versão do modelo ID=%d: %v", modelo.ID, err)
+		return fmt.Errorf("erro ao criar versão do modelo: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit modelo: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID busca um modelo com as perguntas da versão atual
+// Retorna erro específico quando não encontrado
+func (r *ModeloPesquisaRepository) GetByID(ctx context.Context, id int) (*entity.ModeloPesquisa, error) {
+	modelo := &entity.ModeloPesquisa{}
+	var idEmpresa sql.NullInt64
+	var perguntas []byte
+	query := `
+        SELECT m.id_modelo, m.id_empresa, m.nome, m.descricao, m.versao_atual, m.ativo,
+               m.data_criacao, m.data_atualizacao, v.perguntas
+        FROM modelo_pesquisa m
+        JOIN modelo_pesquisa_versao v ON v.id_modelo = m.id_modelo AND v.versao = m.versao_atual
+        WHERE m.id_modelo = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&modelo.ID,
+		&idEmpresa,
+		&modelo.Nome,
+		&modelo.Descricao,
+		&modelo.Versao,
+		&modelo.Ativo,
+		&modelo.DataCriacao,
+		&modelo.DataAtualizacao,
+		&perguntas,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("modelo de pesquisa com ID %d não encontrado", id)
+		}
+		r.logger.Error("erro ao buscar modelo ID=%d: %v", id, err)
+		return nil, fmt.Errorf("erro ao buscar modelo: %v", err)
+	}
+
+	if idEmpresa.Valid {
+		empresaID := int(idEmpresa.Int64)
+		modelo.IDEmpresa = &empresaID
+	}
+	if err := json.Unmarshal(perguntas, &modelo.Perguntas); err != nil {
+		r.logger.Error("erro ao decodificar perguntas do modelo ID=%d: %v", id, err)
+		return nil, fmt.Errorf("erro ao decodificar perguntas do modelo: %v", err)
+	}
+
+	return modelo, nil
+}
+
+// GetVersao busca uma versão específica de um modelo com as suas perguntas
+func (r *ModeloPesquisaRepository) GetVersao(ctx context.Context, modeloID, versao int) (*entity.VersaoModelo, error) {
+	v := &entity.VersaoModelo{}
+	var idUserAdmin sql.NullInt64
+	var perguntas []byte
+	query := `
+        SELECT id_versao, id_modelo, versao, id_user_admin, data_criacao, perguntas
+        FROM modelo_pesquisa_versao
+        WHERE id_modelo = $1 AND versao = $2
+    `
+
+	err := r.db.QueryRowContext(ctx, query, modeloID, versao).Scan(
+		&v.ID,
+		&v.IDModelo,
+		&v.Versao,
+		&idUserAdmin,
+		&v.DataCriacao,
+		&perguntas,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("versão %d do modelo ID %d não encontrada", versao, modeloID)
+		}
+		r.logger.Error("erro ao buscar versão %d do modelo ID=%d: %v", versao, modeloID, err)
+		return nil, fmt.Errorf("erro ao buscar versão do modelo: %v", err)
+	}
+
+	if idUserAdmin.Valid {
+		userAdminID := int(idUserAdmin.Int64)
+		v.IDUserAdmin = &userAdminID
+	}
+	if err := json.Unmarshal(perguntas, &v.Perguntas); err != nil {
+		r.logger.Error("erro ao decodificar perguntas da versão %d do modelo ID=%d: %v", versao, modeloID, err)
+		return nil, fmt.Errorf("erro ao decodificar perguntas do modelo: %v", err)
+	}
+
+	return v, nil
+}
+
+// ListVersoes lista as versões de um modelo, da mais recente para a mais antiga, sem as perguntas
+func (r *ModeloPesquisaRepository) ListVersoes(ctx context.Context, modeloID int) ([]*entity.VersaoModelo, error) {
+	query := `
+        SELECT id_versao, id_modelo, versao, id_user_admin, data_criacao
+        FROM modelo_pesquisa_versao
+        WHERE id_modelo = $1
+        ORDER BY versao DESC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, modeloID)
+	if err != nil {
+		r.logger.Error("erro ao listar versões do modelo ID=%d: %v", modeloID, err)
+		return nil, fmt.Errorf("erro ao listar versões do modelo: %v", err)
+	}
+	defer rows.Close()
+
+	var versoes []*entity.VersaoModelo
+
+	for rows.Next() {
+		v := &entity.VersaoModelo{}
+		var idUserAdmin sql.NullInt64
+		if err := rows.Scan(&v.ID, &v.IDModelo, &v.Versao, &idUserAdmin, &v.DataCriacao); err != nil {
+			r.logger.Error("erro ao escanear versão do modelo: %v", err)
+			return nil, fmt.Errorf("erro ao escanear versão do modelo: %v", err)
+		}
+		if idUserAdmin.Valid {
+			userAdminID := int(idUserAdmin.Int64)
+			v.IDUserAdmin = &userAdminID
+		}
+		versoes = append(versoes, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar versões do modelo: %v", err)
+		return nil, fmt.Errorf("erro ao iterar versões do modelo: %v", err)
+	}
+
+	return versoes, nil
+}
+
+// ListDisponiveis lista os modelos ativos do sistema e da empresa, sem as perguntas
+// Modelos do sistema vêm primeiro, seguidos pelos da empresa em ordem alfabética
+func (r *ModeloPesquisaRepository) ListDisponiveis(ctx context.Context, empresaID int) ([]*entity.ModeloPesquisa, error) {
+	query := `
+        SELECT id_modelo, id_empresa, nome, descricao, versao_atual, ativo, data_criacao, data_atualizacao
+        FROM modelo_pesquisa
+        WHERE ativo AND (id_empresa IS NULL OR id_empresa = $1)
+        ORDER BY id_empresa NULLS FIRST, nome
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, empresaID)
+	if err != nil {
+		r.logger.Error("erro ao listar modelos da empresa ID=%d: %v", empresaID, err)
+		return nil, fmt.Errorf("erro ao listar modelos: %v", err)
+	}
+	defer rows.Close()
+
+	var modelos []*entity.ModeloPesquisa
+
+	for rows.Next() {
+		modelo := &entity.ModeloPesquisa{}
+		var idEmpresa sql.NullInt64
+		err := rows.Scan(
+			&modelo.ID,
+			&idEmpresa,
+			&modelo.Nome,
+			&modelo.Descricao,
+			&modelo.Versao,
+			&modelo.Ativo,
+			&modelo.DataCriacao,
+			&modelo.DataAtualizacao,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear modelo: %v", err)
+			return nil, fmt.Errorf("erro ao escanear modelo: %v", err)
+		}
+		if idEmpresa.Valid {
+			id := int(idEmpresa.Int64)
+			modelo.IDEmpresa = &id
+		}
+		modelos = append(modelos, modelo)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar modelos: %v", err)
+		return nil, fmt.Errorf("erro ao iterar modelos: %v", err)
+	}
+
+	return modelos, nil
+}
+
+// Update altera nome e descrição do modelo; com novaVersao, grava as perguntas como a próxima versão
+// O número da versão é incrementado no próprio UPDATE, de modo que alterações concorrentes geram versões distintas
+func (r *ModeloPesquisaRepository) Update(ctx context.Context, modelo *entity.ModeloPesquisa, novaVersao bool, userAdminID int) error {
+	var perguntas []byte
+	if novaVersao {
+		var err error
+		if perguntas, err = json.Marshal(modelo.Perguntas); err != nil {
+			return fmt.Errorf("erro ao serializar perguntas do modelo: %v", err)
+		}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de modelo: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	incremento := 0
+	if novaVersao {
+		incremento = 1
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        UPDATE modelo_pesquisa
+        SET nome = $2, descricao = $3, versao_atual = versao_atual + $4, data_atualizacao = $5
+        WHERE id_modelo = $1 AND ativo
+        RETURNING versao_atual
+    `, modelo.ID, modelo.Nome, modelo.Descricao, incremento, modelo.DataAtualizacao).Scan(&modelo.Versao)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("modelo de pesquisa com ID %d não encontrado", modelo.ID)
+		}
+		r.logger.Error("erro ao atualizar modelo ID=%d: %v", modelo.ID, err)
+		return fmt.Errorf("erro ao atualizar modelo: %v", err)
+	}
+
+	if novaVersao {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO modelo_pesquisa_versao (id_modelo, versao, perguntas, id_user_admin, data_criacao)
+            VALUES ($1, $2, $3, $4, $5)
+        `, modelo.ID, modelo.Versao, string(perguntas), nullUserAdmin(userAdminID), modelo.DataAtualizacao)
+		if err != nil {
+			r.logger.Error("erro ao criar versão %d do modelo ID=%d: %v", modelo.Versao, modelo.ID, err)
+			return fmt.Errorf("erro ao criar versão do modelo: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit modelo: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// Deactivate remove o modelo da biblioteca sem apagar as versões usadas por pesquisas existentes
+func (r *ModeloPesquisaRepository) Deactivate(ctx context.Context, id int) error {
+	query := `
+        UPDATE modelo_pesquisa
+        SET ativo = FALSE, data_atualizacao = $2
+        WHERE id_modelo = $1 AND ativo
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		r.logger.Error("erro ao desativar modelo ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao desativar modelo: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("modelo de pesquisa com ID %d não encontrado", id)
+	}
+
+	return nil
+}
+
+// CreatePesquisa insere as dimensões novas, a pesquisa, suas perguntas e o registro do modelo de origem em uma única transação
+// As perguntas vinculadas a uma dimensão nova apontam para o ID dela, preenchido aqui antes da inserção das perguntas
+func (r *ModeloPesquisaRepository) CreatePesquisa(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, dimensoes []*entity.Dimensao, modeloID, versao int) ([]*entity.Dimensao, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de pesquisa do modelo: %v", err)
+		return nil, fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	var criadas []*entity.Dimensao
+	for _, dimensao := range dimensoes {
+		criada, err := criarDimensaoTx(ctx, tx, dimensao)
+		if err != nil {
+			r.logger.Error("erro ao criar dimensão nome=%s do modelo ID=%d: %v", dimensao.Nome, modeloID, err)
+			return nil, fmt.Errorf("erro ao criar dimensão '%s': %v", dimensao.Nome, err)
+		}
+		if criada {
+			criadas = append(criadas, dimensao)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO pesquisa (id_empresa, id_user_admin, id_setor, titulo, descricao,
+                            data_criacao, data_abertura, data_fechamento, status,
+                            link_acesso, qrcode_path, config_recorrencia, anonimato)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULL, $11, $12)
+        RETURNING id_pesquisa
+    `,
+		pesquisa.IDEmpresa,
+		pesquisa.IDUserAdmin,
+		pesquisa.IDSetor,
+		pesquisa.Titulo,
+		pesquisa.Descricao,
+		pesquisa.DataCriacao,
+		pesquisa.DataAbertura,
+		pesquisa.DataFechamento,
+		pesquisa.Status,
+		pesquisa.LinkAcesso,
+		pesquisa.ConfigRecorrencia,
+		pesquisa.Anonimato,
+	).Scan(&pesquisa.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar pesquisa do modelo ID=%d: %v", modeloID, err)
+		return nil, fmt.Errorf("erro ao criar pesquisa: %v", err)
+	}
+
+	for _, pergunta := range perguntas {
+		pergunta.IDPesquisa = pesquisa.ID
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+                                  condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            RETURNING id_pergunta
+        `,
+			pergunta.IDPesquisa,
+			pergunta.TextoPergunta,
+			pergunta.TipoPergunta,
+			pergunta.OrdemExibicao,
+			pergunta.OpcoesResposta,
+			pergunta.CondicaoExibicao,
+			pergunta.Obrigatoria,
+			pergunta.IDDimensao,
+			pergunta.PontuacaoInvertida,
+		).Scan(&pergunta.ID)
+		if err != nil {
+			r.logger.Error("erro ao criar pergunta do modelo para pesquisa ID=%d: %v", pesquisa.ID, err)
+			return nil, fmt.Errorf("erro ao criar pergunta: %v", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO pesquisa_modelo (id_pesquisa, id_modelo, versao)
+        VALUES ($1, $2, $3)
+    `, pesquisa.ID, modeloID, versao)
+	if err != nil {
+		r.logger.Error("erro ao registrar modelo ID=%d da pesquisa ID=%d: %v", modeloID, pesquisa.ID, err)
+		return nil, fmt.Errorf("erro ao registrar modelo da pesquisa: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit pesquisa do modelo: %v", err)
+		return nil, fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return criadas, nil
+}
+
+// criarDimensaoTx insere a dimensão na transação; se o nome já existe na empresa, reaproveita a existente
+// Retorna false quando a dimensão foi reaproveitada
+func criarDimensaoTx(ctx context.Context, tx *sql.Tx, dimensao *entity.Dimensao) (bool, error) {
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO dimensao (id_empresa, nome, descricao)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (id_empresa, nome) DO NOTHING
+        RETURNING id_dimensao, data_criacao
+    `, dimensao.IDEmpresa, dimensao.Nome, dimensao.Descricao).Scan(&dimensao.ID, &dimensao.DataCriacao)
+	if err == nil {
+		return true, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, err
+	}
+
+	// Outra requisição criou a mesma dimensão (nome único por empresa)
+	err = tx.QueryRowContext(ctx, `
+        SELECT id_dimensao, descricao, data_criacao
+        FROM dimensao
+        WHERE id_empresa = $1 AND nome = $2
+    `, dimensao.IDEmpresa, dimensao.Nome).Scan(&dimensao.ID, &dimensao.Descricao, &dimensao.DataCriacao)
+	return false, err
+}
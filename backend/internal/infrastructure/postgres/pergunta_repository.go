@@ -9,6 +9,8 @@ import (
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
 	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/lib/pq"
 )
 
 // PerguntaRepository implementa a interface repository.PerguntaRepository
@@ -31,8 +33,9 @@ var _ repository.PerguntaRepository = (*PerguntaRepository)(nil)
 // Create insere uma nova pergunta no banco de dados
 func (r *PerguntaRepository) Create(ctx context.Context, pergunta *entity.Pergunta) error {
 	query := `
-        INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+                              condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id_pergunta
     `
 
@@ -42,6 +45,10 @@ func (r *PerguntaRepository) Create(ctx context.Context, pergunta *entity.Pergun
 		pergunta.TipoPergunta,
 		pergunta.OrdemExibicao,
 		pergunta.OpcoesResposta,
+		pergunta.CondicaoExibicao,
+		pergunta.Obrigatoria,
+		pergunta.IDDimensao,
+		pergunta.PontuacaoInvertida,
 	).Scan(&pergunta.ID)
 
 	if err != nil {
@@ -66,8 +73,9 @@ func (r *PerguntaRepository) CreateBatch(ctx context.Context, perguntas []*entit
 	defer tx.Rollback()
 
 	stmt, err := tx.PrepareContext(ctx, `
-        INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+                              condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id_pergunta
     `)
 	if err != nil {
@@ -83,6 +91,10 @@ func (r *PerguntaRepository) CreateBatch(ctx context.Context, perguntas []*entit
 			pergunta.TipoPergunta,
 			pergunta.OrdemExibicao,
 			pergunta.OpcoesResposta,
+			pergunta.CondicaoExibicao,
+			pergunta.Obrigatoria,
+			pergunta.IDDimensao,
+			pergunta.PontuacaoInvertida,
 		).Scan(&pergunta.ID)
 
 		if err != nil {
@@ -103,7 +115,8 @@ func (r *PerguntaRepository) CreateBatch(ctx context.Context, perguntas []*entit
 func (r *PerguntaRepository) GetByID(ctx context.Context, id int) (*entity.Pergunta, error) {
 	pergunta := &entity.Pergunta{}
 	query := `
-        SELECT id_pergunta, id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta
+        SELECT id_pergunta, id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+               condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida
         FROM pergunta
         WHERE id_pergunta = $1
     `
@@ -115,6 +128,10 @@ func (r *PerguntaRepository) GetByID(ctx context.Context, id int) (*entity.Pergu
 		&pergunta.TipoPergunta,
 		&pergunta.OrdemExibicao,
 		&pergunta.OpcoesResposta,
+		&pergunta.CondicaoExibicao,
+		&pergunta.Obrigatoria,
+		&pergunta.IDDimensao,
+		&pergunta.PontuacaoInvertida,
 	)
 
 	if err != nil {
@@ -132,7 +149,8 @@ func (r *PerguntaRepository) GetByID(ctx context.Context, id int) (*entity.Pergu
 // Ordenadas por ordem de exibição
 func (r *PerguntaRepository) GetByPesquisaID(ctx context.Context, pesquisaID int) ([]*entity.Pergunta, error) {
 	query := `
-        SELECT id_pergunta, id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta
+        SELECT id_pergunta, id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+               condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida
         FROM pergunta
         WHERE id_pesquisa = $1
         ORDER BY ordem_exibicao
@@ -156,6 +174,10 @@ func (r *PerguntaRepository) GetByPesquisaID(ctx context.Context, pesquisaID int
 			&pergunta.TipoPergunta,
 			&pergunta.OrdemExibicao,
 			&pergunta.OpcoesResposta,
+			&pergunta.CondicaoExibicao,
+			&pergunta.Obrigatoria,
+			&pergunta.IDDimensao,
+			&pergunta.PontuacaoInvertida,
 		)
 		if err != nil {
 			r.logger.Error("erro ao escanear pergunta: %v", err)
@@ -173,12 +195,65 @@ func (r *PerguntaRepository) ListByPesquisa(ctx context.Context, pesquisaID int)
 	return r.GetByPesquisaID(ctx, pesquisaID)
 }
 
+// ListByPesquisas lista as perguntas de várias pesquisas em uma única consulta
+// Ordenadas por pesquisa e ordem de exibição
+func (r *PerguntaRepository) ListByPesquisas(ctx context.Context, pesquisaIDs []int) ([]*entity.Pergunta, error) {
+	if len(pesquisaIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+        SELECT id_pergunta, id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+               condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida
+        FROM pergunta
+        WHERE id_pesquisa = ANY($1)
+        ORDER BY id_pesquisa, ordem_exibicao
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(pesquisaIDs))
+	if err != nil {
+		r.logger.Error("erro ao listar perguntas das pesquisas %v: %v", pesquisaIDs, err)
+		return nil, fmt.Errorf("erro ao listar perguntas: %v", err)
+	}
+	defer rows.Close()
+
+	var perguntas []*entity.Pergunta
+	for rows.Next() {
+		pergunta := &entity.Pergunta{}
+		err := rows.Scan(
+			&pergunta.ID,
+			&pergunta.IDPesquisa,
+			&pergunta.TextoPergunta,
+			&pergunta.TipoPergunta,
+			&pergunta.OrdemExibicao,
+			&pergunta.OpcoesResposta,
+			&pergunta.CondicaoExibicao,
+			&pergunta.Obrigatoria,
+			&pergunta.IDDimensao,
+			&pergunta.PontuacaoInvertida,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear pergunta: %v", err)
+			return nil, fmt.Errorf("erro ao escanear pergunta: %v", err)
+		}
+		perguntas = append(perguntas, pergunta)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar perguntas: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+
+	return perguntas, nil
+}
+
 // Update atualiza os dados de uma pergunta existente
 // Retorna erro se a pergunta não for encontrada
 func (r *PerguntaRepository) Update(ctx context.Context, pergunta *entity.Pergunta) error {
 	query := `
         UPDATE pergunta 
-        SET texto_pergunta = $2, tipo_pergunta = $3, ordem_exibicao = $4, opcoes_resposta = $5
+        SET texto_pergunta = $2, tipo_pergunta = $3, ordem_exibicao = $4, opcoes_resposta = $5,
+            condicao_exibicao = $6, obrigatoria = $7, id_dimensao = $8, pontuacao_invertida = $9
         WHERE id_pergunta = $1
     `
 
@@ -188,6 +263,10 @@ func (r *PerguntaRepository) Update(ctx context.Context, pergunta *entity.Pergun
 		pergunta.TipoPergunta,
 		pergunta.OrdemExibicao,
 		pergunta.OpcoesResposta,
+		pergunta.CondicaoExibicao,
+		pergunta.Obrigatoria,
+		pergunta.IDDimensao,
+		pergunta.PontuacaoInvertida,
 	)
 
 	if err != nil {
@@ -265,3 +344,36 @@ func (r *PerguntaRepository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// copiarPerguntas grava as perguntas na pesquisa informada dentro da transação
+// Retorna o mapa do ID original para o ID da cópia
+func copiarPerguntas(ctx context.Context, tx *sql.Tx, pesquisaID int, perguntas []*entity.Pergunta) (map[int]int, error) {
+	// Perguntas em ordem de exibição: as condições só referenciam perguntas anteriores, já copiadas
+	novosIDs := make(map[int]int, len(perguntas))
+	for _, pergunta := range entity.OrdenarPerguntas(perguntas) {
+		idAnterior := pergunta.ID
+		pergunta.IDPesquisa = pesquisaID
+		pergunta.CondicaoExibicao = entity.RemapearCondicaoExibicao(pergunta.CondicaoExibicao, novosIDs)
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO pergunta (id_pesquisa, texto_pergunta, tipo_pergunta, ordem_exibicao, opcoes_resposta,
+                                  condicao_exibicao, obrigatoria, id_dimensao, pontuacao_invertida)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            RETURNING id_pergunta
+        `,
+			pergunta.IDPesquisa,
+			pergunta.TextoPergunta,
+			pergunta.TipoPergunta,
+			pergunta.OrdemExibicao,
+			pergunta.OpcoesResposta,
+			pergunta.CondicaoExibicao,
+			pergunta.Obrigatoria,
+			pergunta.IDDimensao,
+			pergunta.PontuacaoInvertida,
+		).Scan(&pergunta.ID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao copiar pergunta: %v", err)
+		}
+		novosIDs[idAnterior] = pergunta.ID
+	}
+	return novosIDs, nil
+}
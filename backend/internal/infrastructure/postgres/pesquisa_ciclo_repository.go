@@ -0,0 +1,233 @@
+// Package postgres implementa o repositório de ciclos de pesquisa usando PostgreSQL.
+// Fornece a geração transacional de novos ciclos de pesquisas recorrentes.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// PesquisaCicloRepository implementa a interface repository.PesquisaCicloRepository
+type PesquisaCicloRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewPesquisaCicloRepository cria uma nova instância do repositório
+func NewPesquisaCicloRepository(db *DB) *PesquisaCicloRepository {
+	return &PesquisaCicloRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que PesquisaCicloRepository implementa a interface correta
+var _ repository.PesquisaCicloRepository = (*PesquisaCicloRepository)(nil)
+
+// GetByPesquisa busca o vínculo de ciclo de uma pesquisa gerada por recorrência
+// Retorna erro específico quando a pesquisa não foi gerada por recorrência
+func (r *PesquisaCicloRepository) GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.CicloPesquisa, error) {
+	ciclo := &entity.CicloPesquisa{}
+	query := `
+        SELECT id_ciclo, id_pesquisa, COALESCE(id_pesquisa_anterior, 0), COALESCE(id_pesquisa_origem, 0),
+               numero_ciclo, data_inicio_prevista, data_criacao
+        FROM pesquisa_ciclo
+        WHERE id_pesquisa = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, pesquisaID).Scan(
+		&ciclo.ID,
+		&ciclo.IDPesquisa,
+		&ciclo.IDPesquisaAnterior,
+		&ciclo.IDPesquisaOrigem,
+		&ciclo.NumeroCiclo,
+		&ciclo.DataInicioPrevista,
+		&ciclo.DataCriacao,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ciclo da pesquisa ID %d não encontrado", pesquisaID)
+		}
+		r.logger.Error("erro ao buscar ciclo pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao buscar ciclo da pesquisa: %v", err)
+	}
+
+	return ciclo, nil
+}
+
+// ListByOrigem lista os ciclos de uma série recorrente, sem incluir a pesquisa original (ciclo 1)
+func (r *PesquisaCicloRepository) ListByOrigem(ctx context.Context, origemID int) ([]*entity.CicloPesquisa, error) {
+	query := `
+        SELECT id_ciclo, id_pesquisa, COALESCE(id_pesquisa_anterior, 0), COALESCE(id_pesquisa_origem, 0),
+               numero_ciclo, data_inicio_prevista, data_criacao
+        FROM pesquisa_ciclo
+        WHERE id_pesquisa_origem = $1
+        ORDER BY numero_ciclo
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, origemID)
+	if err != nil {
+		r.logger.Error("erro ao listar ciclos da pesquisa origem ID=%d: %v", origemID, err)
+		return nil, fmt.Errorf("erro ao listar ciclos da pesquisa: %v", err)
+	}
+	defer rows.Close()
+
+	var ciclos []*entity.CicloPesquisa
+
+	for rows.Next() {
+		ciclo := &entity.CicloPesquisa{}
+		err := rows.Scan(
+			&ciclo.ID,
+			&ciclo.IDPesquisa,
+			&ciclo.IDPesquisaAnterior,
+			&ciclo.IDPesquisaOrigem,
+			&ciclo.NumeroCiclo,
+			&ciclo.DataInicioPrevista,
+			&ciclo.DataCriacao,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear ciclo: %v", err)
+			return nil, fmt.Errorf("erro ao escanear ciclo: %v", err)
+		}
+		ciclos = append(ciclos, ciclo)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar ciclos: %v", err)
+		return nil, fmt.Errorf("erro ao iterar ciclos: %v", err)
+	}
+
+	return ciclos, nil
+}
+
+// ListRecurringHeads lista a pesquisa mais recente de cada série recorrente
+// Considera apenas pesquisas já abertas (Ativa ou Concluída) que ainda não geraram sucessor
+func (r *PesquisaCicloRepository) ListRecurringHeads(ctx context.Context) ([]*entity.Pesquisa, error) {
+	query := `
+        SELECT p.id_pesquisa, p.id_empresa, p.id_user_admin, p.id_setor, p.titulo, p.descricao,
+               p.data_criacao, p.data_abertura, p.data_fechamento, p.status, p.link_acesso,
+               p.qrcode_path, p.config_recorrencia, p.anonimato
+        FROM pesquisa p
+        WHERE p.config_recorrencia IS NOT NULL AND TRIM(p.config_recorrencia) <> ''
+        AND p.status IN ('Ativa', 'Concluída')
+        AND NOT EXISTS (
+            SELECT 1 FROM pesquisa_ciclo c WHERE c.id_pesquisa_anterior = p.id_pesquisa
+        )
+        ORDER BY p.id_pesquisa
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.Error("erro ao listar pesquisas recorrentes: %v", err)
+		return nil, fmt.Errorf("erro ao listar pesquisas recorrentes: %v", err)
+	}
+	defer rows.Close()
+
+	var pesquisas []*entity.Pesquisa
+
+	for rows.Next() {
+		pesquisa := &entity.Pesquisa{}
+		var qrcodePath sql.NullString
+		err := rows.Scan(
+			&pesquisa.ID,
+			&pesquisa.IDEmpresa,
+			&pesquisa.IDUserAdmin,
+			&pesquisa.IDSetor,
+			&pesquisa.Titulo,
+			&pesquisa.Descricao,
+			&pesquisa.DataCriacao,
+			&pesquisa.DataAbertura,
+			&pesquisa.DataFechamento,
+			&pesquisa.Status,
+			&pesquisa.LinkAcesso,
+			&qrcodePath,
+			&pesquisa.ConfigRecorrencia,
+			&pesquisa.Anonimato,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear pesquisa recorrente: %v", err)
+			return nil, fmt.Errorf("erro ao escanear pesquisa: %v", err)
+		}
+		pesquisa.QRCodePath = qrcodePath.String
+		pesquisas = append(pesquisas, pesquisa)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar pesquisas recorrentes: %v", err)
+		return nil, fmt.Errorf("erro ao iterar pesquisas: %v", err)
+	}
+
+	return pesquisas, nil
+}
+
+// CreateCycle insere a pesquisa do novo ciclo, copia as perguntas e registra o vínculo em uma única transação
+// A restrição UNIQUE em id_pesquisa_anterior impede a geração duplicada do mesmo ciclo
+func (r *PesquisaCicloRepository) CreateCycle(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, ciclo *entity.CicloPesquisa) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de ciclo: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO pesquisa (id_empresa, id_user_admin, id_setor, titulo, descricao,
+                            data_criacao, data_abertura, data_fechamento, status,
+                            link_acesso, qrcode_path, config_recorrencia, anonimato)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULL, $11, $12)
+        RETURNING id_pesquisa
+    `,
+		pesquisa.IDEmpresa,
+		pesquisa.IDUserAdmin,
+		pesquisa.IDSetor,
+		pesquisa.Titulo,
+		pesquisa.Descricao,
+		pesquisa.DataCriacao,
+		pesquisa.DataAbertura,
+		pesquisa.DataFechamento,
+		pesquisa.Status,
+		pesquisa.LinkAcesso,
+		pesquisa.ConfigRecorrencia,
+		pesquisa.Anonimato,
+	).Scan(&pesquisa.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar pesquisa do ciclo anterior ID=%d: %v", ciclo.IDPesquisaAnterior, err)
+		return fmt.Errorf("erro ao criar pesquisa do ciclo: %v", err)
+	}
+
+	if _, err := copiarPerguntas(ctx, tx, pesquisa.ID, perguntas); err != nil {
+		r.logger.Error("erro ao copiar perguntas para pesquisa ID=%d: %v", pesquisa.ID, err)
+		return err
+	}
+
+	ciclo.IDPesquisa = pesquisa.ID
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO pesquisa_ciclo (id_pesquisa, id_pesquisa_anterior, id_pesquisa_origem,
+                                    numero_ciclo, data_inicio_prevista, data_criacao)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id_ciclo
+    `,
+		ciclo.IDPesquisa,
+		ciclo.IDPesquisaAnterior,
+		ciclo.IDPesquisaOrigem,
+		ciclo.NumeroCiclo,
+		ciclo.DataInicioPrevista,
+		ciclo.DataCriacao,
+	).Scan(&ciclo.ID)
+	if err != nil {
+		r.logger.Error("erro ao registrar ciclo da pesquisa anterior ID=%d: %v", ciclo.IDPesquisaAnterior, err)
+		return fmt.Errorf("erro ao registrar ciclo: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit ciclo pesquisa: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package postgres implementa o repositório de pesquisas clonadas usando PostgreSQL.
+// Fornece a clonagem transacional de pesquisas e o vínculo com a pesquisa de origem.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// PesquisaCloneRepository implementa a interface repository.PesquisaCloneRepository
+type PesquisaCloneRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewPesquisaCloneRepository cria uma nova instância do repositório
+func NewPesquisaCloneRepository(db *DB) *PesquisaCloneRepository {
+	return &PesquisaCloneRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que PesquisaCloneRepository implementa a interface correta
+var _ repository.PesquisaCloneRepository = (*PesquisaCloneRepository)(nil)
+
+// Create insere a pesquisa clonada, copia as perguntas e o dashboard e registra a origem em uma única transação
+func (r *PesquisaCloneRepository) Create(ctx context.Context, pesquisa *entity.Pesquisa, perguntas []*entity.Pergunta, dashboard *entity.Dashboard, clone *entity.ClonePesquisa) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de clonagem: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO pesquisa (id_empresa, id_user_admin, id_setor, titulo, descricao,
+                            data_criacao, data_abertura, data_fechamento, status,
+                            link_acesso, qrcode_path, config_recorrencia, anonimato)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULL, $11, $12)
+        RETURNING id_pesquisa
+    `,
+		pesquisa.IDEmpresa,
+		pesquisa.IDUserAdmin,
+		pesquisa.IDSetor,
+		pesquisa.Titulo,
+		pesquisa.Descricao,
+		pesquisa.DataCriacao,
+		pesquisa.DataAbertura,
+		pesquisa.DataFechamento,
+		pesquisa.Status,
+		pesquisa.LinkAcesso,
+		pesquisa.ConfigRecorrencia,
+		pesquisa.Anonimato,
+	).Scan(&pesquisa.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar clone da pesquisa ID=%d: %v", clone.IDPesquisaOrigem, err)
+		return fmt.Errorf("erro ao criar pesquisa clonada: %v", err)
+	}
+
+	if _, err := copiarPerguntas(ctx, tx, pesquisa.ID, perguntas); err != nil {
+		r.logger.Error("erro ao copiar perguntas para pesquisa ID=%d: %v", pesquisa.ID, err)
+		return err
+	}
+
+	dashboard.IDPesquisa = pesquisa.ID
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO dashboard (id_pesquisa, titulo, data_criacao, config_filtros)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id_dashboard
+    `,
+		dashboard.IDPesquisa,
+		dashboard.Titulo,
+		dashboard.DataCriacao,
+		dashboard.ConfigFiltros,
+	).Scan(&dashboard.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar dashboard da pesquisa clonada ID=%d: %v", pesquisa.ID, err)
+		return fmt.Errorf("erro ao criar dashboard: %v", err)
+	}
+
+	clone.IDPesquisa = pesquisa.ID
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO pesquisa_clone (id_pesquisa, id_pesquisa_origem, data_criacao)
+        VALUES ($1, $2, $3)
+    `, clone.IDPesquisa, clone.IDPesquisaOrigem, clone.DataCriacao)
+	if err != nil {
+		r.logger.Error("erro ao registrar origem da pesquisa clonada ID=%d: %v", pesquisa.ID, err)
+		return fmt.Errorf("erro ao registrar origem da pesquisa: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit clonagem de pesquisa: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// GetByPesquisa busca a origem de uma pesquisa criada por clonagem
+// Retorna erro específico quando a pesquisa não foi clonada
+func (r *PesquisaCloneRepository) GetByPesquisa(ctx context.Context, pesquisaID int) (*entity.ClonePesquisa, error) {
+	clone := &entity.ClonePesquisa{}
+	query := `
+        SELECT id_pesquisa, COALESCE(id_pesquisa_origem, 0), data_criacao
+        FROM pesquisa_clone
+        WHERE id_pesquisa = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, pesquisaID).Scan(
+		&clone.IDPesquisa,
+		&clone.IDPesquisaOrigem,
+		&clone.DataCriacao,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("origem da pesquisa ID %d não encontrada", pesquisaID)
+		}
+		r.logger.Error("erro ao buscar origem da pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao buscar origem da pesquisa: %v", err)
+	}
+
+	return clone, nil
+}
+
+// ListByOrigem lista as pesquisas clonadas a partir da pesquisa informada
+func (r *PesquisaCloneRepository) ListByOrigem(ctx context.Context, origemID int) ([]*entity.ClonePesquisa, error) {
+	query := `
+        SELECT id_pesquisa, COALESCE(id_pesquisa_origem, 0), data_criacao
+        FROM pesquisa_clone
+        WHERE id_pesquisa_origem = $1
+        ORDER BY data_criacao, id_pesquisa
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, origemID)
+	if err != nil {
+		r.logger.Error("erro ao listar clones da pesquisa ID=%d: %v", origemID, err)
+		return nil, fmt.Errorf("erro ao listar clones da pesquisa: %v", err)
+	}
+	defer rows.Close()
+
+	var clones []*entity.ClonePesquisa
+
+	for rows.Next() {
+		clone := &entity.ClonePesquisa{}
+		if err := rows.Scan(&clone.IDPesquisa, &clone.IDPesquisaOrigem, &clone.DataCriacao); err != nil {
+			r.logger.Error("erro ao escanear clone: %v", err)
+			return nil, fmt.Errorf("erro ao escanear clone: %v", err)
+		}
+		clones = append(clones, clone)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar clones: %v", err)
+		return nil, fmt.Errorf("erro ao iterar clones: %v", err)
+	}
+
+	return clones, nil
+}
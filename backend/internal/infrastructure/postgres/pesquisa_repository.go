@@ -9,6 +9,7 @@ import (
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
 	"organizational-climate-survey/backend/pkg/logger"
+	"time"
 )
 
 // PesquisaRepository implementa a interface repository.PesquisaRepository
@@ -426,6 +427,58 @@ func (r *PesquisaRepository) UpdateStatus(ctx context.Context, id int, status st
 	return nil
 }
 
+// UpdateLinkAcesso troca o link de acesso da pesquisa junto com o caminho do QR code gerado para ele
+func (r *PesquisaRepository) UpdateLinkAcesso(ctx context.Context, id int, link, qrcodePath string) error {
+	query := `
+        UPDATE pesquisa 
+        SET link_acesso = $2, qrcode_path = $3
+        WHERE id_pesquisa = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, link, qrcodePath)
+	if err != nil {
+		r.logger.Error("erro ao atualizar link de acesso pesquisa ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar link de acesso da pesquisa: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("pesquisa com ID %d não encontrada para atualização de link", id)
+	}
+
+	return nil
+}
+
+// UpdateQRCodePath atualiza apenas o caminho do QR code da pesquisa
+func (r *PesquisaRepository) UpdateQRCodePath(ctx context.Context, id int, qrcodePath string) error {
+	query := `
+        UPDATE pesquisa 
+        SET qrcode_path = $2
+        WHERE id_pesquisa = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, qrcodePath)
+	if err != nil {
+		r.logger.Error("erro ao atualizar QR code pesquisa ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar QR code da pesquisa: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("pesquisa com ID %d não encontrada para atualização do QR code", id)
+	}
+
+	return nil
+}
+
 // Delete remove uma pesquisa do banco de dados ou arquiva se houver respostas
 // Verifica dependências antes da deleção
 func (r *PesquisaRepository) Delete(ctx context.Context, id int) error {
@@ -464,3 +517,82 @@ func (r *PesquisaRepository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// ListScheduledToOpen lista pesquisas em rascunho cuja data de abertura já chegou
+// Considera apenas aberturas das últimas 24 horas (janela aceita na ativação) e ignora pesquisas já encerradas
+func (r *PesquisaRepository) ListScheduledToOpen(ctx context.Context, now time.Time) ([]*entity.Pesquisa, error) {
+	query := `
+        SELECT id_pesquisa, id_empresa, id_user_admin, id_setor, titulo, descricao,
+               data_criacao, data_abertura, data_fechamento, status, link_acesso,
+               qrcode_path, config_recorrencia, anonimato
+        FROM pesquisa
+        WHERE status = 'Rascunho'
+        AND data_abertura IS NOT NULL
+        AND data_abertura <= $1
+        AND data_abertura > $1 - INTERVAL '24 hours'
+        AND (data_fechamento IS NULL OR data_fechamento > $1)
+        ORDER BY data_abertura
+    `
+
+	return r.listScheduled(ctx, query, now)
+}
+
+// ListScheduledToClose lista pesquisas ativas cuja data de fechamento já passou
+func (r *PesquisaRepository) ListScheduledToClose(ctx context.Context, now time.Time) ([]*entity.Pesquisa, error) {
+	query := `
+        SELECT id_pesquisa, id_empresa, id_user_admin, id_setor, titulo, descricao,
+               data_criacao, data_abertura, data_fechamento, status, link_acesso,
+               qrcode_path, config_recorrencia, anonimato
+        FROM pesquisa
+        WHERE status = 'Ativa'
+        AND data_fechamento IS NOT NULL
+        AND data_fechamento <= $1
+        ORDER BY data_fechamento
+    `
+
+	return r.listScheduled(ctx, query, now)
+}
+
+// listScheduled executa consultas do agendador que recebem apenas o instante de referência
+func (r *PesquisaRepository) listScheduled(ctx context.Context, query string, now time.Time) ([]*entity.Pesquisa, error) {
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		r.logger.Error("erro ao listar pesquisas agendadas: %v", err)
+		return nil, fmt.Errorf("erro ao listar pesquisas agendadas: %v", err)
+	}
+	defer rows.Close()
+
+	var pesquisas []*entity.Pesquisa
+
+	for rows.Next() {
+		pesquisa := &entity.Pesquisa{}
+		err := rows.Scan(
+			&pesquisa.ID,
+			&pesquisa.IDEmpresa,
+			&pesquisa.IDUserAdmin,
+			&pesquisa.IDSetor,
+			&pesquisa.Titulo,
+			&pesquisa.Descricao,
+			&pesquisa.DataCriacao,
+			&pesquisa.DataAbertura,
+			&pesquisa.DataFechamento,
+			&pesquisa.Status,
+			&pesquisa.LinkAcesso,
+			&pesquisa.QRCodePath,
+			&pesquisa.ConfigRecorrencia,
+			&pesquisa.Anonimato,
+		)
+		if err != nil {
+			r.logger.Error("erro ao escanear pesquisa: %v", err)
+			return nil, fmt.Errorf("erro ao escanear pesquisa: %v", err)
+		}
+		pesquisas = append(pesquisas, pesquisa)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar pesquisas: %v", err)
+		return nil, fmt.Errorf("erro ao iterar pesquisas: %v", err)
+	}
+
+	return pesquisas, nil
+}
@@ -9,6 +9,8 @@ import (
 	"organizational-climate-survey/backend/internal/domain/entity"
 	"organizational-climate-survey/backend/internal/domain/repository"
 	"organizational-climate-survey/backend/pkg/logger"
+
+	"github.com/lib/pq"
 )
 
 // RespostaRepository implementa a interface repository.RespostaRepository
@@ -134,6 +136,43 @@ func (r *RespostaRepository) CountByPesquisa(ctx context.Context, pesquisaID int
 	return count, nil
 }
 
+// CountRespondentesByDia conta, por dia de envio, as submissões distintas que responderam a pesquisa
+// Base da verificação de anonimato das consultas por período
+func (r *RespostaRepository) CountRespondentesByDia(ctx context.Context, pesquisaID int) (map[string]int, error) {
+	query := `
+        SELECT TO_CHAR(r.data_submissao, 'YYYY-MM-DD') AS dia, COUNT(DISTINCT r.id_submissao)
+        FROM resposta r
+        INNER JOIN pergunta p ON r.id_pergunta = p.id_pergunta
+        WHERE p.id_pesquisa = $1
+        GROUP BY 1
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pesquisaID)
+	if err != nil {
+		r.logger.Error("erro ao contar respondentes por dia pesquisa ID=%d: %v", pesquisaID, err)
+		return nil, fmt.Errorf("erro ao contar respondentes por dia: %v", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+	for rows.Next() {
+		var dia string
+		var quantidade int
+		if err := rows.Scan(&dia, &quantidade); err != nil {
+			r.logger.Error("erro ao escanear respondentes por dia: %v", err)
+			return nil, fmt.Errorf("erro ao escanear resultado: %v", err)
+		}
+		result[dia] = quantidade
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar respondentes por dia: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+
+	return result, nil
+}
+
 // CountByPergunta conta o total de respostas para uma pergunta específica
 func (r *RespostaRepository) CountByPergunta(ctx context.Context, perguntaID int) (int, error) {
 	var count int
@@ -163,6 +202,55 @@ func (r *RespostaRepository) CountBySubmissao(ctx context.Context, submissaoID i
 	return count, nil
 }
 
+// GetValoresBySubmissao retorna os valores das respostas de uma pesquisa agrupados por submissão e pergunta
+// Respostas antigas, sem submissão, não são incluídas
+func (r *RespostaRepository) GetValoresBySubmissao(ctx context.Context, pesquisaID int) (map[int]map[int]string, error) {
+	return r.GetValoresBySubmissaoPesquisas(ctx, []int{pesquisaID})
+}
+
+// GetValoresBySubmissaoPesquisas retorna, em uma única consulta, os valores das respostas de várias pesquisas
+// Submissões e perguntas pertencem a uma só pesquisa, então os resultados não se misturam
+func (r *RespostaRepository) GetValoresBySubmissaoPesquisas(ctx context.Context, pesquisaIDs []int) (map[int]map[int]string, error) {
+	result := make(map[int]map[int]string)
+	if len(pesquisaIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+        SELECT r.id_submissao, r.id_pergunta, r.valor_resposta
+        FROM resposta r
+        INNER JOIN pergunta p ON r.id_pergunta = p.id_pergunta
+        WHERE p.id_pesquisa = ANY($1) AND r.id_submissao IS NOT NULL
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(pesquisaIDs))
+	if err != nil {
+		r.logger.Error("erro ao buscar respostas por submissão pesquisas %v: %v", pesquisaIDs, err)
+		return nil, fmt.Errorf("erro ao buscar respostas por submissão: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var submissaoID, perguntaID int
+		var valor string
+		if err := rows.Scan(&submissaoID, &perguntaID, &valor); err != nil {
+			r.logger.Error("erro ao escanear resposta por submissão: %v", err)
+			return nil, fmt.Errorf("erro ao escanear resultado: %v", err)
+		}
+		if result[submissaoID] == nil {
+			result[submissaoID] = make(map[int]string)
+		}
+		result[submissaoID][perguntaID] = valor
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("erro ao iterar respostas por submissão: %v", err)
+		return nil, fmt.Errorf("erro durante iteração: %v", err)
+	}
+
+	return result, nil
+}
+
 // GetAggregatedByPergunta retorna contagem agrupada de respostas por valor
 // Útil para análises e dashboards
 func (r *RespostaRepository) GetAggregatedByPergunta(ctx context.Context, perguntaID int) (map[string]int, error) {
@@ -207,24 +295,33 @@ func (r *RespostaRepository) GetAggregatedByPergunta(ctx context.Context, pergun
 // GetAggregatedByPesquisa retorna contagem agrupada de todas as respostas da pesquisa
 // Agrupadas por pergunta e valor da resposta
 func (r *RespostaRepository) GetAggregatedByPesquisa(ctx context.Context, pesquisaID int) (map[int]map[string]int, error) {
+	return r.GetAggregatedByPesquisas(ctx, []int{pesquisaID})
+}
+
+// GetAggregatedByPesquisas retorna, em uma única consulta, a contagem agrupada das respostas de várias pesquisas
+// As chaves são IDs de pergunta, únicos entre as pesquisas
+func (r *RespostaRepository) GetAggregatedByPesquisas(ctx context.Context, pesquisaIDs []int) (map[int]map[string]int, error) {
+	result := make(map[int]map[string]int)
+	if len(pesquisaIDs) == 0 {
+		return result, nil
+	}
+
 	query := `
         SELECT r.id_pergunta, r.valor_resposta, COUNT(*) as quantidade
         FROM resposta r
         INNER JOIN pergunta p ON r.id_pergunta = p.id_pergunta
-        WHERE p.id_pesquisa = $1 
+        WHERE p.id_pesquisa = ANY($1)
         GROUP BY r.id_pergunta, r.valor_resposta
         ORDER BY r.id_pergunta, quantidade DESC
     `
 
-	rows, err := r.db.QueryContext(ctx, query, pesquisaID)
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(pesquisaIDs))
 	if err != nil {
-		r.logger.Error("erro ao buscar agregados pesquisa ID=%d: %v", pesquisaID, err)
+		r.logger.Error("erro ao buscar agregados pesquisas %v: %v", pesquisaIDs, err)
 		return nil, fmt.Errorf("erro ao buscar dados agregados por pesquisa: %v", err)
 	}
 	defer rows.Close()
 
-	result := make(map[int]map[string]int)
-
 	for rows.Next() {
 		var perguntaID int
 		var valor string
@@ -0,0 +1,283 @@
+// Package postgres implementa o repositório de sessões de login usando PostgreSQL.
+// Fornece criação, rotação de refresh tokens e revogação de sessões.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// SessaoRepository implementa a interface repository.SessaoRepository
+type SessaoRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewSessaoRepository cria uma nova instância do repositório de sessões
+func NewSessaoRepository(db *DB) *SessaoRepository {
+	return &SessaoRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que SessaoRepository implementa a interface correta
+var _ repository.SessaoRepository = (*SessaoRepository)(nil)
+
+// sessaoColumns lista as colunas lidas por scanSessao
+const sessaoColumns = `s.id_sessao, s.id_user_admin, s.jti_atual, s.data_expiracao_acesso, s.data_criacao,
+               s.data_ultimo_uso, s.data_expiracao, s.data_revogacao, s.endereco_ip, s.user_agent`
+
+// rowScanner abstrai sql.Row e sql.Rows para reutilizar o scan
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSessao lê uma sessão na ordem de sessaoColumns, seguida dos destinos extras
+func scanSessao(row rowScanner, extra ...interface{}) (*entity.SessaoUsuario, error) {
+	sessao := &entity.SessaoUsuario{}
+	var ip, userAgent sql.NullString
+
+	dest := []interface{}{
+		&sessao.ID,
+		&sessao.IDUserAdmin,
+		&sessao.JTIAtual,
+		&sessao.DataExpiracaoAcesso,
+		&sessao.DataCriacao,
+		&sessao.DataUltimoUso,
+		&sessao.DataExpiracao,
+		&sessao.DataRevogacao,
+		&ip,
+		&userAgent,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	sessao.EnderecoIP = ip.String
+	sessao.UserAgent = userAgent.String
+	return sessao, nil
+}
+
+// Create insere a sessão e seu primeiro refresh token em uma única transação
+func (r *SessaoRepository) Create(ctx context.Context, sessao *entity.SessaoUsuario, refreshHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de sessão: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO sessao_usuario (id_user_admin, jti_atual, data_expiracao_acesso, data_criacao,
+                                    data_ultimo_uso, data_expiracao, endereco_ip, user_agent)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id_sessao
+    `,
+		sessao.IDUserAdmin,
+		sessao.JTIAtual,
+		sessao.DataExpiracaoAcesso,
+		sessao.DataCriacao,
+		sessao.DataUltimoUso,
+		sessao.DataExpiracao,
+		sessao.EnderecoIP,
+		sessao.UserAgent,
+	).Scan(&sessao.ID)
+	if err != nil {
+		r.logger.Error("erro ao criar sessão usuário ID=%d: %v", sessao.IDUserAdmin, err)
+		return fmt.Errorf("erro ao criar sessão: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO refresh_token (id_sessao, token_hash, data_criacao)
+        VALUES ($1, $2, $3)
+    `, sessao.ID, refreshHash, sessao.DataCriacao)
+	if err != nil {
+		r.logger.Error("erro ao criar refresh token sessão ID=%d: %v", sessao.ID, err)
+		return fmt.Errorf("erro ao criar refresh token: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit sessão: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID busca uma sessão pelo ID
+// Retorna erro específico quando não encontrada
+func (r *SessaoRepository) GetByID(ctx context.Context, id int) (*entity.SessaoUsuario, error) {
+	query := `SELECT ` + sessaoColumns + ` FROM sessao_usuario s WHERE s.id_sessao = $1`
+
+	sessao, err := scanSessao(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("sessão não encontrada")
+		}
+		r.logger.Error("erro ao buscar sessão ID=%d: %v", id, err)
+		return nil, fmt.Errorf("erro ao buscar sessão: %v", err)
+	}
+
+	return sessao, nil
+}
+
+// GetByRefreshHash retorna a sessão e o refresh token correspondente ao hash
+// Tokens já trocados também são retornados para que o caso de uso detecte reuso
+func (r *SessaoRepository) GetByRefreshHash(ctx context.Context, refreshHash string) (*entity.SessaoUsuario, *entity.RefreshToken, error) {
+	query := `
+        SELECT ` + sessaoColumns + `, rt.id_refresh_token, rt.id_sessao, rt.token_hash, rt.data_criacao, rt.data_uso
+        FROM refresh_token rt
+        JOIN sessao_usuario s ON s.id_sessao = rt.id_sessao
+        WHERE rt.token_hash = $1
+    `
+
+	token := &entity.RefreshToken{}
+	sessao, err := scanSessao(r.db.QueryRowContext(ctx, query, refreshHash),
+		&token.ID,
+		&token.IDSessao,
+		&token.TokenHash,
+		&token.DataCriacao,
+		&token.DataUso,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("refresh token não encontrado")
+		}
+		r.logger.Error("erro ao buscar refresh token: %v", err)
+		return nil, nil, fmt.Errorf("erro ao buscar refresh token: %v", err)
+	}
+
+	return sessao, token, nil
+}
+
+// Rotate marca o refresh token como usado, emite o próximo e atualiza o access token vigente da sessão
+// A condição data_uso IS NULL garante que apenas uma renovação concorrente seja aceita
+func (r *SessaoRepository) Rotate(ctx context.Context, refreshID int, sessao *entity.SessaoUsuario, newRefreshHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("erro ao iniciar transação de rotação: %v", err)
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE refresh_token SET data_uso = $2
+        WHERE id_refresh_token = $1 AND data_uso IS NULL
+    `, refreshID, sessao.DataUltimoUso)
+	if err != nil {
+		r.logger.Error("erro ao marcar refresh token ID=%d: %v", refreshID, err)
+		return fmt.Errorf("erro ao marcar refresh token: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("refresh token já utilizado")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO refresh_token (id_sessao, token_hash, data_criacao)
+        VALUES ($1, $2, $3)
+    `, sessao.ID, newRefreshHash, sessao.DataUltimoUso)
+	if err != nil {
+		r.logger.Error("erro ao criar refresh token sessão ID=%d: %v", sessao.ID, err)
+		return fmt.Errorf("erro ao criar refresh token: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE sessao_usuario
+        SET jti_atual = $2, data_expiracao_acesso = $3, data_ultimo_uso = $4
+        WHERE id_sessao = $1
+    `, sessao.ID, sessao.JTIAtual, sessao.DataExpiracaoAcesso, sessao.DataUltimoUso)
+	if err != nil {
+		r.logger.Error("erro ao atualizar sessão ID=%d: %v", sessao.ID, err)
+		return fmt.Errorf("erro ao atualizar sessão: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("erro ao commit rotação de refresh token: %v", err)
+		return fmt.Errorf("erro ao commit: %v", err)
+	}
+
+	return nil
+}
+
+// ListActiveByUsuario lista as sessões não revogadas e não expiradas de um usuário
+// Ordenadas da mais recentemente usada para a mais antiga
+func (r *SessaoRepository) ListActiveByUsuario(ctx context.Context, userAdminID int, now time.Time) ([]*entity.SessaoUsuario, error) {
+	query := `
+        SELECT ` + sessaoColumns + `
+        FROM sessao_usuario s
+        WHERE s.id_user_admin = $1 AND s.data_revogacao IS NULL AND s.data_expiracao > $2
+        ORDER BY s.data_ultimo_uso DESC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userAdminID, now)
+	if err != nil {
+		r.logger.Error("erro ao listar sessões usuário ID=%d: %v", userAdminID, err)
+		return nil, fmt.Errorf("erro ao listar sessões: %v", err)
+	}
+	defer rows.Close()
+
+	var sessoes []*entity.SessaoUsuario
+
+	for rows.Next() {
+		sessao, err := scanSessao(rows)
+		if err != nil {
+			r.logger.Error("erro ao escanear sessão: %v", err)
+			return nil, fmt.Errorf("erro ao escanear sessão: %v", err)
+		}
+		sessoes = append(sessoes, sessao)
+	}
+
+	return sessoes, nil
+}
+
+// Revoke revoga uma sessão, invalidando seus refresh tokens
+// Sessões já revogadas mantêm a data original
+func (r *SessaoRepository) Revoke(ctx context.Context, id int, revokedAt time.Time) error {
+	query := `
+        UPDATE sessao_usuario
+        SET data_revogacao = COALESCE(data_revogacao, $2)
+        WHERE id_sessao = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		r.logger.Error("erro ao revogar sessão ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao revogar sessão: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("sessão não encontrada")
+	}
+
+	return nil
+}
+
+// DeleteExpired remove sessões expiradas antes da data informada (refresh tokens em cascata)
+// Retorna a quantidade de sessões removidas
+func (r *SessaoRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM sessao_usuario WHERE data_expiracao < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		r.logger.Error("erro ao remover sessões expiradas: %v", err)
+		return 0, fmt.Errorf("erro ao remover sessões expiradas: %v", err)
+	}
+
+	return result.RowsAffected()
+}
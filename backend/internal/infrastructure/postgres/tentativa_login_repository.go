@@ -0,0 +1,175 @@
+// Package postgres implementa o repositório de tentativas de login usando PostgreSQL.
+// Fornece os contadores de falhas usados no atraso exponencial e no bloqueio temporário.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// TentativaLoginRepository implementa a interface repository.TentativaLoginRepository
+type TentativaLoginRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewTentativaLoginRepository cria uma nova instância do repositório
+func NewTentativaLoginRepository(db *DB) *TentativaLoginRepository {
+	return &TentativaLoginRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que TentativaLoginRepository implementa a interface correta
+var _ repository.TentativaLoginRepository = (*TentativaLoginRepository)(nil)
+
+// tentativaLoginColumns lista as colunas lidas por scanTentativaLogin
+const tentativaLoginColumns = `t.id_tentativa, t.tipo, t.chave, t.id_user_admin, t.falhas, t.ultima_falha,
+               t.proxima_tentativa, t.bloqueado_ate`
+
+// scanTentativaLogin lê um contador na ordem de tentativaLoginColumns
+func scanTentativaLogin(row rowScanner) (*entity.TentativaLogin, error) {
+	tentativa := &entity.TentativaLogin{}
+	var userAdminID sql.NullInt64
+
+	err := row.Scan(
+		&tentativa.ID,
+		&tentativa.Tipo,
+		&tentativa.Chave,
+		&userAdminID,
+		&tentativa.Falhas,
+		&tentativa.UltimaFalha,
+		&tentativa.ProximaTentativa,
+		&tentativa.BloqueadoAte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userAdminID.Valid {
+		id := int(userAdminID.Int64)
+		tentativa.IDUserAdmin = &id
+	}
+	return tentativa, nil
+}
+
+// Get busca o contador de falhas da chave
+// Retorna erro específico quando não há falhas registradas
+func (r *TentativaLoginRepository) Get(ctx context.Context, tipo, chave string) (*entity.TentativaLogin, error) {
+	query := `SELECT ` + tentativaLoginColumns + ` FROM tentativa_login t WHERE t.tipo = $1 AND t.chave = $2`
+
+	tentativa, err := scanTentativaLogin(r.db.QueryRowContext(ctx, query, tipo, chave))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tentativa de login não encontrada")
+		}
+		r.logger.Error("erro ao buscar tentativa de login %s: %v", tipo, err)
+		return nil, fmt.Errorf("erro ao buscar tentativa de login: %v", err)
+	}
+
+	return tentativa, nil
+}
+
+// RegisterFailure registra uma falha e retorna o contador atualizado
+// O incremento é feito no próprio UPSERT, portanto falhas concorrentes não se perdem
+func (r *TentativaLoginRepository) RegisterFailure(ctx context.Context, tentativa *entity.TentativaLogin, now, windowStart time.Time) (*entity.TentativaLogin, error) {
+	query := `
+        INSERT INTO tentativa_login AS t (tipo, chave, id_user_admin, falhas, ultima_falha)
+        VALUES ($1, $2, $3, 1, $4)
+        ON CONFLICT (tipo, chave) DO UPDATE
+        SET falhas = CASE WHEN t.ultima_falha < $5 THEN 1 ELSE t.falhas + 1 END,
+            ultima_falha = EXCLUDED.ultima_falha,
+            id_user_admin = COALESCE(EXCLUDED.id_user_admin, t.id_user_admin)
+        RETURNING ` + tentativaLoginColumns
+
+	updated, err := scanTentativaLogin(r.db.QueryRowContext(ctx, query,
+		tentativa.Tipo,
+		tentativa.Chave,
+		tentativa.IDUserAdmin,
+		now,
+		windowStart,
+	))
+	if err != nil {
+		r.logger.Error("erro ao registrar falha de login %s: %v", tentativa.Tipo, err)
+		return nil, fmt.Errorf("erro ao registrar falha de login: %v", err)
+	}
+
+	return updated, nil
+}
+
+// UpdateBlock grava o atraso até a próxima tentativa e o bloqueio temporário
+func (r *TentativaLoginRepository) UpdateBlock(ctx context.Context, id int, proximaTentativa, bloqueadoAte *time.Time) error {
+	query := `UPDATE tentativa_login SET proxima_tentativa = $2, bloqueado_ate = $3 WHERE id_tentativa = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, proximaTentativa, bloqueadoAte); err != nil {
+		r.logger.Error("erro ao atualizar bloqueio de login ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar bloqueio de login: %v", err)
+	}
+
+	return nil
+}
+
+// Delete zera o contador da chave (login bem-sucedido ou desbloqueio manual)
+func (r *TentativaLoginRepository) Delete(ctx context.Context, tipo, chave string) error {
+	query := `DELETE FROM tentativa_login WHERE tipo = $1 AND chave = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, tipo, chave); err != nil {
+		r.logger.Error("erro ao remover tentativa de login %s: %v", tipo, err)
+		return fmt.Errorf("erro ao remover tentativa de login: %v", err)
+	}
+
+	return nil
+}
+
+// ListLockedByEmpresa lista as contas da empresa com bloqueio vigente
+func (r *TentativaLoginRepository) ListLockedByEmpresa(ctx context.Context, empresaID int, now time.Time) ([]*entity.TentativaLogin, error) {
+	query := `
+        SELECT ` + tentativaLoginColumns + `
+        FROM tentativa_login t
+        JOIN usuario_administrador u ON u.id_user_admin = t.id_user_admin
+        WHERE u.id_empresa = $1 AND t.tipo IN ('conta', 'mfa') AND t.bloqueado_ate > $2
+        ORDER BY t.bloqueado_ate DESC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, empresaID, now)
+	if err != nil {
+		r.logger.Error("erro ao listar bloqueios de login empresa ID=%d: %v", empresaID, err)
+		return nil, fmt.Errorf("erro ao listar bloqueios de login: %v", err)
+	}
+	defer rows.Close()
+
+	var tentativas []*entity.TentativaLogin
+	for rows.Next() {
+		tentativa, err := scanTentativaLogin(rows)
+		if err != nil {
+			r.logger.Error("erro ao escanear bloqueio de login: %v", err)
+			return nil, fmt.Errorf("erro ao escanear bloqueio de login: %v", err)
+		}
+		tentativas = append(tentativas, tentativa)
+	}
+
+	return tentativas, rows.Err()
+}
+
+// DeleteExpired remove contadores sem falhas recentes e sem bloqueio vigente
+// Retorna a quantidade de registros removidos
+func (r *TentativaLoginRepository) DeleteExpired(ctx context.Context, before, now time.Time) (int64, error) {
+	query := `
+        DELETE FROM tentativa_login
+        WHERE ultima_falha < $1 AND (bloqueado_ate IS NULL OR bloqueado_ate < $2)
+    `
+
+	result, err := r.db.ExecContext(ctx, query, before, now)
+	if err != nil {
+		r.logger.Error("erro ao remover tentativas de login expiradas: %v", err)
+		return 0, fmt.Errorf("erro ao remover tentativas de login expiradas: %v", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,142 @@
+// Package postgres implementa o repositório de tokens de redefinição de senha usando PostgreSQL.
+// Fornece emissão, consulta e consumo de tokens de uso único.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/entity"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// TokenRedefinicaoSenhaRepository implementa a interface repository.TokenRedefinicaoSenhaRepository
+type TokenRedefinicaoSenhaRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewTokenRedefinicaoSenhaRepository cria uma nova instância do repositório
+func NewTokenRedefinicaoSenhaRepository(db *DB) *TokenRedefinicaoSenhaRepository {
+	return &TokenRedefinicaoSenhaRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que TokenRedefinicaoSenhaRepository implementa a interface correta
+var _ repository.TokenRedefinicaoSenhaRepository = (*TokenRedefinicaoSenhaRepository)(nil)
+
+// Create insere um novo token de redefinição
+// Retorna o ID gerado através do RETURNING
+func (r *TokenRedefinicaoSenhaRepository) Create(ctx context.Context, token *entity.TokenRedefinicaoSenha) error {
+	query := `
+        INSERT INTO token_redefinicao_senha (id_user_admin, token_hash, data_criacao, data_expiracao, ip_solicitacao)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id_token
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		token.IDUserAdmin,
+		token.TokenHash,
+		token.DataCriacao,
+		token.DataExpiracao,
+		token.IPSolicitacao,
+	).Scan(&token.ID)
+
+	if err != nil {
+		r.logger.Error("erro ao criar token de redefinição usuário ID=%d: %v", token.IDUserAdmin, err)
+		return fmt.Errorf("erro ao criar token de redefinição: %v", err)
+	}
+
+	return nil
+}
+
+// GetByHash busca um token pelo hash
+// Retorna erro específico quando não encontrado
+func (r *TokenRedefinicaoSenhaRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.TokenRedefinicaoSenha, error) {
+	token := &entity.TokenRedefinicaoSenha{}
+	var ip sql.NullString
+	query := `
+        SELECT id_token, id_user_admin, token_hash, data_criacao, data_expiracao, data_uso, ip_solicitacao
+        FROM token_redefinicao_senha
+        WHERE token_hash = $1
+    `
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.IDUserAdmin,
+		&token.TokenHash,
+		&token.DataCriacao,
+		&token.DataExpiracao,
+		&token.DataUso,
+		&ip,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("token de redefinição não encontrado")
+		}
+		r.logger.Error("erro ao buscar token de redefinição: %v", err)
+		return nil, fmt.Errorf("erro ao buscar token de redefinição: %v", err)
+	}
+	token.IPSolicitacao = ip.String
+
+	return token, nil
+}
+
+// MarkUsed marca o token como utilizado
+// A condição data_uso IS NULL garante o uso único mesmo com requisições concorrentes
+func (r *TokenRedefinicaoSenhaRepository) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	query := `
+        UPDATE token_redefinicao_senha
+        SET data_uso = $2
+        WHERE id_token = $1 AND data_uso IS NULL
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, usedAt)
+	if err != nil {
+		r.logger.Error("erro ao marcar token de redefinição ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao marcar token de redefinição: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("token de redefinição já utilizado")
+	}
+
+	return nil
+}
+
+// DeleteByUsuario remove todos os tokens de um usuário
+// Usado ao emitir um novo token e após a redefinição, invalidando links anteriores
+func (r *TokenRedefinicaoSenhaRepository) DeleteByUsuario(ctx context.Context, userAdminID int) error {
+	query := `DELETE FROM token_redefinicao_senha WHERE id_user_admin = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userAdminID); err != nil {
+		r.logger.Error("erro ao remover tokens de redefinição usuário ID=%d: %v", userAdminID, err)
+		return fmt.Errorf("erro ao remover tokens de redefinição: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired remove tokens expirados antes da data informada
+// Retorna a quantidade de tokens removidos
+func (r *TokenRedefinicaoSenhaRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM token_redefinicao_senha WHERE data_expiracao < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		r.logger.Error("erro ao remover tokens de redefinição expirados: %v", err)
+		return 0, fmt.Errorf("erro ao remover tokens de redefinição expirados: %v", err)
+	}
+
+	return result.RowsAffected()
+}
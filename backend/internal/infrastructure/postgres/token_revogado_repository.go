@@ -0,0 +1,72 @@
+// Package postgres implementa a denylist de access tokens usando PostgreSQL.
+// Fornece registro e consulta de jti revogados antes da expiração.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"organizational-climate-survey/backend/internal/domain/repository"
+	"organizational-climate-survey/backend/pkg/logger"
+	"time"
+)
+
+// TokenRevogadoRepository implementa a interface repository.TokenRevogadoRepository
+type TokenRevogadoRepository struct {
+	db     *DB           // Conexão com o banco de dados
+	logger logger.Logger // Logger para operações do repositório
+}
+
+// NewTokenRevogadoRepository cria uma nova instância do repositório
+func NewTokenRevogadoRepository(db *DB) *TokenRevogadoRepository {
+	return &TokenRevogadoRepository{
+		db:     db,
+		logger: db.logger,
+	}
+}
+
+// Garante que TokenRevogadoRepository implementa a interface correta
+var _ repository.TokenRevogadoRepository = (*TokenRevogadoRepository)(nil)
+
+// Revoke adiciona o jti à denylist até a expiração do token
+// Revogar um jti já presente não gera erro
+func (r *TokenRevogadoRepository) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `
+        INSERT INTO token_revogado (jti, data_expiracao)
+        VALUES ($1, $2)
+        ON CONFLICT (jti) DO NOTHING
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, jti, expiresAt); err != nil {
+		r.logger.Error("erro ao revogar token jti=%s: %v", jti, err)
+		return fmt.Errorf("erro ao revogar token: %v", err)
+	}
+
+	return nil
+}
+
+// IsRevoked verifica se o jti está na denylist
+func (r *TokenRevogadoRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS(SELECT 1 FROM token_revogado WHERE jti = $1)`
+
+	if err := r.db.QueryRowContext(ctx, query, jti).Scan(&revoked); err != nil {
+		r.logger.Error("erro ao consultar token revogado jti=%s: %v", jti, err)
+		return false, fmt.Errorf("erro ao consultar token revogado: %v", err)
+	}
+
+	return revoked, nil
+}
+
+// DeleteExpired remove da denylist tokens que já expiraram naturalmente
+// Retorna a quantidade de registros removidos
+func (r *TokenRevogadoRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM token_revogado WHERE data_expiracao < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		r.logger.Error("erro ao remover tokens revogados expirados: %v", err)
+		return 0, fmt.Errorf("erro ao remover tokens revogados expirados: %v", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -31,8 +31,8 @@ var _ repository.UsuarioAdministradorRepository = (*UsuarioAdministradorReposito
 // Retorna o ID gerado através do RETURNING
 func (r *UsuarioAdministradorRepository) Create(ctx context.Context, usuario *entity.UsuarioAdministrador) error {
 	query := `
-        INSERT INTO usuario_administrador (id_empresa, nome_admin, email, senha_hash, data_cadastro, status)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO usuario_administrador (id_empresa, nome_admin, email, senha_hash, data_cadastro, status, papel)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING id_user_admin
     `
 
@@ -43,6 +43,7 @@ func (r *UsuarioAdministradorRepository) Create(ctx context.Context, usuario *en
 		usuario.SenhaHash,
 		usuario.DataCadastro,
 		usuario.Status,
+		usuario.Papel,
 	).Scan(&usuario.ID)
 
 	if err != nil {
@@ -58,7 +59,7 @@ func (r *UsuarioAdministradorRepository) Create(ctx context.Context, usuario *en
 func (r *UsuarioAdministradorRepository) GetByID(ctx context.Context, id int) (*entity.UsuarioAdministrador, error) {
 	usuario := &entity.UsuarioAdministrador{}
 	query := `
-        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status
+        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status, papel
         FROM usuario_administrador
         WHERE id_user_admin = $1
     `
@@ -71,6 +72,7 @@ func (r *UsuarioAdministradorRepository) GetByID(ctx context.Context, id int) (*
 		&usuario.SenhaHash,
 		&usuario.DataCadastro,
 		&usuario.Status,
+		&usuario.Papel,
 	)
 
 	if err != nil {
@@ -89,7 +91,7 @@ func (r *UsuarioAdministradorRepository) GetByID(ctx context.Context, id int) (*
 func (r *UsuarioAdministradorRepository) GetByEmail(ctx context.Context, email string) (*entity.UsuarioAdministrador, error) {
 	usuario := &entity.UsuarioAdministrador{}
 	query := `
-        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status
+        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status, papel
         FROM usuario_administrador
         WHERE email = $1
     `
@@ -102,6 +104,7 @@ func (r *UsuarioAdministradorRepository) GetByEmail(ctx context.Context, email s
 		&usuario.SenhaHash,
 		&usuario.DataCadastro,
 		&usuario.Status,
+		&usuario.Papel,
 	)
 
 	if err != nil {
@@ -119,7 +122,7 @@ func (r *UsuarioAdministradorRepository) GetByEmail(ctx context.Context, email s
 // Ordenados por data de cadastro decrescente
 func (r *UsuarioAdministradorRepository) ListByEmpresa(ctx context.Context, empresaID int) ([]*entity.UsuarioAdministrador, error) {
 	query := `
-        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status
+        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status, papel
         FROM usuario_administrador
         WHERE id_empresa = $1
         ORDER BY data_cadastro DESC
@@ -144,6 +147,7 @@ func (r *UsuarioAdministradorRepository) ListByEmpresa(ctx context.Context, empr
 			&usuario.SenhaHash,
 			&usuario.DataCadastro,
 			&usuario.Status,
+			&usuario.Papel,
 		)
 		if err != nil {
 			r.logger.Error("erro ao escanear usuário admin: %v", err)
@@ -164,7 +168,7 @@ func (r *UsuarioAdministradorRepository) ListByEmpresa(ctx context.Context, empr
 // Status podem ser: Ativo, Inativo, Pendente
 func (r *UsuarioAdministradorRepository) ListByStatus(ctx context.Context, empresaID int, status string) ([]*entity.UsuarioAdministrador, error) {
 	query := `
-        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status
+        SELECT id_user_admin, id_empresa, nome_admin, email, senha_hash, data_cadastro, status, papel
         FROM usuario_administrador
         WHERE id_empresa = $1 AND status = $2
         ORDER BY data_cadastro DESC
@@ -189,6 +193,7 @@ func (r *UsuarioAdministradorRepository) ListByStatus(ctx context.Context, empre
 			&usuario.SenhaHash,
 			&usuario.DataCadastro,
 			&usuario.Status,
+			&usuario.Papel,
 		)
 		if err != nil {
 			r.logger.Error("erro ao escanear usuário admin: %v", err)
@@ -292,6 +297,33 @@ func (r *UsuarioAdministradorRepository) UpdateStatus(ctx context.Context, id in
 	return nil
 }
 
+// UpdatePapel atualiza apenas o papel do usuário
+// Usado na gestão de permissões
+func (r *UsuarioAdministradorRepository) UpdatePapel(ctx context.Context, id int, papel string) error {
+	query := `
+        UPDATE usuario_administrador 
+        SET papel = $2
+        WHERE id_user_admin = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, id, papel)
+	if err != nil {
+		r.logger.Error("erro ao atualizar papel usuário ID=%d: %v", id, err)
+		return fmt.Errorf("erro ao atualizar papel: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("usuário administrador com ID %d não encontrado para atualização de papel", id)
+	}
+
+	return nil
+}
+
 // Delete remove um usuário administrador ou inativa se houver dependências
 // Verifica pesquisas associadas antes da deleção
 func (r *UsuarioAdministradorRepository) Delete(ctx context.Context, id int) error {
@@ -0,0 +1,274 @@
+// Package scheduler executa tarefas periódicas da aplicação em segundo plano.
+// Gera ciclos de pesquisas recorrentes, abre e encerra pesquisas conforme as datas agendadas
+// analisa as respostas abertas das pesquisas encerradas e remove submissões, tokens de redefinição
+// de senha e sessões expirados.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"organizational-climate-survey/backend/internal/domain/usecase"
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// Config define os intervalos de execução das tarefas
+type Config struct {
+	Interval        time.Duration // Intervalo entre verificações de abertura/fechamento
+	CleanupInterval time.Duration // Intervalo entre limpezas de submissões e tokens expirados
+}
+
+// Scheduler coordena as tarefas periódicas de ciclo de vida das pesquisas
+type Scheduler struct {
+	config              Config
+	pesquisaUseCase     *usecase.PesquisaUseCase             // Transições de status
+	recorrenciaUseCase  *usecase.RecorrenciaUseCase          // Geração de ciclos recorrentes
+	submissaoUseCase    *usecase.SubmissaoPesquisaUseCase    // Limpeza de submissões
+	usuarioUseCase      *usecase.UsuarioAdministradorUseCase // Limpeza de tokens de redefinição de senha
+	sessaoUseCase       *usecase.SessaoUseCase               // Limpeza de sessões e tokens revogados
+	analiseTextoUseCase *usecase.AnaliseTextoUseCase         // Análise das respostas abertas
+	logUseCase          *usecase.LogAuditoriaUseCase         // Logs de sistema
+	log                 logger.Logger
+	wg                  sync.WaitGroup
+}
+
+// New cria um agendador com as dependências informadas
+// Use cases nulos desativam a tarefa correspondente
+func New(config Config, pesquisaUseCase *usecase.PesquisaUseCase, recorrenciaUseCase *usecase.RecorrenciaUseCase, submissaoUseCase *usecase.SubmissaoPesquisaUseCase, usuarioUseCase *usecase.UsuarioAdministradorUseCase, sessaoUseCase *usecase.SessaoUseCase, analiseTextoUseCase *usecase.AnaliseTextoUseCase, logUseCase *usecase.LogAuditoriaUseCase, log logger.Logger) *Scheduler {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.CleanupInterval <= 0 {
+		config.CleanupInterval = time.Hour
+	}
+
+	return &Scheduler{
+		config:              config,
+		pesquisaUseCase:     pesquisaUseCase,
+		recorrenciaUseCase:  recorrenciaUseCase,
+		submissaoUseCase:    submissaoUseCase,
+		usuarioUseCase:      usuarioUseCase,
+		sessaoUseCase:       sessaoUseCase,
+		analiseTextoUseCase: analiseTextoUseCase,
+		logUseCase:          logUseCase,
+		log:                 log,
+	}
+}
+
+// Start inicia as tarefas em goroutines próprias; elas terminam quando ctx é cancelado
+// As tarefas percorrem todas as empresas e por isso executam no escopo de sistema
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx = usecase.WithSystemScope(ctx)
+	if s.pesquisaUseCase != nil {
+		s.run(ctx, s.config.Interval, "applySchedule", s.applySchedule)
+	}
+	if s.submissaoUseCase != nil {
+		s.run(ctx, s.config.CleanupInterval, "cleanupExpired", s.cleanupExpired)
+	}
+	if s.usuarioUseCase != nil {
+		s.run(ctx, s.config.CleanupInterval, "cleanupResetTokens", s.cleanupResetTokens)
+		s.run(ctx, s.config.CleanupInterval, "cleanupLoginAttempts", s.cleanupLoginAttempts)
+	}
+	if s.sessaoUseCase != nil {
+		s.run(ctx, s.config.CleanupInterval, "cleanupSessions", s.cleanupSessions)
+	}
+	if s.analiseTextoUseCase != nil {
+		s.run(ctx, s.config.Interval, "analyzeOpenAnswers", s.analyzeOpenAnswers)
+	}
+}
+
+// Wait bloqueia até que todas as tarefas em execução terminem
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// run executa a tarefa imediatamente e depois a cada intervalo
+func (s *Scheduler) run(ctx context.Context, interval time.Duration, name string, task func(context.Context)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runOnce(ctx, name, task)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runOnce executa uma rodada da tarefa; um panic é registrado e a rodada descartada sem derrubar o processo
+func (s *Scheduler) runOnce(ctx context.Context, name string, task func(context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.WithFields(map[string]interface{}{"tarefa": name}).Error("Panic na tarefa agendada: %v\n%s", r, debug.Stack())
+		}
+	}()
+	task(ctx)
+}
+
+// applySchedule gera ciclos recorrentes e depois abre e encerra pesquisas conforme DataAbertura e DataFechamento
+// Os ciclos são gerados antes para que os que iniciam imediatamente sejam abertos na mesma execução
+func (s *Scheduler) applySchedule(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if s.recorrenciaUseCase != nil {
+		s.processRecurrences(ctx)
+	}
+
+	result, err := s.pesquisaUseCase.ApplySchedule(ctx, time.Now())
+	if err != nil {
+		s.log.Error("Erro ao aplicar agendamento de pesquisas: %v", err)
+	}
+	if result == nil {
+		return
+	}
+
+	for id, falha := range result.Falhas {
+		s.log.WithFields(map[string]interface{}{"pesquisa_id": id}).Warn("Transição agendada não aplicada: %s", falha)
+	}
+
+	if len(result.Abertas) == 0 && len(result.Encerradas) == 0 {
+		return
+	}
+
+	s.log.Info("Agendamento aplicado: %d pesquisa(s) aberta(s), %d encerrada(s)", len(result.Abertas), len(result.Encerradas))
+	s.systemLog(ctx, "Agendamento de Pesquisas",
+		fmt.Sprintf("Pesquisas abertas: %v; pesquisas encerradas: %v", result.Abertas, result.Encerradas))
+}
+
+// processRecurrences gera o próximo ciclo das pesquisas recorrentes
+func (s *Scheduler) processRecurrences(ctx context.Context) {
+	result, err := s.recorrenciaUseCase.ProcessRecurrences(ctx, time.Now())
+	if err != nil {
+		s.log.Error("Erro ao processar pesquisas recorrentes: %v", err)
+		return
+	}
+
+	for id, falha := range result.Falhas {
+		s.log.WithFields(map[string]interface{}{"pesquisa_id": id}).Warn("Ciclo recorrente não gerado: %s", falha)
+	}
+
+	if len(result.Gerados) == 0 {
+		return
+	}
+
+	s.log.Info("%d ciclo(s) de pesquisa recorrente gerado(s)", len(result.Gerados))
+	s.systemLog(ctx, "Recorrência de Pesquisas", fmt.Sprintf("Ciclos gerados (anterior -> nova): %v", result.Gerados))
+}
+
+// analyzeOpenAnswers calcula sentimento, palavras-chave e tópicos das respostas abertas das pesquisas encerradas
+// Pesquisas encerradas manualmente ou pelo agendamento são processadas na execução seguinte
+func (s *Scheduler) analyzeOpenAnswers(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	result, err := s.analiseTextoUseCase.ProcessPending(ctx)
+	if err != nil {
+		s.log.Error("Erro ao analisar respostas abertas: %v", err)
+		return
+	}
+
+	for id, falha := range result.Falhas {
+		s.log.WithFields(map[string]interface{}{"pesquisa_id": id}).Warn("Respostas abertas não analisadas: %s", falha)
+	}
+
+	if len(result.Analisadas) == 0 {
+		return
+	}
+
+	s.log.Info("Respostas abertas analisadas em %d pesquisa(s)", len(result.Analisadas))
+	s.systemLog(ctx, "Análise de Respostas Abertas", fmt.Sprintf("Pesquisas analisadas: %v", result.Analisadas))
+}
+
+// cleanupExpired remove submissões cujo token expirou sem conclusão
+func (s *Scheduler) cleanupExpired(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	count, err := s.submissaoUseCase.CleanupExpired(ctx)
+	if err != nil {
+		s.log.Error("Erro ao limpar submissões expiradas: %v", err)
+		return
+	}
+
+	if count == 0 {
+		return
+	}
+
+	s.log.Info("%d submissão(ões) expirada(s) removida(s)", count)
+	s.systemLog(ctx, "Limpeza de Submissões", fmt.Sprintf("%d submissões expiradas removidas", count))
+}
+
+// cleanupResetTokens remove tokens de redefinição de senha expirados
+func (s *Scheduler) cleanupResetTokens(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	count, err := s.usuarioUseCase.CleanupExpiredResetTokens(ctx)
+	if err != nil {
+		s.log.Error("Erro ao limpar tokens de redefinição de senha: %v", err)
+		return
+	}
+
+	if count > 0 {
+		s.log.Info("%d token(s) de redefinição de senha expirado(s) removido(s)", count)
+	}
+}
+
+// cleanupLoginAttempts remove contadores de falhas de login fora da janela e sem bloqueio vigente
+func (s *Scheduler) cleanupLoginAttempts(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	count, err := s.usuarioUseCase.CleanupExpiredLoginAttempts(ctx)
+	if err != nil {
+		s.log.Error("Erro ao limpar tentativas de login: %v", err)
+		return
+	}
+
+	if count > 0 {
+		s.log.Info("%d contador(es) de tentativas de login expirado(s) removido(s)", count)
+	}
+}
+
+// cleanupSessions remove sessões expiradas e jti revogados que já expiraram
+func (s *Scheduler) cleanupSessions(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	count, err := s.sessaoUseCase.CleanupExpired(ctx)
+	if err != nil {
+		s.log.Error("Erro ao limpar sessões expiradas: %v", err)
+		return
+	}
+
+	if count > 0 {
+		s.log.Info("%d sessão(ões)/token(s) revogado(s) expirado(s) removido(s)", count)
+	}
+}
+
+func (s *Scheduler) systemLog(ctx context.Context, acao, detalhes string) {
+	if s.logUseCase == nil {
+		return
+	}
+	if err := s.logUseCase.CreateSystemLog(ctx, acao, detalhes, "sistema"); err != nil {
+		s.log.Warn("Erro ao registrar log de sistema: %v", err)
+	}
+}
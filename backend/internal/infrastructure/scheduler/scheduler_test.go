@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+func TestRunSobreviveAPanicNaTarefa(t *testing.T) {
+	s := New(Config{}, nil, nil, nil, nil, nil, nil, nil, logger.New(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var rodadas atomic.Int32
+	s.run(ctx, time.Millisecond, "panico", func(context.Context) {
+		if rodadas.Add(1) == 1 {
+			panic("dados inesperados")
+		}
+	})
+
+	prazo := time.After(2 * time.Second)
+	for rodadas.Load() < 3 {
+		select {
+		case <-prazo:
+			t.Fatalf("tarefa executada %d vez(es) após o panic, esperado ao menos 3", rodadas.Load())
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	cancel()
+	s.Wait()
+}
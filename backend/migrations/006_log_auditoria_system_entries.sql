@@ -0,0 +1,10 @@
+-- Migration 006: log_auditoria system entries
+-- Data: 16/10/2026
+
+-- Permite registros de auditoria gerados pelo sistema (agendador, jobs), sem administrador associado
+-- A aplicação grava NULL quando o ID do administrador é 0
+ALTER TABLE log_auditoria
+    ALTER COLUMN id_user_admin DROP NOT NULL;
+
+-- Índice para localizar pesquisas com abertura/fechamento agendados
+CREATE INDEX IF NOT EXISTS idx_pesquisa_agendamento ON pesquisa(status, data_abertura, data_fechamento);
@@ -0,0 +1,16 @@
+-- Migration 007: pesquisa ciclo
+-- Data: 16/10/2026
+
+-- Liga cada pesquisa gerada por recorrência (Pesquisa.ConfigRecorrencia) ao ciclo anterior e à pesquisa original
+CREATE TABLE pesquisa_ciclo (
+    id_ciclo SERIAL PRIMARY KEY,
+    id_pesquisa INTEGER NOT NULL UNIQUE REFERENCES pesquisa(id_pesquisa) ON DELETE CASCADE,
+    id_pesquisa_anterior INTEGER UNIQUE REFERENCES pesquisa(id_pesquisa) ON DELETE SET NULL,
+    id_pesquisa_origem INTEGER REFERENCES pesquisa(id_pesquisa) ON DELETE SET NULL,
+    numero_ciclo INTEGER NOT NULL CHECK (numero_ciclo > 1),
+    data_inicio_prevista TIMESTAMP NOT NULL,
+    data_criacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- UNIQUE em id_pesquisa_anterior impede que dois ciclos sejam gerados a partir da mesma pesquisa
+CREATE INDEX idx_pesquisa_ciclo_origem ON pesquisa_ciclo(id_pesquisa_origem);
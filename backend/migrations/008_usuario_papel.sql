@@ -0,0 +1,14 @@
+-- Migration 008: usuario papel
+-- Data: 16/10/2026
+
+-- Papel que define as permissões do usuário administrador (RBAC)
+ALTER TABLE usuario_administrador
+    ADD COLUMN papel VARCHAR(50) NOT NULL DEFAULT 'analista';
+
+ALTER TABLE usuario_administrador
+    ADD CONSTRAINT papel_check CHECK (papel IN ('proprietario', 'gestor_pesquisas', 'analista', 'auditor'));
+
+-- Usuários existentes tinham acesso total; mantêm o acesso como proprietários
+UPDATE usuario_administrador SET papel = 'proprietario';
+
+COMMENT ON COLUMN usuario_administrador.papel IS 'Papel RBAC: proprietario, gestor_pesquisas, analista ou auditor';
@@ -0,0 +1,18 @@
+-- Migration 009: token redefinicao senha
+-- Data: 16/10/2026
+
+-- Tokens de uso único para redefinição de senha (armazena apenas o hash SHA-256)
+CREATE TABLE token_redefinicao_senha (
+    id_token SERIAL PRIMARY KEY,
+    id_user_admin INTEGER NOT NULL REFERENCES usuario_administrador(id_user_admin) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    data_criacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_expiracao TIMESTAMP NOT NULL,
+    data_uso TIMESTAMP,
+    ip_solicitacao VARCHAR(45)
+);
+
+CREATE INDEX idx_token_redefinicao_usuario ON token_redefinicao_senha(id_user_admin);
+CREATE INDEX idx_token_redefinicao_expiracao ON token_redefinicao_senha(data_expiracao);
+
+COMMENT ON TABLE token_redefinicao_senha IS 'Tokens de redefinição de senha de usuários administradores';
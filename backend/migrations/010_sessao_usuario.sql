@@ -0,0 +1,44 @@
+-- Migration 010: sessoes e revogacao de tokens
+-- Data: 16/10/2026
+
+-- Sessões de login (uma por dispositivo/login)
+CREATE TABLE sessao_usuario (
+    id_sessao SERIAL PRIMARY KEY,
+    id_user_admin INTEGER NOT NULL REFERENCES usuario_administrador(id_user_admin) ON DELETE CASCADE,
+    jti_atual VARCHAR(64) NOT NULL,
+    data_expiracao_acesso TIMESTAMP NOT NULL,
+    data_criacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_ultimo_uso TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_expiracao TIMESTAMP NOT NULL,
+    data_revogacao TIMESTAMP,
+    endereco_ip VARCHAR(45),
+    user_agent VARCHAR(255)
+);
+
+CREATE INDEX idx_sessao_usuario_user ON sessao_usuario(id_user_admin);
+CREATE INDEX idx_sessao_usuario_expiracao ON sessao_usuario(data_expiracao);
+
+-- Refresh tokens opacos (armazena apenas o hash SHA-256)
+-- Tokens trocados permanecem com data_uso preenchida para detecção de reuso
+CREATE TABLE refresh_token (
+    id_refresh_token SERIAL PRIMARY KEY,
+    id_sessao INTEGER NOT NULL REFERENCES sessao_usuario(id_sessao) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    data_criacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_uso TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_token_sessao ON refresh_token(id_sessao);
+
+-- Denylist de access tokens revogados antes da expiração (por jti)
+CREATE TABLE token_revogado (
+    jti VARCHAR(64) PRIMARY KEY,
+    data_expiracao TIMESTAMP NOT NULL,
+    data_revogacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_token_revogado_expiracao ON token_revogado(data_expiracao);
+
+COMMENT ON TABLE sessao_usuario IS 'Sessões de login de usuários administradores';
+COMMENT ON TABLE refresh_token IS 'Refresh tokens rotacionados por sessão';
+COMMENT ON TABLE token_revogado IS 'Access tokens (jti) revogados antes da expiração';
@@ -0,0 +1,29 @@
+-- Migration 011: autenticacao em dois fatores (TOTP)
+-- Data: 16/10/2026
+
+-- Política da empresa: exige segundo fator de todos os administradores
+ALTER TABLE empresa ADD COLUMN mfa_obrigatorio BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Configuração TOTP por usuário (cadastro pendente enquanto ativo = FALSE)
+CREATE TABLE mfa_usuario (
+    id_user_admin INTEGER PRIMARY KEY REFERENCES usuario_administrador(id_user_admin) ON DELETE CASCADE,
+    segredo VARCHAR(64) NOT NULL,
+    ativo BOOLEAN NOT NULL DEFAULT FALSE,
+    data_criacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_ativacao TIMESTAMP,
+    ultimo_passo BIGINT NOT NULL DEFAULT 0
+);
+
+-- Códigos de recuperação de uso único (armazena apenas o hash)
+CREATE TABLE codigo_recuperacao_mfa (
+    id_codigo SERIAL PRIMARY KEY,
+    id_user_admin INTEGER NOT NULL REFERENCES usuario_administrador(id_user_admin) ON DELETE CASCADE,
+    codigo_hash VARCHAR(255) NOT NULL,
+    data_criacao TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_uso TIMESTAMP
+);
+
+CREATE INDEX idx_codigo_recuperacao_usuario ON codigo_recuperacao_mfa(id_user_admin);
+
+COMMENT ON TABLE mfa_usuario IS 'Segundo fator TOTP (RFC 6238) de usuários administradores';
+COMMENT ON TABLE codigo_recuperacao_mfa IS 'Códigos de recuperação do segundo fator';
@@ -0,0 +1,20 @@
+-- Migration 012: protecao contra forca bruta no login
+-- Data: 16/10/2026
+
+-- Falhas de login por conta (email informado) e por IP, com atraso exponencial e bloqueio temporário
+CREATE TABLE tentativa_login (
+    id_tentativa SERIAL PRIMARY KEY,
+    tipo VARCHAR(10) NOT NULL CHECK (tipo IN ('conta', 'ip')),
+    chave VARCHAR(255) NOT NULL,
+    id_user_admin INTEGER REFERENCES usuario_administrador(id_user_admin) ON DELETE CASCADE,
+    falhas INTEGER NOT NULL DEFAULT 0,
+    ultima_falha TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    proxima_tentativa TIMESTAMP,
+    bloqueado_ate TIMESTAMP,
+    UNIQUE (tipo, chave)
+);
+
+CREATE INDEX idx_tentativa_login_usuario ON tentativa_login(id_user_admin) WHERE id_user_admin IS NOT NULL;
+CREATE INDEX idx_tentativa_login_ultima_falha ON tentativa_login(ultima_falha);
+
+COMMENT ON TABLE tentativa_login IS 'Contadores de falhas de login para atraso exponencial e bloqueio temporário';
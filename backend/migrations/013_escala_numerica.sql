@@ -0,0 +1,49 @@
+-- Migration 013: escalas numericas configuraveis por pergunta
+-- Data: 16/10/2026
+
+-- Perguntas EscalaNumerica guardam a escala em opcoes_resposta: {"min":1,"max":5,"passo":1,"rotulo_min":"...","rotulo_max":"...","permite_na":true}
+-- Sem configuração a escala é de 1 a 10; respostas "Não se aplica" são gravadas como 'NA'
+
+-- Lê um limite da configuração da escala, usando o padrão quando ausente ou inválido
+CREATE OR REPLACE FUNCTION escala_limite(opcoes TEXT, campo TEXT, padrao NUMERIC)
+RETURNS NUMERIC AS $$
+BEGIN
+    IF opcoes IS NULL OR btrim(opcoes) = '' THEN
+        RETURN padrao;
+    END IF;
+    RETURN COALESCE((opcoes::jsonb ->> campo)::NUMERIC, padrao);
+EXCEPTION WHEN others THEN
+    RETURN padrao;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Média por pergunta na escala configurada e normalizada para 0-100
+-- Respostas 'NA' e fora da escala não entram na média; novas colunas ficam ao final para manter a compatibilidade da view
+CREATE OR REPLACE VIEW vw_satisfacao_media AS
+SELECT
+    e.id_pesquisa,
+    e.id_pergunta,
+    e.texto_pergunta,
+    ROUND(AVG(r.valor), 2) AS media_resposta,
+    e.escala_min,
+    e.escala_max,
+    ROUND(AVG((r.valor - e.escala_min) / (e.escala_max - e.escala_min) * 100), 2) AS media_normalizada,
+    COUNT(r.id_resposta) AS total_respostas_validas
+FROM (
+    SELECT pe.id_pesquisa, pe.id_pergunta, pe.texto_pergunta,
+           escala_limite(pe.opcoes_resposta, 'min', 1) AS escala_min,
+           escala_limite(pe.opcoes_resposta, 'max', 10) AS escala_max
+    FROM pergunta pe
+    WHERE pe.tipo_pergunta = 'EscalaNumerica'
+) e
+JOIN (
+    -- CASE garante que valores não numéricos ('NA') nunca sejam convertidos
+    SELECT r.id_resposta, r.id_pergunta,
+           CASE WHEN r.valor_resposta ~ '^-?[0-9]+(\.[0-9]+)?$' THEN CAST(r.valor_resposta AS NUMERIC) END AS valor
+    FROM resposta r
+) r ON r.id_pergunta = e.id_pergunta
+WHERE e.escala_max > e.escala_min
+  AND r.valor BETWEEN e.escala_min AND e.escala_max
+GROUP BY e.id_pesquisa, e.id_pergunta, e.texto_pergunta, e.escala_min, e.escala_max;
+
+COMMENT ON VIEW vw_satisfacao_media IS 'Média das perguntas de escala numérica, na escala configurada e normalizada para 0-100';
@@ -0,0 +1,8 @@
+-- Migration 014: minimo de respondentes (anonimato) por empresa
+-- Data: 16/10/2026
+
+-- Grupos com menos respondentes que o mínimo não são exibidos nos resultados
+ALTER TABLE empresa ADD COLUMN min_respondentes INTEGER NOT NULL DEFAULT 5
+    CHECK (min_respondentes BETWEEN 3 AND 50);
+
+COMMENT ON COLUMN empresa.min_respondentes IS 'Menor grupo de respondentes exibido em resultados agregados (anonimato)';
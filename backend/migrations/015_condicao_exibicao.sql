@@ -0,0 +1,7 @@
+-- Migration 015: condicao de exibicao das perguntas (saltos e ramificacoes)
+-- Data: 16/10/2026
+
+-- Condição em JSON sobre respostas de perguntas anteriores; NULL indica pergunta sempre exibida
+ALTER TABLE pergunta ADD COLUMN condicao_exibicao TEXT NULL;
+
+COMMENT ON COLUMN pergunta.condicao_exibicao IS 'Condição de exibição (JSON) baseada em respostas de perguntas anteriores';
@@ -0,0 +1,7 @@
+-- Migration 016: perguntas obrigatorias e opcionais
+-- Data: 16/10/2026
+
+-- Perguntas existentes continuam obrigatórias; perguntas opcionais exibidas podem ficar sem resposta
+ALTER TABLE pergunta ADD COLUMN obrigatoria BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN pergunta.obrigatoria IS 'Pergunta exibida deve ser respondida para concluir a submissão';
@@ -0,0 +1,87 @@
+-- Migration 017: modelos de pesquisa
+-- Data: 16/10/2026
+
+-- Modelos reutilizáveis de pesquisa: do sistema (id_empresa NULL, somente leitura) ou privados de uma empresa
+CREATE TABLE modelo_pesquisa (
+    id_modelo SERIAL PRIMARY KEY,
+    id_empresa INTEGER REFERENCES empresa(id_empresa) ON DELETE CASCADE,
+    chave VARCHAR(50) UNIQUE,
+    nome VARCHAR(255) NOT NULL,
+    descricao TEXT NOT NULL DEFAULT '',
+    versao_atual INTEGER NOT NULL DEFAULT 1 CHECK (versao_atual > 0),
+    ativo BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    data_atualizacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- chave identifica os modelos do sistema inseridos por migration; modelos criados pela API não têm chave
+CREATE INDEX idx_modelo_pesquisa_empresa ON modelo_pesquisa(id_empresa);
+
+-- Cada alteração das perguntas gera uma nova versão; versões anteriores não são alteradas
+CREATE TABLE modelo_pesquisa_versao (
+    id_versao SERIAL PRIMARY KEY,
+    id_modelo INTEGER NOT NULL REFERENCES modelo_pesquisa(id_modelo) ON DELETE CASCADE,
+    versao INTEGER NOT NULL CHECK (versao > 0),
+    perguntas JSONB NOT NULL,
+    id_user_admin INTEGER REFERENCES usuario_administrador(id_user_admin) ON DELETE SET NULL,
+    data_criacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (id_modelo, versao)
+);
+
+-- Registra o modelo e a versão usados na criação de cada pesquisa
+CREATE TABLE pesquisa_modelo (
+    id_pesquisa INTEGER PRIMARY KEY REFERENCES pesquisa(id_pesquisa) ON DELETE CASCADE,
+    id_modelo INTEGER NOT NULL REFERENCES modelo_pesquisa(id_modelo) ON DELETE CASCADE,
+    versao INTEGER NOT NULL
+);
+
+CREATE INDEX idx_pesquisa_modelo_modelo ON pesquisa_modelo(id_modelo);
+
+-- Modelos do sistema: itens genéricos de concordância de 1 a 5 agrupados por dimensão, com uma pergunta aberta opcional
+-- São pontos de partida para as pesquisas, não instrumentos psicometricamente validados
+INSERT INTO modelo_pesquisa (chave, nome, descricao) VALUES
+    ('engajamento', 'Engajamento', 'Orgulho, motivação, intenção de permanência e recomendação da empresa como lugar para trabalhar (eNPS).'),
+    ('lideranca', 'Liderança', 'Percepção sobre a liderança imediata: comunicação, reconhecimento, feedback, apoio e confiança.'),
+    ('bem_estar', 'Bem-estar', 'Carga de trabalho, equilíbrio entre vida pessoal e trabalho, segurança psicológica e relacionamento com a equipe.');
+
+INSERT INTO modelo_pesquisa_versao (id_modelo, versao, perguntas)
+SELECT m.id_modelo, 1, jsonb_build_array(
+    jsonb_build_object('texto_pergunta', 'Sinto orgulho de trabalhar nesta empresa.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'orgulho'),
+    jsonb_build_object('texto_pergunta', 'Meu trabalho me motiva a dar o meu melhor todos os dias.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'motivacao'),
+    jsonb_build_object('texto_pergunta', 'Entendo como o meu trabalho contribui para os objetivos da empresa.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'proposito'),
+    jsonb_build_object('texto_pergunta', 'Pretendo continuar trabalhando nesta empresa nos próximos dois anos.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'permanencia'),
+    jsonb_build_object('texto_pergunta', 'Tenho oportunidades de aprender e crescer profissionalmente aqui.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'desenvolvimento'),
+    jsonb_build_object('texto_pergunta', 'Em uma escala de 0 a 10, quanto você recomendaria esta empresa como um lugar para trabalhar?', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', '{"variante":"enps"}', 'obrigatoria', true, 'dimensao', 'enps'),
+    jsonb_build_object('texto_pergunta', 'O que mais aumentaria o seu engajamento com a empresa?', 'tipo_pergunta', 'RespostaAberta', 'obrigatoria', false, 'dimensao', 'comentarios')
+)
+FROM modelo_pesquisa m,
+    (SELECT '{"min":1,"max":5,"passo":1,"rotulo_min":"Discordo totalmente","rotulo_max":"Concordo totalmente"}'::text AS concordancia) e
+WHERE m.chave = 'engajamento';
+
+INSERT INTO modelo_pesquisa_versao (id_modelo, versao, perguntas)
+SELECT m.id_modelo, 1, jsonb_build_array(
+    jsonb_build_object('texto_pergunta', 'Minha liderança comunica com clareza o que se espera do meu trabalho.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'comunicacao'),
+    jsonb_build_object('texto_pergunta', 'Recebo feedback frequente e útil sobre o meu desempenho.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'feedback'),
+    jsonb_build_object('texto_pergunta', 'Minha liderança reconhece quando faço um bom trabalho.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'reconhecimento'),
+    jsonb_build_object('texto_pergunta', 'Posso contar com a minha liderança quando preciso de apoio.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'apoio'),
+    jsonb_build_object('texto_pergunta', 'Confio nas decisões tomadas pela minha liderança.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'confianca'),
+    jsonb_build_object('texto_pergunta', 'Minha liderança trata todas as pessoas da equipe com justiça.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'justica'),
+    jsonb_build_object('texto_pergunta', 'O que a sua liderança poderia fazer de diferente?', 'tipo_pergunta', 'RespostaAberta', 'obrigatoria', false, 'dimensao', 'comentarios')
+)
+FROM modelo_pesquisa m,
+    (SELECT '{"min":1,"max":5,"passo":1,"rotulo_min":"Discordo totalmente","rotulo_max":"Concordo totalmente"}'::text AS concordancia) e
+WHERE m.chave = 'lideranca';
+
+INSERT INTO modelo_pesquisa_versao (id_modelo, versao, perguntas)
+SELECT m.id_modelo, 1, jsonb_build_array(
+    jsonb_build_object('texto_pergunta', 'Minha carga de trabalho é adequada ao tempo disponível.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'carga_trabalho'),
+    jsonb_build_object('texto_pergunta', 'Consigo equilibrar a vida pessoal e o trabalho.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'equilibrio'),
+    jsonb_build_object('texto_pergunta', 'Sinto-me à vontade para expressar opiniões e discordar sem medo de consequências.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'seguranca_psicologica'),
+    jsonb_build_object('texto_pergunta', 'O relacionamento com as pessoas da minha equipe é respeitoso e colaborativo.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'relacionamento'),
+    jsonb_build_object('texto_pergunta', 'Raramente termino o dia de trabalho sentindo-me esgotado.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'energia'),
+    jsonb_build_object('texto_pergunta', 'A empresa se preocupa com a saúde e o bem-estar das pessoas.', 'tipo_pergunta', 'EscalaNumerica', 'opcoes_resposta', e.concordancia, 'obrigatoria', true, 'dimensao', 'cuidado'),
+    jsonb_build_object('texto_pergunta', 'O que a empresa poderia fazer para melhorar o seu bem-estar?', 'tipo_pergunta', 'RespostaAberta', 'obrigatoria', false, 'dimensao', 'comentarios')
+)
+FROM modelo_pesquisa m,
+    (SELECT '{"min":1,"max":5,"passo":1,"rotulo_min":"Discordo totalmente","rotulo_max":"Concordo totalmente"}'::text AS concordancia) e
+WHERE m.chave = 'bem_estar';
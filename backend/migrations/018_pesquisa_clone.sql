@@ -0,0 +1,11 @@
+-- Migration 018: pesquisa clone
+-- Data: 16/10/2026
+
+-- Liga cada pesquisa criada por clonagem à pesquisa de origem, para comparação entre aplicações
+CREATE TABLE pesquisa_clone (
+    id_pesquisa INTEGER PRIMARY KEY REFERENCES pesquisa(id_pesquisa) ON DELETE CASCADE,
+    id_pesquisa_origem INTEGER REFERENCES pesquisa(id_pesquisa) ON DELETE SET NULL,
+    data_criacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pesquisa_clone_origem ON pesquisa_clone(id_pesquisa_origem);
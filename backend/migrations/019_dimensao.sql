@@ -0,0 +1,20 @@
+-- Migration 019: dimensoes do clima e pontuacao invertida das perguntas
+-- Data: 16/10/2026
+
+-- Dimensões definidas pela empresa (liderança, comunicação, reconhecimento...), comuns a todas as suas pesquisas
+CREATE TABLE dimensao (
+    id_dimensao SERIAL PRIMARY KEY,
+    id_empresa INTEGER NOT NULL REFERENCES empresa(id_empresa) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    descricao TEXT NOT NULL DEFAULT '',
+    data_criacao TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (id_empresa, nome)
+);
+
+-- Cada pergunta pertence a no máximo uma dimensão; remover a dimensão apenas desvincula as perguntas
+ALTER TABLE pergunta ADD COLUMN id_dimensao INTEGER REFERENCES dimensao(id_dimensao) ON DELETE SET NULL;
+ALTER TABLE pergunta ADD COLUMN pontuacao_invertida BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_pergunta_dimensao ON pergunta(id_dimensao);
+
+COMMENT ON COLUMN pergunta.pontuacao_invertida IS 'Concordar indica clima desfavorável; a pontuação da dimensão usa o valor invertido';
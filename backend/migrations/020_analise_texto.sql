@@ -0,0 +1,21 @@
+-- Migration 020: analise das respostas abertas (sentimento, palavras-chave e topicos)
+-- Data: 16/10/2026
+
+-- Resultado por pergunta aberta, calculado pelo agendador após o encerramento da pesquisa
+-- Apenas agregados são gravados: nenhum trecho de resposta é copiado para esta tabela
+CREATE TABLE analise_texto (
+    id_analise SERIAL PRIMARY KEY,
+    id_pergunta INTEGER NOT NULL UNIQUE REFERENCES pergunta(id_pergunta) ON DELETE CASCADE,
+    id_pesquisa INTEGER NOT NULL REFERENCES pesquisa(id_pesquisa) ON DELETE CASCADE,
+    respostas INTEGER NOT NULL DEFAULT 0,
+    min_respondentes INTEGER NOT NULL,
+    sentimento JSONB NOT NULL DEFAULT '{}',
+    palavras_chave JSONB NOT NULL DEFAULT '[]',
+    topicos JSONB NOT NULL DEFAULT '[]',
+    sem_topico INTEGER NOT NULL DEFAULT 0,
+    data_analise TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_analise_texto_pesquisa ON analise_texto(id_pesquisa);
+
+COMMENT ON COLUMN analise_texto.min_respondentes IS 'Mínimo de respondentes da empresa na análise; termos e tópicos abaixo dele não são gravados';
@@ -0,0 +1,7 @@
+-- Migration 021: falhas do segundo fator na protecao contra forca bruta
+-- Data: 16/10/2026
+
+-- Códigos MFA inválidos contam por conta ('mfa') e por login pendente ('desafio'), além do IP
+ALTER TABLE tentativa_login DROP CONSTRAINT tentativa_login_tipo_check;
+ALTER TABLE tentativa_login ADD CONSTRAINT tentativa_login_tipo_check
+    CHECK (tipo IN ('conta', 'ip', 'mfa', 'desafio'));
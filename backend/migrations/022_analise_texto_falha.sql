@@ -0,0 +1,52 @@
+-- Migration 022: novas tentativas e invalidacao da analise das respostas abertas
+-- Data: 16/10/2026
+
+-- Falhas consecutivas da análise de uma pesquisa; a pesquisa só volta à fila após proxima_tentativa
+-- O registro é removido quando a análise é gravada com sucesso
+CREATE TABLE analise_texto_falha (
+    id_pesquisa INTEGER PRIMARY KEY REFERENCES pesquisa(id_pesquisa) ON DELETE CASCADE,
+    tentativas INTEGER NOT NULL DEFAULT 0,
+    ultimo_erro TEXT,
+    data_falha TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    proxima_tentativa TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_analise_texto_falha_proxima ON analise_texto_falha(proxima_tentativa);
+
+-- Respostas inseridas ou removidas tornam a análise da pergunta desatualizada
+CREATE OR REPLACE FUNCTION trg_invalidar_analise_resposta()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM analise_texto WHERE id_pergunta = OLD.id_pergunta;
+        RETURN OLD;
+    END IF;
+    DELETE FROM analise_texto WHERE id_pergunta = NEW.id_pergunta;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER after_resposta_invalidar_analise
+AFTER INSERT OR DELETE ON resposta
+FOR EACH ROW
+EXECUTE FUNCTION trg_invalidar_analise_resposta();
+
+-- Reabrir ou voltar a pesquisa para rascunho descarta a análise e as falhas anteriores
+-- Entre Concluída e Arquivada as respostas não mudam, e a análise é mantida
+CREATE OR REPLACE FUNCTION trg_invalidar_analise_pesquisa()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM analise_texto WHERE id_pesquisa = NEW.id_pesquisa;
+    DELETE FROM analise_texto_falha WHERE id_pesquisa = NEW.id_pesquisa;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER after_pesquisa_status_invalidar_analise
+AFTER UPDATE OF status ON pesquisa
+FOR EACH ROW
+WHEN (OLD.status IS DISTINCT FROM NEW.status
+      AND NOT (OLD.status IN ('Concluída', 'Arquivada') AND NEW.status IN ('Concluída', 'Arquivada')))
+EXECUTE FUNCTION trg_invalidar_analise_pesquisa();
+
+COMMENT ON TABLE analise_texto_falha IS 'Falhas da análise de texto por pesquisa, com atraso exponencial até a próxima tentativa';
@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// 2026-03-02 é uma segunda-feira
+var base = time.Date(2026, 3, 2, 8, 30, 0, 0, time.UTC)
+
+func data(mes time.Month, dia, hora, minuto int) time.Time {
+	return time.Date(2026, mes, dia, hora, minuto, 0, 0, time.UTC)
+}
+
+func TestScheduleNext(t *testing.T) {
+	casos := []struct {
+		nome     string
+		expr     string
+		inicio   time.Time
+		esperado []time.Time
+	}{
+		{"diário", "0 9 * * *", base, []time.Time{
+			data(3, 2, 9, 0), data(3, 3, 9, 0), data(3, 4, 9, 0),
+		}},
+		{"estritamente posterior", "30 8 * * *", base, []time.Time{
+			data(3, 3, 8, 30),
+		}},
+		{"passo de minutos", "*/20 9 * * *", base, []time.Time{
+			data(3, 2, 9, 0), data(3, 2, 9, 20), data(3, 2, 9, 40), data(3, 3, 9, 0),
+		}},
+		{"passo em intervalo", "0 8-14/3 * * *", base, []time.Time{
+			data(3, 2, 11, 0), data(3, 2, 14, 0), data(3, 3, 8, 0),
+		}},
+		{"passo a partir de valor", "0 0 10/10 * *", base, []time.Time{
+			data(3, 10, 0, 0), data(3, 20, 0, 0), data(3, 30, 0, 0), data(4, 10, 0, 0),
+		}},
+		{"intervalo de dias da semana", "0 9 * * mon-wed", data(3, 4, 10, 0), []time.Time{
+			data(3, 9, 9, 0), data(3, 10, 9, 0), data(3, 11, 9, 0), data(3, 16, 9, 0),
+		}},
+		{"lista e nome de mês", "0 0 1 jan,jul *", base, []time.Time{
+			data(7, 1, 0, 0), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"domingo como 0", "0 0 * * 0", base, []time.Time{
+			data(3, 8, 0, 0), data(3, 15, 0, 0),
+		}},
+		{"domingo como 7", "0 0 * * 7", base, []time.Time{
+			data(3, 8, 0, 0), data(3, 15, 0, 0),
+		}},
+		{"sexta até domingo com 7", "0 0 * * 5-7", base, []time.Time{
+			data(3, 6, 0, 0), data(3, 7, 0, 0), data(3, 8, 0, 0), data(3, 13, 0, 0),
+		}},
+		// Dia do mês e dia da semana restritos: basta um dos dois coincidir
+		{"dia do mês ou dia da semana", "0 0 13 * fri", base, []time.Time{
+			data(3, 6, 0, 0), data(3, 13, 0, 0), data(3, 20, 0, 0), data(3, 27, 0, 0), data(4, 3, 0, 0), data(4, 10, 0, 0), data(4, 13, 0, 0),
+		}},
+		{"apenas dia do mês restrito", "0 0 13 * *", base, []time.Time{
+			data(3, 13, 0, 0), data(4, 13, 0, 0),
+		}},
+		{"dia 31 pula meses curtos", "0 0 31 * *", base, []time.Time{
+			data(3, 31, 0, 0), data(5, 31, 0, 0), data(7, 31, 0, 0),
+		}},
+		{"29 de fevereiro", "0 0 29 2 *", base, []time.Time{
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			s, err := Parse(c.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", c.expr, err)
+			}
+			atual := c.inicio
+			for i, esperado := range c.esperado {
+				atual = s.Next(atual)
+				if !atual.Equal(esperado) {
+					t.Fatalf("ocorrência %d = %v, esperado %v", i+1, atual, esperado)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleNextDataImpossivel(t *testing.T) {
+	for _, expr := range []string{"0 0 30 2 *", "0 0 31 4,6,9,11 *"} {
+		s, err := Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", expr, err)
+		}
+		if next := s.Next(base); !next.IsZero() {
+			t.Errorf("%q: Next = %v, esperado valor zero", expr, next)
+		}
+	}
+}
+
+func TestParseInvalido(t *testing.T) {
+	casos := []struct {
+		nome string
+		expr string
+	}{
+		{"poucos campos", "0 9 * *"},
+		{"campos demais", "0 9 * * * *"},
+		{"minuto fora do intervalo", "60 9 * * *"},
+		{"hora fora do intervalo", "0 24 * * *"},
+		{"dia do mês zero", "0 0 0 * *"},
+		{"mês 13", "0 0 1 13 *"},
+		{"dia da semana 8", "0 0 * * 8"},
+		{"intervalo invertido", "0 0 * * 5-1"},
+		{"passo zero", "*/0 * * * *"},
+		{"passo negativo", "*/-5 * * * *"},
+		{"nome desconhecido", "0 0 * * dom"},
+		{"valor não numérico", "x 0 * * *"},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if _, err := Parse(c.expr); err == nil {
+				t.Errorf("Parse(%q) aceito, esperado erro", c.expr)
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ package crypto
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 
 	"organizational-climate-survey/backend/pkg/logger"
@@ -131,6 +133,13 @@ func (c *cryptoService) GenerateToken(length int) (string, error) {
 	return token, nil
 }
 
+// HashToken retorna o hash SHA-256 (hex) de um token de alta entropia
+// Usado para persistir tokens sem armazenar o valor original (bcrypt é desnecessário para tokens aleatórios)
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // SecureCompare realiza comparação de string em tempo constante para prevenir ataques de tempo
 // Essencial para comparar tokens, hashes e outros valores sensíveis à segurança
 func SecureCompare(a, b string) bool {
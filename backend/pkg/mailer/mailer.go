@@ -0,0 +1,143 @@
+// Package mailer define o envio de emails transacionais da aplicação.
+// A interface Mailer permite trocar o transporte: SMTP em produção e arquivo/log em desenvolvimento.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// Message representa um email em texto simples
+type Message struct {
+	To      string // Destinatário
+	Subject string // Assunto
+	Body    string // Corpo em texto simples
+}
+
+// Mailer envia mensagens de email
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPConfig agrupa as configurações de conexão SMTP
+type SMTPConfig struct {
+	Host     string // Host do servidor SMTP
+	Port     string // Porta do servidor SMTP
+	Username string // Usuário (vazio desativa autenticação)
+	Password string // Senha
+	From     string // Remetente
+}
+
+// SMTPMailer envia emails por um servidor SMTP
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer cria um mailer SMTP
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+// Garante que SMTPMailer implementa Mailer
+var _ Mailer = (*SMTPMailer)(nil)
+
+// Send envia a mensagem pelo servidor SMTP configurado
+// STARTTLS é negociado automaticamente quando suportado pelo servidor
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{msg.To}, formatMessage(m.config.From, msg, time.Now())); err != nil {
+		return fmt.Errorf("erro ao enviar email via SMTP: %v", err)
+	}
+
+	return nil
+}
+
+// FileMailer grava cada email como arquivo .eml em um diretório
+// Com diretório vazio, apenas registra destinatário e assunto no log (útil em desenvolvimento)
+type FileMailer struct {
+	dir    string
+	from   string
+	logger logger.Logger
+}
+
+// NewFileMailer cria um mailer que grava emails em dir ou os registra no log
+func NewFileMailer(dir, from string, log logger.Logger) *FileMailer {
+	return &FileMailer{dir: dir, from: from, logger: log}
+}
+
+// Garante que FileMailer implementa Mailer
+var _ Mailer = (*FileMailer)(nil)
+
+// Send grava a mensagem em disco ou no log
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if m.dir == "" {
+		// O corpo não é registrado: pode conter links com tokens de uso único
+		m.logger.Info("Email para %s: %s", msg.To, msg.Subject)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return fmt.Errorf("erro ao criar diretório de emails: %v", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), sanitizeFileName(msg.To))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, formatMessage(m.from, msg, now), 0o640); err != nil {
+		return fmt.Errorf("erro ao gravar email: %v", err)
+	}
+
+	m.logger.Debug("Email para %s gravado em %s", msg.To, path)
+	return nil
+}
+
+// formatMessage monta a mensagem no formato RFC 5322
+func formatMessage(from string, msg Message, date time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + sanitizeHeader(from) + "\r\n")
+	b.WriteString("To: " + sanitizeHeader(msg.To) + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", sanitizeHeader(msg.Subject)) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// sanitizeHeader remove quebras de linha para evitar injeção de cabeçalhos
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// sanitizeFileName mantém apenas caracteres seguros para nomes de arquivo
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' || r == '@' {
+			return r
+		}
+		return '_'
+	}, s)
+}
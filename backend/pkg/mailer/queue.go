@@ -0,0 +1,109 @@
+// Package mailer define o envio de emails transacionais da aplicação.
+// QueueMailer entrega as mensagens em segundo plano, com novas tentativas e espera crescente entre elas.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"organizational-climate-survey/backend/pkg/logger"
+)
+
+// Parâmetros de entrega da fila de emails
+const (
+	queueSendTimeout = 30 * time.Second // Limite de cada tentativa de envio
+	queueAttempts    = 3                // Tentativas por mensagem
+	queueRetryDelay  = 2 * time.Second  // Espera antes da segunda tentativa, dobrada a cada nova falha
+)
+
+// QueueMailer entrega os emails em segundo plano a partir de uma fila em memória
+// Send apenas enfileira a mensagem, sem esperar o transporte; mensagens pendentes se perdem se o processo for interrompido sem Close
+type QueueMailer struct {
+	next   Mailer
+	logger logger.Logger
+	queue  chan Message
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// NewQueueMailer cria uma fila com a capacidade informada e inicia o worker que entrega as mensagens por next
+func NewQueueMailer(next Mailer, capacity int, log logger.Logger) *QueueMailer {
+	if capacity <= 0 {
+		capacity = 100
+	}
+	m := &QueueMailer{
+		next:   next,
+		logger: log,
+		queue:  make(chan Message, capacity),
+		done:   make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// Garante que QueueMailer implementa Mailer
+var _ Mailer = (*QueueMailer)(nil)
+
+// Send enfileira a mensagem; retorna erro quando a fila está cheia ou encerrada
+func (m *QueueMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return fmt.Errorf("fila de emails encerrada")
+	}
+
+	select {
+	case m.queue <- msg:
+		return nil
+	default:
+		return fmt.Errorf("fila de emails cheia")
+	}
+}
+
+// Close deixa de aceitar mensagens e aguarda a entrega das já enfileiradas ou o cancelamento de ctx
+func (m *QueueMailer) Close(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("emails pendentes não entregues: %d", len(m.queue))
+	}
+}
+
+// run entrega as mensagens em ordem, com novas tentativas em caso de falha
+func (m *QueueMailer) run() {
+	defer close(m.done)
+
+	for msg := range m.queue {
+		delay := queueRetryDelay
+		for attempt := 1; ; attempt++ {
+			ctx, cancel := context.WithTimeout(context.Background(), queueSendTimeout)
+			err := m.next.Send(ctx, msg)
+			cancel()
+			if err == nil {
+				break
+			}
+			if attempt == queueAttempts {
+				m.logger.Error("email para %s descartado após %d tentativas: %v", msg.To, attempt, err)
+				break
+			}
+			m.logger.Warn("erro ao enviar email para %s (tentativa %d): %v", msg.To, attempt, err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+}
@@ -0,0 +1,675 @@
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// O leitor abaixo decodifica a matriz sem usar as tabelas nem as funções do codificador:
+// estrutura de blocos, centros de alinhamento e campos de formato vêm da norma (ISO/IEC 18004)
+
+// estruturaBlocos é a divisão em blocos da tabela 9 da norma para uma versão e nível
+type estruturaBlocos struct {
+	ecc   int   // Codewords de correção por bloco
+	dados []int // Codewords de dados de cada bloco
+}
+
+// estruturas dos casos testados, chaveadas por "versão-nível"
+var estruturas = map[string]estruturaBlocos{
+	"1-L":  {7, []int{19}},
+	"1-M":  {10, []int{16}},
+	"1-Q":  {13, []int{13}},
+	"1-H":  {17, []int{9}},
+	"2-H":  {28, []int{16}},
+	"3-Q":  {18, []int{17, 17}},
+	"5-Q":  {18, []int{15, 15, 16, 16}},
+	"7-M":  {18, []int{31, 31, 31, 31}},
+	"10-H": {28, []int{15, 15, 15, 15, 15, 15, 16, 16}},
+}
+
+// centrosAlinhamento são as coordenadas dos padrões de alinhamento (Anexo E) das versões testadas
+var centrosAlinhamento = map[int][]int{
+	1:  nil,
+	2:  {6, 18},
+	3:  {6, 22},
+	5:  {6, 30},
+	7:  {6, 22, 38},
+	10: {6, 28, 50},
+}
+
+// camposFormato são os 15 bits do campo de formato (já com a máscara 101010000010010), por nível e máscara
+var camposFormato = map[Level][8]string{
+	LevelL: {"111011111000100", "111001011110011", "111110110101010", "111100010011101", "110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+	LevelM: {"101010000010010", "101000100100101", "101111001111100", "101101101001011", "100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+	LevelQ: {"011010101011111", "011000001101000", "011111100110001", "011101000000110", "010010010110100", "010000110000011", "010111011011010", "010101111101101"},
+	LevelH: {"001011010001001", "001001110111110", "001110011100111", "001100111010000", "000011101100010", "000001001010101", "000110100001100", "000100000111011"},
+}
+
+// matriz é uma matriz de módulos lida de um código ou de uma imagem
+type matriz struct {
+	size int
+	dark func(x, y int) bool
+}
+
+// leitura é o resultado da decodificação de uma matriz
+type leitura struct {
+	versao   int
+	nivel    Level
+	mascara  int
+	conteudo string
+	ecc      int               // Codewords de correção por bloco
+	posicao  map[[2]int][2]int // Módulo de dados -> (bloco, codeword no bloco)
+}
+
+func matrizDoCodigo(c *Code) matriz {
+	return matriz{size: c.Size, dark: c.Dark}
+}
+
+// lerFormato retorna nível e máscara das duas cópias do campo de formato, que devem coincidir
+func lerFormato(t *testing.T, m matriz) (Level, int) {
+	t.Helper()
+	copia1 := [][2]int{{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8}, {8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0}}
+	var copia2 [][2]int
+	for i := 1; i <= 7; i++ {
+		copia2 = append(copia2, [2]int{8, m.size - i})
+	}
+	for i := 8; i >= 1; i-- {
+		copia2 = append(copia2, [2]int{m.size - i, 8})
+	}
+
+	ler := func(posicoes [][2]int) string {
+		var sb strings.Builder
+		for _, p := range posicoes {
+			if m.dark(p[0], p[1]) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+		return sb.String()
+	}
+
+	a, b := ler(copia1), ler(copia2)
+	if a != b {
+		t.Fatalf("cópias do campo de formato diferem: %s e %s", a, b)
+	}
+	for nivel, campos := range camposFormato {
+		for mascara, campo := range campos {
+			if campo == a {
+				return nivel, mascara
+			}
+		}
+	}
+	t.Fatalf("campo de formato %s não corresponde a nenhum nível e máscara", a)
+	return 0, 0
+}
+
+// moduloFuncao informa se o módulo pertence a um padrão de função ou área reservada
+func moduloFuncao(versao, size, x, y int) bool {
+	switch {
+	case x < 9 && y < 9, x >= size-8 && y < 9, x < 9 && y >= size-8:
+		return true // Localização, separadores, formato e módulo escuro
+	case x == 6 || y == 6:
+		return true // Temporização
+	case versao >= 7 && (x >= size-11 && x < size-8 && y < 6 || y >= size-11 && y < size-8 && x < 6):
+		return true // Versão
+	}
+	centros := centrosAlinhamento[versao]
+	ultimo := len(centros) - 1
+	for i, cx := range centros {
+		for j, cy := range centros {
+			if i == 0 && j == 0 || i == 0 && j == ultimo || i == ultimo && j == 0 {
+				continue
+			}
+			if abs(x-cx) <= 2 && abs(y-cy) <= 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inverte aplica a condição da máscara da tabela 10 da norma (i = linha, j = coluna)
+func inverte(mascara, j, i int) bool {
+	switch mascara {
+	case 0:
+		return (i+j)%2 == 0
+	case 1:
+		return i%2 == 0
+	case 2:
+		return j%3 == 0
+	case 3:
+		return (i+j)%3 == 0
+	case 4:
+		return (i/2+j/3)%2 == 0
+	case 5:
+		return (i*j)%2+(i*j)%3 == 0
+	case 6:
+		return ((i*j)%2+(i*j)%3)%2 == 0
+	default:
+		return ((i+j)%2+(i*j)%3)%2 == 0
+	}
+}
+
+// gfExp e gfLog são as tabelas de GF(2^8) com o polinômio x^8+x^4+x^3+x^2+1
+var gfExp, gfLog = func() ([512]int, [256]int) {
+	var exp [512]int
+	var log [256]int
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i], log[x] = x, i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}()
+
+// sindromesNulas verifica se o bloco (dados seguidos da correção) é uma palavra válida do código de Reed-Solomon
+func sindromesNulas(bloco []byte, ecc int) bool {
+	n := len(bloco)
+	for j := 0; j < ecc; j++ {
+		s := 0
+		for k, c := range bloco {
+			if c != 0 {
+				s ^= gfExp[(gfLog[c]+j*(n-1-k))%255]
+			}
+		}
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// decodificar lê a matriz: formato, máscara, codewords em zigue-zague, blocos, Reed-Solomon e modo byte
+func decodificar(t *testing.T, m matriz) leitura {
+	t.Helper()
+	if (m.size-17)%4 != 0 {
+		t.Fatalf("tamanho %d não corresponde a uma versão", m.size)
+	}
+	r := leitura{versao: (m.size - 17) / 4, posicao: make(map[[2]int][2]int)}
+	r.nivel, r.mascara = lerFormato(t, m)
+
+	estrutura, ok := estruturas[strconv.Itoa(r.versao)+"-"+r.nivel.String()]
+	if !ok {
+		t.Fatalf("versão %d-%s fora dos casos testados", r.versao, r.nivel)
+	}
+	r.ecc = estrutura.ecc
+
+	var bits []bool
+	var modulos [][2]int
+	subindo := true
+	for direita := m.size - 1; direita >= 1; direita -= 2 {
+		if direita == 6 {
+			direita = 5
+		}
+		for k := 0; k < m.size; k++ {
+			y := k
+			if subindo {
+				y = m.size - 1 - k
+			}
+			for _, x := range []int{direita, direita - 1} {
+				if moduloFuncao(r.versao, m.size, x, y) {
+					continue
+				}
+				bits = append(bits, m.dark(x, y) != inverte(r.mascara, x, y))
+				modulos = append(modulos, [2]int{x, y})
+			}
+		}
+		subindo = !subindo
+	}
+
+	// Ordem de intercalação: dados e depois correção, um codeword de cada bloco por vez
+	var ordem [][2]int
+	maxDados := 0
+	for _, n := range estrutura.dados {
+		maxDados = max(maxDados, n)
+	}
+	for i := 0; i < maxDados; i++ {
+		for b, n := range estrutura.dados {
+			if i < n {
+				ordem = append(ordem, [2]int{b, i})
+			}
+		}
+	}
+	for i := 0; i < estrutura.ecc; i++ {
+		for b, n := range estrutura.dados {
+			ordem = append(ordem, [2]int{b, n + i})
+		}
+	}
+	if len(bits)/8 != len(ordem) {
+		t.Fatalf("%d codewords na matriz, esperado %d", len(bits)/8, len(ordem))
+	}
+
+	blocos := make([][]byte, len(estrutura.dados))
+	for b, n := range estrutura.dados {
+		blocos[b] = make([]byte, n+estrutura.ecc)
+	}
+	for i, destino := range ordem {
+		var codeword byte
+		for k := 0; k < 8; k++ {
+			codeword <<= 1
+			if bits[i*8+k] {
+				codeword |= 1
+			}
+			r.posicao[modulos[i*8+k]] = destino
+		}
+		blocos[destino[0]][destino[1]] = codeword
+	}
+
+	var dados []byte
+	for b, bloco := range blocos {
+		if !sindromesNulas(bloco, estrutura.ecc) {
+			t.Fatalf("bloco %d com síndromes de Reed-Solomon não nulas", b)
+		}
+		dados = append(dados, bloco[:estrutura.dados[b]]...)
+	}
+
+	// Modo byte: 0100, quantidade, bytes, terminador e preenchimento 0xEC/0x11
+	pos := 0
+	ler := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | int(dados[pos/8]>>(7-pos%8)&1)
+			pos++
+		}
+		return v
+	}
+	if modo := ler(4); modo != 0x4 {
+		t.Fatalf("modo %04b, esperado 0100 (byte)", modo)
+	}
+	tamanho := ler(8)
+	if r.versao >= 10 {
+		tamanho = tamanho<<8 | ler(8)
+	}
+	conteudo := make([]byte, tamanho)
+	for i := range conteudo {
+		conteudo[i] = byte(ler(8))
+	}
+	r.conteudo = string(conteudo)
+
+	if resto := len(dados)*8 - pos; resto > 0 && ler(min(4, resto)) != 0 {
+		t.Fatalf("terminador não nulo")
+	}
+	pos = (pos + 7) / 8 * 8
+	for i, pad := pos/8, byte(0xEC); i < len(dados); i, pad = i+1, pad^0xEC^0x11 {
+		if dados[i] != pad {
+			t.Fatalf("preenchimento %#x no codeword %d, esperado %#x", dados[i], i, pad)
+		}
+	}
+	return r
+}
+
+// matrizPNG amostra o centro de cada módulo da imagem; o tamanho do módulo vem do padrão de localização
+func matrizPNG(t *testing.T, data []byte) matriz {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG inválido: %v", err)
+	}
+	escuro := func(x, y int) bool {
+		r, g, b, _ := img.At(x, y).RGBA()
+		return r+g+b < 3*0x8000
+	}
+
+	largura := img.Bounds().Dx()
+	if img.Bounds().Dy() != largura {
+		t.Fatalf("imagem %dx%d não é quadrada", largura, img.Bounds().Dy())
+	}
+	inicio := -1
+	for i := 0; i < largura && inicio < 0; i++ {
+		if escuro(i, i) {
+			inicio = i
+		}
+	}
+	if inicio < 0 {
+		t.Fatalf("nenhum módulo escuro na diagonal")
+	}
+	fim := inicio
+	for fim < largura && escuro(fim, inicio) {
+		fim++
+	}
+	escala := (fim - inicio) / 7
+	if escala < 1 || inicio != QuietZone*escala {
+		t.Fatalf("margem de %d pixels para módulo de %d pixels, esperado %d módulos", inicio, escala, QuietZone)
+	}
+
+	return matriz{
+		size: largura/escala - 2*QuietZone,
+		dark: func(x, y int) bool {
+			return escuro((x+QuietZone)*escala+escala/2, (y+QuietZone)*escala+escala/2)
+		},
+	}
+}
+
+var (
+	viewBoxSVG = regexp.MustCompile(`viewBox="0 0 (\d+) (\d+)"`)
+	moduloSVG  = regexp.MustCompile(`M(\d+) (\d+)h1v1h-1z`)
+)
+
+// matrizSVG reconstrói a matriz a partir dos quadrados do caminho do SVG
+func matrizSVG(t *testing.T, data []byte) matriz {
+	t.Helper()
+	viewBox := viewBoxSVG.FindSubmatch(data)
+	if viewBox == nil {
+		t.Fatalf("SVG sem viewBox")
+	}
+	total, _ := strconv.Atoi(string(viewBox[1]))
+
+	escuros := make(map[[2]int]bool)
+	for _, m := range moduloSVG.FindAllSubmatch(data, -1) {
+		x, _ := strconv.Atoi(string(m[1]))
+		y, _ := strconv.Atoi(string(m[2]))
+		escuros[[2]int{x - QuietZone, y - QuietZone}] = true
+	}
+	return matriz{
+		size: total - 2*QuietZone,
+		dark: func(x, y int) bool { return escuros[[2]int{x, y}] },
+	}
+}
+
+func TestReedSolomonVetorHelloWorld(t *testing.T) {
+	// "HELLO WORLD" em 1-M, exemplo clássico da norma: 16 codewords de dados e 10 de correção
+	dados := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	esperado := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if correcao := reedSolomonRemainder(dados, reedSolomonDivisor(len(esperado))); !bytes.Equal(correcao, esperado) {
+		t.Errorf("correção %v, esperado %v", correcao, esperado)
+	}
+}
+
+func TestCampoFormatoVetores(t *testing.T) {
+	for _, nivel := range []Level{LevelL, LevelM, LevelQ, LevelH} {
+		for mascara := 0; mascara < 8; mascara++ {
+			c := newCode(1, nivel)
+			c.drawFormatBits(mascara)
+			if l, m := lerFormato(t, matrizDoCodigo(c)); l != nivel || m != mascara {
+				t.Errorf("formato %s/%d lido como %s/%d", nivel, mascara, l, m)
+			}
+		}
+	}
+}
+
+func TestCampoVersaoVetores(t *testing.T) {
+	// Anexo D: versão 7 = 000111110010010100, versão 10 = 001010010011010011
+	casos := map[int]string{7: "000111110010010100", 10: "001010010011010011"}
+
+	for versao, esperado := range casos {
+		c := newCode(versao, LevelM)
+		c.drawVersion()
+
+		// Bit 0 (menos significativo) em (size-11, 0), seguindo para baixo em blocos de 3 colunas
+		var sb strings.Builder
+		for i := 17; i >= 0; i-- {
+			if c.Dark(c.Size-11+i%3, i/3) != c.Dark(i/3, c.Size-11+i%3) {
+				t.Fatalf("versão %d: cópias do campo diferem no bit %d", versao, i)
+			}
+			if c.Dark(c.Size-11+i%3, i/3) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+		if sb.String() != esperado {
+			t.Errorf("versão %d: campo %s, esperado %s", versao, sb.String(), esperado)
+		}
+	}
+}
+
+// matrizCLIMA é o código de "CLIMA-ORG-2026" em 1-M (máscara 2), conferido pelo leitor deste arquivo
+var matrizCLIMA = []string{
+	"#######.......#######",
+	"#.....#..##...#.....#",
+	"#.###.#.###...#.###.#",
+	"#.###.#.#..#..#.###.#",
+	"#.###.#.#...#.#.###.#",
+	"#.....#.##..#.#.....#",
+	"#######.#.#.#.#######",
+	"........#............",
+	"#.#####..####.#####..",
+	"#####..#.###.....#...",
+	"....#.##..#.#.#...##.",
+	"#.##.....##..#..#####",
+	"......#.#...#...#.#..",
+	"........####.#.#.....",
+	"#######..#..###..###.",
+	"#.....#.###.#....##.#",
+	"#.###.#.###.#.#.#.###",
+	"#.###.#.#.##.#####...",
+	"#.###.#.###.##.#.##..",
+	"#.....#..##......##..",
+	"#######.###.####..##.",
+}
+
+func TestEncodeMatrizConhecida(t *testing.T) {
+	c, err := Encode("CLIMA-ORG-2026", LevelM)
+	if err != nil {
+		t.Fatalf("erro ao codificar: %v", err)
+	}
+	if c.Version != 1 || c.Level != LevelM || c.Size != len(matrizCLIMA) {
+		t.Fatalf("código %d-%s com %d módulos, esperado 1-M com %d", c.Version, c.Level, c.Size, len(matrizCLIMA))
+	}
+
+	for y, linha := range matrizCLIMA {
+		for x, modulo := range linha {
+			if c.Dark(x, y) != (modulo == '#') {
+				t.Errorf("módulo (%d, %d) diverge da matriz conhecida", x, y)
+			}
+		}
+	}
+
+	if r := decodificar(t, matrizDoCodigo(c)); r.conteudo != "CLIMA-ORG-2026" || r.mascara != 2 {
+		t.Errorf("lido %q com máscara %d", r.conteudo, r.mascara)
+	}
+}
+
+// Cada caso ocupa a capacidade exata em modo byte da versão e nível, de modo que nenhum dos dois muda
+var casosCapacidade = []struct {
+	versao  int
+	nivel   Level
+	tamanho int
+}{
+	{1, LevelL, 17},
+	{1, LevelM, 14},
+	{1, LevelQ, 11},
+	{1, LevelH, 7},
+	{2, LevelH, 14},
+	{5, LevelQ, 60},
+	{7, LevelM, 122},
+	{10, LevelH, 119},
+}
+
+func conteudoTeste(tamanho int) string {
+	base := "https://clima.example.com/p/Qx7-ação?"
+	var sb strings.Builder
+	for sb.Len() < tamanho {
+		sb.WriteString(base)
+	}
+	return sb.String()[:tamanho]
+}
+
+func TestEncodeDecodificaNasCapacidadesDaNorma(t *testing.T) {
+	for _, caso := range casosCapacidade {
+		nome := strconv.Itoa(caso.versao) + "-" + caso.nivel.String()
+		t.Run(nome, func(t *testing.T) {
+			conteudo := conteudoTeste(caso.tamanho)
+			c, err := Encode(conteudo, caso.nivel)
+			if err != nil {
+				t.Fatalf("erro ao codificar: %v", err)
+			}
+			if c.Version != caso.versao || c.Level != caso.nivel {
+				t.Fatalf("código %d-%s, esperado %s", c.Version, c.Level, nome)
+			}
+
+			// Um byte a mais exige a versão seguinte
+			if maior, err := Encode(conteudo+"x", caso.nivel); err != nil || maior.Version != caso.versao+1 {
+				t.Errorf("conteúdo acima da capacidade não passou para a versão %d", caso.versao+1)
+			}
+
+			r := decodificar(t, matrizDoCodigo(c))
+			if r.conteudo != conteudo || r.nivel != caso.nivel {
+				t.Errorf("lido %q (%s), esperado %q (%s)", r.conteudo, r.nivel, conteudo, caso.nivel)
+			}
+		})
+	}
+}
+
+func TestEncodeElevaNivelComEspacoSobrando(t *testing.T) {
+	c, err := Encode("abc", LevelL)
+	if err != nil {
+		t.Fatalf("erro ao codificar: %v", err)
+	}
+	if c.Version != 1 || c.Level != LevelH {
+		t.Errorf("código %d-%s, esperado 1-H", c.Version, c.Level)
+	}
+}
+
+func TestEncodeConteudoMuitoLongo(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 2954), LevelL); err == nil {
+		t.Error("conteúdo acima da capacidade da versão 40-L foi aceito")
+	}
+	if c, err := Encode(strings.Repeat("a", 2953), LevelL); err != nil || c.Version != 40 {
+		t.Errorf("capacidade máxima da versão 40-L rejeitada: %v", err)
+	}
+}
+
+func TestPNGDecodifica(t *testing.T) {
+	for _, caso := range casosCapacidade {
+		conteudo := conteudoTeste(caso.tamanho)
+		data, err := PNG(conteudo, Options{Size: 300, Level: caso.nivel})
+		if err != nil {
+			t.Fatalf("erro ao gerar PNG: %v", err)
+		}
+
+		if r := decodificar(t, matrizPNG(t, data)); r.conteudo != conteudo {
+			t.Errorf("%d-%s: lido %q, esperado %q", caso.versao, caso.nivel, r.conteudo, conteudo)
+		}
+	}
+}
+
+func TestSVGDecodifica(t *testing.T) {
+	for _, caso := range casosCapacidade {
+		conteudo := conteudoTeste(caso.tamanho)
+		data, err := SVG(conteudo, Options{Size: 300, Level: caso.nivel})
+		if err != nil {
+			t.Fatalf("erro ao gerar SVG: %v", err)
+		}
+		if !bytes.Contains(data, []byte(`width="300" height="300"`)) {
+			t.Errorf("%d-%s: dimensões declaradas ausentes", caso.versao, caso.nivel)
+		}
+
+		if r := decodificar(t, matrizSVG(t, data)); r.conteudo != conteudo {
+			t.Errorf("%d-%s: lido %q, esperado %q", caso.versao, caso.nivel, r.conteudo, conteudo)
+		}
+	}
+}
+
+func logoTeste() image.Image {
+	logo := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			logo.Set(x, y, color.RGBA{0xC0, 0x10, 0x10, 0xFF})
+		}
+	}
+	return logo
+}
+
+func TestLogoElevaNivelParaPeloMenosQ(t *testing.T) {
+	casos := []struct {
+		pedido   Level
+		esperado Level
+	}{
+		{LevelL, LevelQ},
+		{LevelM, LevelQ},
+		{LevelQ, LevelQ},
+		{LevelH, LevelH},
+	}
+
+	for _, c := range casos {
+		opts, err := Options{Level: c.pedido, Logo: logoTeste()}.normalize()
+		if err != nil {
+			t.Fatalf("erro ao normalizar: %v", err)
+		}
+		if opts.Level != c.esperado {
+			t.Errorf("nível %s com logo virou %s, esperado %s", c.pedido, opts.Level, c.esperado)
+		}
+
+		semLogo, _ := Options{Level: c.pedido}.normalize()
+		if semLogo.Level != c.pedido {
+			t.Errorf("nível %s sem logo virou %s", c.pedido, semLogo.Level)
+		}
+	}
+}
+
+func TestLogoCabeNaCapacidadeDeCorrecao(t *testing.T) {
+	// 3-Q: com o nível elevado para Q, o logo cobre o centro de um código com dois blocos
+	conteudo := conteudoTeste(30)
+
+	limpo, err := Encode(conteudo, LevelQ)
+	if err != nil {
+		t.Fatalf("erro ao codificar: %v", err)
+	}
+	if limpo.Version != 3 || limpo.Level != LevelQ {
+		t.Fatalf("código %d-%s, esperado 3-Q", limpo.Version, limpo.Level)
+	}
+	referencia := decodificar(t, matrizDoCodigo(limpo))
+
+	data, err := PNG(conteudo, Options{Size: 512, Level: LevelL, Logo: logoTeste()})
+	if err != nil {
+		t.Fatalf("erro ao gerar PNG: %v", err)
+	}
+	comLogo := matrizPNG(t, data)
+	if nivel, _ := lerFormato(t, comLogo); nivel != LevelQ {
+		t.Fatalf("PNG com logo no nível %s, esperado Q", nivel)
+	}
+
+	// Codewords alterados pelo logo, por bloco: cada bloco corrige até ecc/2
+	danificados := make(map[[2]int]bool)
+	for y := 0; y < limpo.Size; y++ {
+		for x := 0; x < limpo.Size; x++ {
+			if comLogo.dark(x, y) == limpo.Dark(x, y) {
+				continue
+			}
+			destino, dado := referencia.posicao[[2]int{x, y}]
+			if !dado {
+				t.Fatalf("logo cobre o módulo de função (%d, %d)", x, y)
+			}
+			danificados[destino] = true
+		}
+	}
+	if len(danificados) == 0 {
+		t.Fatal("logo não alterou nenhum módulo")
+	}
+	porBloco := make(map[int]int)
+	for destino := range danificados {
+		porBloco[destino[0]]++
+	}
+	for bloco, n := range porBloco {
+		if n > referencia.ecc/2 {
+			t.Errorf("bloco %d com %d codewords cobertos pelo logo, limite %d", bloco, n, referencia.ecc/2)
+		}
+	}
+
+	svg, err := SVG(conteudo, Options{Level: LevelL, Logo: logoTeste()})
+	if err != nil {
+		t.Fatalf("erro ao gerar SVG: %v", err)
+	}
+	if !bytes.Contains(svg, []byte("<image ")) {
+		t.Error("SVG sem o logo")
+	}
+	// No SVG o logo é sobreposto: o caminho mantém todos os módulos
+	if r := decodificar(t, matrizSVG(t, svg)); r.nivel != LevelQ || r.conteudo != conteudo {
+		t.Errorf("SVG com logo lido %q no nível %s", r.conteudo, r.nivel)
+	}
+}
@@ -0,0 +1,212 @@
+// Package qrcode gera códigos QR (ISO/IEC 18004) em Go puro.
+// Renderiza a matriz de módulos em PNG e SVG, com logo opcional sobreposto ao centro.
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// Parâmetros de renderização
+const (
+	QuietZone   = 4    // Margem clara obrigatória, em módulos
+	DefaultSize = 512  // Largura padrão da imagem em pixels
+	MinSize     = 64   // Menor largura aceita
+	MaxSize     = 2048 // Maior largura aceita
+	logoRatio   = 0.2  // Fração da largura ocupada pelo logo
+)
+
+// Options controla a renderização da imagem
+type Options struct {
+	Size  int         // Largura desejada em pixels (a imagem PNG usa o maior múltiplo inteiro do módulo que cabe)
+	Level Level       // Nível de correção mínimo
+	Logo  image.Image // Logo opcional sobreposto ao centro; eleva o nível para pelo menos Q
+}
+
+// normalize aplica padrões e limites às opções
+func (o Options) normalize() (Options, error) {
+	if o.Size == 0 {
+		o.Size = DefaultSize
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return o, fmt.Errorf("tamanho deve estar entre %d e %d pixels", MinSize, MaxSize)
+	}
+	if o.Level < LevelL || o.Level > LevelH {
+		return o, fmt.Errorf("nível de correção inválido: %d", o.Level)
+	}
+	if o.Logo != nil && o.Level < LevelQ {
+		o.Level = LevelQ
+	}
+	return o, nil
+}
+
+// PNG gera a imagem PNG do código QR do conteúdo
+func PNG(content string, opts Options) ([]byte, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := Encode(content, opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	total := code.Size + 2*QuietZone
+	scale := opts.Size / total
+	if scale < 1 {
+		scale = 1
+	}
+	width := total * scale
+
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if !code.Dark(x, y) {
+				continue
+			}
+			px, py := (x+QuietZone)*scale, (y+QuietZone)*scale
+			for dy := 0; dy < scale; dy++ {
+				row := img.Pix[(py+dy)*img.Stride:]
+				for dx := 0; dx < scale; dx++ {
+					row[px+dx] = 0x00
+				}
+			}
+		}
+	}
+
+	var out image.Image = img
+	if opts.Logo != nil {
+		rgba := image.NewRGBA(img.Bounds())
+		for y := 0; y < width; y++ {
+			for x := 0; x < width; x++ {
+				rgba.Set(x, y, img.GrayAt(x, y))
+			}
+		}
+		drawLogo(rgba, opts.Logo)
+		out = rgba
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		return nil, fmt.Errorf("erro ao codificar PNG: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG gera a imagem SVG do código QR do conteúdo
+// A imagem é vetorial: Size define apenas as dimensões declaradas
+func SVG(content string, opts Options) ([]byte, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := Encode(content, opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	total := code.Size + 2*QuietZone
+
+	var path strings.Builder
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Dark(x, y) {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		opts.Size, opts.Size, total, total)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="#FFFFFF"/>`+"\n")
+	fmt.Fprintf(&buf, `<path d="%s" fill="#000000"/>`+"\n", path.String())
+
+	if opts.Logo != nil {
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, opts.Logo); err != nil {
+			return nil, fmt.Errorf("erro ao codificar logo: %v", err)
+		}
+		box, pad := logoBox(float64(total))
+		offset := (float64(total) - box) / 2
+		fmt.Fprintf(&buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="#FFFFFF"/>`+"\n", offset, offset, box, box)
+		fmt.Fprintf(&buf, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" preserveAspectRatio="xMidYMid meet" href="data:image/png;base64,%s"/>`+"\n",
+			offset+pad, offset+pad, box-2*pad, box-2*pad, base64.StdEncoding.EncodeToString(logoPNG.Bytes()))
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
+
+// logoBox calcula o lado da área branca do logo e a margem interna, na unidade da imagem
+func logoBox(width float64) (box, pad float64) {
+	box = width * logoRatio
+	pad = box * 0.1
+	return box, pad
+}
+
+// drawLogo desenha o logo centralizado sobre um quadrado branco, mantendo a proporção
+// O redimensionamento é por vizinho mais próximo, suficiente para logos pequenos
+func drawLogo(dst *image.RGBA, logo image.Image) {
+	width := dst.Bounds().Dx()
+	boxF, padF := logoBox(float64(width))
+	box, pad := int(boxF), int(padF)
+	if box-2*pad < 1 {
+		return
+	}
+
+	origin := (width - box) / 2
+	white := color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	for y := origin; y < origin+box; y++ {
+		for x := origin; x < origin+box; x++ {
+			dst.SetRGBA(x, y, white)
+		}
+	}
+
+	src := logo.Bounds()
+	if src.Empty() {
+		return
+	}
+	inner := box - 2*pad
+	w, h := inner, inner
+	if src.Dx() > src.Dy() {
+		h = inner * src.Dy() / src.Dx()
+	} else {
+		w = inner * src.Dx() / src.Dy()
+	}
+	if w < 1 || h < 1 {
+		return
+	}
+
+	x0 := origin + pad + (inner-w)/2
+	y0 := origin + pad + (inner-h)/2
+	for y := 0; y < h; y++ {
+		sy := src.Min.Y + y*src.Dy()/h
+		for x := 0; x < w; x++ {
+			sx := src.Min.X + x*src.Dx()/w
+			r, g, b, a := logo.At(sx, sy).RGBA()
+			if a == 0 {
+				continue
+			}
+			// Composição sobre fundo branco (cores pré-multiplicadas)
+			inv := 0xFFFF - a
+			dst.SetRGBA(x0+x, y0+y, color.RGBA{
+				R: uint8((r + inv) >> 8),
+				G: uint8((g + inv) >> 8),
+				B: uint8((b + inv) >> 8),
+				A: 0xFF,
+			})
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package ratelimit implementa limitação de taxa por token bucket.
+// O armazenamento dos buckets fica atrás da interface Store, permitindo trocar a
+// implementação em memória por um armazenamento compartilhado (ex: Redis) entre instâncias.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit define a capacidade e a taxa de reposição de um bucket
+type Limit struct {
+	Requests int           // Tokens repostos a cada período
+	Period   time.Duration // Período de reposição completa
+	Burst    int           // Capacidade máxima do bucket (padrão: Requests)
+}
+
+// capacity retorna a capacidade efetiva do bucket
+func (l Limit) capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// rate retorna a taxa de reposição em tokens por segundo
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Valid verifica se o limite pode ser aplicado
+func (l Limit) Valid() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// String formata o limite no mesmo formato aceito por ParseLimit
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// ParseLimit interpreta limites no formato "requisições/período[,burst]" (ex: "60/1m", "10/1m,5")
+func ParseLimit(s string) (Limit, error) {
+	var l Limit
+
+	spec, burst, hasBurst := strings.Cut(strings.TrimSpace(s), ",")
+	reqs, period, ok := strings.Cut(spec, "/")
+	if !ok {
+		return l, fmt.Errorf("limite inválido %q: use requisições/período (ex: 60/1m)", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(reqs))
+	if err != nil || n <= 0 {
+		return l, fmt.Errorf("limite inválido %q: número de requisições deve ser positivo", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return l, fmt.Errorf("limite inválido %q: período deve ser uma duração positiva", s)
+	}
+	l.Requests, l.Period = n, d
+
+	if hasBurst {
+		b, err := strconv.Atoi(strings.TrimSpace(burst))
+		if err != nil || b <= 0 {
+			return l, fmt.Errorf("limite inválido %q: burst deve ser positivo", s)
+		}
+		l.Burst = b
+	}
+
+	return l, nil
+}
+
+// Result descreve a decisão para uma requisição
+type Result struct {
+	Allowed    bool          // Requisição permitida
+	Limit      int           // Capacidade do bucket
+	Remaining  int           // Tokens restantes após a requisição
+	RetryAfter time.Duration // Espera até o próximo token (quando negada)
+	Reset      time.Duration // Tempo até o bucket ficar cheio novamente
+}
+
+// Store consome tokens de buckets identificados por chave
+// Implementações compartilhadas devem executar Take de forma atômica
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// bucket guarda o estado de um token bucket
+type bucket struct {
+	tokens float64   // Tokens disponíveis
+	last   time.Time // Última reposição
+}
+
+// MemoryStore armazena buckets em memória, válido para uma única instância
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	idleTTL   time.Duration // Buckets sem uso por este tempo são descartados
+	lastSweep time.Time
+}
+
+// NewMemoryStore cria um armazenamento em memória
+// Buckets ociosos por mais de idleTTL são removidos periodicamente para limitar o uso de memória;
+// idleTTL deve ser maior ou igual ao maior período configurado, senão buckets voltam cheios antes da hora
+func NewMemoryStore(idleTTL time.Duration) *MemoryStore {
+	if idleTTL <= 0 {
+		idleTTL = time.Hour
+	}
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		idleTTL: idleTTL,
+	}
+}
+
+// Garante que MemoryStore implementa Store
+var _ Store = (*MemoryStore)(nil)
+
+// Take consome um token do bucket da chave, repondo os tokens proporcionalmente ao tempo decorrido
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	if !limit.Valid() {
+		return Result{}, fmt.Errorf("limite inválido: %s", limit)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	capacity := float64(limit.capacity())
+	rate := limit.rate()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.last = now
+	}
+
+	res := Result{Limit: limit.capacity()}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+
+	res.Remaining = int(math.Floor(b.tokens))
+	res.Reset = secondsToDuration((capacity - b.tokens) / rate)
+
+	return res, nil
+}
+
+// sweep remove buckets ociosos, no máximo uma vez por idleTTL
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.idleTTL {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.last) > s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,47 @@
+// Package report gera arquivos de relatório (CSV, XLSX e PDF) a partir de um modelo tabular simples.
+// Escreve o CSV em UTF-8 com BOM, uma seção após a outra.
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+)
+
+// utf8BOM faz o Excel reconhecer a codificação UTF-8 ao abrir o CSV
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// renderCSV escreve o documento como CSV, uma seção após a outra separadas por linha em branco
+func renderCSV(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(utf8BOM)
+
+	w := csv.NewWriter(&buf)
+
+	if doc.Title != "" {
+		w.Write([]string{doc.Title})
+	}
+	if doc.Subtitle != "" {
+		w.Write([]string{doc.Subtitle})
+	}
+
+	for _, section := range doc.Sections {
+		w.Write([]string{})
+		if section.Title != "" {
+			w.Write([]string{section.Title})
+		}
+		if len(section.Headers) > 0 {
+			w.Write(section.Headers)
+		}
+		for _, row := range section.Rows {
+			w.Write(row)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("erro ao gerar CSV: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,342 @@
+// Package report gera arquivos de relatório (CSV, XLSX e PDF) a partir de um modelo tabular simples.
+// Escreve o PDF 1.4 com as fontes padrão Helvetica, quebra de páginas e tabela xref.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Geometria da página A4 em pontos PDF
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfMargin       = 40.0
+	pdfFontSize     = 9.0
+	pdfLeading      = 11.0
+	pdfCellPadding  = 3.0
+	pdfCharWidthEm  = 0.52 // largura média aproximada de um caractere Helvetica, em em
+	pdfTitleSize    = 15.0
+	pdfSectionSize  = 11.0
+	pdfFooterSize   = 8.0
+	pdfMinColWidth  = 30.0
+	pdfContentWidth = pdfPageWidth - 2*pdfMargin
+)
+
+// pdfWriter acumula o conteúdo de cada página durante o layout
+type pdfWriter struct {
+	pages []*bytes.Buffer // Stream de conteúdo de cada página
+	y     float64         // Posição vertical atual na página corrente
+}
+
+// renderPDF escreve o documento como PDF usando as fontes padrão Helvetica
+func renderPDF(doc *Document) ([]byte, error) {
+	w := &pdfWriter{}
+	w.newPage()
+
+	if doc.Title != "" {
+		w.text(pdfMargin, w.y-pdfTitleSize, "F2", pdfTitleSize, doc.Title)
+		w.y -= pdfTitleSize + 6
+	}
+	if doc.Subtitle != "" {
+		w.text(pdfMargin, w.y-pdfFontSize, "F1", pdfFontSize, doc.Subtitle)
+		w.y -= pdfFontSize + 6
+	}
+
+	for _, section := range doc.Sections {
+		w.section(section)
+	}
+
+	w.footers()
+	return w.bytes(), nil
+}
+
+func (w *pdfWriter) newPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.y = pdfPageHeight - pdfMargin
+}
+
+func (w *pdfWriter) current() *bytes.Buffer {
+	return w.pages[len(w.pages)-1]
+}
+
+// ensure inicia nova página se não houver espaço vertical suficiente
+func (w *pdfWriter) ensure(height float64) bool {
+	if w.y-height < pdfMargin+pdfFooterSize+6 {
+		w.newPage()
+		return true
+	}
+	return false
+}
+
+func (w *pdfWriter) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(w.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+func (w *pdfWriter) section(section Section) {
+	widths := pdfColumnWidths(section)
+
+	// Título da seção + cabeçalho + ao menos uma linha devem caber juntos
+	w.ensure(pdfSectionSize + 10 + 3*pdfLeading)
+	w.y -= 10
+	if section.Title != "" {
+		w.text(pdfMargin, w.y-pdfSectionSize, "F2", pdfSectionSize, section.Title)
+		w.y -= pdfSectionSize + 6
+	}
+
+	if len(section.Headers) > 0 {
+		w.row(section.Headers, widths, true, nil)
+	}
+
+	for _, row := range section.Rows {
+		lines := wrapRow(row, widths)
+		if w.ensure(rowHeight(lines)) && len(section.Headers) > 0 {
+			w.row(section.Headers, widths, true, nil)
+		}
+		w.row(row, widths, false, section.HeatMap)
+	}
+}
+
+// row desenha uma linha da tabela com quebra de texto nas células
+// heat, quando informado, pinta o fundo das células numéricas
+func (w *pdfWriter) row(cells []string, widths []float64, header bool, heat *HeatMap) {
+	lines := wrapRow(cells, widths)
+	height := rowHeight(lines)
+	w.ensure(height)
+
+	buf := w.current()
+	if header {
+		fmt.Fprintf(buf, "0.90 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, w.y-height, pdfContentWidth, height)
+	}
+
+	if heat != nil {
+		x := pdfMargin
+		for i := range widths {
+			if i < len(cells) {
+				if level, ok := heat.level(i, cells[i]); ok {
+					c := heatColors[level]
+					fmt.Fprintf(buf, "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f 0 g\n",
+						float64(c[0])/255, float64(c[1])/255, float64(c[2])/255, x, w.y-height, widths[i], height)
+				}
+			}
+			x += widths[i]
+		}
+	}
+
+	font := "F1"
+	if header {
+		font = "F2"
+	}
+
+	x := pdfMargin
+	for i, cellLines := range lines {
+		for j, line := range cellLines {
+			baseline := w.y - pdfCellPadding - float64(j+1)*pdfLeading + 2
+			w.text(x+pdfCellPadding, baseline, font, pdfFontSize, line)
+		}
+		x += widths[i]
+	}
+
+	w.y -= height
+	fmt.Fprintf(buf, "0.75 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", pdfMargin, w.y, pdfMargin+pdfContentWidth, w.y)
+}
+
+// footers adiciona numeração "Página X de Y" em todas as páginas
+func (w *pdfWriter) footers() {
+	total := len(w.pages)
+	for i, page := range w.pages {
+		label := fmt.Sprintf("Página %d de %d", i+1, total)
+		x := pdfPageWidth - pdfMargin - float64(len([]rune(label)))*pdfFooterSize*pdfCharWidthEm
+		fmt.Fprintf(page, "BT /F1 %.1f Tf %.2f %.2f Td (%s) Tj ET\n", pdfFooterSize, x, pdfMargin-pdfFooterSize, pdfEscape(label))
+	}
+}
+
+// bytes serializa catálogo, páginas, fontes e tabela xref
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	addObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// Objetos 1-4: catálogo, árvore de páginas e fontes; páginas a partir do 5
+	const firstPageObj = 5
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range w.pages {
+		contentObj := firstPageObj + 2*i + 1
+		addObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, contentObj))
+		addObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// pdfColumnWidths distribui a largura útil proporcionalmente ao conteúdo de cada coluna
+func pdfColumnWidths(section Section) []float64 {
+	cols := len(section.Headers)
+	for _, row := range section.Rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return nil
+	}
+
+	weights := make([]float64, cols)
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			n := float64(len([]rune(cell)))
+			if n > 60 {
+				n = 60 // textos longos quebram em várias linhas em vez de dominar a tabela
+			}
+			if n > weights[i] {
+				weights[i] = n
+			}
+		}
+	}
+	measure(section.Headers)
+	for _, row := range section.Rows {
+		measure(row)
+	}
+
+	var total float64
+	for i := range weights {
+		if weights[i] < 4 {
+			weights[i] = 4
+		}
+		total += weights[i]
+	}
+
+	widths := make([]float64, cols)
+	var assigned float64
+	for i := range weights {
+		widths[i] = pdfContentWidth * weights[i] / total
+		if widths[i] < pdfMinColWidth {
+			widths[i] = pdfMinColWidth
+		}
+		assigned += widths[i]
+	}
+
+	// Reescala caso larguras mínimas tenham excedido a área útil
+	if assigned > pdfContentWidth {
+		for i := range widths {
+			widths[i] *= pdfContentWidth / assigned
+		}
+	}
+
+	return widths
+}
+
+// wrapRow quebra o texto de cada célula para caber na largura da coluna
+func wrapRow(cells []string, widths []float64) [][]string {
+	lines := make([][]string, len(widths))
+	for i := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		maxChars := int((widths[i] - 2*pdfCellPadding) / (pdfFontSize * pdfCharWidthEm))
+		lines[i] = wrapText(cell, maxChars)
+	}
+	return lines
+}
+
+func rowHeight(lines [][]string) float64 {
+	maxLines := 1
+	for _, cell := range lines {
+		if len(cell) > maxLines {
+			maxLines = len(cell)
+		}
+	}
+	return float64(maxLines)*pdfLeading + 2*pdfCellPadding
+}
+
+// wrapText quebra um texto por palavras em linhas de até maxChars caracteres
+func wrapText(s string, maxChars int) []string {
+	if maxChars < 1 {
+		maxChars = 1
+	}
+
+	var lines []string
+	var current []rune
+	for _, word := range strings.Fields(s) {
+		runes := []rune(word)
+		for len(runes) > maxChars {
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+			lines = append(lines, string(runes[:maxChars]))
+			runes = runes[maxChars:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = runes
+		case len(current)+1+len(runes) <= maxChars:
+			current = append(append(current, ' '), runes...)
+		default:
+			lines = append(lines, string(current))
+			current = runes
+		}
+	}
+	if len(current) > 0 || len(lines) == 0 {
+		lines = append(lines, string(current))
+	}
+
+	return lines
+}
+
+// winAnsiExtras mapeia caracteres fora do Latin-1 presentes no WinAnsiEncoding
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// pdfEscape converte UTF-8 para WinAnsi e escapa caracteres especiais de strings PDF
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r >= 0x20 && r < 0x7F:
+			b.WriteByte(byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			if c, ok := winAnsiExtras[r]; ok {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,180 @@
+// Package report gera arquivos de relatório (CSV, XLSX e PDF) a partir de um modelo tabular simples.
+// Não depende de bibliotecas externas: cada formato é escrito diretamente com a biblioteca padrão.
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format identifica o formato de saída de um relatório
+type Format string
+
+// Formatos de relatório suportados
+const (
+	FormatCSV  Format = "csv"  // Valores separados por vírgula (UTF-8 com BOM)
+	FormatXLSX Format = "xlsx" // Planilha Office Open XML
+	FormatPDF  Format = "pdf"  // Documento PDF 1.4
+)
+
+// ParseFormat converte uma string para o formato correspondente
+// Retorna erro para formatos não suportados
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	case FormatPDF:
+		return FormatPDF, nil
+	default:
+		return "", fmt.Errorf("formato inválido: %s. Formatos válidos: pdf, xlsx, csv", s)
+	}
+}
+
+// ContentType retorna o tipo MIME do formato
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Extension retorna a extensão de arquivo do formato (sem ponto)
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Document representa um relatório composto por seções tabulares
+type Document struct {
+	Title    string    // Título principal do relatório
+	Subtitle string    // Linha complementar (ex: data de geração)
+	Sections []Section // Seções exibidas em ordem
+}
+
+// Section representa uma tabela do relatório
+type Section struct {
+	Title   string     // Título da seção (também usado como nome da aba no XLSX)
+	Headers []string   // Cabeçalhos das colunas
+	Rows    [][]string // Linhas de dados
+	HeatMap *HeatMap   // Colore as células numéricas como mapa de calor (opcional)
+}
+
+// HeatMap colore as células numéricas de uma seção do vermelho (Min) ao verde (Max)
+// O CSV não tem formatação e exibe apenas os valores
+type HeatMap struct {
+	FirstColumn int     // Primeira coluna colorida (base 0); as anteriores identificam a linha
+	Min         float64 // Valor pintado de vermelho
+	Max         float64 // Valor pintado de verde
+}
+
+// heatColors é a paleta do mapa de calor, do pior ao melhor resultado (RGB 0–255)
+var heatColors = [][3]int{
+	{0xF8, 0x69, 0x6B},
+	{0xFB, 0xAA, 0x77},
+	{0xFF, 0xEB, 0x84},
+	{0xB1, 0xD5, 0x80},
+	{0x63, 0xBE, 0x7B},
+}
+
+// level retorna o índice em heatColors da célula; false para colunas de identificação e valores não numéricos
+func (h *HeatMap) level(col int, value string) (int, bool) {
+	if h == nil || col < h.FirstColumn || !isNumeric(value) || h.Max <= h.Min {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	pos := (v - h.Min) / (h.Max - h.Min)
+	level := int(pos * float64(len(heatColors)))
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(heatColors) {
+		level = len(heatColors) - 1
+	}
+	return level, true
+}
+
+// File representa um relatório renderizado pronto para download
+type File struct {
+	Name        string // Nome sugerido do arquivo
+	ContentType string // Tipo MIME
+	Data        []byte // Conteúdo binário
+}
+
+// Render gera o conteúdo do documento no formato informado
+func Render(doc *Document, format Format) ([]byte, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("documento não pode ser nulo")
+	}
+
+	switch format {
+	case FormatCSV:
+		return renderCSV(doc)
+	case FormatXLSX:
+		return renderXLSX(doc)
+	case FormatPDF:
+		return renderPDF(doc)
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s", format)
+	}
+}
+
+// Build renderiza o documento e monta o arquivo com nome e tipo MIME
+// baseName não deve conter extensão
+func Build(doc *Document, format Format, baseName string) (*File, error) {
+	data, err := Render(doc, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Name:        fmt.Sprintf("%s.%s", Slugify(baseName), format.Extension()),
+		ContentType: format.ContentType(),
+		Data:        data,
+	}, nil
+}
+
+// Slugify converte um texto em nome de arquivo seguro (ASCII minúsculo, separado por "_")
+func Slugify(s string) string {
+	replacer := strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "è", "e", "ê", "e", "ë", "e",
+		"í", "i", "ì", "i", "î", "i", "ï", "i",
+		"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ú", "u", "ù", "u", "û", "u", "ü", "u",
+		"ç", "c", "ñ", "n",
+	)
+	s = replacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range s {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			lastUnderscore = false
+		case !lastUnderscore && b.Len() > 0:
+			b.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+
+	result := strings.TrimSuffix(b.String(), "_")
+	if result == "" {
+		return "relatorio"
+	}
+	if len(result) > 80 {
+		result = strings.TrimSuffix(result[:80], "_")
+	}
+	return result
+}
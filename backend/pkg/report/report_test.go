@@ -0,0 +1,225 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// documentoTeste tem acentos, caracteres especiais de CSV, XML e PDF e uma seção longa o bastante para quebrar página
+func documentoTeste() *Document {
+	longa := Section{Title: "Distribuição", Headers: []string{"Ordem", "Resposta", "Quantidade"}}
+	for i := 1; i <= 120; i++ {
+		longa.Rows = append(longa.Rows, []string{strconv.Itoa(i), "Opção (" + strconv.Itoa(i) + ")", strconv.Itoa(i * 3)})
+	}
+
+	return &Document{
+		Title:    "Pesquisa de Clima — 2026",
+		Subtitle: "Gerado em 16/10/2026",
+		Sections: []Section{
+			{
+				Title:   "Resumo",
+				Headers: []string{"Pergunta", "Média"},
+				Rows: [][]string{
+					{`Você recomendaria a empresa? "Sim", "Não"`, "4.25"},
+					{"Linha com\nquebra & <tags>", "-1.5"},
+					{`Barra \ e parênteses ( )`, "NaN"},
+				},
+				HeatMap: &HeatMap{FirstColumn: 1, Min: 0, Max: 5},
+			},
+			longa,
+			{Title: "Resumo"}, // Nome de aba repetido
+		},
+	}
+}
+
+func TestRenderCSVComBOMEEscape(t *testing.T) {
+	data, err := Render(documentoTeste(), FormatCSV)
+	if err != nil {
+		t.Fatalf("erro ao gerar CSV: %v", err)
+	}
+	if !bytes.HasPrefix(data, utf8BOM) {
+		t.Fatalf("CSV sem BOM UTF-8: % x", data[:3])
+	}
+
+	r := csv.NewReader(bytes.NewReader(data[len(utf8BOM):]))
+	r.FieldsPerRecord = -1
+	registros, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("CSV inválido: %v", err)
+	}
+
+	if registros[0][0] != "Pesquisa de Clima — 2026" {
+		t.Errorf("título %q", registros[0][0])
+	}
+	esperado := map[string]bool{
+		`Você recomendaria a empresa? "Sim", "Não"`: false,
+		"Linha com\nquebra & <tags>":                false,
+	}
+	for _, registro := range registros {
+		if _, ok := esperado[registro[0]]; ok {
+			esperado[registro[0]] = true
+		}
+	}
+	for valor, encontrado := range esperado {
+		if !encontrado {
+			t.Errorf("valor %q não sobreviveu à leitura do CSV", valor)
+		}
+	}
+	if !bytes.Contains(data, []byte(`"Você recomendaria a empresa? ""Sim"", ""Não"""`)) {
+		t.Error("aspas e vírgulas não foram escapadas com aspas duplas")
+	}
+}
+
+func TestRenderXLSXPartes(t *testing.T) {
+	data, err := Render(documentoTeste(), FormatXLSX)
+	if err != nil {
+		t.Fatalf("erro ao gerar XLSX: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("XLSX não é um zip válido: %v", err)
+	}
+	partes := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("erro ao abrir %s: %v", f.Name, err)
+		}
+		conteudo, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("erro ao ler %s: %v", f.Name, err)
+		}
+		partes[f.Name] = string(conteudo)
+
+		// Toda parte precisa ser XML bem formado
+		d := xml.NewDecoder(bytes.NewReader(conteudo))
+		for {
+			if _, err := d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s não é XML válido: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, nome := range []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml",
+	} {
+		if _, ok := partes[nome]; !ok {
+			t.Errorf("parte %s ausente", nome)
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		override := `PartName="/xl/worksheets/sheet` + strconv.Itoa(i) + `.xml"`
+		if !strings.Contains(partes["[Content_Types].xml"], override) {
+			t.Errorf("content types sem a aba %d", i)
+		}
+		rel := `Target="worksheets/sheet` + strconv.Itoa(i) + `.xml"`
+		if !strings.Contains(partes["xl/_rels/workbook.xml.rels"], rel) {
+			t.Errorf("relacionamentos sem a aba %d", i)
+		}
+	}
+
+	workbook := partes["xl/workbook.xml"]
+	for _, aba := range []string{`name="Resumo"`, `name="Distribuição"`, `name="Resumo (2)"`} {
+		if !strings.Contains(workbook, aba) {
+			t.Errorf("workbook sem a aba %s", aba)
+		}
+	}
+
+	sheet := partes["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, "quebra &amp; &lt;tags&gt;") {
+		t.Error("texto da célula não foi escapado")
+	}
+	if !strings.Contains(sheet, `<v>4.25</v>`) || !strings.Contains(sheet, `<v>-1.5</v>`) {
+		t.Error("valores numéricos não foram gravados como número")
+	}
+	if strings.Contains(sheet, `<v>NaN</v>`) {
+		t.Error("NaN gravado como número")
+	}
+	if !regexp.MustCompile(`<c r="B\d+" s="\d+"><v>4.25</v>`).MatchString(sheet) {
+		t.Error("célula do mapa de calor sem estilo")
+	}
+}
+
+func TestRenderPDFXrefAponta(t *testing.T) {
+	data, err := Render(documentoTeste(), FormatPDF)
+	if err != nil {
+		t.Fatalf("erro ao gerar PDF: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("PDF sem cabeçalho ou marcador de fim")
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if startxref == nil {
+		t.Fatal("PDF sem startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d não aponta para a tabela xref", xref)
+	}
+
+	cabecalho := regexp.MustCompile(`^xref\n0 (\d+)\n`).FindSubmatch(data[xref:])
+	if cabecalho == nil {
+		t.Fatal("tabela xref malformada")
+	}
+	total, _ := strconv.Atoi(string(cabecalho[1]))
+	entradas := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(data[xref:], -1)
+	if len(entradas) != total-1 {
+		t.Fatalf("%d entradas na xref, esperado %d", len(entradas), total-1)
+	}
+	for i, entrada := range entradas {
+		offset, _ := strconv.Atoi(string(entrada[1]))
+		objeto := strconv.Itoa(i+1) + " 0 obj\n"
+		if !bytes.HasPrefix(data[offset:], []byte(objeto)) {
+			t.Errorf("entrada %d aponta para %q, esperado %q", i+1, data[offset:offset+len(objeto)], objeto)
+		}
+	}
+
+	// Cada stream declara o tamanho exato do conteúdo
+	for _, m := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		if !bytes.HasPrefix(data[m[1]+length:], []byte("endstream")) {
+			t.Errorf("stream em %d não termina após %d bytes", m[1], length)
+		}
+	}
+
+	paginas := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(data)
+	if n, _ := strconv.Atoi(string(paginas[1])); n < 2 {
+		t.Errorf("%d página(s), esperado quebra de página", n)
+	}
+	if !bytes.Contains(data, []byte(`(P\341gina 1 de `)) {
+		t.Error("rodapé de numeração ausente")
+	}
+}
+
+func TestPDFEscapeWinAnsi(t *testing.T) {
+	casos := []struct {
+		entrada  string
+		esperado string
+	}{
+		{"texto simples", "texto simples"},
+		{`a (b) \ c`, `a \(b\) \\ c`},
+		{"ação", `a\347\343o`},
+		{"€ – —", `\200 \226 \227`},
+		{"linha\nnova\ttab", "linha nova tab"},
+		{"emoji 😀", "emoji ?"},
+	}
+
+	for _, c := range casos {
+		if escapado := pdfEscape(c.entrada); escapado != c.esperado {
+			t.Errorf("pdfEscape(%q) = %q, esperado %q", c.entrada, escapado, c.esperado)
+		}
+	}
+}
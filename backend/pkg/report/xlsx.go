@@ -0,0 +1,285 @@
+// Package report gera arquivos de relatório (CSV, XLSX e PDF) a partir de um modelo tabular simples.
+// Monta a planilha Office Open XML diretamente no zip, uma aba por seção.
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Partes fixas do pacote OOXML
+const (
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	// Estilo 0: padrão; estilo 1: negrito (cabeçalhos e títulos); estilos a partir de xlsxHeatStyle: cores do mapa de calor
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="7"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFF8696B"/></patternFill></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFFBAA77"/></patternFill></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFFFEB84"/></patternFill></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFB1D580"/></patternFill></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FF63BE7B"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="7"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="0" fontId="0" fillId="2" borderId="0" xfId="0" applyFill="1"/>` +
+		`<xf numFmtId="0" fontId="0" fillId="3" borderId="0" xfId="0" applyFill="1"/>` +
+		`<xf numFmtId="0" fontId="0" fillId="4" borderId="0" xfId="0" applyFill="1"/>` +
+		`<xf numFmtId="0" fontId="0" fillId="5" borderId="0" xfId="0" applyFill="1"/>` +
+		`<xf numFmtId="0" fontId="0" fillId="6" borderId="0" xfId="0" applyFill="1"/></cellXfs>
+</styleSheet>`
+
+	// xlsxHeatStyle é o estilo da primeira cor de heatColors; as demais seguem em ordem
+	xlsxHeatStyle = 2
+)
+
+// renderXLSX escreve o documento como planilha, uma aba por seção
+func renderXLSX(doc *Document) ([]byte, error) {
+	sections := doc.Sections
+	if len(sections) == 0 {
+		sections = []Section{{Title: "Relatório"}}
+	}
+
+	names := sheetNames(sections)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes(len(sections))},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook(names)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sections))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+
+	for i, section := range sections {
+		var header []string
+		if i == 0 {
+			header = []string{doc.Title, doc.Subtitle}
+		}
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxSheet(header, section)})
+	}
+
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar XLSX (%s): %v", f.name, err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, fmt.Errorf("erro ao gerar XLSX (%s): %v", f.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao finalizar XLSX: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func xlsxContentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func xlsxWorkbook(names []string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets>
+</workbook>`)
+	return b.String()
+}
+
+func xlsxWorkbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`, sheets+1)
+	return b.String()
+}
+
+// xlsxSheet monta uma aba; linhas de cabeçalho do documento (se houver) precedem a tabela
+func xlsxSheet(docHeader []string, section Section) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+`)
+
+	if widths := columnWidths(section); len(widths) > 0 {
+		b.WriteString("<cols>")
+		for i, w := range widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%.1f" customWidth="1"/>`, i+1, i+1, w)
+		}
+		b.WriteString("</cols>\n")
+	}
+
+	b.WriteString("<sheetData>\n")
+
+	row := 0
+	var heat *HeatMap // Apenas as linhas de dados da seção são coloridas
+	writeRow := func(cells []string, bold bool) {
+		row++
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for col, value := range cells {
+			ref := fmt.Sprintf("%s%d", columnName(col), row)
+			style := ""
+			if bold {
+				style = ` s="1"`
+			} else if level, ok := heat.level(col, value); ok {
+				style = fmt.Sprintf(` s="%d"`, xlsxHeatStyle+level)
+			}
+			if !bold && isNumeric(value) {
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, style, value)
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(value))
+		}
+		b.WriteString("</row>\n")
+	}
+
+	for _, line := range docHeader {
+		if line != "" {
+			writeRow([]string{line}, true)
+		}
+	}
+	if section.Title != "" {
+		if row > 0 {
+			row++ // linha em branco entre o cabeçalho do documento e a seção
+		}
+		writeRow([]string{section.Title}, true)
+	}
+	if len(section.Headers) > 0 {
+		writeRow(section.Headers, true)
+	}
+	heat = section.HeatMap
+	for _, cells := range section.Rows {
+		writeRow(cells, false)
+	}
+
+	b.WriteString("</sheetData>\n</worksheet>")
+	return b.String()
+}
+
+// sheetNames gera nomes de aba válidos (máx. 31 caracteres, sem []:*?/\) e únicos
+func sheetNames(sections []Section) []string {
+	names := make([]string, len(sections))
+	used := make(map[string]bool)
+
+	for i, section := range sections {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '-'
+			}
+			return r
+		}, strings.TrimSpace(section.Title))
+		if name == "" {
+			name = fmt.Sprintf("Planilha %d", i+1)
+		}
+		name = truncateRunes(name, 31)
+
+		base := name
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncateRunes(base, 31-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+
+	return names
+}
+
+// columnWidths estima a largura de cada coluna a partir do maior conteúdo
+func columnWidths(section Section) []float64 {
+	var widths []float64
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			for len(widths) <= i {
+				widths = append(widths, 8)
+			}
+			if w := float64(utf8.RuneCountInString(cell)) + 2; w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	measure(section.Headers)
+	for _, row := range section.Rows {
+		measure(row)
+	}
+	for i := range widths {
+		if widths[i] > 80 {
+			widths[i] = 80
+		}
+	}
+	return widths
+}
+
+// columnName converte índice (base 0) em letra de coluna: 0 -> A, 26 -> AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// numericRegex aceita apenas números decimais simples (evita "NaN", "1e5", hexadecimais etc.)
+var numericRegex = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+func isNumeric(s string) bool {
+	return numericRegex.MatchString(s)
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
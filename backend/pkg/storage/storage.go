@@ -0,0 +1,126 @@
+// Package storage define o armazenamento de arquivos gerados pela aplicação (QR codes, por exemplo).
+// A interface Storage permite trocar o backend: sistema de arquivos local por padrão, object storage em produção.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound indica que não existe arquivo com a chave informada
+var ErrNotFound = errors.New("arquivo não encontrado")
+
+// Storage grava e lê arquivos identificados por chaves no formato "dir/sub/arquivo.ext"
+type Storage interface {
+	Save(ctx context.Context, key string, data []byte) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalStorage armazena os arquivos em um diretório do sistema de arquivos
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage cria um armazenamento local com raiz em dir
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// Garante que LocalStorage implementa Storage
+var _ Storage = (*LocalStorage)(nil)
+
+// Save grava o arquivo, substituindo o existente
+// A escrita passa por um arquivo temporário para que leitores nunca vejam conteúdo parcial
+func (s *LocalStorage) Save(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	target, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("erro ao criar diretório de armazenamento: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar arquivo: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %v", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o640); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %v", err)
+	}
+
+	return nil
+}
+
+// Open abre o arquivo para leitura; o chamador deve fechá-lo
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	target, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("erro ao abrir arquivo: %v", err)
+	}
+	return f, nil
+}
+
+// Delete remove o arquivo; chaves inexistentes não geram erro
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	target, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("erro ao remover arquivo: %v", err)
+	}
+	return nil
+}
+
+// resolve converte a chave em caminho dentro do diretório raiz, rejeitando travessia de diretórios
+func (s *LocalStorage) resolve(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") || strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("chave de armazenamento inválida: %q", key)
+	}
+	clean := path.Clean(key)
+	if clean != key || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("chave de armazenamento inválida: %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,66 @@
+// Package textanalysis analisa respostas abertas em português sem serviços externos.
+// Define o léxico: stop words, negações, intensificadores e a polaridade das palavras de sentimento.
+package textanalysis
+
+// stopWords são palavras sem conteúdo temático (artigos, preposições, pronomes, verbos auxiliares), sem acentos
+var stopWords = []string{
+	"a", "ao", "aos", "aquela", "aquelas", "aquele", "aqueles", "aquilo", "as", "ate", "com", "como", "da", "das",
+	"de", "dela", "delas", "dele", "deles", "depois", "do", "dos", "e", "ela", "elas", "ele", "eles", "em", "entre",
+	"era", "eram", "essa", "essas", "esse", "esses", "esta", "estamos", "estao", "estar", "estas", "estava", "estavam",
+	"este", "esteja", "estes", "estou", "eu", "foi", "fomos", "for", "foram", "fosse", "fui", "ha", "isso", "isto",
+	"ja", "la", "lhe", "lhes", "mais", "mas", "me", "mesmo", "meu", "meus", "minha", "minhas", "muita", "muitas",
+	"muito", "muitos", "na", "nas", "nao", "nem", "nenhum", "nenhuma", "no", "nos", "nossa", "nossas", "nosso",
+	"nossos", "num", "numa", "nunca", "jamais", "o", "os", "ou", "para", "pela", "pelas", "pelo", "pelos", "per",
+	"por", "pouco", "pouca", "poucos", "poucas", "porque", "pra", "pro", "qual", "quais", "quando", "que", "quem",
+	"se", "sem", "ser", "sera", "seu", "seus", "si", "sido", "so", "sobre", "sua", "suas", "tambem", "te", "tem",
+	"temos", "tenho", "ter", "teu", "teus", "tinha", "tinham", "tipo", "toda", "todas", "todo", "todos", "tu", "tua",
+	"tuas", "um", "uma", "umas", "uns", "voce", "voces", "vos", "bem", "bastante", "ainda", "aqui", "ali", "assim",
+	"cada", "coisa", "coisas", "deve", "devem", "deveria", "deveriam", "dia", "dias", "faz", "fazer", "feito", "forma",
+	"gente", "hoje", "mim", "melhor", "pode", "podem", "poderia", "poderiam", "porem", "pois", "quanto", "quase",
+	"sao", "sempre", "seja", "sejam", "sendo", "ver", "vez", "vezes", "vai", "vao", "acho", "creio", "acredito",
+	"algum", "alguma", "alguns", "algumas", "outro", "outra", "outros", "outras", "demais", "extremamente",
+	"super", "totalmente", "realmente", "bastantes", "etc", "empresa", "sim",
+}
+
+// negations invertem o sentimento das palavras seguintes na mesma oração
+var negations = []string{"nao", "nunca", "jamais", "nem", "nenhum", "nenhuma", "sem", "falta", "faltam", "ausencia"}
+
+// intensifiers dobram o peso da próxima palavra com sentimento
+var intensifiers = []string{"muito", "muita", "muitos", "muitas", "bastante", "extremamente", "super", "totalmente", "realmente", "tao"}
+
+// sentimentLexicon atribui peso às palavras no contexto de clima organizacional (forma no singular, sem acentos)
+// Pesos 2 e -2 marcam avaliações fortes ("ótimo", "péssimo")
+var sentimentLexicon = map[string]int{
+	// Positivas
+	"bom": 1, "boa": 1, "otimo": 2, "otima": 2, "excelente": 2, "maravilhoso": 2, "maravilhosa": 2, "incrivel": 2,
+	"satisfeito": 1, "satisfeita": 1, "satisfacao": 1, "feliz": 1, "felicidade": 1, "motivado": 1, "motivada": 1,
+	"motivacao": 1, "engajado": 1, "engajada": 1, "reconhecimento": 1, "reconhecido": 1, "reconhecida": 1,
+	"valorizado": 1, "valorizada": 1, "valorizacao": 1, "apoio": 1, "apoiado": 1, "apoiada": 1, "ajuda": 1,
+	"colaboracao": 1, "colaborativo": 1, "colaborativa": 1, "respeito": 1, "respeitoso": 1, "respeitosa": 1,
+	"transparente": 1, "transparencia": 1, "confianca": 1, "justo": 1, "justa": 1, "flexivel": 1, "flexibilidade": 1,
+	"agradavel": 1, "acolhedor": 1, "acolhedora": 1, "parceria": 1, "crescimento": 1, "oportunidade": 1,
+	"aprendizado": 1, "evolucao": 1, "melhoria": 1, "orgulho": 1, "gosto": 1, "adoro": 2, "amo": 2, "positivo": 1,
+	"positiva": 1, "eficiente": 1, "organizado": 1, "organizada": 1, "claro": 1, "clara": 1, "clareza": 1,
+	"atencioso": 1, "atenciosa": 1, "prestativo": 1, "prestativa": 1, "competente": 1, "equilibrio": 1,
+	"beneficio": 1, "estabilidade": 1, "seguranca": 1, "uniao": 1, "unido": 1, "unida": 1, "harmonia": 1,
+	"parabens": 2, "obrigado": 1, "obrigada": 1, "elogio": 1, "qualidade": 1, "sucesso": 1, "inspirador": 1,
+	"inspiradora": 1, "autonomia": 1, "saudavel": 1, "tranquilo": 1, "tranquila": 1, "leve": 1,
+
+	// Negativas
+	"ruim": -1, "pessimo": -2, "pessima": -2, "horrivel": -2, "terrivel": -2, "insatisfeito": -1, "insatisfeita": -1,
+	"insatisfacao": -1, "desmotivado": -1, "desmotivada": -1, "desmotivacao": -1, "triste": -1, "tristeza": -1,
+	"cansado": -1, "cansada": -1, "cansaco": -1, "estresse": -1, "estressante": -1, "estressado": -1,
+	"estressada": -1, "ansiedade": -1, "ansioso": -1, "ansiosa": -1, "esgotado": -2, "esgotada": -2,
+	"esgotamento": -2, "burnout": -2, "sobrecarga": -1, "sobrecarregado": -1, "sobrecarregada": -1, "pressao": -1,
+	"cobranca": -1, "injusto": -1, "injusta": -1, "injustica": -1, "desrespeito": -2, "assedio": -2, "abuso": -2,
+	"humilhacao": -2, "grosseria": -1, "grosso": -1, "arrogante": -1, "autoritario": -1, "autoritaria": -1,
+	"microgerenciamento": -1, "desorganizado": -1, "desorganizada": -1, "desorganizacao": -1, "confuso": -1,
+	"confusa": -1, "bagunca": -1, "conflito": -1, "fofoca": -1, "medo": -1, "inseguranca": -1, "inseguro": -1,
+	"insegura": -1, "desvalorizado": -1, "desvalorizada": -1, "desvalorizacao": -1, "problema": -1,
+	"dificuldade": -1, "dificil": -1, "demora": -1, "lento": -1, "lenta": -1, "atraso": -1, "baixo": -1, "baixa": -1,
+	"precario": -1, "precaria": -1, "negativo": -1, "negativa": -1, "reclamacao": -1, "frustrado": -1,
+	"frustrada": -1, "frustracao": -1, "chato": -1, "chata": -1, "toxico": -2, "toxica": -2, "odeio": -2,
+	"pior": -1, "descaso": -1, "favoritismo": -1, "panelinha": -1, "rotatividade": -1, "demissao": -1,
+	"excessivo": -1, "excessiva": -1, "exaustivo": -1, "exaustiva": -1, "desgaste": -1, "desgastante": -1,
+	"omissao": -1, "despreparado": -1, "despreparada": -1, "ineficiente": -1, "incompetente": -2,
+}
@@ -0,0 +1,484 @@
+// Package textanalysis analisa respostas abertas em português sem serviços externos.
+// Normaliza o texto, remove stop words, classifica o sentimento por léxico, conta palavras-chave (unigramas e bigramas)
+// e agrupa as respostas em tópicos. Termos e tópicos citados por menos respostas que o mínimo informado não são retornados.
+package textanalysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Valores padrão das opções
+const (
+	DefaultMaxKeywords   = 20
+	DefaultMaxTopics     = 5
+	DefaultTermsPerTopic = 3
+	minTermLength        = 3 // Termos mais curtos (siglas, abreviações) não viram palavras-chave
+	negationScope        = 3 // Palavras após a negação com sentimento invertido
+)
+
+// Options controla os limites da análise
+type Options struct {
+	MinDocuments  int // Menor número de respostas distintas que citam um termo ou formam um tópico (mínimo 1)
+	MaxKeywords   int // Palavras-chave retornadas (padrão DefaultMaxKeywords)
+	MaxTopics     int // Tópicos retornados (padrão DefaultMaxTopics)
+	TermsPerTopic int // Termos que descrevem cada tópico (padrão DefaultTermsPerTopic)
+}
+
+// Sentiment resume a polaridade de um conjunto de respostas
+type Sentiment struct {
+	Positive int     // Respostas com saldo positivo no léxico
+	Neutral  int     // Respostas sem saldo
+	Negative int     // Respostas com saldo negativo
+	Index    float64 // (positivas - negativas) / respostas * 100, de -100 a 100
+}
+
+// Keyword é um termo frequente nas respostas
+type Keyword struct {
+	Term        string // Forma mais usada do termo, em minúsculas
+	Words       int    // 1 para unigramas, 2 para bigramas
+	Occurrences int    // Ocorrências no total das respostas
+	Documents   int    // Respostas distintas que citam o termo
+}
+
+// Topic é um grupo de respostas que compartilham um termo
+type Topic struct {
+	Terms     []string  // Termo que originou o tópico seguido dos mais citados nas mesmas respostas
+	Documents int       // Respostas do tópico
+	Sentiment Sentiment // Sentimento das respostas do tópico
+}
+
+// Result é o resultado da análise de um conjunto de respostas
+type Result struct {
+	Documents   int       // Respostas não vazias analisadas
+	Sentiment   Sentiment // Sentimento de todas as respostas
+	Keywords    []Keyword // Palavras-chave em ordem de respostas que as citam
+	Topics      []Topic   // Tópicos em ordem de tamanho
+	Unclustered int       // Respostas fora dos tópicos
+}
+
+// token é uma palavra do texto: key identifica o termo (sem acentos, no singular) e surface é a forma escrita
+type token struct {
+	key     string
+	surface string
+}
+
+// document guarda o que a análise usa de cada resposta; o texto original não é mantido
+type document struct {
+	polarity int
+	terms    map[string]int // Chave do termo -> ocorrências na resposta
+}
+
+var (
+	stopWordSet     = toSet(stopWords)
+	negationSet     = toSet(negations)
+	intensifierSet  = toSet(intensifiers)
+	stemmedLexicon  = stemLexicon(sentimentLexicon)
+	accentReplacer  = strings.NewReplacer("á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "é", "e", "è", "e", "ê", "e", "ë", "e", "í", "i", "ì", "i", "î", "i", "ï", "i", "ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o", "ú", "u", "ù", "u", "û", "u", "ü", "u", "ç", "c", "ñ", "n")
+	clauseSeparator = ".,;:!?()[]\n\r\t\"“”"
+)
+
+// Normalize converte a palavra para minúsculas e remove acentos
+func Normalize(s string) string {
+	return accentReplacer.Replace(strings.ToLower(s))
+}
+
+// Analyze processa as respostas e retorna sentimento, palavras-chave e tópicos
+// Respostas vazias são ignoradas; nenhum trecho de resposta é retornado, apenas termos citados por MinDocuments respostas ou mais
+func Analyze(texts []string, opts Options) Result {
+	opts = withDefaults(opts)
+
+	var docs []document
+	surfaces := make(map[string]map[string]int) // Chave do termo -> formas escritas -> ocorrências
+	for _, text := range texts {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		docs = append(docs, analyzeDocument(text, surfaces))
+	}
+
+	result := Result{Documents: len(docs), Sentiment: summarize(docs, nil)}
+	if len(docs) == 0 {
+		return result
+	}
+
+	// Frequência de documentos e de ocorrências de cada termo
+	documents := make(map[string]int)
+	occurrences := make(map[string]int)
+	for _, doc := range docs {
+		for key, count := range doc.terms {
+			documents[key]++
+			occurrences[key] += count
+		}
+	}
+
+	result.Keywords = selectKeywords(documents, occurrences, surfaces, opts)
+	result.Topics, result.Unclustered = clusterTopics(docs, documents, surfaces, opts)
+	return result
+}
+
+// SentimentScore retorna o saldo do léxico de sentimento no texto: positivo, negativo ou zero
+// Negações invertem as palavras seguintes da oração e intensificadores dobram o peso da próxima palavra
+func SentimentScore(text string) int {
+	score := 0
+	for _, clause := range clauses(text) {
+		negated, intensified := 0, false
+		for _, tok := range clause {
+			if negationSet[Normalize(tok.surface)] {
+				negated = negationScope
+				continue
+			}
+			if intensifierSet[Normalize(tok.surface)] {
+				intensified = true
+				continue
+			}
+
+			if weight, ok := stemmedLexicon[tok.key]; ok {
+				if intensified {
+					weight *= 2
+				}
+				if negated > 0 {
+					weight = -weight
+				}
+				score += weight
+				intensified = false
+			}
+			if negated > 0 {
+				negated--
+			}
+		}
+	}
+	return score
+}
+
+// analyzeDocument calcula a polaridade da resposta e conta seus termos (unigramas e bigramas de palavras de conteúdo)
+func analyzeDocument(text string, surfaces map[string]map[string]int) document {
+	doc := document{terms: make(map[string]int)}
+	switch score := SentimentScore(text); {
+	case score > 0:
+		doc.polarity = 1
+	case score < 0:
+		doc.polarity = -1
+	}
+
+	addTerm := func(key, surface string) {
+		doc.terms[key]++
+		if surfaces[key] == nil {
+			surfaces[key] = make(map[string]int)
+		}
+		surfaces[key][surface]++
+	}
+
+	for _, clause := range clauses(text) {
+		var content []token
+		for _, tok := range clause {
+			// "falta" e "ausência" negam o sentimento, mas indicam o tema ("falta de comunicação")
+			normalized := Normalize(tok.surface)
+			if stopWordSet[normalized] || len([]rune(tok.key)) < minTermLength || isNumber(tok.key) {
+				continue
+			}
+			content = append(content, tok)
+		}
+		for i, tok := range content {
+			addTerm(tok.key, tok.surface)
+			if i > 0 {
+				prev := content[i-1]
+				addTerm(prev.key+" "+tok.key, prev.surface+" "+tok.surface)
+			}
+		}
+	}
+	return doc
+}
+
+// selectKeywords ordena os termos citados por ao menos MinDocuments respostas
+// Um unigrama é omitido quando um bigrama selecionado que o contém é citado pelas mesmas respostas
+func selectKeywords(documents, occurrences map[string]int, surfaces map[string]map[string]int, opts Options) []Keyword {
+	var keys []string
+	for key, n := range documents {
+		if n >= opts.MinDocuments {
+			keys = append(keys, key)
+		}
+	}
+	sortTerms(keys, documents, occurrences)
+
+	covered := make(map[string]bool)
+	for _, key := range keys {
+		if words := strings.Fields(key); len(words) == 2 {
+			for _, word := range words {
+				if documents[word] == documents[key] {
+					covered[word] = true
+				}
+			}
+		}
+	}
+
+	var keywords []Keyword
+	for _, key := range keys {
+		if covered[key] {
+			continue
+		}
+		keywords = append(keywords, Keyword{
+			Term:        surfaceOf(key, surfaces),
+			Words:       len(strings.Fields(key)),
+			Occurrences: occurrences[key],
+			Documents:   documents[key],
+		})
+		if len(keywords) == opts.MaxKeywords {
+			break
+		}
+	}
+	return keywords
+}
+
+// clusterTopics agrupa as respostas a partir dos termos mais citados
+// A cada passo o termo citado pelo maior número de respostas ainda sem tópico forma um tópico com essas respostas;
+// termos e tópicos com menos de MinDocuments respostas são descartados
+func clusterTopics(docs []document, documents map[string]int, surfaces map[string]map[string]int, opts Options) ([]Topic, int) {
+	remaining := make([]int, len(docs))
+	for i := range docs {
+		remaining[i] = i
+	}
+
+	var topics []Topic
+	for len(topics) < opts.MaxTopics && len(remaining) >= opts.MinDocuments {
+		counts := termDocuments(docs, remaining, documents, opts.MinDocuments)
+		seed := bestTerm(counts, documents, opts.MinDocuments)
+		if seed == "" {
+			break
+		}
+
+		var members, rest []int
+		for _, i := range remaining {
+			if docs[i].terms[seed] > 0 {
+				members = append(members, i)
+			} else {
+				rest = append(rest, i)
+			}
+		}
+
+		// Termos que mais aparecem junto com o termo do tópico, sem repetir palavras do próprio termo
+		inTopic := termDocuments(docs, members, documents, opts.MinDocuments)
+		var related []string
+		for key := range inTopic {
+			if key != seed && !overlaps(key, seed) {
+				related = append(related, key)
+			}
+		}
+		sortTerms(related, inTopic, documents)
+
+		terms := []string{surfaceOf(seed, surfaces)}
+		used := []string{seed}
+		for _, key := range related {
+			if len(terms) == opts.TermsPerTopic {
+				break
+			}
+			if anyOverlap(key, used) {
+				continue
+			}
+			terms = append(terms, surfaceOf(key, surfaces))
+			used = append(used, key)
+		}
+
+		topics = append(topics, Topic{Terms: terms, Documents: len(members), Sentiment: summarize(docs, members)})
+		remaining = rest
+	}
+
+	return topics, len(remaining)
+}
+
+// termDocuments conta, entre as respostas indicadas, quantas citam cada termo elegível (citado por MinDocuments respostas no total)
+// Termos citados por menos de MinDocuments dessas respostas são omitidos
+func termDocuments(docs []document, indexes []int, documents map[string]int, minDocuments int) map[string]int {
+	counts := make(map[string]int)
+	for _, i := range indexes {
+		for key := range docs[i].terms {
+			if documents[key] >= minDocuments {
+				counts[key]++
+			}
+		}
+	}
+	for key, n := range counts {
+		if n < minDocuments {
+			delete(counts, key)
+		}
+	}
+	return counts
+}
+
+// bestTerm escolhe o termo citado por mais respostas; no empate, bigramas (mais específicos) e depois ordem alfabética
+func bestTerm(counts, documents map[string]int, minDocuments int) string {
+	best := ""
+	for key, n := range counts {
+		if n < minDocuments {
+			continue
+		}
+		if best == "" || n > counts[best] ||
+			(n == counts[best] && strings.Count(key, " ") > strings.Count(best, " ")) ||
+			(n == counts[best] && strings.Count(key, " ") == strings.Count(best, " ") && key < best) {
+			best = key
+		}
+	}
+	return best
+}
+
+// summarize resume a polaridade das respostas indicadas (todas quando indexes é nil)
+func summarize(docs []document, indexes []int) Sentiment {
+	var s Sentiment
+	count := func(doc document) {
+		switch doc.polarity {
+		case 1:
+			s.Positive++
+		case -1:
+			s.Negative++
+		default:
+			s.Neutral++
+		}
+	}
+
+	if indexes == nil {
+		for _, doc := range docs {
+			count(doc)
+		}
+	} else {
+		for _, i := range indexes {
+			count(docs[i])
+		}
+	}
+
+	if total := s.Positive + s.Neutral + s.Negative; total > 0 {
+		s.Index = math.Round(float64(s.Positive-s.Negative)/float64(total)*10000) / 100
+	}
+	return s
+}
+
+// clauses divide o texto em orações (pontuação encerra o alcance das negações e dos bigramas) e as orações em palavras
+func clauses(text string) [][]token {
+	var result [][]token
+	for _, part := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return strings.ContainsRune(clauseSeparator, r)
+	}) {
+		words := strings.FieldsFunc(part, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+		clause := make([]token, len(words))
+		for i, word := range words {
+			clause[i] = token{key: stem(Normalize(word)), surface: word}
+		}
+		result = append(result, clause)
+	}
+	return result
+}
+
+// stem reduz plurais regulares ao singular para que "reuniões" e "reunião" sejam o mesmo termo
+func stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "aes"):
+		return word[:len(word)-3] + "ao"
+	case strings.HasSuffix(word, "ais"):
+		return word[:len(word)-3] + "al"
+	case strings.HasSuffix(word, "eis"):
+		return word[:len(word)-3] + "el"
+	case strings.HasSuffix(word, "ns"):
+		return word[:len(word)-2] + "m"
+	case strings.HasSuffix(word, "res"), strings.HasSuffix(word, "zes"), strings.HasSuffix(word, "ses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// surfaceOf retorna a forma escrita mais frequente do termo (na ordem alfabética em caso de empate)
+func surfaceOf(key string, surfaces map[string]map[string]int) string {
+	best, bestCount := key, 0
+	for surface, count := range surfaces[key] {
+		if count > bestCount || (count == bestCount && surface < best) {
+			best, bestCount = surface, count
+		}
+	}
+	return best
+}
+
+// sortTerms ordena por primary e secondary decrescentes e depois alfabeticamente
+func sortTerms(keys []string, primary, secondary map[string]int) {
+	sort.Slice(keys, func(i, j int) bool {
+		if primary[keys[i]] != primary[keys[j]] {
+			return primary[keys[i]] > primary[keys[j]]
+		}
+		if secondary[keys[i]] != secondary[keys[j]] {
+			return secondary[keys[i]] > secondary[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// overlaps verifica se os termos compartilham alguma palavra
+func overlaps(a, b string) bool {
+	for _, wa := range strings.Fields(a) {
+		for _, wb := range strings.Fields(b) {
+			if wa == wb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyOverlap(key string, used []string) bool {
+	for _, u := range used {
+		if overlaps(key, u) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func withDefaults(opts Options) Options {
+	if opts.MinDocuments < 1 {
+		opts.MinDocuments = 1
+	}
+	if opts.MaxKeywords <= 0 {
+		opts.MaxKeywords = DefaultMaxKeywords
+	}
+	if opts.MaxTopics <= 0 {
+		opts.MaxTopics = DefaultMaxTopics
+	}
+	if opts.TermsPerTopic <= 0 {
+		opts.TermsPerTopic = DefaultTermsPerTopic
+	}
+	return opts
+}
+
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// stemLexicon indexa o léxico pela mesma forma usada nas palavras do texto
+func stemLexicon(lexicon map[string]int) map[string]int {
+	stemmed := make(map[string]int, len(lexicon))
+	for word, weight := range lexicon {
+		stemmed[stem(word)] = weight
+	}
+	return stemmed
+}
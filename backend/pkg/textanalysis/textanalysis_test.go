@@ -0,0 +1,121 @@
+package textanalysis
+
+import (
+	"strings"
+	"testing"
+)
+
+// respostas simula uma pergunta aberta: temas citados por muitas, poucas e uma única resposta
+var respostas = []string{
+	"O salário está abaixo do mercado e a comunicação da liderança é fraca.",
+	"Salários baixos; a comunicação entre as equipes precisa melhorar.",
+	"Gosto da equipe, mas o salário poderia ser melhor.",
+	"A comunicação da liderança melhorou bastante este ano.",
+	"Falta reconhecimento e o salário não acompanha a inflação.",
+	"O refeitório da unidade norte tem comida ruim.",
+	"Meu chefe Carlos não dá retorno sobre as entregas.",
+	"O chefe Carlos é muito bom, sempre disponível.",
+	"Reuniões longas demais atrapalham a comunicação.",
+	"Excelente ambiente, equipe unida e colaborativa.",
+	"",
+	"   ",
+}
+
+// respostasComTermo conta as respostas que citam o termo, com a mesma extração de termos da análise
+func respostasComTermo(textos []string, termo string) int {
+	palavras := strings.Fields(termo)
+	for i, palavra := range palavras {
+		palavras[i] = stem(Normalize(palavra))
+	}
+	chave := strings.Join(palavras, " ")
+
+	n := 0
+	for _, texto := range textos {
+		if strings.TrimSpace(texto) == "" {
+			continue
+		}
+		if analyzeDocument(texto, make(map[string]map[string]int)).terms[chave] > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAnalyzeOmiteTermosETopicosAbaixoDoMinimo(t *testing.T) {
+	for minimo := 1; minimo <= 6; minimo++ {
+		resultado := Analyze(respostas, Options{MinDocuments: minimo})
+
+		if resultado.Documents != 10 {
+			t.Fatalf("mínimo %d: Documents = %d, esperado 10 (respostas vazias ignoradas)", minimo, resultado.Documents)
+		}
+
+		for _, keyword := range resultado.Keywords {
+			if keyword.Documents < minimo {
+				t.Errorf("mínimo %d: palavra-chave %q com %d respostas", minimo, keyword.Term, keyword.Documents)
+			}
+			if n := respostasComTermo(respostas, keyword.Term); n < minimo {
+				t.Errorf("mínimo %d: palavra-chave %q citada por %d respostas", minimo, keyword.Term, n)
+			}
+		}
+
+		agrupadas := 0
+		for _, topico := range resultado.Topics {
+			if topico.Documents < minimo {
+				t.Errorf("mínimo %d: tópico %v com %d respostas", minimo, topico.Terms, topico.Documents)
+			}
+			for _, termo := range topico.Terms {
+				if n := respostasComTermo(respostas, termo); n < minimo {
+					t.Errorf("mínimo %d: termo %q do tópico %v citado por %d respostas", minimo, termo, topico.Terms, n)
+				}
+			}
+			agrupadas += topico.Documents
+		}
+		if agrupadas+resultado.Unclustered != resultado.Documents {
+			t.Errorf("mínimo %d: %d respostas em tópicos + %d sem tópico, esperado %d",
+				minimo, agrupadas, resultado.Unclustered, resultado.Documents)
+		}
+	}
+}
+
+func TestAnalyzeNaoExpoeTemasRaros(t *testing.T) {
+	resultado := Analyze(respostas, Options{MinDocuments: 3})
+
+	raros := []string{"refeitorio", "carlos", "chefe", "inflacao"}
+	for _, keyword := range resultado.Keywords {
+		for _, raro := range raros {
+			if strings.Contains(Normalize(keyword.Term), raro) {
+				t.Errorf("palavra-chave %q expõe tema citado por menos de 3 respostas", keyword.Term)
+			}
+		}
+	}
+	for _, topico := range resultado.Topics {
+		for _, termo := range topico.Terms {
+			for _, raro := range raros {
+				if strings.Contains(Normalize(termo), raro) {
+					t.Errorf("tópico %v expõe tema citado por menos de 3 respostas", topico.Terms)
+				}
+			}
+		}
+	}
+
+	encontrados := make(map[string]bool)
+	for _, keyword := range resultado.Keywords {
+		encontrados[stem(Normalize(keyword.Term))] = true
+	}
+	for _, esperado := range []string{"salario", "comunicacao"} {
+		if !encontrados[esperado] {
+			t.Errorf("palavra-chave %q citada por 3 ou mais respostas não retornada", esperado)
+		}
+	}
+}
+
+func TestAnalyzeMinimoMaiorQueRespostas(t *testing.T) {
+	resultado := Analyze(respostas, Options{MinDocuments: 11})
+
+	if len(resultado.Keywords) != 0 || len(resultado.Topics) != 0 {
+		t.Errorf("esperado nenhum termo ou tópico, obtido %d palavras-chave e %d tópicos", len(resultado.Keywords), len(resultado.Topics))
+	}
+	if resultado.Unclustered != resultado.Documents {
+		t.Errorf("Unclustered = %d, esperado %d", resultado.Unclustered, resultado.Documents)
+	}
+}
@@ -0,0 +1,112 @@
+// Package totp implementa senhas de uso único baseadas em tempo (RFC 6238).
+// Usa HMAC-SHA1, 6 dígitos e passo de 30 segundos, compatível com os aplicativos autenticadores comuns.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parâmetros do algoritmo
+const (
+	Digits      = 6         // Quantidade de dígitos do código
+	Period      = 30        // Duração do passo em segundos
+	SecretBytes = 20        // Tamanho do segredo (160 bits, recomendado pela RFC 4226)
+	Skew        = 1         // Passos aceitos antes/depois do atual (tolerância de relógio)
+	algorithm   = "SHA1"    // Algoritmo anunciado na URI otpauth
+	modulo      = 1_000_000 // 10^Digits
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret gera um segredo aleatório codificado em base32 (sem padding)
+func GenerateSecret() (string, error) {
+	b := make([]byte, SecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar segredo TOTP: %v", err)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Step retorna o passo de tempo correspondente ao instante informado
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// CodeAt calcula o código de um passo de tempo (HOTP com contador = passo)
+func CodeAt(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Truncamento dinâmico (RFC 4226, seção 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo), nil
+}
+
+// Validate verifica o código no instante informado, tolerando Skew passos de diferença
+// Passos até lastStep (o último já aceito) são recusados, impedindo o reuso do mesmo código
+// Retorna o passo correspondente para que o chamador o registre como usado
+func Validate(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for delta := int64(-Skew); delta <= Skew; delta++ {
+		if current+delta <= lastStep {
+			continue
+		}
+		expected, err := CodeAt(secret, current+delta)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return current + delta, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI monta a URI otpauth:// usada para cadastro via QR code nos aplicativos autenticadores
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", algorithm)
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(Period))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	key, err := encoding.DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("segredo TOTP inválido: %v", err)
+	}
+	return key, nil
+}
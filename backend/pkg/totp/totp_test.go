@@ -0,0 +1,138 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// segredoRFC é o segredo SHA-1 do Apêndice B da RFC 6238 ("12345678901234567890" em ASCII)
+var segredoRFC = encoding.EncodeToString([]byte("12345678901234567890"))
+
+// Os vetores da RFC têm 8 dígitos; o código de 6 dígitos são os 6 últimos (valor mod 10^6)
+func TestCodeAtVetoresRFC6238(t *testing.T) {
+	casos := []struct {
+		nome   string
+		tempo  int64
+		codigo string
+	}{
+		{"T=59", 59, "94287082"},
+		{"T=1111111109", 1111111109, "07081804"},
+		{"T=1111111111", 1111111111, "14050471"},
+		{"T=1234567890", 1234567890, "89005924"},
+		{"T=2000000000", 2000000000, "69279037"},
+		{"T=20000000000", 20000000000, "65353130"},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			esperado := c.codigo[len(c.codigo)-Digits:]
+			instante := time.Unix(c.tempo, 0)
+
+			codigo, err := CodeAt(segredoRFC, Step(instante))
+			if err != nil {
+				t.Fatalf("erro ao calcular código: %v", err)
+			}
+			if codigo != esperado {
+				t.Errorf("código %s, esperado %s", codigo, esperado)
+			}
+
+			passo, ok := Validate(segredoRFC, esperado, instante, 0)
+			if !ok || passo != c.tempo/Period {
+				t.Errorf("Validate = (%d, %v), esperado (%d, true)", passo, ok, c.tempo/Period)
+			}
+		})
+	}
+}
+
+func TestValidateJanelaDeUmPasso(t *testing.T) {
+	instante := time.Unix(1111111111, 0)
+	atual := Step(instante)
+
+	casos := []struct {
+		nome   string
+		delta  int64
+		aceito bool
+	}{
+		{"dois passos antes", -2, false},
+		{"um passo antes", -1, true},
+		{"passo atual", 0, true},
+		{"um passo depois", 1, true},
+		{"dois passos depois", 2, false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			codigo, err := CodeAt(segredoRFC, atual+c.delta)
+			if err != nil {
+				t.Fatalf("erro ao calcular código: %v", err)
+			}
+
+			passo, ok := Validate(segredoRFC, codigo, instante, 0)
+			if ok != c.aceito {
+				t.Fatalf("aceito = %v, esperado %v", ok, c.aceito)
+			}
+			if ok && passo != atual+c.delta {
+				t.Errorf("passo %d, esperado %d", passo, atual+c.delta)
+			}
+		})
+	}
+}
+
+func TestValidateRejeitaPassoJaUtilizado(t *testing.T) {
+	instante := time.Unix(1234567890, 0)
+	atual := Step(instante)
+
+	casos := []struct {
+		nome        string
+		passoCodigo int64
+		ultimoPasso int64
+		aceito      bool
+	}{
+		{"código atual reapresentado", atual, atual, false},
+		{"código anterior após uso do atual", atual - 1, atual, false},
+		{"código anterior reapresentado", atual - 1, atual - 1, false},
+		{"código atual após uso do anterior", atual, atual - 1, true},
+		{"código seguinte após uso do atual", atual + 1, atual, true},
+		{"código anterior ainda não utilizado", atual - 1, atual - 2, true},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			codigo, err := CodeAt(segredoRFC, c.passoCodigo)
+			if err != nil {
+				t.Fatalf("erro ao calcular código: %v", err)
+			}
+
+			passo, ok := Validate(segredoRFC, codigo, instante, c.ultimoPasso)
+			if ok != c.aceito {
+				t.Fatalf("aceito = %v, esperado %v", ok, c.aceito)
+			}
+			if ok && passo <= c.ultimoPasso {
+				t.Errorf("passo %d não é posterior ao último utilizado %d", passo, c.ultimoPasso)
+			}
+		})
+	}
+}
+
+func TestValidateFormatoDoCodigo(t *testing.T) {
+	instante := time.Unix(59, 0)
+
+	casos := []struct {
+		nome   string
+		codigo string
+		aceito bool
+	}{
+		{"com espaços", " 287 082 ", true},
+		{"código de 8 dígitos", "94287082", false},
+		{"código incompleto", "28708", false},
+		{"código vazio", "", false},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if _, ok := Validate(segredoRFC, c.codigo, instante, 0); ok != c.aceito {
+				t.Errorf("aceito = %v, esperado %v", ok, c.aceito)
+			}
+		})
+	}
+}